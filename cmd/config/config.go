@@ -0,0 +1,118 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ConfigCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Manage the configuration file",
+	}
+	configInitCmd = &cobra.Command{
+		Use:   "init",
+		Short: "Write a commented sample configuration file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := writeConfig(output, force); err != nil {
+				log.Errorf("Failed to write config: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	output string
+	force  bool
+)
+
+func init() {
+	configInitCmd.Flags().StringVar(&output, "output", "config.yaml", "path of the generated config file, - for stdout")
+	configInitCmd.Flags().BoolVar(&force, "force", false, "overwrite an existing file")
+
+	ConfigCmd.AddCommand(configInitCmd)
+}
+
+func writeConfig(path string, overwrite bool) error {
+	if err := viper.ReadInConfig(); err != nil {
+		log.Debugf("No configuration file found: %v", err)
+	}
+	content := renderConfig()
+	if path == "-" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return fmt.Errorf("%s already exists, use --force to overwrite", path)
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return err
+	}
+	log.Infof("Wrote sample configuration to %s", path)
+	return nil
+}
+
+// renderConfig renders every key with its description, using the current value when set and the default otherwise.
+func renderConfig() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Volcengine webhook provider configuration.\n")
+	buf.WriteString(fmt.Sprintf("# Keys marked with env can also be set via %s_<KEY>, e.g. %s_REGION.\n", EnvPrefix, EnvPrefix))
+	buf.WriteString("# Keys marked with reloaded take effect when this file changes, the others need a restart.\n")
+	buf.WriteString("# Keys marked with kms may be KMS ciphertext prefixed with kms://, decrypted at startup.\n")
+	section := ""
+	for _, key := range Keys {
+		if key.Section != section {
+			section = key.Section
+			buf.WriteString(fmt.Sprintf("\n# --- %s ---\n", section))
+		}
+		comment := key.Description
+		if key.Env {
+			comment += fmt.Sprintf(" (env: %s_%s)", EnvPrefix, strings.ToUpper(key.Name))
+		}
+		if key.Reloadable {
+			comment += " (reloaded)"
+		}
+		if key.KMS {
+			comment += " (kms)"
+		}
+		buf.WriteString("# " + comment + "\n")
+		value := key.Default
+		if !key.Secret && viper.IsSet(key.Name) {
+			value = viper.Get(key.Name)
+		}
+		buf.WriteString(fmt.Sprintf("%s: %s\n", key.Name, formatValue(value)))
+	}
+	return buf.Bytes()
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []string:
+		return strconv.Quote(strings.Join(v, ","))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
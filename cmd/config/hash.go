@@ -0,0 +1,37 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Hash returns a short hash of the effective value of every configuration key except the secrets,
+// equal configurations have equal hashes.
+func Hash() string {
+	h := sha256.New()
+	for _, key := range Keys {
+		if key.Secret {
+			continue
+		}
+		fmt.Fprintf(h, "%s=%v\n", key.Name, viper.Get(key.Name))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"volcengine-provider/pkg/cloudmonitor"
+	"volcengine-provider/pkg/volcengine"
+)
+
+// EnvPrefix is the prefix of the environment variables bound to configuration keys.
+const EnvPrefix = "VOLCENGINE"
+
+// Key describes a supported configuration key.
+type Key struct {
+	Name        string
+	Section     string
+	Description string
+	Default     interface{}
+	// Env binds the key to the VOLCENGINE_<NAME> environment variable.
+	Env bool
+	// Secret keys are never written with their current value.
+	Secret bool
+	// KMS keys may hold KMS ciphertext prefixed with kms://, decrypted at startup by DecryptSecrets.
+	KMS bool
+	// Reloadable keys are applied by the running webhook when the configuration file changes.
+	Reloadable bool
+}
+
+// Keys lists every configuration key accepted by the provider, in the order they are documented.
+var Keys = []Key{
+	{Name: "access_key", Section: "credentials", Description: "Access key for static credentials.", Default: "", Env: true, Secret: true, KMS: true},
+	{Name: "secret_key", Section: "credentials", Description: "Secret key for static credentials.", Default: "", Env: true, Secret: true, KMS: true},
+	{Name: "oidc_token_file", Section: "credentials", Description: "OIDC token file, used together with oidc_role_trn when no access key is set.", Default: "", Env: true},
+	{Name: "oidc_role_trn", Section: "credentials", Description: "Role TRN assumed with the OIDC token.", Default: "", Env: true},
+	{Name: "credentials_secret", Section: "credentials", Description: "Kubernetes Secret (namespace/name or name) holding access-key/secret-key or oidc-role-trn/oidc-token-file, watched for changes; takes precedence over the keys above.", Default: "", Env: true},
+	{Name: "credentials_dir", Section: "credentials", Description: "Directory holding access-key and secret-key files, e.g. a mounted Secret, watched for changes; takes precedence over access_key and secret_key.", Default: "", Env: true},
+	{Name: "assume_role_trn", Section: "credentials", Description: "Role, e.g. of the account owning the zones, assumed with the credentials above through STS AssumeRole; its credentials are renewed before they expire.", Default: "", Env: true},
+	{Name: "assume_role_session_name", Section: "credentials", Description: "Session name of assume_role_trn, shows up in the audit log of the role account.", Default: "external-dns", Env: true},
+	{Name: "use_instance_role", Section: "credentials", Description: "Use the credentials of the ECS instance role from the instance metadata service when no other credentials are set.", Default: false, Env: true},
+	{Name: "instance_role", Section: "credentials", Description: "ECS instance role used with use_instance_role and to decrypt the kms:// values of the kms keys, discovered when empty.", Default: "", Env: true},
+	{Name: "region", Section: "endpoints", Description: "Region of the private zones.", Default: "", Env: true},
+	{Name: "regions", Section: "endpoints", Description: "Comma separated regions whose private zones are managed together, each optionally REGION=ENDPOINT with its privatezone endpoint, e.g. cn-beijing,cn-shanghai=open.cn-shanghai.volcengineapi.com; overrides region for the private zones.", Default: "", Env: true},
+	{Name: "privatezone_endpoint", Section: "endpoints", Description: "OpenAPI endpoint for privatezone.", Default: volcengine.DefaultEndpoint, Env: true},
+	{Name: "clouddns_endpoint", Section: "endpoints", Description: "OpenAPI endpoint for public CloudDNS zones.", Default: volcengine.DefaultEndpoint, Env: true},
+	{Name: "sts_endpoint", Section: "endpoints", Description: "OpenAPI endpoint for sts.", Default: volcengine.DefaultStsEndpoint, Env: true},
+	{Name: "api_headers", Section: "endpoints", Description: "Comma separated Name=value headers attached to every PrivateZone, CloudDNS, STS and KMS API request, e.g. for an OpenAPI gateway.", Default: "", Env: true, Secret: true},
+	{Name: "kms_endpoint", Section: "endpoints", Description: "OpenAPI endpoint for kms.", Default: volcengine.DefaultEndpoint, Env: true},
+	{Name: "dns_mode", Section: "filters", Description: "Zones managed: private for the private zones bound to vpc, public for the public CloudDNS zones of the account, both for all of them.", Default: string(volcengine.DNSModePrivate), Env: true},
+	{Name: "vpc", Section: "filters", Description: "VPC whose bound private zones are managed, comma separated for the zones of several VPCs.", Default: "", Env: true},
+	{Name: "domain_filter", Section: "filters", Description: "Comma separated list of zone suffixes to manage, empty manages all zones.", Default: "", Env: true},
+	{Name: "exclude_domains", Section: "filters", Description: "Comma separated list of domains, and their subdomains, not managed even when domain_filter matches them.", Default: "", Env: true},
+	{Name: "include_disabled_records", Section: "filters", Description: "Report disabled records to external-dns, annotated with the volcengine/disabled-targets property, instead of skipping them.", Default: false, Env: true},
+	{Name: "hide_registry_owner", Section: "filters", Description: "External-dns owner id (--txt-owner-id) whose heritage TXT registry records are not returned to external-dns, for registry-less or externally managed registry setups.", Default: "", Env: true},
+	{Name: "txt_escape_mode", Section: "records", Description: "Quoting of TXT values: auto strips and restores the quotes of heritage= registry values only, never stores values verbatim, always strips and restores the quotes of every value.", Default: string(volcengine.TXTEscapeAuto), Env: true},
+	{Name: "txt_registry_prefixes", Section: "records", Description: "Comma separated prefixes of TXT registry values whose quotes are stripped and restored in auto txt_escape_mode.", Default: volcengine.DefaultTXTRegistryPrefix, Env: true},
+	{Name: "default_ttls", Section: "records", Description: "Comma separated TYPE=seconds TTLs of the records of endpoints without a TTL, e.g. A=60,AAAA=60,TXT=3600. Other types use the privatezone default.", Default: "", Env: true},
+	{Name: "default_ttl", Section: "records", Description: "TTL in seconds of the records of endpoints without a TTL and without a default_ttls entry, 0 uses the privatezone default.", Default: 0, Env: true},
+	{Name: "min_ttl", Section: "records", Description: "Minimum TTL in seconds of the records written, larger endpoint TTLs are kept, 0 disables it.", Default: 0, Env: true},
+	{Name: "max_ttl", Section: "records", Description: "Maximum TTL in seconds of the records written, smaller endpoint TTLs are kept, 0 disables it.", Default: 0, Env: true},
+	{Name: "zone_ttls", Section: "records", Description: "Comma separated ZONE:SETTING=seconds overrides of default_ttl, min_ttl and max_ttl for the records of a zone, e.g. example.com:default=60,example.com:max=300.", Default: "", Env: true},
+	{Name: "target_dot_policy", Section: "records", Description: "Trailing dot of CNAME, MX, SRV and PTR targets written and returned: cname fully qualifies written CNAME names only, preserve keeps names as they are, append fully qualifies them, strip removes the dot.", Default: string(volcengine.TargetDotCNAME), Env: true},
+	{Name: "record_remark", Section: "records", Description: "text/template of the remark of written records, starting with \"managed by external-dns\", e.g. \"managed by external-dns cluster={{.Cluster}} owner={{.Owner}}\". .Owner and .Resource are the endpoint labels.", Default: "", Env: true},
+	{Name: "cluster_name", Section: "records", Description: "Cluster name rendered as {{.Cluster}} by record_remark.", Default: "", Env: true},
+	{Name: "read_qps", Section: "throttling", Description: "Queries per second of list API calls, 0 disables throttling.", Default: 0, Env: true, Reloadable: true},
+	{Name: "read_burst", Section: "throttling", Description: "Burst of list API calls.", Default: 10, Env: true, Reloadable: true},
+	{Name: "write_qps", Section: "throttling", Description: "Queries per second of mutating API calls, 0 disables throttling.", Default: 0, Env: true, Reloadable: true},
+	{Name: "write_burst", Section: "throttling", Description: "Burst of mutating API calls.", Default: 1, Env: true, Reloadable: true},
+	{Name: "max_concurrent_zone_queries", Section: "throttling", Description: "Zones whose records are listed at the same time by a sync, bounded so many zones do not burst past read_qps.", Default: volcengine.DefaultMaxConcurrentZoneQueries, Env: true},
+	{Name: "max_concurrent_zone_writes", Section: "throttling", Description: "Mutating API calls in flight per zone, calls to other zones are not held back; 0 is unlimited.", Default: 0, Env: true},
+	{Name: "max_retries", Section: "throttling", Description: "Retries of API calls failing with throttling, HTTP 429 or 5xx and transient server errors, 0 disables retries.", Default: 3, Env: true},
+	{Name: "retry_min_delay", Section: "throttling", Description: "Backoff before the first retry, doubled with jitter for every further retry; throttled calls wait at least 500ms.", Default: "100ms", Env: true},
+	{Name: "retry_max_delay", Section: "throttling", Description: "Maximum backoff between retries.", Default: "10s", Env: true},
+	{Name: "max_changes_per_sync", Section: "throttling", Description: "Record creates and deletes applied per sync, the rest is deferred to the next syncs, 0 is unlimited.", Default: 0, Env: true},
+	{Name: "apply_changes_timeout", Section: "throttling", Description: "Deadline of one sync, after it no API call is issued and the remaining changes are left to the next sync; 0s derives it from write_timeout.", Default: "0s", Env: true},
+	{Name: "cache_ttl", Section: "cache", Description: "Serve zone and record listings from memory for this long, 0s disables the cache.", Default: "0s", Env: true, Reloadable: true},
+	{Name: "cache_refresh_after", Section: "cache", Description: "Serve cached listings older than this while refreshing them in the background, must be below cache_ttl; 0s refreshes only after cache_ttl.", Default: "0s", Env: true, Reloadable: true},
+	{Name: "cache_file", Section: "cache", Description: "File the cache is snapshotted to and loaded from at startup, so restarts do not list every zone again.", Default: "", Env: true},
+	{Name: "dry_run", Section: "deletion", Description: "List zones and records but only log the creates, updates and deletes a sync would perform, per zone and record.", Default: false, Env: true, Reloadable: true},
+	{Name: "soft_delete", Section: "deletion", Description: "Disable deleted records and tag them with the deletion time instead of removing them.", Default: false, Env: true},
+	{Name: "tombstone_retention", Section: "deletion", Description: "How long soft deleted records are kept before they are purged.", Default: volcengine.DefaultTombstoneRetention.String(), Env: true},
+	{Name: "protected_names", Section: "deletion", Description: "Comma separated names or shell patterns like *.core.example.internal whose records are never deleted or overwritten.", Default: "", Env: true},
+	{Name: "managed_record_guard", Section: "deletion", Description: "Only delete and update records whose remark starts with \"managed by external-dns\", records created by hand or by other tools are left alone.", Default: false, Env: true},
+	{Name: "managed_record_owner", Section: "deletion", Description: "With managed_record_guard, only delete and update the records whose remark carries this owner id, e.g. the external-dns --txt-owner-id.", Default: "", Env: true},
+	{Name: "max_delete_percent", Section: "deletion", Description: "Refuse change batches deleting more than this percentage of the managed records, 0 disables the limit.", Default: 0, Env: true},
+	{Name: "max_delete_records", Section: "deletion", Description: "Refuse change batches deleting more than this number of records, 0 disables the limit.", Default: 0, Env: true},
+	{Name: "force_deletes", Section: "deletion", Description: "Apply change batches exceeding max_delete_percent or max_delete_records anyway.", Default: false, Env: true},
+	{Name: "tombstone_gc_interval", Section: "deletion", Description: "How often expired soft deleted records are purged.", Default: "1h", Env: true},
+	{Name: "notify_url", Section: "notifications", Description: "URL receiving a JSON summary of the created, updated and deleted records and failures after every sync.", Default: "", Env: true},
+	{Name: "chat_webhook_url", Section: "notifications", Description: "Lark or Slack incoming webhook paged when syncs fail repeatedly.", Default: "", Env: true, Secret: true},
+	{Name: "chat_kind", Section: "notifications", Description: "Chat service of chat_webhook_url, lark or slack, detected from the URL when empty.", Default: "", Env: true},
+	{Name: "chat_failure_threshold", Section: "notifications", Description: "Number of consecutive failed syncs that pages the chat webhook.", Default: 3, Env: true},
+	{Name: "notify_timeout", Section: "notifications", Description: "Timeout of each notification.", Default: "10s", Env: true},
+	{Name: "cloud_monitor_namespace", Section: "cloud monitor", Description: "Cloud Monitor custom metric namespace sync metrics are pushed to, empty disables the push.", Default: "", Env: true},
+	{Name: "cloud_monitor_endpoint", Section: "cloud monitor", Description: "OpenAPI endpoint of Cloud Monitor.", Default: volcengine.DefaultEndpoint, Env: true},
+	{Name: "cloud_monitor_interval", Section: "cloud monitor", Description: "Time between two pushes to Cloud Monitor.", Default: cloudmonitor.DefaultInterval.String(), Env: true},
+	{Name: "cloud_monitor_metrics", Section: "cloud monitor", Description: "Comma separated metric families pushed to Cloud Monitor, empty pushes sync failures, records, throttled calls and zones.", Default: "", Env: true},
+	{Name: "leader_election", Section: "leader election", Description: "Elect a leader through a Kubernetes Lease so only one replica applies changes, followers serve reads.", Default: false, Env: true},
+	{Name: "leader_election_namespace", Section: "leader election", Description: "Namespace of the Lease, defaults to the namespace of the pod.", Default: "", Env: true},
+	{Name: "leader_election_lease", Section: "leader election", Description: "Name of the Lease.", Default: "external-dns-volcengine-webhook", Env: true},
+	{Name: "api_record_file", Section: "debug", Description: "JSONL file every Volcengine API request and response is appended to, sanitized, for bug reports.", Default: "", Env: true},
+	{Name: "log_level", Section: "server", Description: "Log level, e.g. debug, overrides --log-level when set.", Default: "", Env: true, Reloadable: true},
+	{Name: "port", Section: "server", Description: "Port the webhook listens on.", Default: 8888},
+	{Name: "metrics_port", Section: "server", Description: "Port of a separate listener serving /metrics, 0 serves it on the webhook port only.", Default: 0, Env: true},
+	{Name: "health_port", Section: "server", Description: "Port of a separate listener serving /healthz, /startupz and /readyz for Kubernetes probes, /readyz lists the zones to verify the credentials, 0 disables it.", Default: 0, Env: true},
+	{Name: "unhealthy_after_failures", Section: "server", Description: "Consecutive failed Volcengine API calls after which /healthz reports unhealthy so the pod is restarted, 0 disables it.", Default: 0, Env: true},
+	{Name: "debug_listen", Section: "server", Description: "Address of the internal listener serving /debug/state, e.g. 127.0.0.1:8081, empty disables it. Keep it off the webhook port, it exposes zones and planned changes.", Default: "", Env: true},
+	{Name: "tls_cert", Section: "server", Description: "Certificate file of the webhook, serves HTTPS together with tls_key; reloaded when it changes.", Default: "", Env: true},
+	{Name: "tls_key", Section: "server", Description: "Private key file of tls_cert.", Default: "", Env: true},
+	{Name: "tls_client_ca", Section: "server", Description: "CA file client certificates are verified against, requires clients to present one (mTLS).", Default: "", Env: true},
+	{Name: "auth_token", Section: "server", Description: "Token webhook API requests must present in auth_header, disabled when empty.", Default: "", Env: true, Secret: true, KMS: true},
+	{Name: "auth_header", Section: "server", Description: "Header carrying auth_token, Authorization accepts it as a bearer token or a basic auth password.", Default: "Authorization", Env: true},
+	{Name: "read_timeout", Section: "server", Description: "Read timeout in seconds.", Default: 60},
+	{Name: "write_timeout", Section: "server", Description: "Write timeout in seconds.", Default: 60},
+	{Name: "startup_validation", Section: "server", Description: "Before serving, obtain the credentials, list the zones, check every domain_filter has a zone and that the credentials may delete records; exit with code 3 when a check fails.", Default: true, Env: true},
+	{Name: "shutdown_grace_period", Section: "server", Description: "Time in-flight requests get to complete on termination, keep it below the terminationGracePeriodSeconds of the pod.", Default: "25s", Env: true},
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Manager watches the configuration file and calls the subscribers of the reloadable keys whose values changed.
+// Credentials and secrets are never reloaded, changes of the other keys are logged as needing a restart.
+type Manager struct {
+	mu          sync.Mutex
+	values      map[string]string
+	subscribers []subscriber
+}
+
+type subscriber struct {
+	keys []string
+	fn   func()
+}
+
+// NewManager returns a manager comparing later reloads with the current configuration.
+func NewManager() *Manager {
+	m := &Manager{values: make(map[string]string)}
+	for _, key := range watchedKeys() {
+		m.values[key.Name] = fmt.Sprint(viper.Get(key.Name))
+	}
+	return m
+}
+
+// watchedKeys returns the keys compared on reload, every key except the credentials and the secrets.
+func watchedKeys() []Key {
+	var keys []Key
+	for _, key := range Keys {
+		if key.Secret || key.Section == "credentials" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Subscribe calls fn once per reload changing at least one of the keys, fn reads the new values from viper.
+func (m *Manager) Subscribe(fn func(), keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscribers = append(m.subscribers, subscriber{keys: keys, fn: fn})
+}
+
+// Watch reloads the configuration file whenever it is written and reports whether it is watched, which needs a
+// configuration file to have been read.
+func (m *Manager) Watch() bool {
+	if viper.ConfigFileUsed() == "" {
+		return false
+	}
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		log.Infof("Configuration file %s changed, reloading", event.Name)
+		m.Reload()
+	})
+	viper.WatchConfig()
+	return true
+}
+
+// Reload compares the keys with their values of the last reload and calls the subscribers of the changed
+// reloadable keys.
+func (m *Manager) Reload() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	changed := make(map[string]bool)
+	for _, key := range watchedKeys() {
+		value := fmt.Sprint(viper.Get(key.Name))
+		if value == m.values[key.Name] {
+			continue
+		}
+		m.values[key.Name] = value
+		if !key.Reloadable {
+			log.Warnf("Ignoring the change of %s to %s until the webhook is restarted", key.Name, value)
+			continue
+		}
+		log.Infof("Reloaded %s=%s", key.Name, value)
+		changed[key.Name] = true
+	}
+	for _, s := range m.subscribers {
+		for _, key := range s.keys {
+			if changed[key] {
+				s.fn()
+				break
+			}
+		}
+	}
+}
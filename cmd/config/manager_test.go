@@ -0,0 +1,68 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagerReload(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("dry_run", false)
+	viper.Set("log_level", "")
+	viper.Set("read_qps", 0)
+	viper.Set("write_qps", 0)
+	viper.Set("port", 8888)
+	viper.Set("access_key", "AK")
+
+	m := NewManager()
+	fired := make(map[string]int)
+	m.Subscribe(func() { fired["dry_run"]++ }, "dry_run")
+	m.Subscribe(func() { fired["log_level"]++ }, "log_level")
+	m.Subscribe(func() { fired["rate_limits"]++ }, "read_qps", "write_qps")
+	m.Subscribe(func() { fired["port"]++ }, "port")
+	m.Subscribe(func() { fired["access_key"]++ }, "access_key")
+
+	// nothing changed
+	m.Reload()
+	assert.Empty(t, fired)
+
+	viper.Set("dry_run", true)
+	m.Reload()
+	assert.Equal(t, map[string]int{"dry_run": 1}, fired)
+
+	// a subscriber of several changed keys fires once
+	viper.Set("read_qps", 5)
+	viper.Set("write_qps", 1)
+	m.Reload()
+	assert.Equal(t, map[string]int{"dry_run": 1, "rate_limits": 1}, fired)
+
+	// keys needing a restart and credentials never fire
+	viper.Set("port", 9999)
+	viper.Set("access_key", "rotated")
+	m.Reload()
+	assert.Equal(t, map[string]int{"dry_run": 1, "rate_limits": 1}, fired)
+
+	// clearing a key is a change too
+	viper.Set("log_level", "debug")
+	m.Reload()
+	viper.Set("log_level", "")
+	m.Reload()
+	assert.Equal(t, map[string]int{"dry_run": 1, "rate_limits": 1, "log_level": 2}, fired)
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"volcengine-provider/pkg/volcengine"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// DecryptSecrets replaces kms:// values of the KMS keys with their plaintext,
+// decrypting with the credentials of the ECS instance role.
+func DecryptSecrets(ctx context.Context) error {
+	var decrypter *volcengine.KMSDecrypter
+	for _, k := range Keys {
+		if !k.KMS {
+			continue
+		}
+		key := k.Name
+		value := viper.GetString(key)
+		if !volcengine.IsKMSCiphertext(value) {
+			continue
+		}
+		if decrypter == nil {
+			headers, err := volcengine.ParseHeaders(viper.GetString("api_headers"))
+			if err != nil {
+				return fmt.Errorf("invalid api_headers: %v", err)
+			}
+			creds := volcengine.NewInstanceMetadataCredentials(viper.GetString("instance_role"))
+			decrypter, err = volcengine.NewKMSDecrypter(viper.GetString("region"), viper.GetString("kms_endpoint"), creds, headers)
+			if err != nil {
+				return err
+			}
+		}
+		plaintext, err := decrypter.Decrypt(ctx, value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %v", key, err)
+		}
+		log.Infof("Decrypted %s with kms", key)
+		viper.Set(key, plaintext)
+	}
+	return nil
+}
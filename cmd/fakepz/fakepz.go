@@ -0,0 +1,86 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package fakepz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"volcengine-provider/pkg/fakepz"
+)
+
+var (
+	FakePZCmd = &cobra.Command{
+		Use:   "fakepz",
+		Short: "Serve a PrivateZone compatible API from memory, for local testing without a cloud account",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := serveFakePZ(); err != nil {
+				log.Errorf("Failed to serve fake privatezone: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	listenAddr string
+	zones      []string
+	vpc        string
+	region     string
+)
+
+func init() {
+	FakePZCmd.Flags().StringVar(&listenAddr, "listen", ":8080", "address to listen on")
+	FakePZCmd.Flags().StringArrayVar(&zones, "zone", nil, "zone to create at startup, may be repeated")
+	FakePZCmd.Flags().StringVar(&vpc, "vpc", "vpc-fake", "vpc the startup zones are bound to")
+	FakePZCmd.Flags().StringVar(&region, "region", "cn-beijing", "region reported in responses")
+}
+
+func serveFakePZ() error {
+	store := fakepz.NewStore()
+	for _, zone := range zones {
+		zid := store.AddZone(zone, vpc)
+		log.Infof("Created zone %s with ZID %d in vpc %s", zone, zid, vpc)
+	}
+	server := &http.Server{
+		Addr:              listenAddr,
+		Handler:           fakepz.NewServer(store, region),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("Failed to shut down: %v", err)
+		}
+	}()
+
+	log.Infof("Serving fake privatezone API on %s", listenAddr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
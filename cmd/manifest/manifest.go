@@ -0,0 +1,368 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	modeSidecar    = "sidecar"
+	modeDeployment = "deployment"
+)
+
+var (
+	ManifestCmd = &cobra.Command{
+		Use:   "manifest",
+		Short: "Render Kubernetes manifests for external-dns with the webhook provider",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := renderManifest(); err != nil {
+				log.Errorf("Failed to render manifest: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	mode             string
+	namespace        string
+	name             string
+	providerImage    string
+	externalDNSImage string
+	secretName       string
+	webhookPort      int
+	healthPort       int
+	replicas         int
+)
+
+func init() {
+	ManifestCmd.Flags().StringVar(&mode, "mode", modeSidecar, "deployment mode, sidecar or deployment")
+	ManifestCmd.Flags().StringVar(&namespace, "namespace", "kube-system", "namespace to deploy into")
+	ManifestCmd.Flags().StringVar(&name, "name", "external-dns", "name of the external-dns resources")
+	ManifestCmd.Flags().StringVar(&providerImage, "image", "volcengine/external-dns-volcengine-webhook:latest", "webhook provider image")
+	ManifestCmd.Flags().StringVar(&externalDNSImage, "external-dns-image", "registry.k8s.io/external-dns/external-dns:v0.18.0", "external-dns controller image")
+	ManifestCmd.Flags().StringVar(&secretName, "secret-name", "volcengine-credentials", "secret holding access-key and secret-key, used unless oidc_role_trn is set")
+	ManifestCmd.Flags().IntVar(&webhookPort, "webhook-port", 8888, "port the webhook provider listens on")
+	ManifestCmd.Flags().IntVar(&healthPort, "health-port", 8080, "port the webhook provider serves /healthz, /startupz and /readyz on")
+	ManifestCmd.Flags().IntVar(&replicas, "replicas", 1, "webhook replicas in deployment mode, more than one enables leader election")
+}
+
+// manifestValues are the values used to render the manifest template.
+type manifestValues struct {
+	Mode             string
+	Namespace        string
+	Name             string
+	ProviderImage    string
+	ExternalDNSImage string
+	SecretName       string
+	Port             int
+	HealthPort       int
+	WebhookURL       string
+	VPC              string
+	Region           string
+	PrivateZone      string
+	STS              string
+	OIDCRoleTrn      string
+	DomainFilters    []string
+	Replicas         int
+	LeaderElection   bool
+}
+
+func renderManifest() error {
+	if mode != modeSidecar && mode != modeDeployment {
+		return fmt.Errorf("invalid mode %q, must be %s or %s", mode, modeSidecar, modeDeployment)
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		log.Debugf("No configuration file found: %v", err)
+	}
+	values := manifestValues{
+		Mode:             mode,
+		Namespace:        namespace,
+		Name:             name,
+		ProviderImage:    providerImage,
+		ExternalDNSImage: externalDNSImage,
+		SecretName:       secretName,
+		Port:             webhookPort,
+		HealthPort:       healthPort,
+		WebhookURL:       fmt.Sprintf("http://localhost:%d", webhookPort),
+		VPC:              viper.GetString("vpc"),
+		Region:           viper.GetString("region"),
+		PrivateZone:      viper.GetString("privatezone_endpoint"),
+		STS:              viper.GetString("sts_endpoint"),
+		OIDCRoleTrn:      viper.GetString("oidc_role_trn"),
+		Replicas:         replicas,
+	}
+	if mode == modeDeployment {
+		values.WebhookURL = fmt.Sprintf("http://%s-webhook.%s.svc:%d", name, namespace, webhookPort)
+		values.LeaderElection = replicas > 1 || viper.GetBool("leader_election")
+	}
+	if domainFilter := viper.GetString("domain_filter"); domainFilter != "" {
+		values.DomainFilters = strings.Split(domainFilter, ",")
+	}
+	if values.VPC == "" {
+		log.Warnf("vpc is not set, the rendered manifest needs VOLCENGINE_VPC filled in")
+	}
+
+	tmpl, err := template.New("manifest").Funcs(template.FuncMap{
+		"join": strings.Join,
+	}).Parse(manifestTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, values)
+}
+
+const manifestTemplate = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ .Name }}-role
+rules:
+  - apiGroups: [""]
+    resources: ["services", "pods", "nodes", "endpoints"]
+    verbs: ["get", "watch", "list"]
+  - apiGroups: ["discovery.k8s.io"]
+    resources: ["endpointslices"]
+    verbs: ["get", "watch", "list"]
+  - apiGroups: ["networking.k8s.io", "extensions"]
+    resources: ["ingresses"]
+    verbs: ["get", "watch", "list"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{ .Name }}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: {{ .Name }}-role
+subjects:
+  - kind: ServiceAccount
+    name: {{ .Name }}
+    namespace: {{ .Namespace }}
+---
+{{- if .LeaderElection }}
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: {{ .Name }}-leader-election
+  namespace: {{ .Namespace }}
+rules:
+  - apiGroups: ["coordination.k8s.io"]
+    resources: ["leases"]
+    verbs: ["get", "create", "update"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: {{ .Name }}-leader-election
+  namespace: {{ .Namespace }}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: {{ .Name }}-leader-election
+subjects:
+  - kind: ServiceAccount
+    name: {{ .Name }}
+    namespace: {{ .Namespace }}
+---
+{{- end }}
+{{- define "provider" }}
+      - name: provider
+        image: {{ .ProviderImage }}
+        args:
+        - start
+        - --port={{ .Port }}
+        - --health_port={{ .HealthPort }}
+        env:
+        {{- if .OIDCRoleTrn }}
+        - name: VOLCENGINE_OIDC_ROLE_TRN
+          value: {{ printf "%q" .OIDCRoleTrn }}
+        - name: VOLCENGINE_OIDC_TOKEN_FILE
+          value: /var/run/secrets/vke.volcengine.com/irsa-tokens/token
+        {{- else }}
+        - name: VOLCENGINE_ACCESS_KEY
+          valueFrom:
+            secretKeyRef:
+              name: {{ .SecretName }}
+              key: access-key
+        - name: VOLCENGINE_SECRET_KEY
+          valueFrom:
+            secretKeyRef:
+              name: {{ .SecretName }}
+              key: secret-key
+        {{- end }}
+        - name: VOLCENGINE_VPC
+          value: {{ printf "%q" .VPC }}
+        - name: VOLCENGINE_REGION
+          value: {{ printf "%q" .Region }}
+        {{- if .PrivateZone }}
+        - name: VOLCENGINE_PRIVATEZONE_ENDPOINT
+          value: {{ printf "%q" .PrivateZone }}
+        {{- end }}
+        {{- if .STS }}
+        - name: VOLCENGINE_STS_ENDPOINT
+          value: {{ printf "%q" .STS }}
+        {{- end }}
+        {{- if .DomainFilters }}
+        - name: VOLCENGINE_DOMAIN_FILTER
+          value: {{ printf "%q" (join .DomainFilters ",") }}
+        {{- end }}
+        {{- if .LeaderElection }}
+        - name: VOLCENGINE_LEADER_ELECTION
+          value: "true"
+        - name: POD_NAME
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.name
+        - name: POD_NAMESPACE
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.namespace
+        {{- end }}
+        ports:
+        - name: webhook
+          containerPort: {{ .Port }}
+          protocol: TCP
+        - name: probes
+          containerPort: {{ .HealthPort }}
+          protocol: TCP
+        startupProbe:
+          httpGet:
+            path: /startupz
+            port: probes
+          periodSeconds: 10
+          timeoutSeconds: 60
+          failureThreshold: 30
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: probes
+          initialDelaySeconds: 10
+          periodSeconds: 10
+          timeoutSeconds: 5
+          failureThreshold: 2
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: probes
+          periodSeconds: 30
+          timeoutSeconds: 10
+          failureThreshold: 2
+        {{- if .OIDCRoleTrn }}
+        volumeMounts:
+        - mountPath: /var/run/secrets/vke.volcengine.com/irsa-tokens
+          name: irsa-oidc-token
+          readOnly: true
+        {{- end }}
+{{- end }}
+{{- define "volumes" }}
+      {{- if .OIDCRoleTrn }}
+      volumes:
+      - name: irsa-oidc-token
+        projected:
+          defaultMode: 420
+          sources:
+          - serviceAccountToken:
+              audience: sts.volcengine.com
+              expirationSeconds: 3600
+              path: token
+      {{- end }}
+{{- end }}
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    k8s-app: {{ .Name }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      k8s-app: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        k8s-app: {{ .Name }}
+    spec:
+      serviceAccountName: {{ .Name }}
+      containers:
+      - name: controller
+        image: {{ .ExternalDNSImage }}
+        args:
+        - --provider=webhook
+        - --webhook-provider-url={{ .WebhookURL }}
+        - --source=service
+        - --source=ingress
+        - --policy=upsert-only
+        - --registry=txt
+        {{- range .DomainFilters }}
+        - --domain-filter={{ . }}
+        {{- end }}
+{{- if eq .Mode "sidecar" }}
+{{- template "provider" . }}
+{{- template "volumes" . }}
+{{- else }}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}-webhook
+  namespace: {{ .Namespace }}
+  labels:
+    k8s-app: {{ .Name }}-webhook
+spec:
+  replicas: {{ .Replicas }}
+  selector:
+    matchLabels:
+      k8s-app: {{ .Name }}-webhook
+  template:
+    metadata:
+      labels:
+        k8s-app: {{ .Name }}-webhook
+    spec:
+      serviceAccountName: {{ .Name }}
+      containers:
+{{- template "provider" . }}
+{{- template "volumes" . }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Name }}-webhook
+  namespace: {{ .Namespace }}
+spec:
+  selector:
+    k8s-app: {{ .Name }}-webhook
+  ports:
+  - name: webhook
+    port: {{ .Port }}
+    targetPort: webhook
+    protocol: TCP
+{{- end }}
+`
@@ -21,6 +21,9 @@ import (
 	"path"
 	"runtime"
 
+	"volcengine-provider/cmd/config"
+	"volcengine-provider/cmd/fakepz"
+	"volcengine-provider/cmd/manifest"
 	"volcengine-provider/cmd/server"
 	"volcengine-provider/cmd/tools"
 
@@ -30,7 +33,9 @@ import (
 )
 
 var (
-	logLevel = "info"
+	logLevel   = "info"
+	logFormat  = "text"
+	configFile string
 
 	rootCmd = &cobra.Command{
 		Use: "volcengine-provider",
@@ -41,17 +46,36 @@ var (
 				lev = logrus.InfoLevel
 			}
 			logrus.SetLevel(lev)
-			logrus.SetFormatter(&logrus.TextFormatter{
-				CallerPrettyfier: func(f *runtime.Frame) (string, string) {
-					filename := path.Base(f.File)
-					return f.Function, fmt.Sprintf("%s:%d", filename, f.Line)
-				},
-			})
+			logrus.SetFormatter(newLogFormatter(logFormat))
 			logrus.SetReportCaller(true)
+
+			if configFile != "" {
+				viper.SetConfigFile(configFile)
+			} else {
+				viper.SetConfigName("config")
+				viper.AddConfigPath(".")
+				viper.AddConfigPath("/etc/volcengine-provider")
+			}
 		},
 	}
 )
 
+// newLogFormatter returns the formatter of the log format, text or json, falling back to text.
+func newLogFormatter(format string) logrus.Formatter {
+	callerPrettyfier := func(f *runtime.Frame) (string, string) {
+		filename := path.Base(f.File)
+		return f.Function, fmt.Sprintf("%s:%d", filename, f.Line)
+	}
+	switch format {
+	case "json":
+		return &logrus.JSONFormatter{CallerPrettyfier: callerPrettyfier}
+	case "text":
+	default:
+		fmt.Printf("Error parsing log format: %q, expected text or json\n", format)
+	}
+	return &logrus.TextFormatter{CallerPrettyfier: callerPrettyfier}
+}
+
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
@@ -61,18 +85,26 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", logLevel, "log level")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logFormat, "log format, text or json")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file, defaults to config.yaml in . or /etc/volcengine-provider")
 	rootCmd.AddCommand(server.StartCmd)
+	rootCmd.AddCommand(server.SyncCmd)
+	rootCmd.AddCommand(server.PlanCmd)
 	rootCmd.AddCommand(tools.RecordCmd)
+	rootCmd.AddCommand(tools.ResolveCmd)
+	rootCmd.AddCommand(tools.VerifyCmd)
+	rootCmd.AddCommand(tools.ZoneCmd)
+	rootCmd.AddCommand(tools.QuotaCmd)
+	rootCmd.AddCommand(manifest.ManifestCmd)
+	rootCmd.AddCommand(config.ConfigCmd)
+	rootCmd.AddCommand(fakepz.FakePZCmd)
 
 	// Bind environment variables
-	viper.SetEnvPrefix("VOLCENGINE") // Prefix for environment variables
-	viper.MustBindEnv("access_key")
-	viper.MustBindEnv("secret_key")
-	viper.MustBindEnv("vpc")
-	viper.MustBindEnv("region")
-	viper.MustBindEnv("privatezone_endpoint")
-	viper.MustBindEnv("sts_endpoint")
-	viper.MustBindEnv("oidc_token_file")
-	viper.MustBindEnv("oidc_role_trn")
-	viper.MustBindEnv("domain_filter")
+	viper.SetEnvPrefix(config.EnvPrefix) // Prefix for environment variables
+	for _, key := range config.Keys {
+		viper.SetDefault(key.Name, key.Default)
+		if key.Env {
+			viper.MustBindEnv(key.Name)
+		}
+	}
 }
@@ -0,0 +1,221 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"volcengine-provider/cmd/config"
+	"volcengine-provider/pkg/source"
+	"volcengine-provider/pkg/utils"
+	"volcengine-provider/pkg/volcengine"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var (
+	PlanCmd = &cobra.Command{
+		Use:     "plan",
+		Aliases: []string{"diff"},
+		Short:   "Show the changes external-dns would make to the zones for the resources of a cluster or a file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := planHandler(cmd); err != nil {
+				log.Errorf("Failed to plan: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	planFile        string
+	planSource      source.Options
+	planPolicy      string
+	planRecordTypes []string
+	planOutput      string
+	planColor       string
+)
+
+func init() {
+	PlanCmd.Flags().StringVar(&planFile, "file", "", "file of the desired endpoints, - is stdin, instead of the resources of the cluster")
+	PlanCmd.Flags().StringVar(&planSource.Kubeconfig, "kubeconfig", "", "kubeconfig of the cluster, defaults to $KUBECONFIG or ~/.kube/config")
+	PlanCmd.Flags().StringVar(&planSource.Context, "context", "", "kubeconfig context, defaults to the current context")
+	// the flags of the external-dns sources, set them like the external-dns deployment
+	PlanCmd.Flags().StringSliceVar(&planSource.Sources, "source", source.DefaultSources, "external-dns sources queried for endpoints, e.g. service, ingress, crd or gateway-httproute")
+	PlanCmd.Flags().StringVar(&planSource.Namespace, "namespace", "", "namespace of the resources, all namespaces when empty")
+	PlanCmd.Flags().StringVar(&planSource.AnnotationFilter, "annotation-filter", "", "only resources matching this annotation selector, as external-dns --annotation-filter")
+	PlanCmd.Flags().StringVar(&planSource.LabelFilter, "label-filter", "", "only resources matching this label selector, as external-dns --label-filter")
+	PlanCmd.Flags().StringSliceVar(&planSource.IngressClassNames, "ingress-class", nil, "only ingresses of these classes, as external-dns --ingress-class")
+	PlanCmd.Flags().StringSliceVar(&planSource.ServiceTypeFilter, "service-type-filter", nil, "only services of these types, as external-dns --service-type-filter")
+	PlanCmd.Flags().BoolVar(&planSource.PublishInternal, "publish-internal-services", false, "publish the cluster IP of ClusterIP services, as external-dns --publish-internal-services")
+	PlanCmd.Flags().StringVar(&planSource.FQDNTemplate, "fqdn-template", "", "hostnames of resources without hostname annotation, as external-dns --fqdn-template")
+	PlanCmd.Flags().BoolVar(&planSource.CombineFQDNAndAnnotation, "combine-fqdn-annotation", false, "combine --fqdn-template and the hostname annotations, as external-dns --combine-fqdn-annotation")
+	PlanCmd.Flags().BoolVar(&planSource.IgnoreHostnameAnnotation, "ignore-hostname-annotation", false, "ignore the hostname annotation, as external-dns --ignore-hostname-annotation")
+	PlanCmd.Flags().StringSliceVar(&planSource.DefaultTargets, "default-targets", nil, "targets of the endpoints without targets, as external-dns --default-targets")
+	PlanCmd.Flags().StringVar(&planPolicy, "policy", "upsert-only", "sync (create/update/delete), upsert-only or create-only")
+	PlanCmd.Flags().StringSliceVar(&planRecordTypes, "record-types", volcengine.DefaultSyncRecordTypes, "record types managed, records of other types are left alone")
+	PlanCmd.Flags().String("vpc", "", "VPCs whose zones are planned, comma separated, defaults to the vpc setting")
+	PlanCmd.Flags().String("domain-filter", "", "domains whose zones are planned, comma separated, defaults to the domain_filter setting")
+	PlanCmd.Flags().StringVar(&planOutput, "output", "text", "output format, text or json")
+	PlanCmd.Flags().StringVar(&planColor, "color", "auto", "color the text output, auto (when stdout is a terminal), always or never")
+}
+
+// planHandler prints the changes from the records of the configured zones to the endpoints of the cluster or the
+// file, without applying them.
+func planHandler(cmd *cobra.Command) error {
+	if planOutput != "text" && planOutput != "json" {
+		return fmt.Errorf("invalid --output %q, expected text or json", planOutput)
+	}
+	colored, err := useColor(planColor)
+	if err != nil {
+		return err
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		log.Infof("No configuration file found: %v\n", err)
+	}
+	for flag, key := range map[string]string{"vpc": "vpc", "domain-filter": "domain_filter"} {
+		if cmd.Flags().Changed(flag) {
+			value, _ := cmd.Flags().GetString(flag)
+			viper.Set(key, value)
+		}
+	}
+	if err := config.DecryptSecrets(context.Background()); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	ctx = utils.WithRequestID(ctx, utils.NewRequestID())
+	var desired []*endpoint.Endpoint
+	if planFile != "" {
+		desired, err = readEndpointFile(planFile)
+	} else {
+		desired, err = clusterEndpoints(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	options, _, closeOptions := providerOptions(ctx, 0)
+	defer closeOptions()
+	p, err := volcengine.NewVolcengineProvider(options)
+	if err != nil {
+		return err
+	}
+	changes, err := p.PlanSync(ctx, desired, planPolicy, normalizeRecordTypes(planRecordTypes))
+	if err != nil {
+		return err
+	}
+	if planOutput == "json" {
+		return printPlanJSON(os.Stdout, changes)
+	}
+	printPlanText(os.Stdout, changes, colored)
+	return nil
+}
+
+// clusterEndpoints lists the endpoints the external-dns sources publish for the resources of the kubeconfig cluster.
+func clusterEndpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := source.Endpoints(ctx, planSource)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Found %d endpoints in the resources of the sources %s", len(endpoints), strings.Join(planSource.Sources, ","))
+	return endpoints, nil
+}
+
+// useColor reports whether the text output is colored, auto colors a terminal unless NO_COLOR is set.
+func useColor(mode string) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		info, err := os.Stdout.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid --color %q, expected auto, always or never", mode)
+	}
+}
+
+const (
+	colorReset  = "\033[0m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+)
+
+// printPlanText prints a summary and the +/~/- lines of the changes, creations green, updates yellow and deletions
+// red when colored.
+func printPlanText(w io.Writer, changes *plan.Changes, colored bool) {
+	fmt.Fprintf(w, "%d to create, %d to update, %d to delete\n", len(changes.Create), len(changes.UpdateNew), len(changes.Delete))
+	for _, line := range strings.SplitAfter(volcengine.FormatChanges(changes), "\n") {
+		if line == "" {
+			continue
+		}
+		if !colored {
+			fmt.Fprint(w, line)
+			continue
+		}
+		color := colorGreen
+		switch line[0] {
+		case '~':
+			color = colorYellow
+		case '-':
+			color = colorRed
+		}
+		fmt.Fprintf(w, "%s%s%s\n", color, strings.TrimSuffix(line, "\n"), colorReset)
+	}
+}
+
+// planUpdate is an update of the JSON output.
+type planUpdate struct {
+	Old *endpoint.Endpoint `json:"old"`
+	New *endpoint.Endpoint `json:"new"`
+}
+
+// printPlanJSON prints the changes as a JSON object of the endpoints to create, update and delete.
+func printPlanJSON(w io.Writer, changes *plan.Changes) error {
+	out := struct {
+		Create []*endpoint.Endpoint `json:"create"`
+		Update []planUpdate         `json:"update"`
+		Delete []*endpoint.Endpoint `json:"delete"`
+	}{Create: changes.Create, Update: make([]planUpdate, 0, len(changes.UpdateNew)), Delete: changes.Delete}
+	for i, ep := range changes.UpdateNew {
+		out.Update = append(out.Update, planUpdate{Old: changes.UpdateOld[i], New: ep})
+	}
+	if out.Create == nil {
+		out.Create = []*endpoint.Endpoint{}
+	}
+	if out.Delete == nil {
+		out.Delete = []*endpoint.Endpoint{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
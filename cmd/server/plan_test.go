@@ -0,0 +1,79 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func testChanges() *plan.Changes {
+	return &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "10.0.0.1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "10.0.0.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "10.0.0.3")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "10.0.0.4")},
+	}
+}
+
+func TestPrintPlanText(t *testing.T) {
+	var b bytes.Buffer
+	printPlanText(&b, testChanges(), false)
+	assert.Equal(t, "1 to create, 1 to update, 1 to delete\n"+
+		"+ new.example.com 0 IN A  10.0.0.1 []\n"+
+		"~ web.example.com 0 IN A  10.0.0.2 [] -> web.example.com 0 IN A  10.0.0.3 []\n"+
+		"- old.example.com 0 IN A  10.0.0.4 []\n", b.String())
+
+	b.Reset()
+	printPlanText(&b, testChanges(), true)
+	assert.Equal(t, "1 to create, 1 to update, 1 to delete\n"+
+		colorGreen+"+ new.example.com 0 IN A  10.0.0.1 []"+colorReset+"\n"+
+		colorYellow+"~ web.example.com 0 IN A  10.0.0.2 [] -> web.example.com 0 IN A  10.0.0.3 []"+colorReset+"\n"+
+		colorRed+"- old.example.com 0 IN A  10.0.0.4 []"+colorReset+"\n", b.String())
+
+	b.Reset()
+	printPlanText(&b, &plan.Changes{}, true)
+	assert.Equal(t, "0 to create, 0 to update, 0 to delete\n", b.String())
+}
+
+func TestPrintPlanJSON(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, printPlanJSON(&b, testChanges()))
+	var out struct {
+		Create []*endpoint.Endpoint `json:"create"`
+		Update []planUpdate         `json:"update"`
+		Delete []*endpoint.Endpoint `json:"delete"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &out))
+	require.Len(t, out.Create, 1)
+	assert.Equal(t, "new.example.com", out.Create[0].DNSName)
+	require.Len(t, out.Update, 1)
+	assert.Equal(t, endpoint.Targets{"10.0.0.2"}, out.Update[0].Old.Targets)
+	assert.Equal(t, endpoint.Targets{"10.0.0.3"}, out.Update[0].New.Targets)
+	require.Len(t, out.Delete, 1)
+	assert.Equal(t, "old.example.com", out.Delete[0].DNSName)
+
+	// an empty plan renders empty lists rather than null
+	b.Reset()
+	require.NoError(t, printPlanJSON(&b, &plan.Changes{}))
+	assert.JSONEq(t, `{"create": [], "update": [], "delete": []}`, b.String())
+}
@@ -17,19 +17,36 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"volcengine-provider/cmd/config"
+	"volcengine-provider/pkg/cloudmonitor"
+	"volcengine-provider/pkg/leader"
+	"volcengine-provider/pkg/notify"
+	"volcengine-provider/pkg/tracing"
 	"volcengine-provider/pkg/volcengine"
+	"volcengine-provider/pkg/webhook"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"sigs.k8s.io/external-dns/provider/webhook/api"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/external-dns/provider"
 )
 
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// exitStartupValidation is the exit code of a start refused by the startup validation.
+const exitStartupValidation = 3
+
 // Initialize the start command
 var (
 	StartCmd = &cobra.Command{
@@ -39,21 +56,45 @@ var (
 			startServer()
 		},
 	}
-
-	readTimeOut  int
-	writeTimeOut int
+	// startupLogLevel is the level set by --log-level, restored when log_level is cleared
+	startupLogLevel log.Level
 )
 
 func init() {
 	// Bind flags to the start command
 	StartCmd.Flags().Int("port", 8888, "Port to listen on")
-	StartCmd.Flags().IntVarP(&readTimeOut, "read_timeout", "", 60, "Read timeout in seconds")
-	StartCmd.Flags().IntVarP(&writeTimeOut, "write_timeout", "", 60, "Write timeout in seconds")
+	StartCmd.Flags().Int("metrics_port", 0, "Port of a separate listener serving /metrics, 0 disables it")
+	StartCmd.Flags().Int("health_port", 0, "Port of a separate listener serving /healthz, /startupz and /readyz, 0 disables it")
+	StartCmd.Flags().String("tls_cert", "", "Certificate file of the webhook HTTPS server")
+	StartCmd.Flags().String("tls_key", "", "Private key file of the webhook HTTPS server")
+	StartCmd.Flags().String("tls_client_ca", "", "CA file verifying client certificates, enables mTLS")
+	StartCmd.Flags().String("auth_token", "", "Token webhook API requests must present, disabled when empty")
+	StartCmd.Flags().String("auth_header", webhook.DefaultAuthHeader, "Header carrying auth_token, Authorization takes a bearer token or a basic auth password")
+	StartCmd.Flags().Int("read_timeout", 60, "Read timeout in seconds")
+	StartCmd.Flags().Int("write_timeout", 60, "Write timeout in seconds")
+	StartCmd.Flags().Duration("shutdown_grace_period", 25*time.Second, "Time in-flight requests get to complete on termination")
+	StartCmd.Flags().Float64("read_qps", 0, "Queries per second of list API calls, 0 disables throttling")
+	StartCmd.Flags().Int("read_burst", 10, "Burst of list API calls")
+	StartCmd.Flags().Float64("write_qps", 0, "Queries per second of mutating API calls, 0 disables throttling")
+	StartCmd.Flags().Int("write_burst", 1, "Burst of mutating API calls")
+	StartCmd.Flags().Int("max_concurrent_zone_queries", volcengine.DefaultMaxConcurrentZoneQueries, "Zones whose records are listed at the same time")
+	StartCmd.Flags().String("target_dot_policy", string(volcengine.TargetDotCNAME), "Trailing dot of CNAME, MX, SRV and PTR targets: cname, preserve, append or strip")
+	StartCmd.Flags().Bool("startup_validation", true, "Verify the credentials, zones, domain filter and write permission before serving")
+	StartCmd.Flags().Bool("dry_run", false, "Only log the record changes a sync would perform")
+	StartCmd.Flags().Bool("managed_record_guard", false, "Only delete and update records created by external-dns")
+	StartCmd.Flags().String("record_remark", "", "Template of the record remarks, e.g. \"managed by external-dns cluster={{.Cluster}}\"")
+	StartCmd.Flags().String("regions", "", "Comma separated regions, optionally REGION=ENDPOINT, whose private zones are managed together")
+	StartCmd.Flags().String("dns_mode", string(volcengine.DNSModePrivate), "Zones managed: private, public or both")
+	StartCmd.Flags().Int64("default_ttl", 0, "TTL in seconds of records of endpoints without a TTL, 0 uses the privatezone default")
+	StartCmd.Flags().Int64("min_ttl", 0, "Minimum TTL in seconds of the records written, 0 disables it")
+	StartCmd.Flags().Int64("max_ttl", 0, "Maximum TTL in seconds of the records written, 0 disables it")
+	StartCmd.Flags().String("txt_escape_mode", string(volcengine.TXTEscapeAuto), "Quoting of TXT values: auto, never or always")
 
 	// Bind flags to Viper
-	err := viper.BindPFlag("port", StartCmd.Flags().Lookup("port"))
-	if err != nil {
-		log.Fatalf("failed to bind flags: %v", err)
+	for _, name := range []string{"port", "metrics_port", "health_port", "tls_cert", "tls_key", "tls_client_ca", "auth_token", "auth_header", "read_timeout", "write_timeout", "shutdown_grace_period", "read_qps", "read_burst", "write_qps", "write_burst", "max_concurrent_zone_queries", "txt_escape_mode", "target_dot_policy", "regions", "dns_mode", "startup_validation", "dry_run", "managed_record_guard", "record_remark", "default_ttl", "min_ttl", "max_ttl"} {
+		if err := viper.BindPFlag(name, StartCmd.Flags().Lookup(name)); err != nil {
+			log.Fatalf("failed to bind flags: %v", err)
+		}
 	}
 }
 
@@ -62,8 +103,202 @@ func startServer() {
 	if err := viper.ReadInConfig(); err != nil {
 		log.Infof("No configuration file found: %v\n", err)
 	}
+	if err := config.DecryptSecrets(context.Background()); err != nil {
+		log.Fatalf("Failed to decrypt secrets: %v", err)
+	}
+	startupLogLevel = log.GetLevel()
+	applyLogLevel()
+	configHash := config.Hash()
+	log.Infof("Starting webhook version=%s commit=%s config_hash=%s\n", webhook.Version, webhook.BuildCommit(), configHash)
+	webhook.SetBuildInfo(configHash)
 	// Read configuration values
 	port := viper.GetInt("port")
+	readTimeOut := viper.GetInt("read_timeout")
+	writeTimeOut := viper.GetInt("write_timeout")
+	regionID := viper.GetString("region")
+	softDelete := viper.GetBool("soft_delete")
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		syscall.SIGTERM, // Normal termination signal
+		syscall.SIGINT,  // Ctrl+C interrupt
+		// syscall.SIGKILL cannot be caught (kernel-level signal)
+	)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, webhook.Version)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	if tracing.Enabled() {
+		log.Infof("Exporting traces over OTLP\n")
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			log.Warnf("Failed to flush traces: %v", err)
+		}
+	}()
+
+	// leave time to write the response before the server times out the request
+	options, checkedTokenFile, closeOptions := providerOptions(ctx, time.Duration(writeTimeOut)*time.Second*9/10)
+	defer closeOptions()
+
+	volcProvider, err := volcengine.NewVolcengineProvider(options)
+	if err != nil {
+		panic(err)
+	}
+	defer volcProvider.FlushCache()
+	if viper.GetBool("startup_validation") {
+		if err := validateStartup(ctx, volcProvider); err != nil {
+			log.Errorf("Startup validation failed: %v", err)
+			os.Exit(exitStartupValidation)
+		}
+	}
+	watchConfig(volcProvider)
+	if softDelete {
+		go volcProvider.RunTombstoneGC(ctx, viper.GetDuration("tombstone_gc_interval"))
+	}
+
+	if namespace := viper.GetString("cloud_monitor_namespace"); namespace != "" {
+		var metrics []string
+		if names := viper.GetString("cloud_monitor_metrics"); names != "" {
+			metrics = strings.Split(names, ",")
+		}
+		exporter, err := cloudmonitor.NewExporter(regionID, viper.GetString("cloud_monitor_endpoint"), volcProvider.Credentials(), namespace,
+			cloudmonitor.WithInterval(viper.GetDuration("cloud_monitor_interval")),
+			cloudmonitor.WithMetrics(metrics...))
+		if err != nil {
+			panic(err)
+		}
+		log.Infof("Pushing metrics to cloud_monitor_namespace=%s every cloud_monitor_interval=%s\n", namespace, viper.GetDuration("cloud_monitor_interval"))
+		go exporter.Run(ctx)
+	}
+
+	var webhookProvider provider.Provider = volcProvider
+	var notifiers []notify.Notifier
+	if notifyURL := viper.GetString("notify_url"); notifyURL != "" {
+		log.Infof("Sending change notifications to %s\n", notifyURL)
+		notifiers = append(notifiers, notify.NewHTTPNotifier(notifyURL))
+	}
+	if chatURL := viper.GetString("chat_webhook_url"); chatURL != "" {
+		chat, err := notify.NewChatNotifier(chatURL, viper.GetString("chat_kind"), viper.GetInt("chat_failure_threshold"))
+		if err != nil {
+			panic(err)
+		}
+		chat.ImmediateCodes = append(chat.ImmediateCodes, volcengine.ErrCodeMassDeletion)
+		log.Infof("Paging %s after %d consecutive sync failures\n", chat.Kind, chat.Threshold)
+		notifiers = append(notifiers, chat)
+	}
+	if len(notifiers) > 0 {
+		notifyProvider := notify.NewProvider(webhookProvider, viper.GetDuration("notify_timeout"), notifiers...)
+		defer func() {
+			// send the notifications of the last syncs before exiting
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := notifyProvider.Close(flushCtx); err != nil {
+				log.Warnf("Failed to send the queued change notifications: %v", err)
+			}
+		}()
+		webhookProvider = notifyProvider
+	}
+
+	health := webhook.NewHealth(viper.GetInt("unhealthy_after_failures"))
+	if checkedTokenFile != "" {
+		if err := volcengine.CheckOIDCTokenFile(checkedTokenFile); err != nil {
+			log.Warnf("The oidc token file is not usable: %v\n", err)
+		}
+		health.AddCheck("oidc token file", func() error {
+			return volcengine.CheckOIDCTokenFile(checkedTokenFile)
+		})
+	}
+	startup := webhook.NewStartup(webhookProvider, health)
+	webhookOptions := []webhook.Option{
+		webhook.WithHealth(health),
+		webhook.WithStartup(startup),
+	}
+	if authToken := viper.GetString("auth_token"); authToken != "" {
+		authHeader := viper.GetString("auth_header")
+		log.Infof("Authenticating webhook API requests with auth_header=%s", authHeader)
+		webhookOptions = append(webhookOptions, webhook.WithAuthToken(authHeader, authToken))
+	}
+	if viper.GetBool("leader_election") {
+		elector, err := startLeaderElection(ctx, viper.GetString("leader_election_namespace"), viper.GetString("leader_election_lease"))
+		if err != nil {
+			panic(err)
+		}
+		log.Infof("Using leader election with identity %s\n", elector.Identity())
+		webhookOptions = append(webhookOptions, webhook.WithLeaderElection(elector))
+	}
+
+	if debugListen := viper.GetString("debug_listen"); debugListen != "" {
+		handler := webhook.NewDebugHandler(func() any { return volcProvider.DebugState() })
+		if err := webhook.StartDebugListener(debugListen, handler); err != nil {
+			panic(err)
+		}
+		log.Infof("Serving %s on debug_listen=%s\n", webhook.UrlDebugState, debugListen)
+	}
+
+	if metricsPort := viper.GetInt("metrics_port"); metricsPort > 0 {
+		if err := webhook.StartMetricsListener(fmt.Sprintf("0.0.0.0:%d", metricsPort)); err != nil {
+			panic(err)
+		}
+		log.Infof("Serving %s on metrics_port=%d\n", webhook.UrlMetrics, metricsPort)
+	}
+
+	if healthPort := viper.GetInt("health_port"); healthPort > 0 {
+		handler := webhook.NewProbeHandler(health, startup, webhook.NewReadiness(volcProvider.CheckAPIAccess))
+		if err := webhook.StartProbeListener(fmt.Sprintf("0.0.0.0:%d", healthPort), handler); err != nil {
+			panic(err)
+		}
+		log.Infof("Serving %s, %s and %s on health_port=%d\n", webhook.UrlHealthz, webhook.UrlStartupz, webhook.UrlReadyz, healthPort)
+	}
+
+	var tlsConfig *tls.Config
+	if tlsCert, tlsKey := viper.GetString("tls_cert"), viper.GetString("tls_key"); tlsCert != "" || tlsKey != "" {
+		clientCA := viper.GetString("tls_client_ca")
+		if tlsConfig, err = webhook.NewTLSConfig(tlsCert, tlsKey, clientCA); err != nil {
+			panic(err)
+		}
+		log.Infof("Serving HTTPS with tls_cert=%s tls_client_ca=%s\n", tlsCert, clientCA)
+	} else if viper.GetString("tls_client_ca") != "" {
+		panic("tls_client_ca requires tls_cert and tls_key")
+	}
+
+	shutdownGracePeriod := viper.GetDuration("shutdown_grace_period")
+	if shutdownGracePeriod < 0 {
+		panic(fmt.Sprintf("invalid shutdown_grace_period %s", shutdownGracePeriod))
+	}
+	log.Infof("Using shutdown_grace_period=%s\n", shutdownGracePeriod)
+
+	startedChan := make(chan struct{})
+	stoppedChan := make(chan struct{})
+	go func() {
+		defer close(stoppedChan)
+		webhook.StartHTTPApi(
+			ctx, webhookProvider, startedChan,
+			time.Duration(readTimeOut)*time.Second,
+			time.Duration(writeTimeOut)*time.Second,
+			shutdownGracePeriod,
+			fmt.Sprintf("0.0.0.0:%d", port),
+			tlsConfig,
+			webhookOptions...,
+		)
+	}()
+
+	// Wait for the HTTP server to start and then set the healthy and ready flags
+	<-startedChan
+	log.Infof("Listening on port %d...\n", port)
+
+	<-ctx.Done()
+	log.Infof("Shutting down...\n")
+	<-stoppedChan
+}
+
+// providerOptions returns the provider options of the configuration, the oidc token file checked by the liveness
+// probe when the credentials come from it, and a function releasing the resources of the options. defaultApplyTimeout
+// bounds each ApplyChanges when apply_changes_timeout is not set.
+func providerOptions(ctx context.Context, defaultApplyTimeout time.Duration) ([]volcengine.Option, string, func()) {
 	accessKey := viper.GetString("access_key")
 	secretKey := viper.GetString("secret_key")
 	vpcID := viper.GetString("vpc")
@@ -73,53 +308,350 @@ func startServer() {
 	oidcTokenFile := viper.GetString("oidc_token_file")
 	oidcRoleTrn := viper.GetString("oidc_role_trn")
 	domainFilter := viper.GetString("domain_filter")
+	credentialsSecret := viper.GetString("credentials_secret")
+	credentialsDir := viper.GetString("credentials_dir")
+	softDelete := viper.GetBool("soft_delete")
 
 	// Print debug logs if enabled
-	log.Debugf("Starting server with configuration: port=%d, access_key=%s, secret_key=%s vpc=%s, endpoint=%s, region=%s, oidc_token_file=%s oidc_role_trn=%s \n",
-		port, volcengine.MaskSecret(accessKey), volcengine.MaskSecret(secretKey), vpcID, pvzEndpoint, regionID, oidcTokenFile, oidcRoleTrn)
+	log.Debugf("Using provider configuration: access_key=%s, secret_key=%s vpc=%s, endpoint=%s, region=%s, oidc_token_file=%s oidc_role_trn=%s \n",
+		volcengine.MaskSecret(accessKey), volcengine.MaskSecret(secretKey), vpcID, pvzEndpoint, regionID, oidcTokenFile, oidcRoleTrn)
 
+	logger := log.StandardLogger()
 	options := []volcengine.Option{
+		volcengine.WithLogger(logger),
 		volcengine.WithPrivateZone(regionID, vpcID),
 		volcengine.WithPrivateZoneEndpoint(pvzEndpoint),
 	}
-	if accessKey != "" && secretKey != "" {
+	if regionList := viper.GetString("regions"); regionList != "" {
+		regions, err := volcengine.ParseRegions(regionList)
+		if err != nil {
+			panic(err)
+		}
+		log.Infof("Managing the private zones of regions=%s\n", regionList)
+		options = append(options, volcengine.WithRegions(regions...))
+	}
+	dnsMode, err := volcengine.ParseDNSMode(viper.GetString("dns_mode"))
+	if err != nil {
+		panic(err)
+	}
+	if dnsMode != volcengine.DNSModePrivate {
+		cloudDNSEndpoint := viper.GetString("clouddns_endpoint")
+		log.Infof("Using dns_mode=%s clouddns_endpoint=%s\n", dnsMode, cloudDNSEndpoint)
+		options = append(options, volcengine.WithDNSMode(dnsMode), volcengine.WithCloudDNSEndpoint(cloudDNSEndpoint))
+	}
+
+	var checkedTokenFile string
+	closeOptions := func() {}
+	if credentialsSecret != "" {
+		log.Infof("Using credentials from secret %s\n", credentialsSecret)
+		creds, err := newSecretCredentials(ctx, credentialsSecret, stsEndpoint, logger.WithField("component", "credentials"))
+		if err != nil {
+			panic(err)
+		}
+		options = append(options, volcengine.WithCredentials(creds))
+	} else if credentialsDir != "" {
+		log.Infof("Using credentials from credentials_dir=%s\n", credentialsDir)
+		creds, err := volcengine.NewFileCredentials(ctx, credentialsDir, logger.WithField("component", "credentials"))
+		if err != nil {
+			panic(err)
+		}
+		options = append(options, volcengine.WithCredentials(creds))
+	} else if accessKey != "" && secretKey != "" {
 		log.Infof("Using static credentials with access_key=%s and secret_key=%s\n", volcengine.MaskSecret(accessKey), volcengine.MaskSecret(secretKey))
 		options = append(options, volcengine.WithStaticCredentials(accessKey, secretKey))
 	} else if oidcTokenFile != "" && oidcRoleTrn != "" {
 		log.Infof("Using oidc token file with oidcTokenFile=%s oidc_role_trn=%s \n", oidcTokenFile, oidcRoleTrn)
 		options = append(options, volcengine.WithOIDCCredentials(stsEndpoint, oidcRoleTrn, oidcTokenFile))
+		checkedTokenFile = oidcTokenFile
+	} else if viper.GetBool("use_instance_role") {
+		instanceRole := viper.GetString("instance_role")
+		log.Infof("Using instance role credentials with instance_role=%q\n", instanceRole)
+		options = append(options, volcengine.WithInstanceRoleCredentials(instanceRole))
 	} else {
-		panic("aksk or oidc token file is required")
+		panic("aksk, oidc token file or use_instance_role is required")
+	}
+	if assumeRoleTrn := viper.GetString("assume_role_trn"); assumeRoleTrn != "" {
+		sessionName := viper.GetString("assume_role_session_name")
+		log.Infof("Assuming assume_role_trn=%s with assume_role_session_name=%s\n", assumeRoleTrn, sessionName)
+		options = append(options, volcengine.WithAssumeRole(assumeRoleTrn, sessionName, stsEndpoint))
 	}
 	if domainFilter != "" {
 		log.Infof("Using domain_filter=%s\n", domainFilter)
 		options = append(options, volcengine.WithDomainFilter(domainFilter))
 	}
-
-	provider, err := volcengine.NewVolcengineProvider(options)
+	if excludeDomains := viper.GetString("exclude_domains"); excludeDomains != "" {
+		log.Infof("Using exclude_domains=%s\n", excludeDomains)
+		options = append(options, volcengine.WithExcludeDomains(excludeDomains))
+	}
+	if apiHeaders := viper.GetString("api_headers"); apiHeaders != "" {
+		headers, err := volcengine.ParseHeaders(apiHeaders)
+		if err != nil {
+			panic(err)
+		}
+		log.Infof("Attaching headers %v to API requests\n", volcengine.HeaderNames(headers))
+		options = append(options, volcengine.WithHeaders(headers))
+	}
+	if recordFile := viper.GetString("api_record_file"); recordFile != "" {
+		recorder, err := volcengine.NewRecorder(recordFile)
+		if err != nil {
+			panic(err)
+		}
+		closeOptions = func() { recorder.Close() }
+		log.Warnf("Recording every API request and response to api_record_file=%s\n", recordFile)
+		options = append(options, volcengine.WithRecorder(recorder))
+	}
+	txtEscapeMode, err := volcengine.ParseTXTEscapeMode(viper.GetString("txt_escape_mode"))
+	if err != nil {
+		panic(err)
+	}
+	if txtEscapeMode != volcengine.TXTEscapeAuto {
+		log.Infof("Using txt_escape_mode=%s\n", txtEscapeMode)
+		options = append(options, volcengine.WithTXTEscapeMode(txtEscapeMode))
+	}
+	if prefixes := viper.GetString("txt_registry_prefixes"); prefixes != "" && prefixes != volcengine.DefaultTXTRegistryPrefix {
+		log.Infof("Recognizing TXT registry values by txt_registry_prefixes=%s\n", prefixes)
+		options = append(options, volcengine.WithTXTRegistryPrefixes(strings.Split(prefixes, ",")...))
+	}
+	if defaultTTLs := viper.GetString("default_ttls"); defaultTTLs != "" {
+		ttls, err := volcengine.ParseDefaultTTLs(defaultTTLs)
+		if err != nil {
+			panic(err)
+		}
+		log.Infof("Using default_ttls=%s\n", defaultTTLs)
+		options = append(options, volcengine.WithDefaultTTLs(ttls))
+	}
+	ttlPolicy := volcengine.TTLPolicy{
+		Default: viper.GetInt64("default_ttl"),
+		Min:     viper.GetInt64("min_ttl"),
+		Max:     viper.GetInt64("max_ttl"),
+	}
+	if err := ttlPolicy.Validate(); err != nil {
+		panic(err)
+	}
+	if ttlPolicy != (volcengine.TTLPolicy{}) {
+		log.Infof("Using default_ttl=%d min_ttl=%d max_ttl=%d\n", ttlPolicy.Default, ttlPolicy.Min, ttlPolicy.Max)
+		options = append(options, volcengine.WithTTLPolicy(ttlPolicy))
+	}
+	if zoneTTLs := viper.GetString("zone_ttls"); zoneTTLs != "" {
+		zones, err := volcengine.ParseZoneTTLPolicies(zoneTTLs)
+		if err != nil {
+			panic(err)
+		}
+		log.Infof("Using zone_ttls=%s\n", zoneTTLs)
+		options = append(options, volcengine.WithZoneTTLPolicies(zones))
+	}
+	targetDotPolicy, err := volcengine.ParseTargetDotPolicy(viper.GetString("target_dot_policy"))
 	if err != nil {
 		panic(err)
 	}
+	if targetDotPolicy != volcengine.TargetDotCNAME {
+		log.Infof("Using target_dot_policy=%s\n", targetDotPolicy)
+		options = append(options, volcengine.WithTargetDotPolicy(targetDotPolicy))
+	}
+	if owner := viper.GetString("hide_registry_owner"); owner != "" {
+		log.Infof("Hiding TXT registry records of hide_registry_owner=%s\n", owner)
+		options = append(options, volcengine.WithHiddenRegistryOwner(owner))
+	}
+	if viper.GetBool("include_disabled_records") {
+		log.Infof("Including disabled records\n")
+		options = append(options, volcengine.WithIncludeDisabledRecords())
+	}
 
-	ctx, stop := signal.NotifyContext(context.Background(),
-		syscall.SIGTERM, // Normal termination signal
-		syscall.SIGINT,  // Ctrl+C interrupt
-		// syscall.SIGKILL cannot be caught (kernel-level signal)
-	)
-	defer stop()
+	if protectedNames := viper.GetString("protected_names"); protectedNames != "" {
+		log.Infof("Protecting protected_names=%s\n", protectedNames)
+		options = append(options, volcengine.WithProtectedNames(strings.Split(protectedNames, ",")...))
+	}
+	if remark := viper.GetString("record_remark"); remark != "" {
+		cluster := viper.GetString("cluster_name")
+		log.Infof("Writing record remarks from record_remark=%q cluster_name=%s\n", remark, cluster)
+		options = append(options, volcengine.WithRecordRemark(remark, cluster))
+	}
+	if viper.GetBool("managed_record_guard") {
+		owner := viper.GetString("managed_record_owner")
+		log.Infof("Only changing records managed by external-dns with managed_record_owner=%s\n", owner)
+		options = append(options, volcengine.WithManagedRecordGuard(owner))
+	}
+	guard := volcengine.DeletionGuard{
+		MaxPercent: viper.GetFloat64("max_delete_percent"),
+		MaxRecords: viper.GetInt("max_delete_records"),
+		Force:      viper.GetBool("force_deletes"),
+	}
+	if guard.MaxPercent > 0 || guard.MaxRecords > 0 {
+		log.Infof("Refusing mass deletions with max_delete_percent=%g max_delete_records=%d force_deletes=%t\n",
+			guard.MaxPercent, guard.MaxRecords, guard.Force)
+		options = append(options, volcengine.WithDeletionGuard(guard))
+	}
 
-	startedChan := make(chan struct{})
-	go api.StartHTTPApi(
-		provider, startedChan,
-		time.Duration(readTimeOut)*time.Second,
-		time.Duration(writeTimeOut)*time.Second,
-		fmt.Sprintf("0.0.0.0:%d", port),
-	)
+	readLimit := volcengine.RateLimit{QPS: viper.GetFloat64("read_qps"), Burst: viper.GetInt("read_burst")}
+	writeLimit := volcengine.RateLimit{QPS: viper.GetFloat64("write_qps"), Burst: viper.GetInt("write_burst")}
+	if readLimit.QPS > 0 || writeLimit.QPS > 0 {
+		log.Infof("Throttling API calls with read_qps=%g read_burst=%d write_qps=%g write_burst=%d\n",
+			readLimit.QPS, readLimit.Burst, writeLimit.QPS, writeLimit.Burst)
+		options = append(options, volcengine.WithRateLimits(readLimit, writeLimit))
+	}
+	zoneQueries := viper.GetInt("max_concurrent_zone_queries")
+	if zoneQueries <= 0 {
+		panic(fmt.Sprintf("invalid max_concurrent_zone_queries %d, at least one zone must be listed at a time", zoneQueries))
+	}
+	log.Infof("Using max_concurrent_zone_queries=%d\n", zoneQueries)
+	options = append(options, volcengine.WithMaxConcurrentZoneQueries(zoneQueries))
+	if zoneWrites := viper.GetInt("max_concurrent_zone_writes"); zoneWrites > 0 {
+		log.Infof("Limiting writes per zone with max_concurrent_zone_writes=%d\n", zoneWrites)
+		options = append(options, volcengine.WithMaxConcurrentZoneWrites(zoneWrites))
+	}
+	retryPolicy := volcengine.RetryPolicy{
+		MaxRetries: viper.GetInt("max_retries"),
+		MinDelay:   viper.GetDuration("retry_min_delay"),
+		MaxDelay:   viper.GetDuration("retry_max_delay"),
+	}
+	if retryPolicy.MaxRetries < 0 || retryPolicy.MinDelay <= 0 || retryPolicy.MaxDelay < retryPolicy.MinDelay {
+		panic(fmt.Sprintf("invalid retry policy max_retries=%d retry_min_delay=%s retry_max_delay=%s",
+			retryPolicy.MaxRetries, retryPolicy.MinDelay, retryPolicy.MaxDelay))
+	}
+	log.Infof("Retrying API calls with max_retries=%d retry_min_delay=%s retry_max_delay=%s\n",
+		retryPolicy.MaxRetries, retryPolicy.MinDelay, retryPolicy.MaxDelay)
+	options = append(options, volcengine.WithRetryPolicy(retryPolicy))
+	applyTimeout := viper.GetDuration("apply_changes_timeout")
+	if applyTimeout == 0 {
+		applyTimeout = defaultApplyTimeout
+	}
+	if applyTimeout > 0 {
+		log.Infof("Bounding each sync with apply_changes_timeout=%s\n", applyTimeout)
+		options = append(options, volcengine.WithApplyChangesTimeout(applyTimeout))
+	}
+	if budget := viper.GetInt("max_changes_per_sync"); budget > 0 {
+		log.Infof("Applying at most max_changes_per_sync=%d record changes per sync\n", budget)
+		options = append(options, volcengine.WithChangeBudget(budget))
+	}
+	if cacheTTL := viper.GetDuration("cache_ttl"); cacheTTL > 0 {
+		log.Infof("Using cache with cache_ttl=%s cache_file=%s\n", cacheTTL, viper.GetString("cache_file"))
+		options = append(options, volcengine.WithCache(cacheTTL, viper.GetString("cache_file")))
+		if refreshAfter := viper.GetDuration("cache_refresh_after"); refreshAfter > 0 {
+			log.Infof("Refreshing cached listings in the background after cache_refresh_after=%s\n", refreshAfter)
+			options = append(options, volcengine.WithCacheRefresh(refreshAfter))
+		}
+	}
+	if viper.GetBool("dry_run") {
+		log.Warnf("Using dry_run, record changes are logged and not applied\n")
+		options = append(options, volcengine.WithDryRun())
+	}
+	if softDelete {
+		log.Infof("Using soft delete with tombstone_retention=%s\n", viper.GetDuration("tombstone_retention"))
+		options = append(options, volcengine.WithSoftDelete(viper.GetDuration("tombstone_retention")))
+	}
 
-	// Wait for the HTTP server to start and then set the healthy and ready flags
-	<-startedChan
-	log.Infof("Listening on port %d...\n", port)
+	return options, checkedTokenFile, closeOptions
+}
 
-	<-ctx.Done()
-	log.Infof("Shutting down...\n")
+// watchConfig applies the changes of the reloadable keys in the configuration file to the running webhook.
+func watchConfig(p *volcengine.Provider) {
+	manager := config.NewManager()
+	manager.Subscribe(applyLogLevel, "log_level")
+	manager.Subscribe(func() {
+		if viper.GetBool("dry_run") {
+			log.Warnf("Using dry_run, record changes are logged and not applied\n")
+		} else {
+			log.Infof("Disabled dry_run, record changes are applied\n")
+		}
+		p.SetDryRun(viper.GetBool("dry_run"))
+	}, "dry_run")
+	manager.Subscribe(func() {
+		if err := p.SetCacheTTL(viper.GetDuration("cache_ttl"), viper.GetDuration("cache_refresh_after")); err != nil {
+			log.Warnf("Failed to change cache_ttl: %v", err)
+		}
+	}, "cache_ttl", "cache_refresh_after")
+	manager.Subscribe(func() {
+		p.SetRateLimits(
+			volcengine.RateLimit{QPS: viper.GetFloat64("read_qps"), Burst: viper.GetInt("read_burst")},
+			volcengine.RateLimit{QPS: viper.GetFloat64("write_qps"), Burst: viper.GetInt("write_burst")})
+	}, "read_qps", "read_burst", "write_qps", "write_burst")
+	if manager.Watch() {
+		log.Infof("Reloading changes of %s\n", viper.ConfigFileUsed())
+	}
+}
+
+// applyLogLevel sets the log level of log_level, the level of the --log-level flag when it is empty.
+func applyLogLevel() {
+	level := viper.GetString("log_level")
+	if level == "" {
+		log.SetLevel(startupLogLevel)
+		return
+	}
+	lev, err := log.ParseLevel(level)
+	if err != nil {
+		log.Warnf("Ignoring log_level: %v", err)
+		return
+	}
+	log.SetLevel(lev)
+}
+
+// validateStartup runs the startup checks of the provider, bounded so an unreachable endpoint does not hang the start.
+func validateStartup(ctx context.Context, p *volcengine.Provider) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if err := p.ValidateStartup(ctx); err != nil {
+		return err
+	}
+	log.Infof("Startup validation passed\n")
+	return nil
+}
+
+// newSecretCredentials watches the credentials secret given as namespace/name or name,
+// the namespace defaults to the namespace of the pod.
+func newSecretCredentials(ctx context.Context, ref, stsEndpoint string, logger volcengine.Logger) (*credentials.Credentials, error) {
+	namespace, name, found := strings.Cut(ref, "/")
+	if !found {
+		name = ref
+		var err error
+		if namespace, err = podNamespace(); err != nil {
+			return nil, fmt.Errorf("failed to detect namespace of secret %s: %v", ref, err)
+		}
+	}
+	client, err := newKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return volcengine.NewSecretCredentials(ctx, client, namespace, name, stsEndpoint, logger)
+}
+
+// startLeaderElection campaigns for the lease in namespace, or the namespace of the pod when empty.
+func startLeaderElection(ctx context.Context, namespace, leaseName string) (*leader.Elector, error) {
+	if namespace == "" {
+		var err error
+		if namespace, err = podNamespace(); err != nil {
+			return nil, fmt.Errorf("failed to detect namespace of lease %s: %v", leaseName, err)
+		}
+	}
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		var err error
+		if identity, err = os.Hostname(); err != nil {
+			return nil, err
+		}
+	}
+	client, err := newKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return leader.Run(ctx, client, leader.DefaultConfig(namespace, leaseName, identity))
+}
+
+// podNamespace returns the namespace of the pod from POD_NAMESPACE or the service account.
+func podNamespace() (string, error) {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace, nil
+	}
+	data, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func newKubeClient() (kubernetes.Interface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load in-cluster config: %v", err)
+	}
+	return kubernetes.NewForConfig(restConfig)
 }
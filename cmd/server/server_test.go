@@ -0,0 +1,47 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLogLevel(t *testing.T) {
+	previous := log.GetLevel()
+	t.Cleanup(func() {
+		log.SetLevel(previous)
+		viper.Reset()
+	})
+	startupLogLevel = log.WarnLevel
+
+	viper.Set("log_level", "debug")
+	applyLogLevel()
+	assert.Equal(t, log.DebugLevel, log.GetLevel())
+
+	// an invalid level keeps the current one
+	viper.Set("log_level", "loud")
+	applyLogLevel()
+	assert.Equal(t, log.DebugLevel, log.GetLevel())
+
+	// clearing log_level restores the level of --log-level
+	viper.Set("log_level", "")
+	applyLogLevel()
+	assert.Equal(t, log.WarnLevel, log.GetLevel())
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"volcengine-provider/cmd/config"
+	"volcengine-provider/pkg/utils"
+	"volcengine-provider/pkg/volcengine"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+var (
+	SyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Reconcile the zones once against the endpoints of a YAML or JSON file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := syncHandler(); err != nil {
+				log.Errorf("Failed to sync: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	syncFile        string
+	syncPolicy      string
+	syncRecordTypes []string
+	syncDryRun      bool
+)
+
+func init() {
+	SyncCmd.Flags().StringVar(&syncFile, "file", "-", "file of the desired endpoints, - is stdin")
+	SyncCmd.Flags().StringVar(&syncPolicy, "policy", "upsert-only", "sync (create/update/delete), upsert-only or create-only")
+	SyncCmd.Flags().StringSliceVar(&syncRecordTypes, "record-types", volcengine.DefaultSyncRecordTypes, "record types managed, records of other types are left alone")
+	SyncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "print the changes without applying them")
+}
+
+// syncHandler plans the changes from the records of the configured zones to the endpoints of the file, prints them
+// and applies them unless --dry-run is set.
+func syncHandler() error {
+	if err := viper.ReadInConfig(); err != nil {
+		log.Infof("No configuration file found: %v\n", err)
+	}
+	if err := config.DecryptSecrets(context.Background()); err != nil {
+		return err
+	}
+	desired, err := readEndpointFile(syncFile)
+	if err != nil {
+		return err
+	}
+	recordTypes := normalizeRecordTypes(syncRecordTypes)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	ctx = utils.WithRequestID(ctx, utils.NewRequestID())
+	options, _, closeOptions := providerOptions(ctx, 0)
+	defer closeOptions()
+	p, err := volcengine.NewVolcengineProvider(options)
+	if err != nil {
+		return err
+	}
+
+	changes, err := p.PlanSync(ctx, desired, syncPolicy, recordTypes)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d to create, %d to update, %d to delete\n", len(changes.Create), len(changes.UpdateNew), len(changes.Delete))
+	fmt.Print(volcengine.FormatChanges(changes))
+	if syncDryRun || !changes.HasChanges() {
+		return nil
+	}
+	return p.ApplyChanges(ctx, changes)
+}
+
+// readEndpointFile reads the desired endpoints of a file, - is stdin.
+func readEndpointFile(path string) ([]*endpoint.Endpoint, error) {
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	endpoints, err := volcengine.ReadEndpoints(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return endpoints, nil
+}
+
+func normalizeRecordTypes(recordTypes []string) []string {
+	res := make([]string, len(recordTypes))
+	for i, recordType := range recordTypes {
+		res[i] = strings.ToUpper(strings.TrimSpace(recordType))
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+
+	"volcengine-provider/pkg/volcengine"
+)
+
+var (
+	recordExplainCmd = &cobra.Command{
+		Use:   "explain",
+		Short: "Explain which zone and host a dns name maps to and show its records",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := recordExplainHandler(); err != nil {
+				log.Errorf("Failed to explain %s: %v", explainName, err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	explainName string
+)
+
+func init() {
+	recordExplainCmd.Flags().StringVar(&explainName, "name", "", "dns name to explain, like www.foo.example.internal")
+
+	RecordCmd.AddCommand(recordExplainCmd)
+}
+
+func recordExplainHandler() error {
+	if explainName == "" {
+		return fmt.Errorf("--name is required")
+	}
+	name := strings.ToLower(strings.TrimSuffix(explainName, "."))
+	if err := volcengine.ValidateDNSName(name); err != nil {
+		fmt.Printf("Name:          %s is invalid and skipped by the provider: %v\n", name, err)
+	} else {
+		fmt.Printf("Name:          %s\n", name)
+	}
+
+	var domainFilter *endpoint.DomainFilter
+	if filter := viper.GetString("domain_filter"); filter != "" {
+		domainFilter = endpoint.NewDomainFilter(strings.Split(filter, ","))
+		if domainFilter.Match(name) {
+			fmt.Printf("Domain filter: %s matches %s\n", filter, name)
+		} else {
+			fmt.Printf("Domain filter: %s does not match %s, external-dns drops the endpoint\n", filter, name)
+		}
+	} else {
+		fmt.Printf("Domain filter: not configured, every zone is managed\n")
+	}
+
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	vpc := viper.GetString("vpc")
+	zones, err := client.ListPrivateZones(ctx, vpc)
+	if err != nil {
+		return err
+	}
+	var candidates []string
+	for _, zone := range zones {
+		zoneName := sdk.StringValue(zone.ZoneName)
+		if name == zoneName || strings.HasSuffix(name, "."+zoneName) {
+			candidates = append(candidates, fmt.Sprintf("%s (%d)", zoneName, sdk.Int32Value(zone.ZID)))
+		}
+	}
+	fmt.Printf("Zones:         %d bound to vpc %q, suffix matches: %v\n", len(zones), vpc, candidates)
+
+	match, ok := volcengine.MatchZone(zones, name)
+	if !ok {
+		fmt.Printf("Zone:          none, changes of %s are skipped\n", name)
+		return nil
+	}
+	fmt.Printf("Zone:          %s (%d), the longest suffix match\n", match.ZoneName, match.ZID)
+	if domainFilter != nil && !domainFilter.Match(match.ZoneName) {
+		fmt.Printf("               excluded by the domain filter, its records are not reported to external-dns\n")
+	}
+	fmt.Printf("Host:          %s\n", match.Host)
+
+	records, err := client.GetPrivateZoneRecords(ctx, match.ZID)
+	if err != nil {
+		return err
+	}
+	found := 0
+	for _, r := range records {
+		if sdk.StringValue(r.Host) != match.Host {
+			continue
+		}
+		found++
+		state := "enabled"
+		if r.Enable != nil && !*r.Enable {
+			state = "disabled"
+		}
+		fmt.Printf("Record:        id: %s, type: %s, value: %s, ttl: %d, %s, remark: %q\n",
+			sdk.StringValue(r.RecordID), sdk.StringValue(r.Type), sdk.StringValue(r.Value), sdk.Int32Value(r.TTL), state, sdk.StringValue(r.Remark))
+	}
+	if found == 0 {
+		fmt.Printf("Records:       none with host %s\n", match.Host)
+	}
+	return nil
+}
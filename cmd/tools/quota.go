@@ -0,0 +1,108 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+var (
+	QuotaCmd = &cobra.Command{
+		Use:   "quota",
+		Short: "Show zone, record and vpc binding usage of the account",
+		Long: `Show zone, record and vpc binding usage of the account.
+
+The PrivateZone API does not expose the account quotas, pass the limits of the
+account with --zone-limit, --record-limit and --vpc-limit to report the usage
+against them.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := quotaHandler(); err != nil {
+				log.Errorf("Failed to show quota: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	zoneLimit   int
+	recordLimit int
+	vpcLimit    int
+)
+
+func init() {
+	QuotaCmd.Flags().IntVar(&zoneLimit, "zone-limit", 0, "zones allowed in the account, 0 if unknown")
+	QuotaCmd.Flags().IntVar(&recordLimit, "record-limit", 0, "records allowed in a zone, 0 if unknown")
+	QuotaCmd.Flags().IntVar(&vpcLimit, "vpc-limit", 0, "vpcs a zone can be bound to, 0 if unknown")
+}
+
+// usage formats a count against an optional limit.
+func usage(used, limit int) string {
+	if limit <= 0 {
+		return fmt.Sprintf("%d", used)
+	}
+	return fmt.Sprintf("%d/%d (%.0f%%)", used, limit, float64(used)*100/float64(limit))
+}
+
+func quotaHandler() error {
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	zones, err := client.ListPrivateZones(ctx, "")
+	if err != nil {
+		return err
+	}
+	vpc := viper.GetString("vpc")
+	var bound []string
+	if vpc != "" {
+		vpcZones, err := client.ListPrivateZones(ctx, vpc)
+		if err != nil {
+			return err
+		}
+		for _, zone := range vpcZones {
+			bound = append(bound, sdk.StringValue(zone.ZoneName))
+		}
+	}
+
+	records := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ZID\tZONE\tRECORDS\tVPCS")
+	for _, zone := range zones {
+		zid := int64(sdk.Int32Value(zone.ZID))
+		detail, err := client.QueryPrivateZone(ctx, zid)
+		if err != nil {
+			return fmt.Errorf("query zone %d: %w", zid, err)
+		}
+		count := int(sdk.Int32Value(zone.RecordCount))
+		records += count
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", zid, sdk.StringValue(zone.ZoneName), usage(count, recordLimit), usage(len(detail.BindVPCs), vpcLimit))
+	}
+
+	fmt.Printf("Zones:   %s\n", usage(len(zones), zoneLimit))
+	if vpc != "" {
+		fmt.Printf("VPC:     %s, %d zones bound\n", vpc, len(bound))
+	}
+	fmt.Printf("Records: %d\n\n", records)
+	return w.Flush()
+}
@@ -19,20 +19,23 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
 
+	"volcengine-provider/cmd/config"
 	"volcengine-provider/pkg/volcengine"
 )
 
 var (
 	RecordCmd = &cobra.Command{
 		Use:   "record",
-		Short: "Add/Delete/List records",
+		Short: "Add/Update/Delete/List records",
 	}
 	recordAddCmd = &cobra.Command{
 		Use:   "add",
@@ -58,12 +61,14 @@ var (
 
 	record string
 	zone   int64
+	group  bool
 )
 
 func init() {
 	RecordCmd.PersistentFlags().Int64Var(&zone, "zone", 0, "zone id")
 	recordAddCmd.PersistentFlags().StringVar(&record, "record", "", "record to add, like host#type#target")
-	recordDeleteCmd.PersistentFlags().StringVar(&record, "record", "", "record to delete, like host#type#target")
+	recordDeleteCmd.PersistentFlags().StringVar(&record, "record", "", "record to delete, like host#type#target, or host#type to delete the record set")
+	recordListCmd.Flags().BoolVar(&group, "group", false, "group records into record sets of host and type with all values")
 
 	RecordCmd.AddCommand(recordAddCmd)
 	RecordCmd.AddCommand(recordDeleteCmd)
@@ -71,6 +76,9 @@ func init() {
 }
 
 func newPrivateZoneClient() (*volcengine.PrivateZoneWrapper, error) {
+	if err := config.DecryptSecrets(context.Background()); err != nil {
+		return nil, err
+	}
 	accessKey := viper.GetString("access_key")
 	secretKey := viper.GetString("secret_key")
 	stsEndpoint := viper.GetString("sts_endpoint")
@@ -92,7 +100,12 @@ func newPrivateZoneClient() (*volcengine.PrivateZoneWrapper, error) {
 	} else {
 		return nil, fmt.Errorf("aksk or oidc token file is required")
 	}
-	client, err := volcengine.NewPrivateZoneWrapper(viper.GetString("region"), viper.GetString("privatezone_endpoint"), c)
+	headers, err := volcengine.ParseHeaders(viper.GetString("api_headers"))
+	if err != nil {
+		return nil, err
+	}
+	client, err := volcengine.NewPrivateZoneWrapper(viper.GetString("region"), viper.GetString("privatezone_endpoint"), c,
+		volcengine.WithPrivateZoneHeaders(headers))
 	if err != nil {
 		log.Errorf("Failed to create client: %v", err)
 		return nil, err
@@ -144,6 +157,12 @@ func recordDelHandler() {
 		os.Exit(1)
 	}
 	recordValue := strings.Split(record, "#")
+	if len(recordValue) == 2 {
+		if err := delRecordSet(client, recordValue[0], recordValue[1]); err != nil {
+			log.Errorf("Delete record set error: %v", err)
+		}
+		return
+	}
 	if len(recordValue) != 3 {
 		log.Errorf("Invalid record value: %s", record)
 		return
@@ -156,7 +175,7 @@ func recordDelHandler() {
 
 func addRecord(client *volcengine.PrivateZoneWrapper, host string, recordType string, target string) error {
 	log.Debugf("add record: %s, type: %s, target: %s", host, recordType, target)
-	err := client.CreatePrivateZoneRecord(context.Background(), zone, host, recordType, target, 0)
+	err := client.CreatePrivateZoneRecord(context.Background(), zone, host, recordType, target, 0, 0, "", "")
 	if err != nil {
 		log.Errorf("Failed to add record: %v", err)
 		return err
@@ -174,6 +193,16 @@ func delRecord(client *volcengine.PrivateZoneWrapper, host string, recordType, t
 	return nil
 }
 
+func delRecordSet(client *volcengine.PrivateZoneWrapper, host string, recordType string) error {
+	log.Debugf("del record set: %s, type: %s", host, recordType)
+	err := client.DeletePrivateZoneRecordSet(context.Background(), zone, host, recordType)
+	if err != nil {
+		log.Errorf("Failed to del record set: %v", err)
+		return err
+	}
+	return nil
+}
+
 func listRecordByZid(client *volcengine.PrivateZoneWrapper, zoneID int64) error {
 	log.Debugf("list record: %d", zoneID)
 	records, err := client.GetPrivateZoneRecords(context.Background(), zoneID)
@@ -181,6 +210,10 @@ func listRecordByZid(client *volcengine.PrivateZoneWrapper, zoneID int64) error
 		log.Errorf("Failed to show record: %v", err)
 		return err
 	}
+	if group {
+		printRecordSets(zoneID, volcengine.GroupRecordSets(records))
+		return nil
+	}
 	for _, r := range records {
 		if r.Host != nil {
 			log.Infof("id: %s, host: %s, type: %s, target: %s, ttl: %d", *r.RecordID, *r.Host, *r.Type, *r.Value, *r.TTL)
@@ -205,3 +238,32 @@ func listRecordByVpc(client *volcengine.PrivateZoneWrapper, vpcID string) error
 
 	return nil
 }
+
+// printRecordSets prints one line per record set with its TTLs and values, weights and disabled values are marked.
+func printRecordSets(zoneID int64, sets []*volcengine.RecordSet) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "ZONE\tHOST\tTYPE\tSET-ID\tTTL\tVALUES\n")
+	for _, set := range sets {
+		ttls := make([]string, 0, 1)
+		for _, ttl := range set.TTLs() {
+			ttls = append(ttls, strconv.Itoa(ttl))
+		}
+		values := make([]string, 0, len(set.Records))
+		for _, r := range set.Records {
+			value := r.Target
+			if r.Weight > 0 {
+				value += fmt.Sprintf(" (weight %d)", r.Weight)
+			}
+			if r.Disabled {
+				value += " (disabled)"
+			}
+			values = append(values, value)
+		}
+		setIdentifier := set.SetIdentifier
+		if setIdentifier == "" {
+			setIdentifier = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", zoneID, set.Host, set.Type, setIdentifier, strings.Join(ttls, ","), strings.Join(values, ", "))
+	}
+	w.Flush()
+}
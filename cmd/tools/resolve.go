@@ -0,0 +1,180 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"volcengine-provider/pkg/volcengine"
+)
+
+var (
+	ResolveCmd = &cobra.Command{
+		Use:   "resolve",
+		Short: "Query a managed name against a resolver and compare the answer with the private zone records",
+		Run: func(cmd *cobra.Command, args []string) {
+			consistent, err := resolveHandler()
+			if err != nil {
+				log.Errorf("Failed to resolve %s: %v", resolveName, err)
+				os.Exit(1)
+			}
+			if !consistent {
+				os.Exit(2)
+			}
+		},
+	}
+
+	resolveName    string
+	resolveType    string
+	resolveServer  string
+	resolveTimeout time.Duration
+)
+
+func init() {
+	ResolveCmd.Flags().StringVar(&resolveName, "name", "", "dns name to resolve, like www.example.internal")
+	ResolveCmd.Flags().StringVar(&resolveType, "type", "A", "record type, one of A, AAAA, CNAME, TXT, MX, SRV")
+	addResolverFlags(ResolveCmd, &resolveServer)
+	ResolveCmd.Flags().DurationVar(&resolveTimeout, "timeout", 5*time.Second, "timeout of the query")
+}
+
+// resolveHandler reports whether the resolver answers exactly the values of the private zone records.
+func resolveHandler() (bool, error) {
+	if resolveName == "" {
+		return false, fmt.Errorf("--name is required")
+	}
+	recordType := strings.ToUpper(resolveType)
+	ctx := context.Background()
+
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return false, err
+	}
+	zones, err := client.ListPrivateZones(ctx, viper.GetString("vpc"))
+	if err != nil {
+		return false, err
+	}
+	match, ok := volcengine.MatchZone(zones, resolveName)
+	if !ok {
+		return false, fmt.Errorf("no private zone bound to vpc %q matches %s", viper.GetString("vpc"), resolveName)
+	}
+	records, err := client.GetPrivateZoneRecordsByHostType(ctx, match.ZID, match.Host, recordType)
+	if err != nil {
+		return false, err
+	}
+	expected := volcengine.RecordValues(records, match.Host, recordType)
+	log.Infof("Zone %s (%d), host %s, %s records: %v", match.ZoneName, match.ZID, match.Host, recordType, expected)
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+	answered, err := lookup(ctx, newResolver(resolveServer), resolveName, recordType)
+	if err != nil {
+		return false, err
+	}
+	log.Infof("Resolver %s answered: %v", resolveServer, answered)
+
+	missing, unexpected := volcengine.CompareRecordValues(recordType, expected, answered)
+	if len(missing) == 0 && len(unexpected) == 0 {
+		log.Infof("Answer matches the private zone")
+		return true, nil
+	}
+	if len(missing) > 0 {
+		log.Warnf("Missing from the answer, not propagated yet or cached negatively: %v", missing)
+	}
+	if len(unexpected) > 0 {
+		log.Warnf("Answered but not in the zone, stale cache or another zone or resolver rule: %v", unexpected)
+	}
+	return false, nil
+}
+
+// DefaultResolver is the DNS server of Volcengine VPCs, which answers for the private zones bound to the VPC.
+const DefaultResolver = "100.96.0.2:53"
+
+// addResolverFlags adds --resolver, and --server as its deprecated alias, defaulting to the VPC DNS.
+// The host running the CLI often cannot reach the resolver of the cluster, so it can be pointed elsewhere.
+func addResolverFlags(cmd *cobra.Command, resolver *string) {
+	cmd.Flags().StringVar(resolver, "resolver", DefaultResolver, "resolver to query as IP:port, port 53 unless given, like the VPC DNS")
+	cmd.Flags().StringVar(resolver, "server", DefaultResolver, "resolver to query")
+	_ = cmd.Flags().MarkDeprecated("server", "use --resolver instead")
+}
+
+// newResolver returns a resolver sending every query to server.
+func newResolver(server string) *net.Resolver {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// lookup returns the answer in the value format of private zone records.
+func lookup(ctx context.Context, resolver *net.Resolver, name, recordType string) ([]string, error) {
+	var values []string
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			values = append(values, ip.String())
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, cname)
+	case "TXT":
+		return resolver.LookupTXT(ctx, name)
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			values = append(values, fmt.Sprintf("%d %s", mx.Pref, mx.Host))
+		}
+	case "SRV":
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range srvs {
+			values = append(values, fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, srv.Target))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", recordType)
+	}
+	return values, nil
+}
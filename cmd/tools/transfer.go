@@ -0,0 +1,200 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+
+	"volcengine-provider/pkg/volcengine"
+)
+
+var (
+	recordExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export the records of a zone, or of all zones of the VPC, as json, yaml or a BIND zone file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := recordExportHandler(); err != nil {
+				log.Errorf("Failed to export records: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+	recordImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import exported records into the zones of the VPC with the same names, or into --zone",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := recordImportHandler(); err != nil {
+				log.Errorf("Failed to import records: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	transferFormat string
+	transferFile   string
+	importDryRun   bool
+	importPrune    bool
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{recordExportCmd, recordImportCmd} {
+		cmd.Flags().StringVar(&transferFormat, "format", string(volcengine.ExportFormatJSON), "file format, json, yaml or zonefile")
+		cmd.Flags().StringVar(&transferFile, "file", "-", "file to write or read, - is stdout or stdin")
+	}
+	recordImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "print the changes without applying them")
+	recordImportCmd.Flags().BoolVar(&importPrune, "prune", false, "delete the records of the zones missing from the file")
+
+	RecordCmd.AddCommand(recordExportCmd)
+	RecordCmd.AddCommand(recordImportCmd)
+}
+
+func recordExportHandler() error {
+	format, err := volcengine.ParseExportFormat(transferFormat)
+	if err != nil {
+		return err
+	}
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	targets, err := transferZones(ctx, client)
+	if err != nil {
+		return err
+	}
+	zones := make([]volcengine.ExportedZone, 0, len(targets))
+	for _, zoneName := range slices.Sorted(maps.Keys(targets)) {
+		zoneID := targets[zoneName]
+		records, err := client.GetPrivateZoneRecords(ctx, zoneID)
+		if err != nil {
+			return fmt.Errorf("failed to list records of zone %s: %v", zoneName, err)
+		}
+		zones = append(zones, volcengine.ExportZone(zoneName, zoneID, records))
+	}
+
+	out := io.Writer(os.Stdout)
+	if transferFile != "-" {
+		f, err := os.Create(transferFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return volcengine.EncodeZones(out, zones, format)
+}
+
+func recordImportHandler() error {
+	format, err := volcengine.ParseExportFormat(transferFormat)
+	if err != nil {
+		return err
+	}
+	in := io.Reader(os.Stdin)
+	if transferFile != "-" {
+		f, err := os.Open(transferFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	zones, err := volcengine.DecodeZones(in, format)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", transferFile, err)
+	}
+	if zone != 0 && len(zones) != 1 {
+		return fmt.Errorf("--zone imports a file of one zone, the file has %d", len(zones))
+	}
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	targets, err := transferZones(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	// plan every zone before changing any, so a file naming a missing zone changes nothing
+	plans := make([]*volcengine.ImportPlan, len(zones))
+	zoneIDs := make([]int64, len(zones))
+	for i, z := range zones {
+		zoneIDs[i] = zone
+		if zone == 0 {
+			zoneID, ok := targets[strings.ToLower(z.Zone)]
+			if !ok {
+				return fmt.Errorf("zone %s is not bound to vpc %s, create it with zone create", z.Zone, viper.GetString("vpc"))
+			}
+			zoneIDs[i] = zoneID
+		}
+		records, err := client.GetPrivateZoneRecords(ctx, zoneIDs[i])
+		if err != nil {
+			return fmt.Errorf("failed to list records of zone %s: %v", z.Zone, err)
+		}
+		plans[i] = volcengine.PlanImport(z, records, importPrune)
+		for _, r := range plans[i].Skipped {
+			log.Warnf("Skipping %s record %s of zone %s, disabled or not supported by privatezone", r.Type, r.Host, z.Zone)
+		}
+	}
+
+	for i, plan := range plans {
+		fmt.Printf("zone %s (%d): %d to create, %d to update, %d to delete\n",
+			zones[i].Zone, zoneIDs[i], len(plan.Create), len(plan.Update), len(plan.Delete))
+		fmt.Print(plan)
+		if importDryRun || plan.Empty() {
+			continue
+		}
+		if err := client.ApplyImport(ctx, zoneIDs[i], plan); err != nil {
+			return fmt.Errorf("failed to import zone %s: %v", zones[i].Zone, err)
+		}
+	}
+	return nil
+}
+
+// transferZones returns the zone IDs by lower case name, of --zone when set or else of the zones of the VPC.
+func transferZones(ctx context.Context, client *volcengine.PrivateZoneWrapper) (map[string]int64, error) {
+	if zone != 0 {
+		resp, err := client.QueryPrivateZone(ctx, zone)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]int64{strings.ToLower(sdk.StringValue(resp.ZoneName)): zone}, nil
+	}
+	vpc := viper.GetString("vpc")
+	if vpc == "" {
+		return nil, fmt.Errorf("--zone or vpc is required")
+	}
+	zones, err := client.ListPrivateZones(ctx, vpc)
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]int64, len(zones))
+	for _, z := range zones {
+		targets[strings.ToLower(sdk.StringValue(z.ZoneName))] = int64(sdk.Int32Value(z.ZID))
+	}
+	return targets, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+
+	"volcengine-provider/pkg/volcengine"
+)
+
+var (
+	recordUpdateCmd = &cobra.Command{
+		Use:   "update",
+		Short: "Update the value, TTL, weight or remark of a record in place",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := recordUpdateHandler(cmd); err != nil {
+				log.Errorf("Failed to update record: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	updateRecordID string
+	updateValue    string
+	updateTTL      int32
+	updateWeight   int32
+	updateRemark   string
+)
+
+func init() {
+	recordUpdateCmd.Flags().StringVar(&record, "record", "", "record to update, like host#type, or host#type#target when the record set has several values")
+	recordUpdateCmd.Flags().StringVar(&updateRecordID, "id", "", "id of the record to update, instead of --record")
+	recordUpdateCmd.Flags().StringVar(&updateValue, "value", "", "new value of the record")
+	recordUpdateCmd.Flags().Int32Var(&updateTTL, "ttl", 0, "new TTL of the record in seconds")
+	recordUpdateCmd.Flags().Int32Var(&updateWeight, "weight", 0, "new weight of the record, used when the zone load balances")
+	recordUpdateCmd.Flags().StringVar(&updateRemark, "remark", "", "new remark of the record")
+
+	RecordCmd.AddCommand(recordUpdateCmd)
+}
+
+func recordUpdateHandler(cmd *cobra.Command) error {
+	if zone == 0 {
+		return fmt.Errorf("--zone is required")
+	}
+	if (record == "") == (updateRecordID == "") {
+		return fmt.Errorf("either --record or --id is required")
+	}
+	flags := cmd.Flags()
+	if !flags.Changed("value") && !flags.Changed("ttl") && !flags.Changed("weight") && !flags.Changed("remark") {
+		return fmt.Errorf("nothing to update, use --value, --ttl, --weight or --remark")
+	}
+	if flags.Changed("value") && updateValue == "" {
+		return fmt.Errorf("--value must not be empty")
+	}
+	if flags.Changed("ttl") && updateTTL <= 0 {
+		return fmt.Errorf("invalid --ttl %d, expected a positive number of seconds", updateTTL)
+	}
+	if flags.Changed("weight") && updateWeight <= 0 {
+		return fmt.Errorf("invalid --weight %d, expected a positive weight", updateWeight)
+	}
+
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	r, err := findRecord(client)
+	if err != nil {
+		return err
+	}
+	// the API replaces every field, the fields not given keep their current values
+	value, ttl, remark := sdk.StringValue(r.Value), sdk.Int32Value(r.TTL), sdk.StringValue(r.Remark)
+	if flags.Changed("value") {
+		value = updateValue
+	}
+	if flags.Changed("ttl") {
+		ttl = updateTTL
+	}
+	if flags.Changed("remark") {
+		remark = updateRemark
+	}
+	// a zero weight and an empty line keep the weight and line of the record
+	err = client.UpdatePrivateZoneRecord(context.Background(), zone, sdk.StringValue(r.RecordID), sdk.StringValue(r.Host),
+		sdk.StringValue(r.Type), value, ttl, updateWeight, "", remark)
+	if err != nil {
+		return err
+	}
+	log.Infof("Updated record id: %s, host: %s, type: %s, target: %s -> %s, ttl: %d -> %d", sdk.StringValue(r.RecordID),
+		sdk.StringValue(r.Host), sdk.StringValue(r.Type), sdk.StringValue(r.Value), value, sdk.Int32Value(r.TTL), ttl)
+	return nil
+}
+
+// findRecord returns the record given with --id, or the single record matching --record.
+func findRecord(client *volcengine.PrivateZoneWrapper) (*privatezone.RecordForListRecordsOutput, error) {
+	if updateRecordID != "" {
+		records, err := client.GetPrivateZoneRecords(context.Background(), zone)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			if sdk.StringValue(r.RecordID) == updateRecordID {
+				return r, nil
+			}
+		}
+		return nil, fmt.Errorf("record %s not found in zone %d", updateRecordID, zone)
+	}
+
+	recordValue := strings.Split(record, "#")
+	if len(recordValue) != 2 && len(recordValue) != 3 {
+		return nil, fmt.Errorf("invalid record value: %s", record)
+	}
+	records, err := client.GetPrivateZoneRecordsByHostType(context.Background(), zone, recordValue[0], recordValue[1])
+	if err != nil {
+		return nil, err
+	}
+	var matches []*privatezone.RecordForListRecordsOutput
+	for _, r := range records {
+		if len(recordValue) == 3 && sdk.StringValue(r.Value) != recordValue[2] {
+			continue
+		}
+		matches = append(matches, r)
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("record %s not found in zone %d", record, zone)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, r := range matches {
+		ids = append(ids, fmt.Sprintf("%s (%s)", sdk.StringValue(r.RecordID), sdk.StringValue(r.Value)))
+	}
+	return nil, fmt.Errorf("%d records match %s, give the target as host#type#target or the record --id: %s",
+		len(matches), record, strings.Join(ids, ", "))
+}
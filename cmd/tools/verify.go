@@ -0,0 +1,120 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+
+	"volcengine-provider/pkg/volcengine"
+)
+
+var (
+	VerifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Resolve every record managed by the webhook and report the names answered differently than the zone",
+		Run: func(cmd *cobra.Command, args []string) {
+			consistent, err := verifyHandler()
+			if err != nil {
+				log.Errorf("Failed to verify records: %v", err)
+				os.Exit(1)
+			}
+			if !consistent {
+				os.Exit(2)
+			}
+		},
+	}
+
+	verifyZone    int64
+	verifyServer  string
+	verifyTimeout time.Duration
+)
+
+func init() {
+	VerifyCmd.Flags().Int64Var(&verifyZone, "zone", 0, "zone id, all zones bound to the vpc when not set")
+	addResolverFlags(VerifyCmd, &verifyServer)
+	VerifyCmd.Flags().DurationVar(&verifyTimeout, "timeout", 5*time.Second, "timeout of each query")
+}
+
+// verifiableTypes are the record types lookup can query.
+var verifiableTypes = map[string]bool{"A": true, "AAAA": true, "CNAME": true, "TXT": true, "MX": true, "SRV": true}
+
+// verifyHandler reports whether the resolver answers the values of every managed name.
+func verifyHandler() (bool, error) {
+	ctx := context.Background()
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return false, err
+	}
+	zones, err := client.ListPrivateZones(ctx, viper.GetString("vpc"))
+	if err != nil {
+		return false, err
+	}
+	resolver := newResolver(verifyServer)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tMISSING\tUNEXPECTED\tERROR")
+	checked, mismatches := 0, 0
+	for _, zone := range zones {
+		zid := int64(sdk.Int32Value(zone.ZID))
+		if verifyZone != 0 && zid != verifyZone {
+			continue
+		}
+		records, err := client.GetPrivateZoneRecords(ctx, zid)
+		if err != nil {
+			return false, err
+		}
+		for _, name := range volcengine.ManagedNames(sdk.StringValue(zone.ZoneName), records) {
+			if !verifiableTypes[name.Type] {
+				log.Debugf("Skipping %s %s, the record type cannot be resolved", name.Name, name.Type)
+				continue
+			}
+			checked++
+			qctx, cancel := context.WithTimeout(ctx, verifyTimeout)
+			answered, err := lookup(qctx, resolver, name.Name, name.Type)
+			cancel()
+			if err != nil {
+				mismatches++
+				fmt.Fprintf(w, "%s\t%s\t%s\t\t%v\n", name.Name, name.Type, strings.Join(name.Values, ","), err)
+				continue
+			}
+			missing, unexpected := volcengine.CompareRecordValues(name.Type, name.Values, answered)
+			if len(missing) == 0 && len(unexpected) == 0 {
+				continue
+			}
+			mismatches++
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", name.Name, name.Type, strings.Join(missing, ","), strings.Join(unexpected, ","))
+		}
+	}
+	if verifyZone != 0 && checked == 0 {
+		log.Warnf("No managed records found in zone %d bound to vpc %q", verifyZone, viper.GetString("vpc"))
+	}
+	if mismatches == 0 {
+		log.Infof("All %d managed names resolve to the zone data", checked)
+		return true, nil
+	}
+	log.Warnf("%d of %d managed names resolve differently than the zone data", mismatches, checked)
+	return false, w.Flush()
+}
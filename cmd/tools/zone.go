@@ -0,0 +1,371 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+
+	"volcengine-provider/pkg/volcengine"
+)
+
+var (
+	ZoneCmd = &cobra.Command{
+		Use:   "zone",
+		Short: "List/Create/Delete privatezones, show/set their settings and VPC bindings",
+	}
+	zoneListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List zones, of all VPCs unless --vpc is set",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneListHandler(); err != nil {
+				log.Errorf("Failed to list zones: %v", err)
+				os.Exit(1)
+			}
+		},
+	}
+	zoneCreateCmd = &cobra.Command{
+		Use:   "create <zone name>",
+		Short: "Create a zone bound to the VPCs",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneCreateHandler(args[0]); err != nil {
+				log.Errorf("Failed to create zone %s: %v", args[0], err)
+				os.Exit(1)
+			}
+		},
+	}
+	zoneDeleteCmd = &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an empty zone, or a zone with its records with --force",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneDeleteHandler(); err != nil {
+				log.Errorf("Failed to delete zone %d: %v", zoneID, err)
+				os.Exit(1)
+			}
+		},
+	}
+	zoneBindVPCCmd = &cobra.Command{
+		Use:   "bind-vpc",
+		Short: "Bind VPCs to a zone, keeping its other bindings",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneBindHandler(true); err != nil {
+				log.Errorf("Failed to bind vpc to zone %d: %v", zoneID, err)
+				os.Exit(1)
+			}
+		},
+	}
+	zoneUnbindVPCCmd = &cobra.Command{
+		Use:   "unbind-vpc",
+		Short: "Unbind VPCs from a zone, keeping its other bindings",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneBindHandler(false); err != nil {
+				log.Errorf("Failed to unbind vpc from zone %d: %v", zoneID, err)
+				os.Exit(1)
+			}
+		},
+	}
+	zoneShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show zone settings",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneShowHandler(); err != nil {
+				log.Errorf("Failed to show zone %d: %v", zoneID, err)
+				os.Exit(1)
+			}
+		},
+	}
+	zoneStatsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Show record counts by type, owner and TTL of a zone",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneStatsHandler(); err != nil {
+				log.Errorf("Failed to show stats of zone %d: %v", zoneID, err)
+				os.Exit(1)
+			}
+		},
+	}
+	zoneSetCmd = &cobra.Command{
+		Use:   "set",
+		Short: "Set zone recursion, load balance and remark",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := zoneSetHandler(cmd); err != nil {
+				log.Errorf("Failed to set zone %d: %v", zoneID, err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	zoneID      int64
+	recursion   string
+	loadBalance string
+	zoneRemark  string
+	zoneVPCs    string
+	vpcRegion   string
+	forceDelete bool
+)
+
+func init() {
+	ZoneCmd.PersistentFlags().Int64Var(&zoneID, "zone", 0, "zone id")
+	zoneSetCmd.Flags().StringVar(&recursion, "recursion", "", "resolve names missing from the zone through public dns, on or off")
+	zoneSetCmd.Flags().StringVar(&loadBalance, "load-balance", "", "answer with weighted records of the same host and type, on or off")
+	zoneSetCmd.Flags().StringVar(&zoneRemark, "remark", "", "zone remark")
+	zoneListCmd.Flags().StringVar(&zoneVPCs, "vpc", "", "list the zones bound to these comma separated VPCs only")
+	zoneCreateCmd.Flags().StringVar(&zoneRemark, "remark", "", "zone remark")
+	zoneDeleteCmd.Flags().BoolVar(&forceDelete, "force", false, "delete the zone together with its records")
+	for _, cmd := range []*cobra.Command{zoneCreateCmd, zoneBindVPCCmd, zoneUnbindVPCCmd} {
+		cmd.Flags().StringVar(&zoneVPCs, "vpc", "", "comma separated VPCs, defaults to the configured vpc")
+		cmd.Flags().StringVar(&vpcRegion, "vpc-region", "", "region of the VPCs, defaults to the configured region")
+	}
+
+	ZoneCmd.AddCommand(zoneShowCmd)
+	ZoneCmd.AddCommand(zoneSetCmd)
+	ZoneCmd.AddCommand(zoneStatsCmd)
+	ZoneCmd.AddCommand(zoneListCmd)
+	ZoneCmd.AddCommand(zoneCreateCmd)
+	ZoneCmd.AddCommand(zoneDeleteCmd)
+	ZoneCmd.AddCommand(zoneBindVPCCmd)
+	ZoneCmd.AddCommand(zoneUnbindVPCCmd)
+}
+
+// bindingVPCs returns the VPCs and their region of the create and bind commands, defaulting to the configuration.
+func bindingVPCs() ([]string, string, error) {
+	vpcs, region := zoneVPCs, vpcRegion
+	if vpcs == "" {
+		vpcs = viper.GetString("vpc")
+	}
+	if region == "" {
+		region = viper.GetString("region")
+	}
+	var res []string
+	for _, vpc := range strings.Split(vpcs, ",") {
+		if vpc = strings.TrimSpace(vpc); vpc != "" {
+			res = append(res, vpc)
+		}
+	}
+	if len(res) == 0 {
+		return nil, "", fmt.Errorf("--vpc is required")
+	}
+	if region == "" {
+		return nil, "", fmt.Errorf("--vpc-region is required")
+	}
+	return res, region, nil
+}
+
+func zoneListHandler() error {
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	zones, err := client.ListPrivateZones(context.Background(), zoneVPCs)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ZID\tZONE\tRECORDS\tUPDATED")
+	for _, zone := range zones {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", sdk.Int32Value(zone.ZID), sdk.StringValue(zone.ZoneName),
+			sdk.Int32Value(zone.RecordCount), sdk.StringValue(zone.UpdatedAt))
+	}
+	return w.Flush()
+}
+
+func zoneCreateHandler(zoneName string) error {
+	vpcs, region, err := bindingVPCs()
+	if err != nil {
+		return err
+	}
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	if zoneID, err = client.CreatePrivateZone(context.Background(), zoneName, region, vpcs, zoneRemark); err != nil {
+		return err
+	}
+	return printZone(client)
+}
+
+func zoneDeleteHandler() error {
+	if zoneID == 0 {
+		return fmt.Errorf("--zone is required")
+	}
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	if err := client.DeletePrivateZone(context.Background(), zoneID, forceDelete); err != nil {
+		if !forceDelete {
+			return fmt.Errorf("%v, use --force to delete a zone with records", err)
+		}
+		return err
+	}
+	fmt.Printf("Deleted zone %d\n", zoneID)
+	return nil
+}
+
+func zoneBindHandler(bind bool) error {
+	if zoneID == 0 {
+		return fmt.Errorf("--zone is required")
+	}
+	vpcs, region, err := bindingVPCs()
+	if err != nil {
+		return err
+	}
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	if bind {
+		err = client.BindVPC(context.Background(), zoneID, region, vpcs)
+	} else {
+		err = client.UnbindVPC(context.Background(), zoneID, region, vpcs)
+	}
+	if err != nil {
+		return err
+	}
+	return printZone(client)
+}
+
+// parseSwitch parses an on/off flag value.
+func parseSwitch(flag, value string) (*bool, error) {
+	switch strings.ToLower(value) {
+	case "on", "true":
+		return sdk.Bool(true), nil
+	case "off", "false":
+		return sdk.Bool(false), nil
+	default:
+		return nil, fmt.Errorf("invalid --%s %q, expected on or off", flag, value)
+	}
+}
+
+func zoneSetHandler(cmd *cobra.Command) error {
+	if zoneID == 0 {
+		return fmt.Errorf("--zone is required")
+	}
+	var settings volcengine.ZoneSettings
+	var err error
+	if cmd.Flags().Changed("recursion") {
+		if settings.RecursionMode, err = parseSwitch("recursion", recursion); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("load-balance") {
+		if settings.LoadBalance, err = parseSwitch("load-balance", loadBalance); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("remark") {
+		settings.Remark = sdk.String(zoneRemark)
+	}
+	if settings == (volcengine.ZoneSettings{}) {
+		return fmt.Errorf("nothing to set, use --recursion, --load-balance or --remark")
+	}
+
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	if err := client.UpdatePrivateZone(context.Background(), zoneID, settings); err != nil {
+		return err
+	}
+	return printZone(client)
+}
+
+func zoneShowHandler() error {
+	if zoneID == 0 {
+		return fmt.Errorf("--zone is required")
+	}
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	return printZone(client)
+}
+
+func zoneStatsHandler() error {
+	if zoneID == 0 {
+		return fmt.Errorf("--zone is required")
+	}
+	client, err := newPrivateZoneClient()
+	if err != nil {
+		return err
+	}
+	records, err := client.GetPrivateZoneRecords(context.Background(), zoneID)
+	if err != nil {
+		return err
+	}
+	stats := volcengine.ComputeZoneStats(records)
+
+	fmt.Printf("Records:      %d (%d disabled)\n", stats.Records, stats.Disabled)
+	fmt.Printf("Managed:      %d\n", stats.Managed)
+	fmt.Printf("Unmanaged:    %d\n", stats.Unmanaged)
+	if stats.LastUpdated.IsZero() {
+		fmt.Printf("Last updated: -\n")
+	} else {
+		fmt.Printf("Last updated: %s (%s)\n", stats.LastUpdated.Local().Format(time.RFC3339), stats.LastUpdatedHost)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "\nTYPE\tRECORDS")
+	for _, t := range slices.Sorted(maps.Keys(stats.Types)) {
+		fmt.Fprintf(w, "%s\t%d\n", t, stats.Types[t])
+	}
+	fmt.Fprintln(w, "\nTTL\tRECORDS")
+	for _, ttl := range slices.Sorted(maps.Keys(stats.TTLs)) {
+		fmt.Fprintf(w, "%d\t%d\n", ttl, stats.TTLs[ttl])
+	}
+	return w.Flush()
+}
+
+func printZone(client *volcengine.PrivateZoneWrapper) error {
+	zone, err := client.QueryPrivateZone(context.Background(), zoneID)
+	if err != nil {
+		return err
+	}
+	vpcs := make([]string, 0, len(zone.BindVPCs))
+	for _, vpc := range zone.BindVPCs {
+		vpcs = append(vpcs, sdk.StringValue(vpc.ID))
+	}
+	loadBalance := "-"
+	if zone.LoadBalance != nil {
+		loadBalance = onOff(*zone.LoadBalance)
+	}
+	fmt.Printf("Zone:         %d %s\n", sdk.Int32Value(zone.ZID), sdk.StringValue(zone.ZoneName))
+	fmt.Printf("VPCs:         %s\n", strings.Join(vpcs, ", "))
+	fmt.Printf("Records:      %d\n", sdk.Int32Value(zone.RecordCount))
+	fmt.Printf("Recursion:    %s\n", onOff(sdk.BoolValue(zone.RecursionMode)))
+	fmt.Printf("Load balance: %s\n", loadBalance)
+	fmt.Printf("Remark:       %s\n", sdk.StringValue(zone.Remark))
+	return nil
+}
+
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
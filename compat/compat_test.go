@@ -0,0 +1,199 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package compat drives the webhook with the external-dns webhook client of the release pinned in go.mod.
+// CI runs it once per supported release after `go get sigs.k8s.io/external-dns@<version>`,
+// the webhook itself is built from the parent module so it always runs with its own dependencies.
+package compat
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/webhook"
+)
+
+const (
+	compatZone = "compat.example.com"
+	compatVpc  = "vpc-compat"
+)
+
+// webhookURL is the URL of the webhook started by TestMain.
+var webhookURL string
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run builds the provider binary and starts a fake PrivateZone API and the webhook in front of it.
+func run(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "compat")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	bin := filepath.Join(dir, "volcengine-provider")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = ".."
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to build the webhook:", err)
+		return 1
+	}
+
+	apiPort, webhookPort := freePort(), freePort()
+	fakepz := exec.Command(bin, "fakepz", "--listen", fmt.Sprintf("127.0.0.1:%d", apiPort), "--zone", compatZone, "--vpc", compatVpc)
+	server := exec.Command(bin, "start", "--port", fmt.Sprint(webhookPort))
+	server.Env = append(os.Environ(),
+		"VOLCENGINE_PRIVATEZONE_ENDPOINT="+fmt.Sprintf("http://127.0.0.1:%d", apiPort),
+		"VOLCENGINE_REGION=cn-beijing",
+		"VOLCENGINE_VPC="+compatVpc,
+		"VOLCENGINE_ACCESS_KEY=compat",
+		"VOLCENGINE_SECRET_KEY=compat",
+	)
+	for _, cmd := range []*exec.Cmd{fakepz, server} {
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Start(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer func(cmd *exec.Cmd) {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+		}(cmd)
+		// the webhook validates the zones at startup, the fake API must be serving before it starts
+		if cmd == fakepz {
+			if err := waitListening(fmt.Sprintf("127.0.0.1:%d", apiPort), 30*time.Second); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+		}
+	}
+
+	webhookURL = fmt.Sprintf("http://127.0.0.1:%d", webhookPort)
+	if err := waitReady(webhookURL+"/healthz", 30*time.Second); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return m.Run()
+}
+
+func freePort() int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func waitListening(addr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("fake privatezone API not listening on %s after %s", addr, timeout)
+}
+
+func waitReady(url string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("webhook not ready at %s after %s", url, timeout)
+}
+
+// records returns the records of the zone by name and type, without the zone apex records.
+func records(t *testing.T, p *webhook.WebhookProvider) map[string]*endpoint.Endpoint {
+	eps, err := p.Records(context.Background())
+	require.NoError(t, err)
+	res := make(map[string]*endpoint.Endpoint)
+	for _, ep := range eps {
+		sort.Strings(ep.Targets)
+		res[ep.DNSName+" "+ep.RecordType] = ep
+	}
+	return res
+}
+
+func TestNegotiation(t *testing.T) {
+	p, err := webhook.NewWebhookProvider(webhookURL)
+	require.NoError(t, err)
+	assert.True(t, p.GetDomainFilter().Match("www."+compatZone), "domain filter of the negotiation is not understood")
+}
+
+func TestRecordsAndApplyChanges(t *testing.T) {
+	p, err := webhook.NewWebhookProvider(webhookURL)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	www := endpoint.NewEndpointWithTTL("www."+compatZone, endpoint.RecordTypeA, 300, "10.0.0.1", "10.0.0.2")
+	txt := endpoint.NewEndpoint("a-www."+compatZone, endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=compat\"")
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{www, txt}}))
+
+	got := records(t, p)
+	require.Contains(t, got, "www."+compatZone+" A")
+	assert.Equal(t, endpoint.Targets{"10.0.0.1", "10.0.0.2"}, got["www."+compatZone+" A"].Targets)
+	assert.Equal(t, endpoint.TTL(300), got["www."+compatZone+" A"].RecordTTL)
+	require.Contains(t, got, "a-www."+compatZone+" TXT")
+
+	updated := endpoint.NewEndpointWithTTL("www."+compatZone, endpoint.RecordTypeA, 600, "10.0.0.3")
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{UpdateOld: []*endpoint.Endpoint{www}, UpdateNew: []*endpoint.Endpoint{updated}}))
+	got = records(t, p)
+	require.Contains(t, got, "www."+compatZone+" A")
+	assert.Equal(t, endpoint.Targets{"10.0.0.3"}, got["www."+compatZone+" A"].Targets)
+	assert.Equal(t, endpoint.TTL(600), got["www."+compatZone+" A"].RecordTTL)
+
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{updated, txt}}))
+	got = records(t, p)
+	assert.NotContains(t, got, "www."+compatZone+" A")
+	assert.NotContains(t, got, "a-www."+compatZone+" TXT")
+}
+
+func TestAdjustEndpoints(t *testing.T) {
+	p, err := webhook.NewWebhookProvider(webhookURL)
+	require.NoError(t, err)
+
+	in := []*endpoint.Endpoint{endpoint.NewEndpoint("api."+compatZone, endpoint.RecordTypeCNAME, "lb.example.com")}
+	out, err := p.AdjustEndpoints(in)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, in[0].DNSName, out[0].DNSName)
+	assert.Equal(t, in[0].Targets, out[0].Targets)
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// batchSize mirrors the chunk size BatchForEach is called with for record writes.
+const batchSize = 100
+
+// batchHosts returns n hostnames under the domain.
+func batchHosts(n int, domain string) []string {
+	hosts := make([]string, n)
+	for i := range hosts {
+		hosts[i] = fmt.Sprintf("batch-%03d.%s", i, domain)
+	}
+	return hosts
+}
+
+var _ = Describe("Batch boundaries", Label("local"), func() {
+	var local *LocalWebhook
+
+	BeforeEach(func() {
+		var err error
+		local, err = NewLocalWebhook("example.internal")
+		Expect(err).NotTo(HaveOccurred(), "Failed to start local webhook")
+		DeferCleanup(local.Close)
+	})
+
+	It("creates every record exactly once across chunk boundaries", func() {
+		// the last endpoint has three targets, so its records straddle the first chunk boundary
+		hosts := batchHosts(batchSize, "example.internal")
+		var creates []*endpoint.Endpoint
+		for _, host := range hosts[:batchSize-1] {
+			creates = append(creates, endpoint.NewEndpoint(host, endpoint.RecordTypeA, "10.0.0.1"))
+		}
+		creates = append(creates, endpoint.NewEndpoint(hosts[batchSize-1], endpoint.RecordTypeA, "10.0.1.1", "10.0.1.2", "10.0.1.3"))
+		Expect(local.ApplyChanges(&plan.Changes{Create: creates})).To(Equal(http.StatusNoContent))
+
+		Expect(local.Calls("BatchCreateRecord")).To(Equal(2), "Records were not chunked by %d", batchSize)
+		records, err := local.Store.ListRecords(local.ZoneID, "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(batchSize + 2))
+		seen := make(map[string]int, len(records))
+		for _, record := range records {
+			seen[volcengine.StringValue(record.Host)+" "+volcengine.StringValue(record.Value)]++
+		}
+		for key, n := range seen {
+			Expect(n).To(Equal(1), "Record %s was created %d times", key, n)
+		}
+		Expect(seen).To(HaveLen(batchSize + 2))
+	})
+})
+
+var _ = Describe("Batch boundaries in a cluster", Label("batch"), func() {
+	var (
+		config        *TestConfig
+		kubeClient    *KubernetesClient
+		pzClient      *PrivateZoneClient
+		testZoneID    int64
+		testNamespace = "external-dns-e2e-batch"
+	)
+
+	BeforeEach(func() {
+		var err error
+		config, err = LoadTestConfig()
+		Expect(err).NotTo(HaveOccurred(), "Failed to load test config")
+		Expect(config.DomainName).NotTo(BeEmpty(), "DomainName must be provided")
+		testZoneID, err = strconv.ParseInt(config.PrivateZoneID, 10, 64)
+		Expect(err).NotTo(HaveOccurred(), "Failed to parse private zone ID")
+
+		pzClient, err = NewPrivateZoneClient(config)
+		Expect(err).NotTo(HaveOccurred(), "Failed to create privatezone client")
+		kubeconfig, err := GetClusterKubeconfig(config)
+		Expect(err).NotTo(HaveOccurred(), "Failed to get cluster kubeconfig")
+		kubeClient, err = NewKubernetesClient(kubeconfig)
+		Expect(err).NotTo(HaveOccurred(), "Failed to create kubernetes client")
+
+		ctx := context.Background()
+		_ = kubeClient.DeleteNamespace(ctx, testNamespace)
+		time.Sleep(3 * time.Second)
+		Expect(kubeClient.CreateNamespace(ctx, testNamespace)).To(Succeed())
+		Expect(pzClient.CleanupRecordsForDomain(ctx, testZoneID, config.DomainName)).To(Succeed())
+
+		DeferCleanup(func(ctx context.Context) {
+			Expect(kubeClient.DeleteNamespace(ctx, testNamespace)).To(Succeed())
+			Expect(pzClient.CleanupRecordsForDomain(ctx, testZoneID, config.DomainName)).To(Succeed())
+		})
+	})
+
+	It("creates every record of a Service with more hostnames than a batch exactly once", func(ctx context.Context) {
+		hosts := batchHosts(batchSize+1, config.DomainName)
+		By(fmt.Sprintf("Creating a Service with %d hostnames", len(hosts)))
+		Expect(kubeClient.CreateTestService(ctx, testNamespace, "batch-app", strings.Join(hosts, ","))).To(Succeed())
+
+		suffix := "." + config.DomainName
+		counts := func(ctx context.Context) map[string]int {
+			records, err := pzClient.ListRecords(ctx, testZoneID)
+			Expect(err).NotTo(HaveOccurred(), "Failed to list DNS records")
+			counts := make(map[string]int)
+			for _, record := range records {
+				host := volcengine.StringValue(record.Host)
+				if volcengine.StringValue(record.Type) == endpoint.RecordTypeA && strings.HasPrefix(host, "batch-") {
+					counts[host]++
+				}
+			}
+			return counts
+		}
+		Eventually(func(ctx context.Context) int {
+			return len(counts(ctx))
+		}).WithContext(ctx).WithTimeout(10*time.Minute).WithPolling(15*time.Second).Should(Equal(len(hosts)),
+			"Not every hostname got an A record")
+
+		By("Checking that no record was duplicated, also after further syncs")
+		Consistently(func(ctx context.Context) []string {
+			var duplicates []string
+			for host, n := range counts(ctx) {
+				if n > 1 {
+					duplicates = append(duplicates, fmt.Sprintf("%s%s x%d", host, suffix, n))
+				}
+			}
+			return duplicates
+		}).WithContext(ctx).WithTimeout(2 * config.SyncInterval).WithPolling(15 * time.Second).Should(BeEmpty())
+	}, SpecTimeout(20*time.Minute))
+})
@@ -0,0 +1,61 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package e2e
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var _ = Describe("Dual-stack records", Label("local"), func() {
+	const lbName = "lb.dualstack.internal"
+	var local *LocalWebhook
+
+	BeforeEach(func() {
+		var err error
+		local, err = NewLocalWebhook("dualstack.internal")
+		Expect(err).NotTo(HaveOccurred(), "Failed to start local webhook")
+		DeferCleanup(local.Close)
+	})
+
+	It("creates the A and AAAA records of a dual-stack LoadBalancer service", func() {
+		Expect(local.ApplyChanges(&plan.Changes{Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint(lbName, endpoint.RecordTypeA, "192.0.2.10"),
+			endpoint.NewEndpoint(lbName, endpoint.RecordTypeAAAA, "2001:0db8:0:0:0:0:0:10", "2001:db8::11"),
+		}})).To(Equal(http.StatusNoContent))
+		Expect(local.Values("lb", endpoint.RecordTypeA)).To(ConsistOf("192.0.2.10"))
+		Expect(local.Values("lb", endpoint.RecordTypeAAAA)).To(ConsistOf("2001:db8::10", "2001:db8::11"))
+
+		By("deleting an AAAA target given in its expanded form")
+		Expect(local.ApplyChanges(&plan.Changes{Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint(lbName, endpoint.RecordTypeAAAA, "2001:db8:0000:0000:0000:0000:0000:0011"),
+		}})).To(Equal(http.StatusNoContent))
+		Expect(local.Values("lb", endpoint.RecordTypeAAAA)).To(ConsistOf("2001:db8::10"))
+		Expect(local.Values("lb", endpoint.RecordTypeA)).To(ConsistOf("192.0.2.10"))
+	})
+
+	It("skips AAAA endpoints with IPv4 targets without API writes", func() {
+		Expect(local.ApplyChanges(&plan.Changes{Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint(lbName, endpoint.RecordTypeAAAA, "192.0.2.10"),
+		}})).To(Equal(http.StatusNoContent))
+		Expect(local.Writes()).To(BeZero())
+		Expect(local.Values("lb", endpoint.RecordTypeAAAA)).To(BeEmpty())
+	})
+})
@@ -24,6 +24,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 var _ = Describe("ExternalDNS Volcengine Provider", func() {
@@ -559,4 +560,87 @@ var _ = Describe("ExternalDNS Volcengine Provider", func() {
 			}
 		})
 	})
+
+	Describe("DNSEndpoint CRD source tests", func() {
+		BeforeEach(func() {
+			installed, err := kubeClient.DNSEndpointCRDInstalled()
+			Expect(err).NotTo(HaveOccurred(), "Failed to discover the DNSEndpoint CRD")
+			if !installed {
+				Skip("the DNSEndpoint CRD is not installed, external-dns needs it for --source=crd")
+			}
+		})
+
+		It("should create, update and delete records of several types from a DNSEndpoint", func() {
+			ctx := context.Background()
+
+			By("Preparing DNSEndpoint endpoints")
+			host := "crd-test"
+			domain := fmt.Sprintf("%s.%s", host, testDomain)
+			endpoints := []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL(domain, endpoint.RecordTypeA, 300, "192.168.10.10", "192.168.10.11"),
+				endpoint.NewEndpointWithTTL(domain, endpoint.RecordTypeAAAA, 300, "2001:db8::10"),
+				endpoint.NewEndpointWithTTL("crd-alias."+testDomain, endpoint.RecordTypeCNAME, 300, domain),
+				endpoint.NewEndpointWithTTL("crd-mail."+testDomain, endpoint.RecordTypeMX, 300, "10 "+domain),
+				endpoint.NewEndpointWithTTL("_http._tcp."+domain, endpoint.RecordTypeSRV, 300, "10 5 80 "+domain),
+				endpoint.NewEndpointWithTTL("crd-txt."+testDomain, endpoint.RecordTypeTXT, 300, "owner=crd-test"),
+			}
+
+			By("Creating DNSEndpoint")
+			err := kubeClient.CreateTestDNSEndpoint(ctx, testNamespace, testName, endpoints)
+			Expect(err).NotTo(HaveOccurred(), "Failed to create test DNSEndpoint")
+
+			By("Waiting for external-dns to process and create DNS records")
+			expected := map[string]string{
+				host + "/A":                   "192.168.10.10",
+				host + "/AAAA":                "2001:db8::10",
+				"crd-alias/CNAME":             domain,
+				"crd-mail/MX":                 "10 " + domain,
+				"_http._tcp." + host + "/SRV": "10 5 80 " + domain,
+				"crd-txt/TXT":                 "owner=crd-test",
+			}
+			for key, value := range expected {
+				recordHost, recordType, _ := strings.Cut(key, "/")
+				found, err := kubeClient.WaitForDNSRecordUpdate(ctx, pzClient, testZoneID, recordHost, recordType, "", 300, 2*time.Minute)
+				Expect(err).NotTo(HaveOccurred(), "Error waiting for %s record of %s", recordType, recordHost)
+				Expect(found).To(BeTrue(), "%s record of %s was not created within timeout", recordType, recordHost)
+
+				record, err := pzClient.GetRecordByHostAndType(ctx, testZoneID, recordHost, recordType)
+				Expect(err).NotTo(HaveOccurred(), "Failed to get %s record of %s", recordType, recordHost)
+				Expect(strings.TrimSuffix(strings.Trim(*record.Value, "\""), ".")).To(Equal(value), "%s record of %s has an unexpected value", recordType, recordHost)
+			}
+
+			By("Updating the A endpoint targets and TTL")
+			endpoints[0] = endpoint.NewEndpointWithTTL(domain, endpoint.RecordTypeA, 600, "192.168.10.20")
+			err = kubeClient.UpdateTestDNSEndpoint(ctx, testNamespace, testName, endpoints)
+			Expect(err).NotTo(HaveOccurred(), "Failed to update test DNSEndpoint")
+
+			By("Waiting for external-dns to process and update the A record")
+			updated, err := kubeClient.WaitForDNSRecordUpdate(ctx, pzClient, testZoneID, host, "A", "192.168.10.20", 600, 2*time.Minute)
+			Expect(err).NotTo(HaveOccurred(), "Failed to wait for A record update")
+			Expect(updated).To(BeTrue(), "A record was not updated within timeout period")
+
+			By("Verifying the removed target is deleted")
+			records, err := pzClient.ListRecords(ctx, testZoneID)
+			Expect(err).NotTo(HaveOccurred(), "Failed to list DNS records")
+			aRecords := 0
+			for _, record := range records {
+				if *record.Host == host && *record.Type == "A" {
+					aRecords++
+				}
+			}
+			Expect(aRecords).To(Equal(1), "Stale A records of the DNSEndpoint were not deleted")
+
+			By("Deleting DNSEndpoint")
+			err = kubeClient.DeleteTestResources(ctx, testNamespace, testName)
+			Expect(err).NotTo(HaveOccurred(), "Failed to delete test DNSEndpoint")
+
+			By("Waiting for external-dns to delete the DNS records")
+			for key := range expected {
+				recordHost, recordType, _ := strings.Cut(key, "/")
+				deleted, err := pzClient.WaitForRecordDeleted(ctx, testZoneID, recordHost, recordType, 2*time.Minute)
+				Expect(err).NotTo(HaveOccurred(), "Error waiting for deletion of %s record of %s", recordType, recordHost)
+				Expect(deleted).To(BeTrue(), "%s record of %s was not deleted within timeout", recordType, recordHost)
+			}
+		})
+	})
 })
@@ -17,23 +17,33 @@ package e2e
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
+// dnsEndpointResource is the external-dns DNSEndpoint custom resource.
+var dnsEndpointResource = schema.GroupVersionResource{Group: "externaldns.k8s.io", Version: "v1alpha1", Resource: "dnsendpoints"}
+
 // KubernetesClient encapsulates operations on Kubernetes resources
 type KubernetesClient struct {
 	clientset *kubernetes.Clientset
+	dynamic   dynamic.Interface
 }
 
 // NewKubernetesClient creates a new Kubernetes client
@@ -66,7 +76,13 @@ func NewKubernetesClient(kubeconfig string) (*KubernetesClient, error) {
 		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
 	}
 
-	return &KubernetesClient{clientset: clientset}, nil
+	// Create dynamic client for custom resources
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes dynamic client: %w", err)
+	}
+
+	return &KubernetesClient{clientset: clientset, dynamic: dynamicClient}, nil
 }
 
 // CreateTestService creates a test Service resource
@@ -183,9 +199,82 @@ func (k *KubernetesClient) DeleteTestResources(ctx context.Context, namespace, n
 		}
 	}
 
+	// Delete DNSEndpoint, a missing CRD is reported as not found as well
+	if err := k.dynamic.Resource(dnsEndpointResource).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete dnsendpoint: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// DNSEndpointCRDInstalled reports whether the cluster serves the external-dns DNSEndpoint resource
+func (k *KubernetesClient) DNSEndpointCRDInstalled() (bool, error) {
+	resources, err := k.clientset.Discovery().ServerResourcesForGroupVersion(dnsEndpointResource.GroupVersion().String())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == dnsEndpointResource.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateTestDNSEndpoint creates a test DNSEndpoint resource with the endpoints
+func (k *KubernetesClient) CreateTestDNSEndpoint(ctx context.Context, namespace, name string, endpoints []*endpoint.Endpoint) error {
+	spec, err := dnsEndpointSpec(endpoints)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": dnsEndpointResource.GroupVersion().String(),
+		"kind":       "DNSEndpoint",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": spec,
+	}}
+
+	_, err = k.dynamic.Resource(dnsEndpointResource).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{})
+	return err
+}
+
+// UpdateTestDNSEndpoint replaces the endpoints of a test DNSEndpoint resource
+func (k *KubernetesClient) UpdateTestDNSEndpoint(ctx context.Context, namespace, name string, endpoints []*endpoint.Endpoint) error {
+	obj, err := k.dynamic.Resource(dnsEndpointResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get dnsendpoint: %w", err)
+	}
+	spec, err := dnsEndpointSpec(endpoints)
+	if err != nil {
+		return err
+	}
+	obj.Object["spec"] = spec
+
+	_, err = k.dynamic.Resource(dnsEndpointResource).Namespace(namespace).Update(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
+
+// dnsEndpointSpec returns the spec of a DNSEndpoint with the endpoints, in their JSON form
+func dnsEndpointSpec(endpoints []*endpoint.Endpoint) (map[string]interface{}, error) {
+	data, err := json.Marshal(map[string]interface{}{"endpoints": endpoints})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dnsendpoint spec: %w", err)
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dnsendpoint spec: %w", err)
+	}
+	return spec, nil
+}
+
 // WaitForDNSRecord continuously queries PrivateZone, waiting for DNS record creation to complete
 func (k *KubernetesClient) WaitForDNSRecord(ctx context.Context, pzClient *PrivateZoneClient, zoneID int64, host string, timeout time.Duration) (bool, error) {
 	ticker := time.NewTicker(5 * time.Second)
@@ -439,3 +528,55 @@ func (k *KubernetesClient) WaitForDNSRecordUpdate(ctx context.Context, pzClient
 		}
 	}
 }
+
+// SetDeploymentImage sets the image of a container of the deployment, given as namespace/name.
+func (k *KubernetesClient) SetDeploymentImage(ctx context.Context, deployment, container, image string) error {
+	namespace, name, _ := strings.Cut(deployment, "/")
+	d, err := k.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s: %w", deployment, err)
+	}
+	found := false
+	for i := range d.Spec.Template.Spec.Containers {
+		if d.Spec.Template.Spec.Containers[i].Name == container {
+			d.Spec.Template.Spec.Containers[i].Image = image
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("deployment %s has no container %s", deployment, container)
+	}
+	_, err = k.clientset.AppsV1().Deployments(namespace).Update(ctx, d, metav1.UpdateOptions{})
+	return err
+}
+
+// WaitForRollout waits until every replica of the deployment, given as namespace/name, runs the latest template.
+func (k *KubernetesClient) WaitForRollout(ctx context.Context, deployment string, timeout time.Duration) error {
+	namespace, name, _ := strings.Cut(deployment, "/")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		d, err := k.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil && rolledOut(d) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for the rollout of deployment %s", deployment)
+		case <-ticker.C:
+		}
+	}
+}
+
+func rolledOut(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.AvailableReplicas == replicas &&
+		d.Status.Replicas == replicas
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"volcengine-provider/pkg/fakepz"
+	"volcengine-provider/pkg/volcengine"
+	"volcengine-provider/pkg/webhook"
+
+	. "github.com/onsi/gomega"
+	volc "github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+const (
+	localRegion = "cn-beijing"
+	localVpc    = "vpc-e2e"
+)
+
+// LocalWebhook runs the webhook in process against an in-memory PrivateZone API,
+// for specs that do not need a cluster or cloud credentials.
+type LocalWebhook struct {
+	Store  *fakepz.Store
+	ZoneID int32
+	// URL of the webhook API
+	URL string
+
+	api     *httptest.Server
+	webhook *httptest.Server
+	writes  atomic.Int64
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// NewLocalWebhook starts the webhook for a zone bound to the VPC.
+func NewLocalWebhook(zone string) (*LocalWebhook, error) {
+	l := &LocalWebhook{Store: fakepz.NewStore(), calls: map[string]int{}}
+	l.ZoneID = l.Store.AddZone(zone, localVpc)
+
+	pz := fakepz.NewServer(l.Store, localRegion)
+	l.api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		action := req.URL.Query().Get("Action")
+		if !strings.HasPrefix(action, "List") && !strings.HasPrefix(action, "Query") {
+			l.writes.Add(1)
+		}
+		l.mu.Lock()
+		l.calls[action]++
+		l.mu.Unlock()
+		pz.ServeHTTP(w, req)
+	}))
+
+	p, err := volcengine.NewVolcengineProvider([]volcengine.Option{
+		volcengine.WithPrivateZone(localRegion, localVpc),
+		volcengine.WithPrivateZoneEndpoint(l.api.URL),
+		volcengine.WithStaticCredentials("ak", "sk"),
+	})
+	if err != nil {
+		l.api.Close()
+		return nil, err
+	}
+	l.webhook = httptest.NewServer(webhook.NewHandler(p))
+	l.URL = l.webhook.URL
+	return l, nil
+}
+
+// Writes returns the number of mutating PrivateZone API calls.
+func (l *LocalWebhook) Writes() int64 {
+	return l.writes.Load()
+}
+
+// Calls returns the number of PrivateZone API calls of the action, e.g. BatchCreateRecord.
+func (l *LocalWebhook) Calls(action string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.calls[action]
+}
+
+// ApplyChanges posts the changes to the webhook API and returns the status code.
+func (l *LocalWebhook) ApplyChanges(changes *plan.Changes) int {
+	body, err := json.Marshal(changes)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	req, err := http.NewRequest(http.MethodPost, l.URL+api.UrlRecords, strings.NewReader(string(body)))
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	req.Header.Set(api.ContentTypeHeader, api.MediaTypeFormatAndVersion)
+	resp, err := http.DefaultClient.Do(req)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+// Values returns the values of the records of the host, relative to the zone, and type in the zone.
+func (l *LocalWebhook) Values(host, recordType string) []string {
+	records, err := l.Store.ListRecords(l.ZoneID, host, recordType)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	var values []string
+	for _, record := range records {
+		values = append(values, volc.StringValue(record.Value))
+	}
+	return values
+}
+
+// Close stops the webhook and the API.
+func (l *LocalWebhook) Close() {
+	l.webhook.Close()
+	l.api.Close()
+}
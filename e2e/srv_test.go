@@ -0,0 +1,78 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+var _ = Describe("SRV records", Label("local"), func() {
+	var local *LocalWebhook
+
+	BeforeEach(func() {
+		var err error
+		local, err = NewLocalWebhook("example.internal")
+		Expect(err).NotTo(HaveOccurred(), "Failed to start local webhook")
+		DeferCleanup(local.Close)
+	})
+
+	srvRecords := func() []string {
+		return local.Values("_sip._udp", endpoint.RecordTypeSRV)
+	}
+
+	It("creates, lists and deletes SRV records of a headless service", func() {
+		srv := &endpoint.Endpoint{
+			DNSName:    "_sip._udp.example.internal",
+			RecordType: endpoint.RecordTypeSRV,
+			Targets:    endpoint.Targets{"10 5 5060 sip-0.example.internal.", "10 5 5060 sip-1.example.internal."},
+		}
+		Expect(local.ApplyChanges(&plan.Changes{Create: []*endpoint.Endpoint{srv}})).To(Equal(http.StatusNoContent))
+		Expect(srvRecords()).To(ConsistOf("10 5 5060 sip-0.example.internal.", "10 5 5060 sip-1.example.internal."))
+
+		req, err := http.NewRequest(http.MethodGet, local.URL+api.UrlRecords, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", api.MediaTypeFormatAndVersion)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		var listed []*endpoint.Endpoint
+		Expect(json.NewDecoder(resp.Body).Decode(&listed)).To(Succeed())
+		Expect(listed).To(ContainElement(HaveField("DNSName", "_sip._udp.example.internal")))
+
+		// external-dns may send the targets without trailing dot, they still match the stored values
+		deleted := &endpoint.Endpoint{
+			DNSName:    "_sip._udp.example.internal",
+			RecordType: endpoint.RecordTypeSRV,
+			Targets:    endpoint.Targets{"10 5 5060 sip-0.example.internal"},
+		}
+		Expect(local.ApplyChanges(&plan.Changes{Delete: []*endpoint.Endpoint{deleted}})).To(Equal(http.StatusNoContent))
+		Expect(srvRecords()).To(ConsistOf("10 5 5060 sip-1.example.internal."))
+	})
+
+	It("skips malformed SRV values without API writes", func() {
+		bad := endpoint.NewEndpoint("_sip._udp.example.internal", endpoint.RecordTypeSRV, "sip.example.internal")
+		Expect(local.ApplyChanges(&plan.Changes{Create: []*endpoint.Endpoint{bad}})).To(Equal(http.StatusNoContent))
+		Expect(local.Writes()).To(BeZero())
+		Expect(srvRecords()).To(BeEmpty())
+	})
+})
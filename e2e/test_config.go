@@ -19,6 +19,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/volcengine/volcengine-go-sdk/service/vke"
 	"github.com/volcengine/volcengine-go-sdk/volcengine"
@@ -35,6 +36,14 @@ type TestConfig struct {
 	ClusterName   string
 	DomainName    string
 	PrivateZoneID string
+	// Upgrade spec: the webhook container of WebhookDeployment (namespace/name) is moved from
+	// PreviousWebhookImage to CurrentWebhookImage, the spec is skipped unless both are set
+	WebhookDeployment    string
+	WebhookContainer     string
+	PreviousWebhookImage string
+	CurrentWebhookImage  string
+	// SyncInterval is the external-dns --interval, changes are expected to converge within a few of them
+	SyncInterval time.Duration
 }
 
 // LoadTestConfig loads test configuration from environment variables or config file
@@ -47,6 +56,25 @@ func LoadTestConfig() (*TestConfig, error) {
 		ClusterName:   os.Getenv("VOLCENGINE_CLUSTER_NAME"),
 		DomainName:    os.Getenv("TEST_DOMAIN_NAME"),
 		PrivateZoneID: os.Getenv("PRIVATE_ZONE_ID"),
+
+		WebhookDeployment:    os.Getenv("WEBHOOK_DEPLOYMENT"),
+		WebhookContainer:     os.Getenv("WEBHOOK_CONTAINER"),
+		PreviousWebhookImage: os.Getenv("PREVIOUS_WEBHOOK_IMAGE"),
+		CurrentWebhookImage:  os.Getenv("CURRENT_WEBHOOK_IMAGE"),
+		SyncInterval:         time.Minute,
+	}
+	if interval := os.Getenv("EXTERNAL_DNS_INTERVAL"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXTERNAL_DNS_INTERVAL %q: %w", interval, err)
+		}
+		config.SyncInterval = d
+	}
+	if config.WebhookDeployment == "" {
+		config.WebhookDeployment = "external-dns/external-dns"
+	}
+	if config.WebhookContainer == "" {
+		config.WebhookContainer = "webhook"
 	}
 
 	if config.AK == "" || config.SK == "" || (config.ClusterID == "" && config.ClusterName == "") {
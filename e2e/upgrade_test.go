@@ -0,0 +1,121 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+var _ = Describe("Webhook upgrade", Label("upgrade"), func() {
+	var (
+		config        *TestConfig
+		kubeClient    *KubernetesClient
+		pzClient      *PrivateZoneClient
+		testZoneID    int64
+		testNamespace = "external-dns-e2e-upgrade"
+	)
+
+	BeforeEach(func() {
+		var err error
+		config, err = LoadTestConfig()
+		Expect(err).NotTo(HaveOccurred(), "Failed to load test config")
+		if config.PreviousWebhookImage == "" || config.CurrentWebhookImage == "" {
+			Skip("PREVIOUS_WEBHOOK_IMAGE and CURRENT_WEBHOOK_IMAGE are required for the upgrade spec")
+		}
+		Expect(config.DomainName).NotTo(BeEmpty(), "DomainName must be provided")
+		testZoneID, err = strconv.ParseInt(config.PrivateZoneID, 10, 64)
+		Expect(err).NotTo(HaveOccurred(), "Failed to parse private zone ID")
+
+		pzClient, err = NewPrivateZoneClient(config)
+		Expect(err).NotTo(HaveOccurred(), "Failed to create privatezone client")
+		kubeconfig, err := GetClusterKubeconfig(config)
+		Expect(err).NotTo(HaveOccurred(), "Failed to get cluster kubeconfig")
+		kubeClient, err = NewKubernetesClient(kubeconfig)
+		Expect(err).NotTo(HaveOccurred(), "Failed to create kubernetes client")
+
+		ctx := context.Background()
+		_ = kubeClient.DeleteNamespace(ctx, testNamespace)
+		time.Sleep(3 * time.Second)
+		Expect(kubeClient.CreateNamespace(ctx, testNamespace)).To(Succeed())
+		Expect(pzClient.CleanupRecordsForDomain(ctx, testZoneID, config.DomainName)).To(Succeed())
+
+		DeferCleanup(func(ctx context.Context) {
+			By("Restoring the current webhook image")
+			Expect(kubeClient.SetDeploymentImage(ctx, config.WebhookDeployment, config.WebhookContainer, config.CurrentWebhookImage)).To(Succeed())
+			Expect(kubeClient.WaitForRollout(ctx, config.WebhookDeployment, 5*time.Minute)).To(Succeed())
+			Expect(kubeClient.DeleteNamespace(ctx, testNamespace)).To(Succeed())
+			Expect(pzClient.CleanupRecordsForDomain(ctx, testZoneID, config.DomainName)).To(Succeed())
+		})
+	})
+
+	// snapshot returns the id, value and TTL of every record of the hosts, including their TXT registry records.
+	snapshot := func(ctx context.Context, hosts ...string) []string {
+		records, err := pzClient.ListRecords(ctx, testZoneID)
+		Expect(err).NotTo(HaveOccurred(), "Failed to list DNS records")
+		var lines []string
+		for _, record := range records {
+			host := volcengine.StringValue(record.Host)
+			for _, h := range hosts {
+				if host == h || strings.HasSuffix(host, "-"+h) || strings.HasPrefix(host, h+"-") {
+					lines = append(lines, fmt.Sprintf("%s %s %s %s %d", volcengine.StringValue(record.RecordID), host,
+						volcengine.StringValue(record.Type), volcengine.StringValue(record.Value), volcengine.Int32Value(record.TTL)))
+					break
+				}
+			}
+		}
+		sort.Strings(lines)
+		return lines
+	}
+
+	It("preserves the records of the previous release without re-creating them", func(ctx context.Context) {
+		By("Deploying the previous webhook release " + config.PreviousWebhookImage)
+		Expect(kubeClient.SetDeploymentImage(ctx, config.WebhookDeployment, config.WebhookContainer, config.PreviousWebhookImage)).To(Succeed())
+		Expect(kubeClient.WaitForRollout(ctx, config.WebhookDeployment, 5*time.Minute)).To(Succeed())
+
+		By("Creating records of every normalized kind with the previous release")
+		aHost, cnameHost := "upgrade", "upgrade-cname"
+		Expect(kubeClient.CreateTestService(ctx, testNamespace, "upgrade-app", aHost+"."+config.DomainName)).To(Succeed())
+		Expect(kubeClient.CreateTestExternalNameService(ctx, testNamespace, "upgrade-cname", cnameHost+"."+config.DomainName, "target.example.com")).To(Succeed())
+		for _, host := range []string{aHost, cnameHost} {
+			found, err := kubeClient.WaitForDNSRecord(ctx, pzClient, testZoneID, host, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred(), "Error waiting for DNS record %s", host)
+			Expect(found).To(BeTrue(), "DNS record %s was not created within timeout", host)
+		}
+		// let the previous release converge, e.g. on the TXT registry records
+		time.Sleep(2 * config.SyncInterval)
+		before := snapshot(ctx, aHost, cnameHost)
+		Expect(before).NotTo(BeEmpty())
+
+		By("Upgrading to the current build " + config.CurrentWebhookImage)
+		Expect(kubeClient.SetDeploymentImage(ctx, config.WebhookDeployment, config.WebhookContainer, config.CurrentWebhookImage)).To(Succeed())
+		Expect(kubeClient.WaitForRollout(ctx, config.WebhookDeployment, 5*time.Minute)).To(Succeed())
+
+		By("Checking that several syncs of the current build leave every record untouched")
+		Consistently(func(ctx context.Context) []string {
+			return snapshot(ctx, aHost, cnameHost)
+		}).WithContext(ctx).WithTimeout(3*config.SyncInterval).WithPolling(15*time.Second).Should(Equal(before),
+			"records were re-created or changed after the upgrade")
+	}, SpecTimeout(20*time.Minute))
+})
@@ -0,0 +1,117 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+
+	"volcengine-provider/pkg/volcengine"
+	"volcengine-provider/pkg/webhook"
+)
+
+var _ = Describe("Webhook payload validation", Label("local"), func() {
+	var local *LocalWebhook
+
+	BeforeEach(func() {
+		var err error
+		local, err = NewLocalWebhook("example.internal")
+		Expect(err).NotTo(HaveOccurred(), "Failed to start local webhook")
+	})
+
+	AfterEach(func() {
+		local.Close()
+	})
+
+	post := func(path, contentType, body string) (int, webhook.ErrorResponse) {
+		req, err := http.NewRequest(http.MethodPost, local.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		if contentType != "" {
+			req.Header.Set(api.ContentTypeHeader, contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		var errResp webhook.ErrorResponse
+		if len(data) > 0 {
+			Expect(json.Unmarshal(data, &errResp)).To(Succeed(), "Error response is not JSON: %s", data)
+		}
+		return resp.StatusCode, errResp
+	}
+
+	DescribeTable("rejects malformed payloads with a well-formed error and no API writes",
+		func(path, contentType, body string, status int, code string) {
+			gotStatus, errResp := post(path, contentType, body)
+			Expect(gotStatus).To(Equal(status))
+			Expect(errResp.Code).To(Equal(code))
+			Expect(errResp.Message).NotTo(BeEmpty())
+			Expect(local.Writes()).To(BeZero())
+		},
+		Entry("invalid JSON changes", api.UrlRecords, api.MediaTypeFormatAndVersion, `{"Create": [`,
+			http.StatusBadRequest, webhook.ErrCodeInvalidRequest),
+		Entry("changes of the wrong shape", api.UrlRecords, api.MediaTypeFormatAndVersion, `{"Create": "www.example.internal"}`,
+			http.StatusBadRequest, webhook.ErrCodeInvalidRequest),
+		Entry("invalid JSON endpoints", api.UrlAdjustEndpoints, api.MediaTypeFormatAndVersion, `[{"dnsName": }]`,
+			http.StatusBadRequest, webhook.ErrCodeInvalidRequest),
+		Entry("plain JSON media type", api.UrlRecords, "application/json", `{}`,
+			http.StatusUnsupportedMediaType, webhook.ErrCodeUnsupportedMediaType),
+		Entry("unsupported webhook version", api.UrlRecords, "application/external.dns.webhook+json;version=2", `{}`,
+			http.StatusUnsupportedMediaType, webhook.ErrCodeUnsupportedMediaType),
+		Entry("missing media type", api.UrlRecords, "", `{}`,
+			http.StatusUnsupportedMediaType, webhook.ErrCodeUnsupportedMediaType),
+	)
+
+	It("rejects an unsupported Accept header", func() {
+		req, err := http.NewRequest(http.MethodGet, local.URL+api.UrlRecords, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", "text/html")
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotAcceptable))
+		var errResp webhook.ErrorResponse
+		Expect(json.NewDecoder(resp.Body).Decode(&errResp)).To(Succeed())
+		Expect(errResp.Code).To(Equal(webhook.ErrCodeNotAcceptable))
+	})
+
+	It("rejects endpoints of unknown record types without API writes", func() {
+		status, errResp := post(api.UrlRecords, api.MediaTypeFormatAndVersion,
+			`{"Create": [{"dnsName": "www.example.internal", "recordType": "FOO", "targets": ["1.2.3.4"]}]}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+		Expect(errResp.Code).To(Equal(volcengine.ErrCodeUnsupportedRecordType))
+		Expect(errResp.Endpoints).To(HaveLen(1))
+		Expect(local.Writes()).To(BeZero())
+
+		records, err := local.Store.ListRecords(local.ZoneID, "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(BeEmpty())
+	})
+
+	It("applies well-formed changes", func() {
+		status, _ := post(api.UrlRecords, api.MediaTypeFormatAndVersion,
+			`{"Create": [{"dnsName": "www.example.internal", "recordType": "A", "targets": ["1.2.3.4"]}]}`)
+		Expect(status).To(Equal(http.StatusNoContent))
+		Expect(local.Writes()).To(BeNumerically(">", 0))
+	})
+})
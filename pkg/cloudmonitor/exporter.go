@@ -0,0 +1,229 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudmonitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/session"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/universal"
+)
+
+const (
+	// serviceName and apiVersion address the Cloud Monitor OpenAPI.
+	serviceName = "volc_observe"
+	apiVersion  = "2018-01-01"
+	// putMetricDataAction writes custom metric data points.
+	putMetricDataAction = "PutMetricData"
+
+	// DefaultInterval is the default time between two pushes.
+	DefaultInterval = time.Minute
+)
+
+// DefaultMetrics are the metric families pushed when none are configured: sync failures by code, records managed,
+// throttled API calls and zones bound to the VPC.
+var DefaultMetrics = []string{
+	"volcengine_privatezone_apply_failures_total",
+	"volcengine_privatezone_records",
+	"volcengine_privatezone_throttled_calls_total",
+	"volcengine_privatezone_zones",
+}
+
+// Dimension is a label of a data point.
+type Dimension struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// Datum is one custom metric data point.
+type Datum struct {
+	MetricName string      `json:"MetricName"`
+	Value      float64     `json:"Value"`
+	Timestamp  int64       `json:"Timestamp"`
+	Dimensions []Dimension `json:"Dimensions,omitempty"`
+}
+
+type putMetricDataInput struct {
+	Namespace  string  `json:"Namespace"`
+	MetricData []Datum `json:"MetricData"`
+}
+
+// Exporter pushes counters and gauges of a Prometheus gatherer to Cloud Monitor custom metrics,
+// so alerts can be set up without running Prometheus. Counters are pushed as their cumulative value.
+type Exporter struct {
+	namespace string
+	interval  time.Duration
+	gatherer  prometheus.Gatherer
+	metrics   map[string]bool
+	put       func(input *putMetricDataInput) error
+}
+
+// Option configures an Exporter.
+type Option func(*Exporter)
+
+// WithInterval sets the time between two pushes, defaults to DefaultInterval.
+func WithInterval(interval time.Duration) Option {
+	return func(e *Exporter) {
+		if interval > 0 {
+			e.interval = interval
+		}
+	}
+}
+
+// WithMetrics sets the pushed metric families, defaults to DefaultMetrics.
+func WithMetrics(names ...string) Option {
+	return func(e *Exporter) {
+		if len(names) > 0 {
+			e.metrics = toSet(names)
+		}
+	}
+}
+
+// WithGatherer sets the gatherer the metrics are read from, defaults to the Prometheus default registry.
+func WithGatherer(gatherer prometheus.Gatherer) Option {
+	return func(e *Exporter) {
+		e.gatherer = gatherer
+	}
+}
+
+// NewExporter returns an exporter pushing to the Cloud Monitor namespace of the region through endpoint.
+func NewExporter(region, endpoint string, creds *credentials.Credentials, namespace string, options ...Option) (*Exporter, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("cloud monitor namespace is required")
+	}
+	s, err := session.NewSession(volcengine.NewConfig().
+		WithRegion(region).
+		WithCredentials(creds).
+		WithEndpoint(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud monitor session: %v", err)
+	}
+	client := universal.New(s)
+	e := &Exporter{
+		namespace: namespace,
+		interval:  DefaultInterval,
+		gatherer:  prometheus.DefaultGatherer,
+		metrics:   toSet(DefaultMetrics),
+		put: func(input *putMetricDataInput) error {
+			return client.DoCallWithType(universal.RequestUniversal{
+				ServiceName: serviceName,
+				Action:      putMetricDataAction,
+				Version:     apiVersion,
+				HttpMethod:  universal.POST,
+				ContentType: universal.ApplicationJSON,
+			}, input, &map[string]interface{}{})
+		},
+	}
+	for _, option := range options {
+		option(e)
+	}
+	return e, nil
+}
+
+// Run pushes the metrics every interval until ctx is done, failed pushes are logged and retried on the next tick.
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.Push(ctx); err != nil {
+				log.Errorf("Failed to push metrics to cloud monitor: %v", err)
+			}
+		}
+	}
+}
+
+// Push sends the current values of the metrics.
+func (e *Exporter) Push(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := e.collect(time.Now())
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := e.put(&putMetricDataInput{Namespace: e.namespace, MetricData: data}); err != nil {
+		return err
+	}
+	log.Debugf("Pushed %d data points to cloud monitor namespace %s", len(data), e.namespace)
+	return nil
+}
+
+// collect converts the counters, gauges and untyped metrics of the selected families to data points at now,
+// histograms and summaries are skipped.
+func (e *Exporter) collect(now time.Time) ([]Datum, error) {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather metrics: %v", err)
+	}
+	var data []Datum
+	for _, family := range families {
+		if !e.metrics[family.GetName()] {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			var value float64
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				value = m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				value = m.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				value = m.GetUntyped().GetValue()
+			default:
+				continue
+			}
+			data = append(data, Datum{
+				MetricName: family.GetName(),
+				Value:      value,
+				Timestamp:  now.Unix(),
+				Dimensions: dimensions(m.GetLabel()),
+			})
+		}
+	}
+	return data, nil
+}
+
+func dimensions(labels []*dto.LabelPair) []Dimension {
+	dims := make([]Dimension, 0, len(labels))
+	for _, l := range labels {
+		dims = append(dims, Dimension{Name: l.GetName(), Value: l.GetValue()})
+	}
+	sort.Slice(dims, func(i, j int) bool { return dims[i].Name < dims[j].Name })
+	return dims
+}
+
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+)
+
+func testRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	failures := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "failures_total", Help: "h"}, []string{"code", "a"})
+	failures.WithLabelValues("CreateFailed", "x").Add(2)
+	records := prometheus.NewGauge(prometheus.GaugeOpts{Name: "records", Help: "h"})
+	records.Set(42)
+	duration := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "duration_seconds", Help: "h"})
+	duration.Observe(1)
+	ignored := prometheus.NewGauge(prometheus.GaugeOpts{Name: "ignored", Help: "h"})
+	reg.MustRegister(failures, records, duration, ignored)
+	return reg
+}
+
+func TestCollect(t *testing.T) {
+	e := &Exporter{gatherer: testRegistry(), metrics: toSet([]string{"failures_total", "records", "duration_seconds"})}
+	now := time.Unix(1700000000, 0)
+	data, err := e.collect(now)
+	require.NoError(t, err)
+	assert.Equal(t, []Datum{
+		{MetricName: "failures_total", Value: 2, Timestamp: now.Unix(), Dimensions: []Dimension{{Name: "a", Value: "x"}, {Name: "code", Value: "CreateFailed"}}},
+		{MetricName: "records", Value: 42, Timestamp: now.Unix(), Dimensions: []Dimension{}},
+	}, data)
+}
+
+func TestPush(t *testing.T) {
+	var action string
+	var input putMetricDataInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action = r.URL.Query().Get("Action")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &input)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ResponseMetadata": {"RequestId": "1"}, "Result": {}}`))
+	}))
+	defer server.Close()
+
+	e, err := NewExporter("cn-beijing", server.URL, credentials.NewStaticCredentials("ak", "sk", ""), "external-dns",
+		WithGatherer(testRegistry()), WithMetrics("records"))
+	require.NoError(t, err)
+	require.NoError(t, e.Push(context.Background()))
+	assert.Equal(t, putMetricDataAction, action)
+	assert.Equal(t, "external-dns", input.Namespace)
+	require.Len(t, input.MetricData, 1)
+	assert.Equal(t, "records", input.MetricData[0].MetricName)
+	assert.Equal(t, float64(42), input.MetricData[0].Value)
+
+	_, err = NewExporter("cn-beijing", server.URL, credentials.NewStaticCredentials("ak", "sk", ""), "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,374 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package fakepz
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/response"
+)
+
+const (
+	serviceName = "private_zone"
+	apiVersion  = "2022-06-01"
+)
+
+// apiError is returned in ResponseMetadata.Error.
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func notFound(format string, args ...interface{}) *apiError {
+	return &apiError{Status: http.StatusNotFound, Code: "NotFound", Message: fmt.Sprintf(format, args...) + " not found"}
+}
+
+func invalidParameter(message string) *apiError {
+	return &apiError{Status: http.StatusBadRequest, Code: "InvalidParameter", Message: message}
+}
+
+// Server serves the subset of the PrivateZone OpenAPI used by the provider from a Store.
+// Requests are not authenticated, any credentials are accepted.
+type Server struct {
+	store   *Store
+	region  string
+	actions map[string]func(*http.Request) (interface{}, error)
+	request atomic.Int64
+
+	// record IDs created by each ClientToken, repeated creates with a token return the same records
+	mu     sync.Mutex
+	tokens map[string][]string
+}
+
+// NewServer returns a PrivateZone compatible handler backed by store.
+func NewServer(store *Store, region string) *Server {
+	s := &Server{store: store, region: region, tokens: make(map[string][]string)}
+	s.actions = map[string]func(*http.Request) (interface{}, error){
+		"ListPrivateZones":  s.listPrivateZones,
+		"ListRecords":       s.listRecords,
+		"CreateRecord":      s.createRecord,
+		"BatchCreateRecord": s.batchCreateRecord,
+		"UpdateRecord":      s.updateRecord,
+		"DeleteRecord":      s.deleteRecord,
+		"BatchDeleteRecord": s.batchDeleteRecord,
+		"QueryPrivateZone":  s.queryPrivateZone,
+		"UpdatePrivateZone": s.updatePrivateZone,
+		"CreatePrivateZone": s.createPrivateZone,
+		"DeletePrivateZone": s.deletePrivateZone,
+		"IncBindVPC":        s.incBindVPC,
+	}
+	return s
+}
+
+// ServeHTTP dispatches on the Action query parameter.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	action := req.URL.Query().Get("Action")
+	meta := &response.ResponseMetadata{
+		RequestId: strconv.FormatInt(s.request.Add(1), 10),
+		Action:    action,
+		Version:   apiVersion,
+		Service:   serviceName,
+		Region:    s.region,
+	}
+	var (
+		result interface{}
+		err    error
+	)
+	if handle, ok := s.actions[action]; ok {
+		if result, err = handle(req); err == nil {
+			result, err = withoutMetadata(result)
+		}
+	} else {
+		err = &apiError{Status: http.StatusNotFound, Code: "InvalidActionOrVersion", Message: fmt.Sprintf("action %q is not supported", action)}
+	}
+	status := http.StatusOK
+	if err != nil {
+		apiErr, ok := err.(*apiError)
+		if !ok {
+			apiErr = &apiError{Status: http.StatusInternalServerError, Code: "InternalError", Message: err.Error()}
+		}
+		status = apiErr.Status
+		meta.Error = &response.Error{Code: apiErr.Code, Message: apiErr.Message}
+		result = nil
+	}
+	log.Debugf("%s %s: status %d, error: %v", req.Method, action, status, err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response.VolcengineResponse{ResponseMetadata: meta, Result: result}); err != nil {
+		log.Errorf("Failed to encode response: %v", err)
+	}
+}
+
+// withoutMetadata converts an SDK output to a map without its Metadata field,
+// which the SDK fills from ResponseMetadata and would otherwise be overwritten with null.
+func withoutMetadata(output interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	delete(result, "Metadata")
+	return result, nil
+}
+
+// decode reads the JSON body of a POST action into input.
+func decode(req *http.Request, input interface{}) error {
+	if err := json.NewDecoder(req.Body).Decode(input); err != nil {
+		return invalidParameter(fmt.Sprintf("invalid request body: %v", err))
+	}
+	return nil
+}
+
+// page returns the items of the 1-based page, the page size defaults to 20.
+func page[T any](items []T, pageNumber, pageSize int) []T {
+	if pageNumber < 1 {
+		pageNumber = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	start := (pageNumber - 1) * pageSize
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func (s *Server) listPrivateZones(req *http.Request) (interface{}, error) {
+	var input privatezone.ListPrivateZonesInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	zones := s.store.ListZones(volcengine.StringValue(input.VpcID))
+	pageNumber, pageSize := int(volcengine.Int32Value(input.PageNumber)), int(volcengine.Int32Value(input.PageSize))
+	return &privatezone.ListPrivateZonesOutput{
+		Zones:      page(zones, pageNumber, pageSize),
+		Total:      volcengine.Int32(int32(len(zones))),
+		PageNumber: volcengine.Int32(int32(pageNumber)),
+		PageSize:   volcengine.Int32(int32(pageSize)),
+	}, nil
+}
+
+func (s *Server) queryPrivateZone(req *http.Request) (interface{}, error) {
+	zid, err := strconv.ParseInt(req.URL.Query().Get("ZID"), 10, 32)
+	if err != nil {
+		return nil, invalidParameter("ZID is required")
+	}
+	return s.store.QueryZone(int32(zid))
+}
+
+func (s *Server) updatePrivateZone(req *http.Request) (interface{}, error) {
+	var input privatezone.UpdatePrivateZoneInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	if err := s.store.UpdateZone(&input); err != nil {
+		return nil, err
+	}
+	return &privatezone.UpdatePrivateZoneOutput{}, nil
+}
+
+func (s *Server) createPrivateZone(req *http.Request) (interface{}, error) {
+	var input privatezone.CreatePrivateZoneInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	if volcengine.StringValue(input.ZoneName) == "" {
+		return nil, invalidParameter("ZoneName is required")
+	}
+	var vpcs []string
+	for _, vpc := range input.Vpcs {
+		vpcs = append(vpcs, volcengine.StringValue(vpc.VpcId))
+	}
+	zid := s.store.AddZone(volcengine.StringValue(input.ZoneName), vpcs...)
+	if input.Remark != nil || input.RecursionMode != nil {
+		if err := s.store.UpdateZone(&privatezone.UpdatePrivateZoneInput{
+			ZID: volcengine.Int64(int64(zid)), Remark: input.Remark, RecursionMode: input.RecursionMode,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &privatezone.CreatePrivateZoneOutput{ZID: volcengine.Int64(int64(zid))}, nil
+}
+
+func (s *Server) deletePrivateZone(req *http.Request) (interface{}, error) {
+	var input privatezone.DeletePrivateZoneInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteZone(int32(volcengine.Int64Value(input.ZID)), volcengine.BoolValue(input.DeleteWhenEmpty)); err != nil {
+		return nil, err
+	}
+	return &privatezone.DeletePrivateZoneOutput{}, nil
+}
+
+func (s *Server) incBindVPC(req *http.Request) (interface{}, error) {
+	var input privatezone.IncBindVPCInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	var binds, unbinds []string
+	for _, vpc := range input.Binds {
+		binds = append(binds, volcengine.StringValue(vpc.VpcId))
+	}
+	for _, vpc := range input.Unbinds {
+		unbinds = append(unbinds, volcengine.StringValue(vpc.VpcId))
+	}
+	if err := s.store.BindVPCs(int32(volcengine.Int64Value(input.ZID)), binds, unbinds); err != nil {
+		return nil, err
+	}
+	return &privatezone.IncBindVPCOutput{}, nil
+}
+
+func (s *Server) listRecords(req *http.Request) (interface{}, error) {
+	query := req.URL.Query()
+	zid, err := strconv.ParseInt(query.Get("ZID"), 10, 32)
+	if err != nil {
+		return nil, invalidParameter("ZID is required")
+	}
+	records, err := s.store.ListRecords(int32(zid), query.Get("Host"), query.Get("Type"))
+	if err != nil {
+		return nil, err
+	}
+	pageNumber, _ := strconv.Atoi(query.Get("PageNumber"))
+	pageSize, _ := strconv.Atoi(query.Get("PageSize"))
+	return &privatezone.ListRecordsOutput{
+		Records:    page(records, pageNumber, pageSize),
+		Total:      volcengine.Int32(int32(len(records))),
+		PageNumber: volcengine.Int32(int32(pageNumber)),
+		PageSize:   volcengine.Int32(int32(pageSize)),
+	}, nil
+}
+
+// idempotent returns the record IDs of an earlier create with the token, or creates them and remembers the token.
+func (s *Server) idempotent(token *string, create func() ([]string, error)) ([]string, error) {
+	if token == nil || *token == "" {
+		return create()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ids, ok := s.tokens[*token]; ok {
+		return ids, nil
+	}
+	ids, err := create()
+	if err != nil {
+		return nil, err
+	}
+	s.tokens[*token] = ids
+	return ids, nil
+}
+
+func (s *Server) createRecord(req *http.Request) (interface{}, error) {
+	var input privatezone.CreateRecordInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	ids, err := s.idempotent(input.ClientToken, func() ([]string, error) {
+		id, err := s.createOne(&input)
+		return []string{id}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &privatezone.CreateRecordOutput{RecordID: volcengine.String(ids[0])}, nil
+}
+
+func (s *Server) createOne(input *privatezone.CreateRecordInput) (string, error) {
+	return s.store.CreateRecord(int32(volcengine.Int64Value(input.ZID)), &privatezone.RecordForBatchCreateRecordInput{
+		Host:   input.Host,
+		Type:   input.Type,
+		Value:  input.Value,
+		TTL:    input.TTL,
+		Weight: input.Weight,
+		Line:   input.Line,
+		Remark: input.Remark,
+	})
+}
+
+func (s *Server) batchCreateRecord(req *http.Request) (interface{}, error) {
+	var input privatezone.BatchCreateRecordInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	ids, err := s.idempotent(input.ClientToken, func() ([]string, error) {
+		ids := make([]string, 0, len(input.Records))
+		for _, record := range input.Records {
+			id, err := s.store.CreateRecord(int32(volcengine.Int64Value(input.ZID)), record)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &privatezone.BatchCreateRecordOutput{RecordIDs: volcengine.StringSlice(ids)}, nil
+}
+
+func (s *Server) updateRecord(req *http.Request) (interface{}, error) {
+	var input privatezone.UpdateRecordInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	if err := s.store.UpdateRecord(&input); err != nil {
+		return nil, err
+	}
+	return &privatezone.UpdateRecordOutput{}, nil
+}
+
+func (s *Server) deleteRecord(req *http.Request) (interface{}, error) {
+	var input privatezone.DeleteRecordInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteRecords(int32(volcengine.Int64Value(input.ZID)), []string{volcengine.StringValue(input.RecordID)}); err != nil {
+		return nil, err
+	}
+	return &privatezone.DeleteRecordOutput{}, nil
+}
+
+func (s *Server) batchDeleteRecord(req *http.Request) (interface{}, error) {
+	var input privatezone.BatchDeleteRecordInput
+	if err := decode(req, &input); err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteRecords(int32(volcengine.Int64Value(input.ZID)), volcengine.StringValueSlice(input.RecordIDs)); err != nil {
+		return nil, err
+	}
+	return &privatezone.BatchDeleteRecordOutput{}, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package fakepz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"volcengine-provider/pkg/volcengine"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+)
+
+func newTestWrapper(t *testing.T) (*Store, *volcengine.PrivateZoneWrapper) {
+	store := NewStore()
+	server := httptest.NewServer(NewServer(store, "cn-beijing"))
+	t.Cleanup(server.Close)
+	wrapper, err := volcengine.NewPrivateZoneWrapper("cn-beijing", server.URL, credentials.NewStaticCredentials("ak", "sk", ""))
+	require.NoError(t, err)
+	return store, wrapper
+}
+
+func TestServerThroughWrapper(t *testing.T) {
+	ctx := context.Background()
+	store, wrapper := newTestWrapper(t)
+	zid := int64(store.AddZone("example.com", "vpc-1"))
+	store.AddZone("other.com", "vpc-2")
+
+	zones, err := wrapper.ListPrivateZones(ctx, "vpc-1")
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "example.com", *zones[0].ZoneName)
+
+	require.NoError(t, wrapper.CreatePrivateZoneRecord(ctx, zid, "www", "A", "10.0.0.1", 300, 0, "", "created"))
+	require.NoError(t, wrapper.BatchCreatePrivateZoneRecord(ctx, zid, []*privatezone.RecordForBatchCreateRecordInput{
+		{Host: sdk.String("api"), Type: sdk.String("A"), Value: sdk.String("10.0.0.2")},
+		{Host: sdk.String("api"), Type: sdk.String("A"), Value: sdk.String("10.0.0.3")},
+	}))
+	records, err := wrapper.GetPrivateZoneRecords(ctx, zid)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, int32(300), *records[0].TTL)
+	assert.Equal(t, int32(600), *records[1].TTL)
+
+	require.NoError(t, wrapper.UpdatePrivateZoneRecord(ctx, zid, *records[0].RecordID, "www", "A", "10.0.0.9", 60, 0, "", "created"))
+	require.NoError(t, wrapper.DisablePrivateZoneRecord(ctx, zid, *records[1].RecordID, "deleted-at=2025-01-01T00:00:00Z"))
+	require.NoError(t, wrapper.DeletePrivateZoneRecord(ctx, zid, "api", "A", []string{"10.0.0.3"}))
+
+	records, err = wrapper.GetPrivateZoneRecords(ctx, zid)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "10.0.0.9", *records[0].Value)
+	assert.Equal(t, int32(60), *records[0].TTL)
+	assert.False(t, *records[1].Enable)
+	assert.Equal(t, "deleted-at=2025-01-01T00:00:00Z", *records[1].Remark)
+}
+
+func TestServerZoneSettings(t *testing.T) {
+	ctx := context.Background()
+	store, wrapper := newTestWrapper(t)
+	zid := int64(store.AddZone("example.com", "vpc-1"))
+
+	require.NoError(t, wrapper.UpdatePrivateZone(ctx, zid, volcengine.ZoneSettings{RecursionMode: sdk.Bool(true),
+		LoadBalance: sdk.Bool(true), Remark: sdk.String("managed")}))
+	zone, err := wrapper.QueryPrivateZone(ctx, zid)
+	require.NoError(t, err)
+	assert.True(t, *zone.RecursionMode)
+	// decoded from the response, the SDK output does not have it
+	require.NotNil(t, zone.LoadBalance)
+	assert.True(t, *zone.LoadBalance)
+	assert.Equal(t, "managed", *zone.Remark)
+	assert.Equal(t, "vpc-1", *zone.BindVPCs[0].ID)
+
+	require.NoError(t, wrapper.UpdatePrivateZone(ctx, zid, volcengine.ZoneSettings{RecursionMode: sdk.Bool(false)}))
+	zone, err = wrapper.QueryPrivateZone(ctx, zid)
+	require.NoError(t, err)
+	assert.False(t, *zone.RecursionMode)
+	assert.True(t, *zone.LoadBalance)
+	assert.Equal(t, "managed", *zone.Remark)
+
+	_, err = wrapper.QueryPrivateZone(ctx, 1)
+	assert.Error(t, err)
+}
+
+func TestServerZoneLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, wrapper := newTestWrapper(t)
+
+	zid, err := wrapper.CreatePrivateZone(ctx, "example.com", "cn-beijing", []string{"vpc-1"}, "bootstrap")
+	require.NoError(t, err)
+	require.NoError(t, wrapper.BindVPC(ctx, zid, "cn-beijing", []string{"vpc-2"}))
+	require.NoError(t, wrapper.UnbindVPC(ctx, zid, "cn-beijing", []string{"vpc-1"}))
+	zone, err := wrapper.QueryPrivateZone(ctx, zid)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", *zone.ZoneName)
+	assert.Equal(t, "bootstrap", *zone.Remark)
+	require.Len(t, zone.BindVPCs, 1)
+	assert.Equal(t, "vpc-2", *zone.BindVPCs[0].ID)
+
+	// Zones holding records are only deleted with force
+	require.NoError(t, wrapper.CreatePrivateZoneRecord(ctx, zid, "www", "A", "10.0.0.1", 300, 0, "", ""))
+	assert.ErrorContains(t, wrapper.DeletePrivateZone(ctx, zid, false), "ZoneNotEmpty")
+	require.NoError(t, wrapper.DeletePrivateZone(ctx, zid, true))
+	zones, err := wrapper.ListPrivateZones(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, zones)
+}
+
+func TestServerErrors(t *testing.T) {
+	ctx := context.Background()
+	store, wrapper := newTestWrapper(t)
+	zid := int64(store.AddZone("example.com"))
+
+	assert.Error(t, wrapper.CreatePrivateZoneRecord(ctx, 1, "www", "A", "10.0.0.1", 300, 0, "", ""))
+	require.NoError(t, wrapper.CreatePrivateZoneRecord(ctx, zid, "www", "A", "10.0.0.1", 300, 0, "", ""))
+	assert.Error(t, wrapper.CreatePrivateZoneRecord(ctx, zid, "www", "A", "10.0.0.1", 300, 0, "", ""))
+	assert.Error(t, wrapper.DeletePrivateZoneRecordById(ctx, zid, "404"))
+}
+
+func TestServerClientToken(t *testing.T) {
+	store := NewStore()
+	zid := store.AddZone("example.com")
+	server := NewServer(store, "cn-beijing")
+	create := func(body string) string {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/?Action=CreateRecord&Version=2022-06-01", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		server.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp struct{ Result struct{ RecordID string } }
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp.Result.RecordID
+	}
+
+	body := fmt.Sprintf(`{"ZID":%d,"Host":"www","Type":"A","Value":"10.0.0.1","ClientToken":"token-1"}`, zid)
+	first := create(body)
+	assert.NotEmpty(t, first)
+	assert.Equal(t, first, create(body))
+	records, err := store.ListRecords(zid, "", "")
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestServerUnknownAction(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewServer(NewStore(), "cn-beijing").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?Action=BindRuleVPC&Version=2022-06-01", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	var resp struct {
+		ResponseMetadata struct {
+			Action string
+			Error  struct{ Code string }
+		}
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "BindRuleVPC", resp.ResponseMetadata.Action)
+	assert.Equal(t, "InvalidActionOrVersion", resp.ResponseMetadata.Error.Code)
+}
+
+func TestPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []int{1, 2}, page(items, 1, 2))
+	assert.Equal(t, []int{5}, page(items, 3, 2))
+	assert.Equal(t, []int{}, page(items, 4, 2))
+	assert.Equal(t, items, page(items, 0, 0))
+}
@@ -0,0 +1,313 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package fakepz
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+// Store is an in-memory set of private zones and their records.
+type Store struct {
+	mu           sync.Mutex
+	nextZoneID   int32
+	nextRecordID int64
+	zones        map[int32]*zone
+}
+
+type zone struct {
+	zid           int32
+	name          string
+	vpcs          map[string]bool
+	records       map[string]*privatezone.RecordForListRecordsOutput
+	recursionMode bool
+	loadBalance   bool
+	remark        string
+}
+
+// ZoneDetails is the result of QueryPrivateZone, the SDK output does not have the LoadBalance setting.
+type ZoneDetails struct {
+	*privatezone.QueryPrivateZoneOutput
+	LoadBalance *bool
+}
+
+// NewStore returns an empty store.
+func NewStore() *Store {
+	return &Store{
+		nextZoneID:   100000,
+		nextRecordID: 1,
+		zones:        make(map[int32]*zone),
+	}
+}
+
+// AddZone creates a zone bound to the VPCs and returns its ZID.
+func (s *Store) AddZone(name string, vpcs ...string) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextZoneID++
+	z := &zone{
+		zid:     s.nextZoneID,
+		name:    name,
+		vpcs:    make(map[string]bool),
+		records: make(map[string]*privatezone.RecordForListRecordsOutput),
+	}
+	for _, vpc := range vpcs {
+		z.vpcs[vpc] = true
+	}
+	s.zones[z.zid] = z
+	return z.zid
+}
+
+// ListZones returns the zones bound to vpc, or every zone when vpc is empty, ordered by ZID.
+func (s *Store) ListZones(vpc string) []*privatezone.ZoneForListPrivateZonesOutput {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]*privatezone.ZoneForListPrivateZonesOutput, 0, len(s.zones))
+	for _, z := range s.zones {
+		if vpc != "" && !z.vpcs[vpc] {
+			continue
+		}
+		res = append(res, &privatezone.ZoneForListPrivateZonesOutput{
+			ZID:         volcengine.Int32(z.zid),
+			ZoneName:    volcengine.String(z.name),
+			RecordCount: volcengine.Int32(int32(len(z.records))),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return *res[i].ZID < *res[j].ZID
+	})
+	return res
+}
+
+// QueryZone returns the details and settings of the zone.
+func (s *Store) QueryZone(zid int32) (*ZoneDetails, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zid]
+	if !ok {
+		return nil, notFound("zone %d", zid)
+	}
+	vpcs := make([]*privatezone.BindVPCForQueryPrivateZoneOutput, 0, len(z.vpcs))
+	for vpc := range z.vpcs {
+		vpcs = append(vpcs, &privatezone.BindVPCForQueryPrivateZoneOutput{ID: volcengine.String(vpc)})
+	}
+	sort.Slice(vpcs, func(i, j int) bool {
+		return *vpcs[i].ID < *vpcs[j].ID
+	})
+	return &ZoneDetails{
+		QueryPrivateZoneOutput: &privatezone.QueryPrivateZoneOutput{
+			ZID:           volcengine.Int32(z.zid),
+			ZoneName:      volcengine.String(z.name),
+			BindVPCs:      vpcs,
+			RecordCount:   volcengine.Int32(int32(len(z.records))),
+			RecursionMode: volcengine.Bool(z.recursionMode),
+			Remark:        volcengine.String(z.remark),
+		},
+		LoadBalance: volcengine.Bool(z.loadBalance),
+	}, nil
+}
+
+// UpdateZone changes the recursion mode, load balancing and remark of the zone when they are set in the input.
+func (s *Store) UpdateZone(in *privatezone.UpdatePrivateZoneInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[int32(volcengine.Int64Value(in.ZID))]
+	if !ok {
+		return notFound("zone %d", volcengine.Int64Value(in.ZID))
+	}
+	if in.RecursionMode != nil {
+		z.recursionMode = *in.RecursionMode
+	}
+	if in.LoadBalance != nil {
+		z.loadBalance = *in.LoadBalance
+	}
+	if in.Remark != nil {
+		z.remark = *in.Remark
+	}
+	return nil
+}
+
+// DeleteZone deletes the zone, with whenEmpty only while it holds no records.
+func (s *Store) DeleteZone(zid int32, whenEmpty bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zid]
+	if !ok {
+		return notFound("zone %d", zid)
+	}
+	if whenEmpty && len(z.records) > 0 {
+		return &apiError{Status: http.StatusBadRequest, Code: "ZoneNotEmpty", Message: fmt.Sprintf("zone %d holds %d records", zid, len(z.records))}
+	}
+	delete(s.zones, zid)
+	return nil
+}
+
+// BindVPCs binds and unbinds VPCs of the zone, keeping its other bindings.
+func (s *Store) BindVPCs(zid int32, binds, unbinds []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zid]
+	if !ok {
+		return notFound("zone %d", zid)
+	}
+	for _, vpc := range binds {
+		z.vpcs[vpc] = true
+	}
+	for _, vpc := range unbinds {
+		delete(z.vpcs, vpc)
+	}
+	return nil
+}
+
+// ListRecords returns the records of the zone matching the optional host and type, ordered by record ID.
+func (s *Store) ListRecords(zid int32, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zid]
+	if !ok {
+		return nil, notFound("zone %d", zid)
+	}
+	res := make([]*privatezone.RecordForListRecordsOutput, 0, len(z.records))
+	for _, r := range z.records {
+		if (host != "" && host != *r.Host) || (recordType != "" && recordType != *r.Type) {
+			continue
+		}
+		copied := *r
+		res = append(res, &copied)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		a, _ := strconv.ParseInt(*res[i].RecordID, 10, 64)
+		b, _ := strconv.ParseInt(*res[j].RecordID, 10, 64)
+		return a < b
+	})
+	return res, nil
+}
+
+// CreateRecord adds a record to the zone and returns its ID, an existing record with the same host, type and value is an error.
+func (s *Store) CreateRecord(zid int32, in *privatezone.RecordForBatchCreateRecordInput) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zid]
+	if !ok {
+		return "", notFound("zone %d", zid)
+	}
+	if volcengine.StringValue(in.Host) == "" || volcengine.StringValue(in.Type) == "" || volcengine.StringValue(in.Value) == "" {
+		return "", invalidParameter("Host, Type and Value are required")
+	}
+	for _, r := range z.records {
+		if *r.Host == *in.Host && *r.Type == *in.Type && *r.Value == *in.Value {
+			return "", &apiError{Status: http.StatusBadRequest, Code: "RecordDuplicate", Message: fmt.Sprintf("record %s %s %s already exists", *in.Host, *in.Type, *in.Value)}
+		}
+	}
+	ttl := volcengine.Int32Value(in.TTL)
+	if ttl == 0 {
+		ttl = 600
+	}
+	line := volcengine.StringValue(in.Line)
+	if line == "" {
+		line = "default"
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	id := strconv.FormatInt(s.nextRecordID, 10)
+	s.nextRecordID++
+	z.records[id] = &privatezone.RecordForListRecordsOutput{
+		RecordID:  volcengine.String(id),
+		ZID:       volcengine.Int32(zid),
+		Host:      volcengine.String(*in.Host),
+		Type:      volcengine.String(*in.Type),
+		Value:     volcengine.String(*in.Value),
+		TTL:       volcengine.Int32(ttl),
+		Weight:    volcengine.Int32(volcengine.Int32Value(in.Weight)),
+		Line:      volcengine.String(line),
+		Remark:    volcengine.String(volcengine.StringValue(in.Remark)),
+		Enable:    volcengine.Bool(true),
+		CreatedAt: volcengine.String(now),
+		UpdatedAt: volcengine.String(now),
+	}
+	return id, nil
+}
+
+// UpdateRecord applies the set fields of in to the record.
+func (s *Store) UpdateRecord(in *privatezone.UpdateRecordInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, err := s.record(volcengine.StringValue(in.RecordID))
+	if err != nil {
+		return err
+	}
+	if in.Host != nil {
+		r.Host = volcengine.String(*in.Host)
+	}
+	if in.Type != nil {
+		r.Type = volcengine.String(*in.Type)
+	}
+	if in.Value != nil {
+		r.Value = volcengine.String(*in.Value)
+	}
+	if in.TTL != nil {
+		r.TTL = volcengine.Int32(*in.TTL)
+	}
+	if in.Weight != nil {
+		r.Weight = volcengine.Int32(*in.Weight)
+	}
+	if in.Line != nil {
+		r.Line = volcengine.String(*in.Line)
+	}
+	if in.Remark != nil {
+		r.Remark = volcengine.String(*in.Remark)
+	}
+	if in.Enable != nil {
+		r.Enable = volcengine.Bool(*in.Enable)
+	}
+	r.UpdatedAt = volcengine.String(time.Now().UTC().Format(time.RFC3339))
+	return nil
+}
+
+// DeleteRecords removes the records of the zone, unknown IDs are an error and nothing is removed.
+func (s *Store) DeleteRecords(zid int32, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zid]
+	if !ok {
+		return notFound("zone %d", zid)
+	}
+	for _, id := range ids {
+		if _, ok := z.records[id]; !ok {
+			return notFound("record %s", id)
+		}
+	}
+	for _, id := range ids {
+		delete(z.records, id)
+	}
+	return nil
+}
+
+// record returns the record with the ID in any zone, the caller holds the lock.
+func (s *Store) record(id string) (*privatezone.RecordForListRecordsOutput, error) {
+	for _, z := range s.zones {
+		if r, ok := z.records[id]; ok {
+			return r, nil
+		}
+	}
+	return nil, notFound("record %s", id)
+}
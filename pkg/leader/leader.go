@@ -0,0 +1,115 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package leader
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Config configures the lease based leader election.
+type Config struct {
+	Namespace string
+	LeaseName string
+	// Identity of this replica, usually the pod name.
+	Identity      string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// DefaultConfig returns the election timings used by Kubernetes controllers.
+func DefaultConfig(namespace, leaseName, identity string) Config {
+	return Config{
+		Namespace:     namespace,
+		LeaseName:     leaseName,
+		Identity:      identity,
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+	}
+}
+
+// Elector reports whether this replica currently holds the lease.
+type Elector struct {
+	identity string
+	leading  atomic.Bool
+	leader   atomic.Value
+}
+
+// Run starts the leader election in the background until ctx is done, the lease is released on shutdown.
+func Run(ctx context.Context, client kubernetes.Interface, c Config) (*Elector, error) {
+	lock, err := resourcelock.New(resourcelock.LeasesResourceLock, c.Namespace, c.LeaseName,
+		client.CoreV1(), client.CoordinationV1(), resourcelock.ResourceLockConfig{Identity: c.Identity})
+	if err != nil {
+		return nil, err
+	}
+	e := &Elector{identity: c.Identity}
+	e.leader.Store("")
+	le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   c.LeaseDuration,
+		RenewDeadline:   c.RenewDeadline,
+		RetryPeriod:     c.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            c.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				log.Infof("Started leading lease %s/%s as %s", c.Namespace, c.LeaseName, c.Identity)
+				e.leading.Store(true)
+			},
+			OnStoppedLeading: func() {
+				log.Warnf("Stopped leading lease %s/%s as %s", c.Namespace, c.LeaseName, c.Identity)
+				e.leading.Store(false)
+			},
+			OnNewLeader: func(identity string) {
+				log.Infof("Lease %s/%s is held by %s", c.Namespace, c.LeaseName, identity)
+				e.leader.Store(identity)
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		// Run returns when the lease is lost, campaign again until ctx is done
+		for ctx.Err() == nil {
+			le.Run(ctx)
+		}
+	}()
+	return e, nil
+}
+
+// IsLeader reports whether this replica holds the lease.
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Leader returns the identity of the current lease holder, empty if unknown.
+func (e *Elector) Leader() string {
+	return e.leader.Load().(string)
+}
+
+// Identity returns the identity of this replica.
+func (e *Elector) Identity() string {
+	return e.identity
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testConfig(identity string) Config {
+	return Config{
+		Namespace:     "kube-system",
+		LeaseName:     "external-dns-webhook",
+		Identity:      identity,
+		LeaseDuration: time.Second,
+		RenewDeadline: 500 * time.Millisecond,
+		RetryPeriod:   100 * time.Millisecond,
+	}
+}
+
+func TestElection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset()
+
+	first, err := Run(ctx, client, testConfig("pod-a"))
+	assert.NoError(t, err)
+	assert.Eventually(t, first.IsLeader, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, "pod-a", first.Leader())
+
+	secondCtx, secondCancel := context.WithCancel(ctx)
+	defer secondCancel()
+	second, err := Run(secondCtx, client, testConfig("pod-b"))
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return second.Leader() == "pod-a" }, 5*time.Second, 50*time.Millisecond)
+	assert.False(t, second.IsLeader())
+	assert.Equal(t, "pod-b", second.Identity())
+
+	lease, err := client.CoordinationV1().Leases("kube-system").Get(ctx, "external-dns-webhook", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "pod-a", *lease.Spec.HolderIdentity)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Chat services supported by ChatNotifier.
+const (
+	ChatLark  = "lark"
+	ChatSlack = "slack"
+)
+
+// ChatNotifier posts a message to a Lark or Slack incoming webhook when ApplyChanges fails Threshold times in a row,
+// or at once for failures whose code is in ImmediateCodes. It pages once per failure streak, and posts again when
+// the next successful sync, with or without changes, ends a streak it paged for.
+type ChatNotifier struct {
+	URL    string
+	Kind   string
+	Client *http.Client
+	// Threshold is the number of consecutive failures that triggers a message.
+	Threshold int
+	// ImmediateCodes are failure codes that trigger a message on the first occurrence.
+	ImmediateCodes []string
+
+	mu       sync.Mutex
+	failures int
+	paged    bool
+}
+
+// NewChatNotifier returns a notifier for the incoming webhook url, an empty kind is detected from the url.
+func NewChatNotifier(url, kind string, threshold int) (*ChatNotifier, error) {
+	if kind == "" {
+		kind = ChatLark
+		if strings.Contains(url, "hooks.slack.com") {
+			kind = ChatSlack
+		}
+	}
+	if kind != ChatLark && kind != ChatSlack {
+		return nil, fmt.Errorf("unsupported chat kind %q, expected %s or %s", kind, ChatLark, ChatSlack)
+	}
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &ChatNotifier{URL: url, Kind: kind, Client: http.DefaultClient, Threshold: threshold}, nil
+}
+
+// Notify tracks the failure streak and posts a message when it should page or the paged streak ended.
+// Implementation for Notifier
+func (n *ChatNotifier) Notify(ctx context.Context, summary *Summary) error {
+	text := n.track(summary)
+	if text == "" {
+		return nil
+	}
+	body, err := n.payload(text)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.Client, n.URL, body)
+}
+
+// NotifyEverySync makes the successful syncs without changes end the failure streak too.
+// Implementation for EverySyncNotifier
+func (n *ChatNotifier) NotifyEverySync() bool {
+	return true
+}
+
+// track updates the failure streak with the summary and returns the message to post, empty when there is none.
+func (n *ChatNotifier) track(summary *Summary) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if summary.Failure == nil {
+		failures, paged := n.failures, n.paged
+		n.failures = 0
+		n.paged = false
+		if !paged {
+			return ""
+		}
+		return fmt.Sprintf("[external-dns volcengine] DNS sync recovered after %d failure(s)\nTime: %s",
+			failures, summary.Time.Format("2006-01-02 15:04:05 MST"))
+	}
+	n.failures++
+	for _, code := range n.ImmediateCodes {
+		if code == summary.Failure.Code {
+			n.paged = true
+			return n.message(summary, n.failures)
+		}
+	}
+	if n.paged || n.failures < n.Threshold {
+		return ""
+	}
+	n.paged = true
+	return n.message(summary, n.failures)
+}
+
+func (n *ChatNotifier) message(summary *Summary, failures int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[external-dns volcengine] DNS sync failed %d time(s) in a row\n", failures)
+	fmt.Fprintf(&b, "Code: %s\nMessage: %s\n", summary.Failure.Code, summary.Failure.Message)
+	for _, ep := range summary.Failure.Endpoints {
+		fmt.Fprintf(&b, "- %s %s %v\n", ep.DNSName, ep.RecordType, ep.Targets)
+	}
+	fmt.Fprintf(&b, "Time: %s", summary.Time.Format("2006-01-02 15:04:05 MST"))
+	return b.String()
+}
+
+func (n *ChatNotifier) payload(text string) ([]byte, error) {
+	if n.Kind == ChatSlack {
+		return json.Marshal(map[string]string{"text": text})
+	}
+	return json.Marshal(map[string]interface{}{
+		"msg_type": "text",
+		"content":  map[string]string{"text": text},
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestChatNotifierThreshold(t *testing.T) {
+	var messages []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		var msg map[string]interface{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&msg))
+		messages = append(messages, msg)
+	}))
+	defer server.Close()
+
+	n, err := NewChatNotifier(server.URL, "", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, ChatLark, n.Kind)
+
+	failure := &Summary{Failure: &Failure{Code: "CreateFailed", Message: "quota exceeded",
+		Endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")}}}
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, n.Notify(context.Background(), failure))
+	}
+	// Paged once when the streak reached the threshold
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "text", messages[0]["msg_type"])
+	text := messages[0]["content"].(map[string]interface{})["text"].(string)
+	assert.Contains(t, text, "failed 3 time(s) in a row")
+	assert.Contains(t, text, "CreateFailed")
+	assert.Contains(t, text, "www.example.com A")
+
+	// A success ends the streak and posts the recovery
+	assert.NoError(t, n.Notify(context.Background(), &Summary{}))
+	assert.Len(t, messages, 2)
+	text = messages[1]["content"].(map[string]interface{})["text"].(string)
+	assert.Contains(t, text, "recovered after 4 failure(s)")
+	assert.NoError(t, n.Notify(context.Background(), &Summary{}))
+	assert.Len(t, messages, 2)
+
+	// A success of a streak below the threshold posts nothing
+	assert.NoError(t, n.Notify(context.Background(), failure))
+	assert.NoError(t, n.Notify(context.Background(), &Summary{}))
+	assert.Len(t, messages, 2)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, n.Notify(context.Background(), failure))
+	}
+	assert.Len(t, messages, 3)
+}
+
+func TestProviderEndsChatStreakWithoutChanges(t *testing.T) {
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		var msg map[string]interface{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&msg))
+		texts = append(texts, msg["content"].(map[string]interface{})["text"].(string))
+	}))
+	defer server.Close()
+	n, err := NewChatNotifier(server.URL, ChatLark, 1)
+	assert.NoError(t, err)
+	failing := &fakeProvider{err: errors.New("throttled")}
+	r := &recorder{}
+	p := NewProvider(failing, time.Second, n, r)
+
+	assert.Error(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+	failing.err = nil
+	// the sync without changes ends the streak, the other notifiers are not told of it
+	assert.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+	closeProvider(t, p)
+	assert.Len(t, texts, 2)
+	assert.Contains(t, texts[0], "failed 1 time(s) in a row")
+	assert.Contains(t, texts[1], "recovered after 1 failure(s)")
+	assert.Len(t, r.summaries, 1)
+}
+
+func TestChatNotifierImmediateCodes(t *testing.T) {
+	var messages []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		var msg map[string]interface{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&msg))
+		messages = append(messages, msg)
+	}))
+	defer server.Close()
+
+	n, err := NewChatNotifier(server.URL, ChatSlack, 5)
+	assert.NoError(t, err)
+	n.ImmediateCodes = []string{"Blocked"}
+
+	assert.NoError(t, n.Notify(context.Background(), &Summary{Failure: &Failure{Code: "Blocked", Message: "too many deletes"}}))
+	assert.Len(t, messages, 1)
+	assert.Contains(t, messages[0]["text"], "too many deletes")
+}
+
+func TestNewChatNotifier(t *testing.T) {
+	n, err := NewChatNotifier("https://hooks.slack.com/services/T000/B000/XXX", "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, ChatSlack, n.Kind)
+	assert.Equal(t, 1, n.Threshold)
+
+	_, err = NewChatNotifier("https://example.com", "teams", 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPNotifier posts the Summary as JSON to a URL, e.g. a CMDB or change-management endpoint.
+type HTTPNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPNotifier returns a notifier posting to url.
+func NewHTTPNotifier(url string) *HTTPNotifier {
+	return &HTTPNotifier{URL: url, Client: http.DefaultClient}
+}
+
+// Notify posts summary to the URL, a non-2xx response is an error.
+// Implementation for Notifier
+func (n *HTTPNotifier) Notify(ctx context.Context, summary *Summary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.Client, n.URL, body)
+}
+
+// postJSON posts body to url and fails on non-2xx responses.
+func postJSON(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("notification to %s failed: status %d, body: %s", url, resp.StatusCode, string(data))
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"volcengine-provider/pkg/volcengine"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// Summary describes the outcome of one ApplyChanges call.
+type Summary struct {
+	Time    time.Time            `json:"time"`
+	Created []*endpoint.Endpoint `json:"created"`
+	Updated []*endpoint.Endpoint `json:"updated"`
+	Deleted []*endpoint.Endpoint `json:"deleted"`
+	Failure *Failure             `json:"failure,omitempty"`
+}
+
+// Failure describes why ApplyChanges failed and which endpoints were affected.
+type Failure struct {
+	Code      string               `json:"code"`
+	Message   string               `json:"message"`
+	Endpoints []*endpoint.Endpoint `json:"endpoints,omitempty"`
+}
+
+// Notifier receives a Summary after every ApplyChanges.
+type Notifier interface {
+	Notify(ctx context.Context, summary *Summary) error
+}
+
+// queueSize bounds the summaries waiting to be sent, summaries are dropped while the queue is full.
+const queueSize = 64
+
+// EverySyncNotifier is a Notifier that also receives the summaries of the successful syncs without changes, which
+// the other notifiers are spared, e.g. to end a failure streak.
+type EverySyncNotifier interface {
+	Notifier
+	NotifyEverySync() bool
+}
+
+// everySync reports whether n receives the summaries of the successful syncs without changes.
+func everySync(n Notifier) bool {
+	e, ok := n.(EverySyncNotifier)
+	return ok && e.NotifyEverySync()
+}
+
+// Provider wraps a provider and sends a Summary to the notifiers after every ApplyChanges that changed something or failed,
+// and to the EverySyncNotifiers after every ApplyChanges. The summaries are sent in order by a background goroutine, so slow notifiers never delay the sync.
+type Provider struct {
+	provider.Provider
+
+	notifiers []Notifier
+	timeout   time.Duration
+	// some notifiers receive the summaries of the successful syncs without changes
+	everySync bool
+
+	mu     sync.Mutex
+	closed bool
+	queue  chan *Summary
+	done   chan struct{}
+}
+
+// NewProvider returns p notifying the notifiers, each notification is bounded by timeout.
+func NewProvider(p provider.Provider, timeout time.Duration, notifiers ...Notifier) *Provider {
+	n := &Provider{
+		Provider:  p,
+		notifiers: notifiers,
+		timeout:   timeout,
+		queue:     make(chan *Summary, queueSize),
+		done:      make(chan struct{}),
+	}
+	for _, notifier := range notifiers {
+		n.everySync = n.everySync || everySync(notifier)
+	}
+	go n.run()
+	return n
+}
+
+// ApplyChanges applies the changes with the wrapped provider and queues the notification of the result.
+// Implementation for provider.Provider
+func (p *Provider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	err := p.Provider.ApplyChanges(ctx, changes)
+	if changes == nil || (!changes.HasChanges() && err == nil && !p.everySync) {
+		return err
+	}
+	p.enqueue(newSummary(changes, err))
+	return err
+}
+
+// Close sends the queued summaries and stops the background goroutine, it returns early when ctx is done.
+// Summaries of later ApplyChanges calls are dropped.
+func (p *Provider) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Provider) enqueue(summary *Summary) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		log.Warnf("Dropping change notification, the notifier is closed")
+		return
+	}
+	select {
+	case p.queue <- summary:
+	default:
+		log.Warnf("Dropping change notification, %d notifications are waiting to be sent", len(p.queue))
+	}
+}
+
+func (p *Provider) run() {
+	defer close(p.done)
+	for summary := range p.queue {
+		p.notify(summary)
+	}
+}
+
+func (p *Provider) notify(summary *Summary) {
+	quiet := summary.Failure == nil && len(summary.Created)+len(summary.Updated)+len(summary.Deleted) == 0
+	for _, n := range p.notifiers {
+		if quiet && !everySync(n) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		if err := n.Notify(ctx, summary); err != nil {
+			log.Errorf("Failed to send change notification: %v", err)
+		}
+		cancel()
+	}
+}
+
+func newSummary(changes *plan.Changes, err error) *Summary {
+	s := &Summary{
+		Time:    time.Now().UTC(),
+		Created: changes.Create,
+		Updated: changes.UpdateNew,
+		Deleted: changes.Delete,
+	}
+	if err != nil {
+		s.Failure = &Failure{Code: "InternalError", Message: err.Error()}
+		var changeErr *volcengine.ChangeError
+		if errors.As(err, &changeErr) {
+			s.Failure = &Failure{Code: changeErr.Code, Message: changeErr.Err.Error(), Endpoints: changeErr.Endpoints}
+		}
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"volcengine-provider/pkg/volcengine"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// fakeProvider returns err from ApplyChanges.
+type fakeProvider struct {
+	provider.BaseProvider
+	err error
+}
+
+func (p *fakeProvider) Records(context.Context) ([]*endpoint.Endpoint, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) ApplyChanges(context.Context, *plan.Changes) error {
+	return p.err
+}
+
+// recorder keeps the received summaries.
+type recorder struct {
+	summaries []*Summary
+}
+
+func (r *recorder) Notify(_ context.Context, summary *Summary) error {
+	r.summaries = append(r.summaries, summary)
+	return nil
+}
+
+// blocking blocks every notification until release is closed.
+type blocking struct {
+	release chan struct{}
+}
+
+func (b *blocking) Notify(context.Context, *Summary) error {
+	<-b.release
+	return nil
+}
+
+// closeProvider sends the queued summaries of p.
+func closeProvider(t *testing.T, p *Provider) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, p.Close(ctx))
+}
+
+func TestProviderNotifiesChanges(t *testing.T) {
+	r := &recorder{}
+	p := NewProvider(&fakeProvider{}, time.Second, r)
+
+	created := endpoint.NewEndpoint("new.example.com", "A", "1.2.3.4")
+	deleted := endpoint.NewEndpoint("old.example.com", "A", "5.6.7.8")
+	assert.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{created},
+		Delete: []*endpoint.Endpoint{deleted},
+	}))
+	// Empty changes are not notified
+	assert.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+	closeProvider(t, p)
+	assert.Len(t, r.summaries, 1)
+	assert.Equal(t, []*endpoint.Endpoint{created}, r.summaries[0].Created)
+	assert.Equal(t, []*endpoint.Endpoint{deleted}, r.summaries[0].Deleted)
+	assert.Nil(t, r.summaries[0].Failure)
+}
+
+func TestProviderNotifiesInBackground(t *testing.T) {
+	slow := &blocking{release: make(chan struct{})}
+	r := &recorder{}
+	p := NewProvider(&fakeProvider{}, time.Second, slow, r)
+
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", "A", "1.2.3.4")}}
+	// ApplyChanges returns while the notifier blocks, the summaries beyond the queue are dropped
+	for i := 0; i < queueSize+3; i++ {
+		assert.NoError(t, p.ApplyChanges(context.Background(), changes))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.Close(ctx), context.DeadlineExceeded)
+
+	close(slow.release)
+	closeProvider(t, p)
+	// the first summary was taken from the queue before it filled up
+	assert.GreaterOrEqual(t, len(r.summaries), queueSize)
+	assert.LessOrEqual(t, len(r.summaries), queueSize+1)
+	// closed providers drop the summaries
+	assert.NoError(t, p.ApplyChanges(context.Background(), changes))
+	assert.LessOrEqual(t, len(r.summaries), queueSize+1)
+}
+
+func TestProviderNotifiesFailure(t *testing.T) {
+	r := &recorder{}
+	failed := endpoint.NewEndpoint("new.example.com", "A", "1.2.3.4")
+	err := &volcengine.ChangeError{Code: volcengine.ErrCodeCreateFailed, Endpoints: []*endpoint.Endpoint{failed}, Err: errors.New("quota exceeded")}
+	p := NewProvider(&fakeProvider{err: err}, time.Second, r)
+
+	assert.ErrorIs(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{failed}}), err)
+	closeProvider(t, p)
+	assert.Len(t, r.summaries, 1)
+	assert.Equal(t, &Failure{Code: volcengine.ErrCodeCreateFailed, Message: "quota exceeded", Endpoints: []*endpoint.Endpoint{failed}}, r.summaries[0].Failure)
+}
+
+func TestHTTPNotifier(t *testing.T) {
+	var got Summary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&got))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	n := NewHTTPNotifier(server.URL)
+	err := n.Notify(context.Background(), &Summary{Created: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", "A", "1.2.3.4")}})
+	assert.NoError(t, err)
+	assert.Equal(t, "new.example.com", got.Created[0].DNSName)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	err = NewHTTPNotifier(failing.URL).Notify(context.Background(), &Summary{})
+	assert.ErrorContains(t, err, "status 500")
+}
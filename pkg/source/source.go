@@ -0,0 +1,164 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package source lists the endpoints external-dns would publish for the resources of a cluster, through the sources
+// of external-dns itself, to preview the changes before the webhook is deployed.
+package source
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+const (
+	// defaultCRDAPIVersion and defaultCRDKind are the defaults of --crd-source-apiversion and --crd-source-kind.
+	defaultCRDAPIVersion = "externaldns.k8s.io/v1alpha1"
+	defaultCRDKind       = "DNSEndpoint"
+	// defaultRequestTimeout is the default of --request-timeout.
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// DefaultSources are the sources queried unless others are given.
+var DefaultSources = []string{"service", "ingress", "crd"}
+
+// Options are the cluster and the external-dns source flags of the preview, named after the flags of external-dns.
+type Options struct {
+	// Kubeconfig and Context select the cluster, $KUBECONFIG or ~/.kube/config and its current context when empty.
+	Kubeconfig string
+	Context    string
+
+	Sources                  []string
+	Namespace                string
+	AnnotationFilter         string
+	LabelFilter              string
+	IngressClassNames        []string
+	ServiceTypeFilter        []string
+	PublishInternal          bool
+	FQDNTemplate             string
+	CombineFQDNAndAnnotation bool
+	IgnoreHostnameAnnotation bool
+	DefaultTargets           []string
+}
+
+// config returns the external-dns source configuration of the options for the kubeconfig file.
+func (o Options) config(kubeconfig string) (*source.Config, error) {
+	labelFilter, err := labels.Parse(o.LabelFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label filter %q: %w", o.LabelFilter, err)
+	}
+	return &source.Config{
+		Namespace:                o.Namespace,
+		AnnotationFilter:         o.AnnotationFilter,
+		LabelFilter:              labelFilter,
+		IngressClassNames:        o.IngressClassNames,
+		ServiceTypeFilter:        o.ServiceTypeFilter,
+		PublishInternal:          o.PublishInternal,
+		FQDNTemplate:             o.FQDNTemplate,
+		CombineFQDNAndAnnotation: o.CombineFQDNAndAnnotation,
+		IgnoreHostnameAnnotation: o.IgnoreHostnameAnnotation,
+		DefaultTargets:           o.DefaultTargets,
+		CRDSourceAPIVersion:      defaultCRDAPIVersion,
+		CRDSourceKind:            defaultCRDKind,
+		KubeConfig:               kubeconfig,
+		RequestTimeout:           defaultRequestTimeout,
+	}, nil
+}
+
+// Endpoints returns the endpoints the external-dns sources publish for the resources of the cluster, merged and
+// deduplicated like external-dns does, sorted by name and type.
+func Endpoints(ctx context.Context, opts Options) ([]*endpoint.Endpoint, error) {
+	kubeconfig, cleanup, err := resolveKubeconfig(opts.Kubeconfig, opts.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	cfg, err := opts.config(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return listEndpoints(ctx, &source.SingletonClientGenerator{KubeConfig: kubeconfig, RequestTimeout: cfg.RequestTimeout}, opts.Sources, cfg)
+}
+
+// listEndpoints builds the named sources with the clients and lists their endpoints, all of DefaultSources when no
+// source is named.
+func listEndpoints(ctx context.Context, clients source.ClientGenerator, names []string, cfg *source.Config) ([]*endpoint.Endpoint, error) {
+	if len(names) == 0 {
+		names = DefaultSources
+	}
+	sources, err := source.ByNames(ctx, clients, names, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the sources: %w", err)
+	}
+	res, err := source.NewDedupSource(source.NewMultiSource(sources, cfg.DefaultTargets, cfg.ForceDefaultTargets)).Endpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the endpoints of the sources: %w", err)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].DNSName != res[j].DNSName {
+			return res[i].DNSName < res[j].DNSName
+		}
+		return res[i].RecordType < res[j].RecordType
+	})
+	return res, nil
+}
+
+// resolveKubeconfig returns a kubeconfig file of the context, the external-dns sources only take a file and use its
+// current context. Without any kubeconfig the sources fall back to the in-cluster configuration. cleanup removes the
+// file written for a context.
+func resolveKubeconfig(path, contextName string) (kubeconfig string, cleanup func(), err error) {
+	cleanup = func() {}
+	if contextName == "" && (path != "" || os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "") {
+		return path, cleanup, nil
+	}
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = path
+	raw, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{CurrentContext: contextName}).RawConfig()
+	if err != nil {
+		return "", cleanup, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if contextName != "" {
+		if _, ok := raw.Contexts[contextName]; !ok {
+			return "", cleanup, fmt.Errorf("context %q not found in kubeconfig", contextName)
+		}
+		raw.CurrentContext = contextName
+	}
+	if err := clientcmdapi.MinifyConfig(&raw); err != nil {
+		return "", cleanup, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if err := clientcmdapi.FlattenConfig(&raw); err != nil {
+		return "", cleanup, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	f, err := os.CreateTemp("", "kubeconfig-*")
+	if err != nil {
+		return "", cleanup, err
+	}
+	f.Close()
+	cleanup = func() { os.Remove(f.Name()) }
+	if err := clientcmd.WriteToFile(raw, f.Name()); err != nil {
+		cleanup()
+		return "", func() {}, err
+	}
+	return f.Name(), cleanup, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// fakeClients serves the kubernetes client of the service and ingress sources.
+type fakeClients struct {
+	source.SingletonClientGenerator
+	kube kubernetes.Interface
+}
+
+func (f *fakeClients) KubeClient() (kubernetes.Interface, error) {
+	return f.kube, nil
+}
+
+func TestEndpoints(t *testing.T) {
+	kube := fake.NewSimpleClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "web.example.com",
+				"external-dns.alpha.kubernetes.io/ttl":      "1m",
+			}},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "172.16.0.1"},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+				{IP: "10.0.0.1"}}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db", Annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "db.example.com",
+			}},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "172.16.0.2"},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api", Annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/target": "lb.example.com",
+			}},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "api.example.com"}}},
+		},
+	)
+	clients := &fakeClients{kube: kube}
+
+	cfg, err := Options{}.config("")
+	require.NoError(t, err)
+	endpoints, err := listEndpoints(context.Background(), clients, []string{"service", "ingress"}, cfg)
+	require.NoError(t, err)
+	var names []string
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName+" "+ep.RecordType+" "+ep.Targets.String())
+	}
+	// cluster IP services are only published with --publish-internal-services
+	assert.Equal(t, []string{
+		"api.example.com CNAME lb.example.com",
+		"web.example.com A 10.0.0.1",
+	}, names)
+	assert.EqualValues(t, 60, endpoints[1].RecordTTL)
+
+	cfg, err = Options{PublishInternal: true}.config("")
+	require.NoError(t, err)
+	endpoints, err = listEndpoints(context.Background(), clients, []string{"service"}, cfg)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, "db.example.com", endpoints[0].DNSName)
+}
+
+func TestEndpointsUnknownSource(t *testing.T) {
+	cfg, err := Options{}.config("")
+	require.NoError(t, err)
+	_, err = listEndpoints(context.Background(), &fakeClients{kube: fake.NewSimpleClientset()}, []string{"services"}, cfg)
+	assert.ErrorContains(t, err, "source not found")
+}
+
+func TestOptionsInvalidLabelFilter(t *testing.T) {
+	_, err := Options{LabelFilter: "app in ("}.config("")
+	assert.ErrorContains(t, err, "invalid label filter")
+}
+
+func TestResolveKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(`apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: prod
+  cluster: {server: https://prod.example.com}
+- name: staging
+  cluster: {server: https://staging.example.com}
+contexts:
+- name: prod
+  context: {cluster: prod, user: admin}
+- name: staging
+  context: {cluster: staging, user: admin}
+users:
+- name: admin
+  user: {token: secret}
+`), 0600))
+
+	kubeconfig, cleanup, err := resolveKubeconfig(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, path, kubeconfig)
+	cleanup()
+
+	kubeconfig, cleanup, err = resolveKubeconfig(path, "staging")
+	require.NoError(t, err)
+	resolved, err := clientcmd.LoadFromFile(kubeconfig)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", resolved.CurrentContext)
+	assert.Len(t, resolved.Clusters, 1)
+	assert.Equal(t, "https://staging.example.com", resolved.Clusters["staging"].Server)
+	cleanup()
+	_, err = os.Stat(kubeconfig)
+	assert.True(t, os.IsNotExist(err))
+
+	_, _, err = resolveKubeconfig(path, "missing")
+	assert.ErrorContains(t, err, `context "missing" not found`)
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryFunc runs call, possibly several times, and returns its final error.
+type RetryFunc func(ctx context.Context, call func(context.Context) error) error
+
+// Hooks are optional callbacks invoked around every page or batch call.
+type Hooks struct {
+	// Before is called before each call with its 1-based index.
+	Before func(ctx context.Context, index int)
+	// After is called after each call with its duration and result.
+	After func(ctx context.Context, index int, elapsed time.Duration, err error)
+	// Retry wraps each call, it is the injection point for retry policies.
+	Retry RetryFunc
+}
+
+type Option func(*Hooks)
+
+// WithBeforeHook sets the hook called before each call.
+func WithBeforeHook(f func(ctx context.Context, index int)) Option {
+	return func(h *Hooks) {
+		h.Before = f
+	}
+}
+
+// WithAfterHook sets the hook called after each call, usually to record metrics.
+func WithAfterHook(f func(ctx context.Context, index int, elapsed time.Duration, err error)) Option {
+	return func(h *Hooks) {
+		h.After = f
+	}
+}
+
+// WithRetry sets the retry policy applied to each call.
+func WithRetry(f RetryFunc) Option {
+	return func(h *Hooks) {
+		h.Retry = f
+	}
+}
+
+func newHooks(options []Option) *Hooks {
+	h := &Hooks{}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+func (h *Hooks) call(ctx context.Context, index int, f func(context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if h.Before != nil {
+		h.Before(ctx, index)
+	}
+	start := time.Now()
+	var err error
+	if h.Retry != nil {
+		err = h.Retry(ctx, f)
+	} else {
+		err = f(ctx)
+	}
+	if h.After != nil {
+		h.After(ctx, index, time.Since(start), err)
+	}
+	return err
+}
+
+// BatchForEach splits the items into batches and calls the function for each batch.
+func BatchForEach[T any, R any](ctx context.Context, items []T, batchSize int, f func(context.Context, []T) ([]R, error), options ...Option) ([]R, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be greater than 0")
+	}
+	n := len(items)
+	if n == 0 {
+		return []R{}, nil
+	}
+	h := newHooks(options)
+	var all []R
+	for i := 0; i < n; i += batchSize {
+		end := i + batchSize
+		if end > n {
+			end = n
+		}
+		var part []R
+		err := h.call(ctx, i/batchSize+1, func(ctx context.Context) error {
+			var err error
+			part, err = f(ctx, items[i:end])
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, part...)
+	}
+
+	return all, nil
+}
+
+// QueryAll is a generic pagination function: query is responsible for cloning, setting page number, and returning (data, total, err).
+// Pagination stops when the reported total is reached or a page comes back empty.
+func QueryAll[T any](ctx context.Context, pageSize int, query func(ctx context.Context, pageNum, pageSize int) ([]T, int, error), options ...Option) ([]T, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("pageSize must be greater than 0")
+	}
+	h := newHooks(options)
+	var all []T
+	pageNum := 1
+	for {
+		var (
+			data  []T
+			total int
+		)
+		err := h.call(ctx, pageNum, func(ctx context.Context) error {
+			var err error
+			data, total, err = query(ctx, pageNum, pageSize)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, data...)
+		if len(data) == 0 || pageNum*pageSize >= total {
+			break
+		}
+		pageNum++
+	}
+
+	return all, nil
+}
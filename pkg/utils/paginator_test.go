@@ -0,0 +1,172 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchForEach(t *testing.T) {
+	cases := []struct {
+		name      string
+		items     []int
+		batchSize int
+		expected  []int
+		wantErr   bool
+	}{{
+		name:      "normal case with batch size 2",
+		items:     []int{1, 2, 3, 4, 5},
+		batchSize: 2,
+		expected:  []int{2, 4, 6, 8, 10},
+		wantErr:   false,
+	}, {
+		name:      "empty items",
+		items:     []int{},
+		batchSize: 2,
+		expected:  []int{},
+		wantErr:   false,
+	}, {
+		name:      "batch size 0",
+		items:     []int{1, 2, 3},
+		batchSize: 0,
+		expected:  nil,
+		wantErr:   true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Define a function that multiplies each number by 2
+			doubleFunc := func(_ context.Context, batch []int) ([]int, error) {
+				result := make([]int, 0, len(batch))
+				for _, item := range batch {
+					result = append(result, item*2)
+				}
+				return result, nil
+			}
+
+			result, err := BatchForEach(context.Background(), tc.items, tc.batchSize, doubleFunc)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBatchForEachHooks(t *testing.T) {
+	var before, after []int
+	_, err := BatchForEach(context.Background(), []int{1, 2, 3}, 2, func(_ context.Context, batch []int) ([]int, error) {
+		return batch, nil
+	},
+		WithBeforeHook(func(_ context.Context, index int) { before = append(before, index) }),
+		WithAfterHook(func(_ context.Context, index int, _ time.Duration, err error) {
+			assert.NoError(t, err)
+			after = append(after, index)
+		}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, before)
+	assert.Equal(t, []int{1, 2}, after)
+}
+
+func TestBatchForEachCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	_, err := BatchForEach(ctx, []int{1, 2, 3}, 1, func(_ context.Context, batch []int) ([]int, error) {
+		calls++
+		return batch, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, calls)
+}
+
+func TestQueryAll(t *testing.T) {
+	// Mock a query function that returns paginated data
+	mockQuery := func(_ context.Context, pageNum, pageSize int) ([]string, int, error) {
+		// Mock a total of 100 data items
+		total := 100
+		start := (pageNum - 1) * pageSize
+		end := start + pageSize
+		if end > total {
+			end = total
+		}
+
+		// Generate mock data
+		data := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			data = append(data, fmt.Sprintf("item-%d", i))
+		}
+
+		return data, total, nil
+	}
+
+	// Test normal case
+	result, err := QueryAll(context.Background(), 20, mockQuery)
+	assert.NoError(t, err)
+	assert.Len(t, result, 100)
+
+	// Test case when pageSize is 0
+	result, err = QueryAll(context.Background(), 0, mockQuery)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestQueryAllStopsOnEmptyPage(t *testing.T) {
+	calls := 0
+	result, err := QueryAll(context.Background(), 10, func(_ context.Context, pageNum, pageSize int) ([]int, int, error) {
+		calls++
+		if pageNum > 1 {
+			return nil, 100, nil
+		}
+		return []int{1, 2, 3}, 100, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, result)
+	assert.Equal(t, 2, calls)
+}
+
+func TestQueryAllRetry(t *testing.T) {
+	attempts := 0
+	retry := func(ctx context.Context, call func(context.Context) error) error {
+		var err error
+		for i := 0; i < 3; i++ {
+			if err = call(ctx); err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+	result, err := QueryAll(context.Background(), 10, func(_ context.Context, pageNum, pageSize int) ([]int, int, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, 0, errors.New("throttled")
+		}
+		return []int{1}, 1, nil
+	}, WithRetry(retry))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, result)
+	assert.Equal(t, 3, attempts)
+}
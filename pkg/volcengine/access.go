@@ -0,0 +1,52 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+)
+
+// CheckAPIAccess lists the zones through the API, bypassing the cache, to verify that the credentials are valid and
+// allowed to list them, e.g. for a readiness probe.
+func (p *Provider) CheckAPIAccess(ctx context.Context) error {
+	if p.pzClient != nil {
+		if _, err := uncachedAPI(p.pzClient).ListPrivateZones(ctx, p.vpcID); err != nil {
+			if p.public {
+				return fmt.Errorf("failed to list public zones: %w", err)
+			}
+			return fmt.Errorf("failed to list private zones: %w", err)
+		}
+	}
+	if p.publicZones != nil {
+		return p.publicZones.CheckAPIAccess(ctx)
+	}
+	return nil
+}
+
+// uncachedAPI returns the API client wrapped by the cache and the dry-run wrapper.
+func uncachedAPI(api privateZoneAPI) privateZoneAPI {
+	for {
+		switch a := api.(type) {
+		case *dryRunAPI:
+			api = a.privateZoneAPI
+		case *cachedPrivateZoneAPI:
+			api = a.privateZoneAPI
+		default:
+			return api
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+)
+
+func TestCheckAPIAccess(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(testZones(), nil).Once()
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput(nil), errors.New("InvalidAccessKey")).Once()
+	cache := newCachedPrivateZoneAPI(mockAPI, time.Hour, "", logrus.StandardLogger())
+	provider := &Provider{pzClient: newDryRunAPI(cache, logrus.StandardLogger()), privateZone: true, vpcID: "vpc-123"}
+	ctx := context.Background()
+
+	// Every check calls the API, also when the zones are cached
+	assert.NoError(t, provider.CheckAPIAccess(ctx))
+	err := provider.CheckAPIAccess(ctx)
+	assert.ErrorContains(t, err, "failed to list private zones: InvalidAccessKey")
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCheckAPIAccessPublicZones(t *testing.T) {
+	publicAPI := new(MockPrivateZoneAPI)
+	publicAPI.On("ListPrivateZones", mock.Anything, "").Return([]*privatezone.ZoneForListPrivateZonesOutput(nil), errors.New("AccessDenied")).Once()
+	provider := &Provider{publicZones: &Provider{pzClient: publicAPI, public: true}}
+
+	assert.ErrorContains(t, provider.CheckAPIAccess(context.Background()), "failed to list public zones: AccessDenied")
+	publicAPI.AssertExpectations(t)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// AdjustEndpoints rewrites the desired endpoints the way the provider writes them, so external-dns compares
+// them with Records without planning changes that never converge: endpoints of record types the managed zones do
+// not accept are dropped, TTLs are clamped to the range of the TTL policy and of privatezone, the trailing dot of
+// name targets follows the target dot policy, IPv6 addresses and MX values are written in their canonical form,
+// duplicate targets are collapsed and weights and lines other than the default are set under ProviderSpecificWeight
+// and ProviderSpecificLine. With disabled records included, the ProviderSpecificDisabledTargets of the current
+// endpoint is copied to the desired one.
+func (p *Provider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	adjusted := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if !p.acceptsRecordType(ep.RecordType) {
+			p.logger().Warnf("Dropping endpoint %s %s, the record type is not supported by the managed zones", ep.DNSName, ep.RecordType)
+			continue
+		}
+		if ttl := clampTTL(p.ttlPolicy(ep).clamp(ep.RecordTTL)); ttl != ep.RecordTTL {
+			p.logger().Debugf("Clamping TTL %d of %s %s to %d", ep.RecordTTL, ep.DNSName, ep.RecordType, ttl)
+			ep.RecordTTL = ttl
+		}
+		targets := make(endpoint.Targets, 0, len(ep.Targets))
+		for _, target := range ep.Targets {
+			target = normalizeTarget(ep.RecordType, target)
+			target = p.targetDot.apply(ep.RecordType, target)
+			if !containsTarget(ep.RecordType, targets, target) {
+				targets = append(targets, target)
+			}
+		}
+		ep.Targets = targets
+		adjustWeight(ep)
+		adjustLine(ep)
+		if p.includeDisabled {
+			p.mirrorDisabledTargets(ep)
+		}
+		adjusted = append(adjusted, ep)
+	}
+	return adjusted, nil
+}
+
+// rememberDisabledTargets keeps the ProviderSpecificDisabledTargets of the endpoints returned by Records for
+// mirrorDisabledTargets.
+func (p *Provider) rememberDisabledTargets(endpoints []*endpoint.Endpoint) {
+	disabled := make(map[string]string)
+	for _, ep := range endpoints {
+		if value, ok := ep.GetProviderSpecificProperty(ProviderSpecificDisabledTargets); ok {
+			disabled[updateKey(ep)] = value
+		}
+	}
+	p.disabledTargets.Store(&disabled)
+}
+
+// mirrorDisabledTargets sets the ProviderSpecificDisabledTargets the current endpoint was returned with on the
+// desired endpoint. external-dns plans an update for a provider-specific property only one side has, so the
+// endpoints with disabled records would otherwise be updated on every sync.
+func (p *Provider) mirrorDisabledTargets(ep *endpoint.Endpoint) {
+	ep.DeleteProviderSpecificProperty(ProviderSpecificDisabledTargets)
+	disabled := p.disabledTargets.Load()
+	if disabled == nil {
+		return
+	}
+	if value, ok := (*disabled)[updateKey(ep)]; ok {
+		ep.WithProviderSpecific(ProviderSpecificDisabledTargets, value)
+	}
+}
+
+// acceptsRecordType reports whether the private or the public zones accept records of the type.
+func (p *Provider) acceptsRecordType(recordType string) bool {
+	return supportedRecordTypes[recordType] || (p.publicZones != nil && publicOnlyRecordTypes[recordType])
+}
+
+// containsTarget reports whether targets holds a value equal to target, ignoring the trailing dot of names.
+func containsTarget(recordType string, targets endpoint.Targets, target string) bool {
+	for _, t := range targets {
+		if sameTarget(recordType, t, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestAdjustEndpoints(t *testing.T) {
+	p := &Provider{targetDot: TargetDotStrip}
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("short.example.com", "A", 1, "10.0.0.1", "10.0.0.1", "10.0.0.2"),
+		endpoint.NewEndpointWithTTL("long.example.com", "TXT", 604800, "hello"),
+		{DNSName: "api.example.com", RecordType: "CNAME", Targets: endpoint.Targets{"lb.example.com."}},
+		{DNSName: "mail.example.com", RecordType: "MX", Targets: endpoint.Targets{"10 mx.example.com.", "10 mx.example.com"}},
+		endpoint.NewEndpoint("example.com", "CAA", `0 issue "letsencrypt.org"`),
+		endpoint.NewEndpoint("ns.example.com", "NS", "ns1.example.com"),
+		endpoint.NewEndpoint("lb.example.com", "AAAA", "2001:0db8:0:0:0:0:0:1", "2001:db8::1"),
+	})
+	require.NoError(t, err)
+	require.Len(t, adjusted, 5)
+
+	assert.Equal(t, endpoint.TTL(5), adjusted[0].RecordTTL)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1", "10.0.0.2"}, adjusted[0].Targets)
+	assert.Equal(t, endpoint.TTL(86400), adjusted[1].RecordTTL)
+	assert.Equal(t, endpoint.Targets{"lb.example.com"}, adjusted[2].Targets)
+	assert.False(t, adjusted[2].RecordTTL.IsConfigured(), "unset TTLs are left to privatezone")
+	assert.Equal(t, endpoint.Targets{"10 mx.example.com"}, adjusted[3].Targets)
+	assert.Equal(t, endpoint.Targets{"2001:db8::1"}, adjusted[4].Targets)
+}
+
+func TestAdjustEndpointsPublicZones(t *testing.T) {
+	p := &Provider{publicZones: &Provider{public: true}}
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.com", "CAA", `0 issue "letsencrypt.org"`),
+		{DNSName: "api.example.com", RecordType: "CNAME", Targets: endpoint.Targets{"lb.example.com."}},
+	})
+	require.NoError(t, err)
+	require.Len(t, adjusted, 2)
+	assert.Equal(t, endpoint.Targets{"lb.example.com."}, adjusted[1].Targets, "preserve keeps the trailing dot")
+}
+
+func TestAdjustEndpointsMirrorsDisabledTargets(t *testing.T) {
+	p := &Provider{includeDisabled: true}
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "A", "10.0.0.1", "10.0.0.2").WithProviderSpecific(ProviderSpecificDisabledTargets, "10.0.0.2"),
+		endpoint.NewEndpoint("api.example.com", "A", "10.0.1.1"),
+	}
+	p.rememberDisabledTargets(current)
+
+	desired, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("WWW.example.com.", "A", "10.0.0.2", "10.0.0.1"),
+		endpoint.NewEndpoint("api.example.com", "A", "10.0.1.1").WithProviderSpecific(ProviderSpecificDisabledTargets, "10.0.1.1"),
+	})
+	require.NoError(t, err)
+	value, ok := desired[0].GetProviderSpecificProperty(ProviderSpecificDisabledTargets)
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.2", value)
+	_, ok = desired[1].GetProviderSpecificProperty(ProviderSpecificDisabledTargets)
+	assert.False(t, ok)
+
+	// the endpoints with disabled records converge
+	changes := (&plan.Plan{
+		Current:        current,
+		Desired:        desired,
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges())
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/service/sts"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/session"
+)
+
+const (
+	// AssumeRoleProviderName is the provider name of credentials of an assumed role.
+	AssumeRoleProviderName = "AssumeRoleProvider"
+
+	defaultAssumeRoleSessionName = "external-dns"
+	// assumeRoleDuration is the lifetime requested for the role credentials.
+	assumeRoleDuration = time.Hour
+	// assumeRoleExpiryWindow renews the role credentials this long before they expire.
+	assumeRoleExpiryWindow = 5 * time.Minute
+	// assumeRoleTimeout bounds the STS call, credentials.Provider offers no context to cancel a hung renewal.
+	assumeRoleTimeout = 10 * time.Second
+)
+
+// AssumeRole are the role, typically of another account, whose credentials are used instead of the configured ones.
+type AssumeRole struct {
+	RoleTrn     string
+	SessionName string
+	// StsEndpoint defaults to DefaultStsEndpoint.
+	StsEndpoint string
+	// Headers are attached to the STS requests, e.g. for an OpenAPI gateway.
+	Headers http.Header
+}
+
+// assumeRoleAPI is the STS call of AssumeRoleProvider, *sts.STS satisfies it.
+type assumeRoleAPI interface {
+	AssumeRoleWithContext(ctx volcengine.Context, input *sts.AssumeRoleInput, opts ...request.Option) (*sts.AssumeRoleOutput, error)
+}
+
+// AssumeRoleProvider exchanges base credentials for the credentials of a role through STS AssumeRole, and renews
+// them before they expire.
+type AssumeRoleProvider struct {
+	credentials.Expiry
+
+	RoleTrn     string
+	SessionName string
+	// Duration is the lifetime requested for the role credentials.
+	Duration time.Duration
+	// ExpiryWindow renews the credentials this long before they expire.
+	ExpiryWindow time.Duration
+
+	client assumeRoleAPI
+	log    Logger
+}
+
+// NewAssumeRoleCredentials returns the credentials of the role, assumed with the base credentials, the renewals are
+// logged to log.
+func NewAssumeRoleCredentials(base *credentials.Credentials, region string, role AssumeRole, log Logger) (*credentials.Credentials, error) {
+	endpoint := role.StsEndpoint
+	if endpoint == "" {
+		endpoint = DefaultStsEndpoint
+	}
+	s, err := session.NewSession(volcengine.NewConfig().
+		WithRegion(region).
+		WithCredentials(base).
+		WithEndpoint(endpoint).
+		WithLogger(NewLoggerAdapter(log.WithField("client", "sts"))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sts session: %w", err)
+	}
+	installStaticHeaders(&s.Handlers, role.Headers)
+	return credentials.NewExpireAbleCredentials(newAssumeRoleProvider(sts.New(s), role, log)), nil
+}
+
+func newAssumeRoleProvider(client assumeRoleAPI, role AssumeRole, log Logger) *AssumeRoleProvider {
+	sessionName := role.SessionName
+	if sessionName == "" {
+		sessionName = defaultAssumeRoleSessionName
+	}
+	return &AssumeRoleProvider{
+		RoleTrn:      role.RoleTrn,
+		SessionName:  sessionName,
+		Duration:     assumeRoleDuration,
+		ExpiryWindow: assumeRoleExpiryWindow,
+		client:       client,
+		log:          log,
+	}
+}
+
+// Retrieve assumes the role.
+// Implementation for credentials.Provider
+func (p *AssumeRoleProvider) Retrieve() (credentials.Value, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), assumeRoleTimeout)
+	defer cancel()
+	output, err := p.client.AssumeRoleWithContext(ctx, &sts.AssumeRoleInput{
+		RoleTrn:         volcengine.String(p.RoleTrn),
+		RoleSessionName: volcengine.String(p.SessionName),
+		DurationSeconds: volcengine.Int32(int32(p.Duration / time.Second)),
+	})
+	if err != nil {
+		return credentials.Value{ProviderName: AssumeRoleProviderName}, fmt.Errorf("failed to assume role %s: %w", p.RoleTrn, err)
+	}
+	creds := output.Credentials
+	if creds == nil || volcengine.StringValue(creds.AccessKeyId) == "" {
+		return credentials.Value{ProviderName: AssumeRoleProviderName}, fmt.Errorf("assuming role %s returned no credentials", p.RoleTrn)
+	}
+	expiration, err := time.Parse(time.RFC3339, volcengine.StringValue(creds.ExpiredTime))
+	if err != nil {
+		return credentials.Value{ProviderName: AssumeRoleProviderName}, fmt.Errorf("failed to parse expiration %q of role %s credentials: %v",
+			volcengine.StringValue(creds.ExpiredTime), p.RoleTrn, err)
+	}
+	p.SetExpiration(expiration, p.ExpiryWindow)
+	p.log.Infof("Assumed role %s as session %s, credentials expire at %s", p.RoleTrn, p.SessionName, expiration.Format(time.RFC3339))
+
+	return credentials.Value{
+		AccessKeyID:     volcengine.StringValue(creds.AccessKeyId),
+		SecretAccessKey: volcengine.StringValue(creds.SecretAccessKey),
+		SessionToken:    volcengine.StringValue(creds.SessionToken),
+		ProviderName:    AssumeRoleProviderName,
+	}, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/sts"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+)
+
+type fakeAssumeRoleAPI struct {
+	expiration time.Time
+	err        error
+	inputs     []*sts.AssumeRoleInput
+	deadlines  []time.Time
+}
+
+func (f *fakeAssumeRoleAPI) AssumeRoleWithContext(ctx volcengine.Context, input *sts.AssumeRoleInput, _ ...request.Option) (*sts.AssumeRoleOutput, error) {
+	f.inputs = append(f.inputs, input)
+	deadline, _ := ctx.Deadline()
+	f.deadlines = append(f.deadlines, deadline)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &sts.AssumeRoleOutput{Credentials: &sts.CredentialsForAssumeRoleOutput{
+		AccessKeyId:     volcengine.String("AKTP1"),
+		SecretAccessKey: volcengine.String("secret"),
+		SessionToken:    volcengine.String("token"),
+		ExpiredTime:     volcengine.String(f.expiration.Format(time.RFC3339)),
+	}}, nil
+}
+
+func TestAssumeRoleProvider(t *testing.T) {
+	api := &fakeAssumeRoleAPI{expiration: time.Now().Add(time.Hour)}
+	creds := credentials.NewExpireAbleCredentials(newAssumeRoleProvider(api, AssumeRole{RoleTrn: "trn:iam::2100000001:role/dns"}, logrus.StandardLogger()))
+
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "AKTP1", value.AccessKeyID)
+	assert.Equal(t, "secret", value.SecretAccessKey)
+	assert.Equal(t, "token", value.SessionToken)
+	assert.Equal(t, AssumeRoleProviderName, value.ProviderName)
+	require.Len(t, api.inputs, 1)
+	assert.Equal(t, "trn:iam::2100000001:role/dns", volcengine.StringValue(api.inputs[0].RoleTrn))
+	assert.Equal(t, defaultAssumeRoleSessionName, volcengine.StringValue(api.inputs[0].RoleSessionName))
+	assert.Equal(t, int32(3600), volcengine.Int32Value(api.inputs[0].DurationSeconds))
+	assert.WithinDuration(t, time.Now().Add(assumeRoleTimeout), api.deadlines[0], time.Second)
+
+	// Valid credentials are reused
+	_, err = creds.Get()
+	require.NoError(t, err)
+	assert.Len(t, api.inputs, 1)
+
+	// Credentials within the expiry window are renewed
+	api.expiration = time.Now().Add(time.Minute)
+	creds.Expire()
+	_, err = creds.Get()
+	require.NoError(t, err)
+	assert.True(t, creds.IsExpired())
+	_, err = creds.Get()
+	require.NoError(t, err)
+	assert.Len(t, api.inputs, 3)
+}
+
+func TestAssumeRoleProviderError(t *testing.T) {
+	api := &fakeAssumeRoleAPI{err: errors.New("AccessDenied")}
+	p := newAssumeRoleProvider(api, AssumeRole{RoleTrn: "trn:iam::2100000001:role/dns", SessionName: "cluster-a"}, logrus.StandardLogger())
+
+	_, err := p.Retrieve()
+	assert.EqualError(t, err, "failed to assume role trn:iam::2100000001:role/dns: AccessDenied")
+	assert.Equal(t, "cluster-a", volcengine.StringValue(api.inputs[0].RoleSessionName))
+}
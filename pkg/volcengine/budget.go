@@ -0,0 +1,51 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// applyChangeBudget keeps the deletes and then the creates that fit into budget records, counting every target
+// as one operation. The remaining endpoints are dropped, external-dns plans them again in the next sync.
+// The first endpoint is always kept so an endpoint larger than the budget is not deferred forever.
+func (p *Provider) applyChangeBudget(deletes, creates []*endpoint.Endpoint) ([]*endpoint.Endpoint, []*endpoint.Endpoint) {
+	if p.changeBudget <= 0 {
+		return deletes, creates
+	}
+	remaining := p.changeBudget
+	deferred := 0
+	take := func(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+		kept := make([]*endpoint.Endpoint, 0, len(endpoints))
+		for _, ep := range endpoints {
+			ops := len(ep.Targets)
+			if ops > remaining && remaining < p.changeBudget {
+				deferred += ops
+				remaining = 0
+				continue
+			}
+			remaining -= ops
+			kept = append(kept, ep)
+		}
+		return kept
+	}
+	deletes = take(deletes)
+	creates = take(creates)
+	if deferred > 0 {
+		p.logger().Warnf("Change budget of %d records per sync exceeded, deferring %d record changes to the next syncs", p.changeBudget, deferred)
+	}
+	return deletes, creates
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestApplyChangeBudget(t *testing.T) {
+	deletes := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", "A", "1.1.1.1", "2.2.2.2"),
+		endpoint.NewEndpoint("b.example.com", "A", "3.3.3.3"),
+	}
+	creates := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("c.example.com", "A", "4.4.4.4"),
+		endpoint.NewEndpoint("d.example.com", "A", "5.5.5.5"),
+	}
+
+	tests := []struct {
+		name            string
+		budget          int
+		deletes         []*endpoint.Endpoint
+		creates         []*endpoint.Endpoint
+		expectedDeletes []*endpoint.Endpoint
+		expectedCreates []*endpoint.Endpoint
+	}{
+		{name: "unlimited", deletes: deletes, creates: creates, expectedDeletes: deletes, expectedCreates: creates},
+		{name: "fits", budget: 5, deletes: deletes, creates: creates, expectedDeletes: deletes, expectedCreates: creates},
+		{name: "defers creates", budget: 4, deletes: deletes, creates: creates, expectedDeletes: deletes, expectedCreates: creates[:1]},
+		{name: "defers in order", budget: 2, deletes: deletes, creates: creates, expectedDeletes: deletes[:1], expectedCreates: []*endpoint.Endpoint{}},
+		{name: "keeps first endpoint larger than budget", budget: 1, deletes: deletes, creates: creates, expectedDeletes: deletes[:1], expectedCreates: []*endpoint.Endpoint{}},
+		{name: "creates only", budget: 1, creates: creates, expectedDeletes: []*endpoint.Endpoint{}, expectedCreates: creates[:1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Provider{changeBudget: tt.budget}
+			gotDeletes, gotCreates := p.applyChangeBudget(tt.deletes, tt.creates)
+			assert.Equal(t, tt.expectedDeletes, gotDeletes)
+			assert.Equal(t, tt.expectedCreates, gotCreates)
+		})
+	}
+}
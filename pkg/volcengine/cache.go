@@ -0,0 +1,396 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+// cacheSnapshotVersion is bumped whenever the snapshot format changes, other versions are ignored.
+const cacheSnapshotVersion = 1
+
+type zonesCacheEntry struct {
+	Zones     []*privatezone.ZoneForListPrivateZonesOutput `json:"zones"`
+	FetchedAt time.Time                                    `json:"fetchedAt"`
+}
+
+type recordsCacheEntry struct {
+	Records   []*privatezone.RecordForListRecordsOutput `json:"records"`
+	FetchedAt time.Time                                 `json:"fetchedAt"`
+}
+
+// cacheSnapshot is the on-disk format of the cache.
+type cacheSnapshot struct {
+	Version int                          `json:"version"`
+	Zones   map[string]*zonesCacheEntry  `json:"zones"`
+	Records map[int64]*recordsCacheEntry `json:"records"`
+}
+
+// cachedPrivateZoneAPI serves zone and record listings from memory for ttl, writes invalidate the zone.
+// When file is set the cache is snapshotted by flush, after each sync and at shutdown, and loaded at startup,
+// so a restarted webhook does not list every zone again right away.
+// When refreshAfter is set, listings older than it are still served but refreshed in the background.
+type cachedPrivateZoneAPI struct {
+	privateZoneAPI
+
+	// guards ttl and refreshAfter, changed at runtime by setTTL
+	settingsMu   sync.RWMutex
+	ttl          time.Duration
+	refreshAfter time.Duration
+	file         string
+	log          Logger
+	now          func() time.Time
+
+	mu      sync.Mutex
+	zones   map[string]*zonesCacheEntry
+	records map[int64]*recordsCacheEntry
+	// keys of the listings refreshed in the background
+	refreshing map[string]bool
+	// bumped by every invalidation, so a background refresh started before a write is dropped
+	generations map[int64]uint64
+	// set by every change of the listings since the last snapshot
+	dirty     bool
+	refreshes sync.WaitGroup
+}
+
+var _ privateZoneAPI = &cachedPrivateZoneAPI{}
+
+func newCachedPrivateZoneAPI(api privateZoneAPI, ttl time.Duration, file string, log Logger) *cachedPrivateZoneAPI {
+	c := &cachedPrivateZoneAPI{
+		privateZoneAPI: api,
+		ttl:            ttl,
+		file:           file,
+		log:            log,
+		now:            time.Now,
+		zones:          make(map[string]*zonesCacheEntry),
+		records:        make(map[int64]*recordsCacheEntry),
+		refreshing:     make(map[string]bool),
+		generations:    make(map[int64]uint64),
+	}
+	if file != "" {
+		if err := c.load(); err != nil {
+			log.Warnf("Ignoring cache snapshot %s: %v", file, err)
+		}
+	}
+	return c
+}
+
+func (c *cachedPrivateZoneAPI) fresh(fetchedAt time.Time) bool {
+	ttl, _ := c.settings()
+	age := c.now().Sub(fetchedAt)
+	return age >= 0 && age < ttl
+}
+
+// stale reports whether a fresh listing should be refreshed in the background.
+func (c *cachedPrivateZoneAPI) stale(fetchedAt time.Time) bool {
+	_, refreshAfter := c.settings()
+	return refreshAfter > 0 && c.now().Sub(fetchedAt) >= refreshAfter
+}
+
+// settings returns the ttl and refreshAfter of the listings.
+func (c *cachedPrivateZoneAPI) settings() (time.Duration, time.Duration) {
+	c.settingsMu.RLock()
+	defer c.settingsMu.RUnlock()
+	return c.ttl, c.refreshAfter
+}
+
+// setTTL changes the ttl and refreshAfter of the listings, the cached listings are kept and expire by the new ttl.
+func (c *cachedPrivateZoneAPI) setTTL(ttl, refreshAfter time.Duration) {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
+	c.ttl, c.refreshAfter = ttl, refreshAfter
+}
+
+// refresh runs fetch in the background unless a refresh of key is running already.
+// The request context is detached so the refresh outlives the request that triggered it.
+func (c *cachedPrivateZoneAPI) refresh(ctx context.Context, key string, fetch func(ctx context.Context)) {
+	c.mu.Lock()
+	if c.refreshing[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[key] = true
+	c.mu.Unlock()
+
+	c.refreshes.Add(1)
+	go func() {
+		defer c.refreshes.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, key)
+			c.mu.Unlock()
+		}()
+		fetch(context.WithoutCancel(ctx))
+	}()
+}
+
+// ListPrivateZones returns the cached zones of the VPC, listing them when expired.
+func (c *cachedPrivateZoneAPI) ListPrivateZones(ctx context.Context, vpcID string) ([]*privatezone.ZoneForListPrivateZonesOutput, error) {
+	c.mu.Lock()
+	entry, ok := c.zones[vpcID]
+	c.mu.Unlock()
+	if ok && c.fresh(entry.FetchedAt) {
+		contextLogger(ctx, c.log).Debugf("Using cached privatezones of vpc %s fetched at %s", vpcID, entry.FetchedAt)
+		if c.stale(entry.FetchedAt) {
+			c.refresh(ctx, "zones/"+vpcID, func(ctx context.Context) {
+				zones, err := c.privateZoneAPI.ListPrivateZones(ctx, vpcID)
+				if err != nil {
+					contextLogger(ctx, c.log).Warnf("Failed to refresh cached privatezones of vpc %s: %v", vpcID, err)
+					return
+				}
+				c.mu.Lock()
+				c.zones[vpcID] = &zonesCacheEntry{Zones: zones, FetchedAt: c.now()}
+				c.dirty = true
+				c.mu.Unlock()
+			})
+		}
+		return entry.Zones, nil
+	}
+	zones, err := c.privateZoneAPI.ListPrivateZones(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.zones[vpcID] = &zonesCacheEntry{Zones: zones, FetchedAt: c.now()}
+	c.dirty = true
+	c.mu.Unlock()
+	return zones, nil
+}
+
+// GetPrivateZoneRecords returns the cached records of the zone, listing them when expired. A listing is not cached
+// when the zone was written to while it ran.
+func (c *cachedPrivateZoneAPI) GetPrivateZoneRecords(ctx context.Context, zid int64) ([]*privatezone.RecordForListRecordsOutput, error) {
+	c.mu.Lock()
+	entry, ok := c.records[zid]
+	generation := c.generations[zid]
+	c.mu.Unlock()
+	if ok && c.fresh(entry.FetchedAt) {
+		contextLogger(ctx, c.log).Debugf("Using cached records of zone %d fetched at %s", zid, entry.FetchedAt)
+		if c.stale(entry.FetchedAt) {
+			c.refreshRecords(ctx, zid)
+		}
+		return entry.Records, nil
+	}
+	records, err := c.privateZoneAPI.GetPrivateZoneRecords(ctx, zid)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.generations[zid] == generation {
+		c.records[zid] = &recordsCacheEntry{Records: records, FetchedAt: c.now()}
+		c.dirty = true
+	}
+	c.mu.Unlock()
+	return records, nil
+}
+
+// GetPrivateZoneRecordsByHostType filters the cached records of the zone when fresh,
+// otherwise it queries the API without caching the partial result.
+func (c *cachedPrivateZoneAPI) GetPrivateZoneRecordsByHostType(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	c.mu.Lock()
+	entry, ok := c.records[zid]
+	c.mu.Unlock()
+	if !ok || !c.fresh(entry.FetchedAt) {
+		return c.privateZoneAPI.GetPrivateZoneRecordsByHostType(ctx, zid, host, recordType)
+	}
+	res := make([]*privatezone.RecordForListRecordsOutput, 0)
+	for _, record := range entry.Records {
+		if volcengine.StringValue(record.Host) == host && volcengine.StringValue(record.Type) == recordType {
+			res = append(res, record)
+		}
+	}
+	return res, nil
+}
+
+func (c *cachedPrivateZoneAPI) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, domain, recordType, target string, TTL, weight int32, line, remark string) error {
+	defer c.invalidate(zoneID)
+	return c.privateZoneAPI.CreatePrivateZoneRecord(ctx, zoneID, domain, recordType, target, TTL, weight, line, remark)
+}
+
+func (c *cachedPrivateZoneAPI) BatchCreatePrivateZoneRecord(ctx context.Context, zoneID int64, records []*privatezone.RecordForBatchCreateRecordInput) error {
+	defer c.invalidate(zoneID)
+	return c.privateZoneAPI.BatchCreatePrivateZoneRecord(ctx, zoneID, records)
+}
+
+func (c *cachedPrivateZoneAPI) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL, weight int32, line, remark string) error {
+	defer c.invalidate(zoneID)
+	return c.privateZoneAPI.UpdatePrivateZoneRecord(ctx, zoneID, recordID, host, recordType, target, TTL, weight, line, remark)
+}
+
+func (c *cachedPrivateZoneAPI) BatchDeletePrivateZoneRecords(ctx context.Context, zoneID int64, recordIDs []string) error {
+	defer c.invalidate(zoneID)
+	return c.privateZoneAPI.BatchDeletePrivateZoneRecords(ctx, zoneID, recordIDs)
+}
+
+func (c *cachedPrivateZoneAPI) DeletePrivateZoneRecordById(ctx context.Context, zoneID int64, recordID string) error {
+	defer c.invalidate(zoneID)
+	return c.privateZoneAPI.DeletePrivateZoneRecordById(ctx, zoneID, recordID)
+}
+
+func (c *cachedPrivateZoneAPI) DisablePrivateZoneRecord(ctx context.Context, zoneID int64, recordID, remark string) error {
+	defer c.invalidate(zoneID)
+	return c.privateZoneAPI.DisablePrivateZoneRecord(ctx, zoneID, recordID, remark)
+}
+
+// refreshRecords lists the records of the zone in the background, the result is dropped when the zone
+// was written to in the meantime.
+func (c *cachedPrivateZoneAPI) refreshRecords(ctx context.Context, zid int64) {
+	c.mu.Lock()
+	generation := c.generations[zid]
+	c.mu.Unlock()
+	c.refresh(ctx, fmt.Sprintf("records/%d", zid), func(ctx context.Context) {
+		records, err := c.privateZoneAPI.GetPrivateZoneRecords(ctx, zid)
+		if err != nil {
+			contextLogger(ctx, c.log).Warnf("Failed to refresh cached records of zone %d: %v", zid, err)
+			return
+		}
+		c.mu.Lock()
+		if c.generations[zid] != generation {
+			c.mu.Unlock()
+			return
+		}
+		c.records[zid] = &recordsCacheEntry{Records: records, FetchedAt: c.now()}
+		c.dirty = true
+		c.mu.Unlock()
+	})
+}
+
+// invalidate drops the cached records of the zone after a write.
+func (c *cachedPrivateZoneAPI) invalidate(zoneID int64) {
+	c.mu.Lock()
+	delete(c.records, zoneID)
+	c.generations[zoneID]++
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// flush writes the snapshot when the listings changed since the last one.
+func (c *cachedPrivateZoneAPI) flush() {
+	c.mu.Lock()
+	dirty := c.dirty
+	c.dirty = false
+	c.mu.Unlock()
+	if dirty {
+		c.save()
+	}
+}
+
+// FlushCache writes the snapshot of the cache when the listings changed since the last one. It runs after every
+// Records and ApplyChanges and should run at shutdown, so background refreshes are kept too.
+func (p *Provider) FlushCache() {
+	for _, zones := range p.zoneProviders() {
+		api := zones.pzClient
+		if dryRun, ok := api.(*dryRunAPI); ok {
+			api = dryRun.privateZoneAPI
+		}
+		if cache, ok := api.(*cachedPrivateZoneAPI); ok {
+			cache.flush()
+		}
+	}
+}
+
+// save writes the snapshot atomically, failures only cost a full listing after the next restart.
+func (c *cachedPrivateZoneAPI) save() {
+	if c.file == "" {
+		return
+	}
+	c.mu.Lock()
+	data, err := json.Marshal(&cacheSnapshot{Version: cacheSnapshotVersion, Zones: c.zones, Records: c.records})
+	c.mu.Unlock()
+	if err != nil {
+		c.log.Errorf("Failed to encode cache snapshot: %v", err)
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.file), filepath.Base(c.file)+".*")
+	if err != nil {
+		c.log.Errorf("Failed to write cache snapshot: %v", err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		c.log.Errorf("Failed to write cache snapshot: %v", err)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		c.log.Errorf("Failed to write cache snapshot: %v", err)
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.file); err != nil {
+		c.log.Errorf("Failed to write cache snapshot: %v", err)
+	}
+}
+
+// load reads the snapshot, keeping only well-formed entries that are still fresh.
+func (c *cachedPrivateZoneAPI) load() error {
+	data, err := os.ReadFile(c.file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var snapshot cacheSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode: %v", err)
+	}
+	if snapshot.Version != cacheSnapshotVersion {
+		return fmt.Errorf("unsupported version %d, expected %d", snapshot.Version, cacheSnapshotVersion)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for vpcID, entry := range snapshot.Zones {
+		if entry == nil || !c.fresh(entry.FetchedAt) || !validZones(entry.Zones) {
+			continue
+		}
+		c.zones[vpcID] = entry
+	}
+	for zid, entry := range snapshot.Records {
+		if entry == nil || !c.fresh(entry.FetchedAt) || !validRecords(entry.Records) {
+			continue
+		}
+		c.records[zid] = entry
+	}
+	c.log.Infof("Loaded cache snapshot %s with zones of %d vpcs and records of %d zones", c.file, len(c.zones), len(c.records))
+	return nil
+}
+
+func validZones(zones []*privatezone.ZoneForListPrivateZonesOutput) bool {
+	for _, zone := range zones {
+		if zone == nil || zone.ZID == nil || volcengine.StringValue(zone.ZoneName) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func validRecords(records []*privatezone.RecordForListRecordsOutput) bool {
+	for _, record := range records {
+		if record == nil || volcengine.StringValue(record.RecordID) == "" || record.Host == nil || record.Type == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+func testZones() []*privatezone.ZoneForListPrivateZonesOutput {
+	return []*privatezone.ZoneForListPrivateZonesOutput{
+		{
+			ZID:      volcengine.Int32(123),
+			ZoneName: volcengine.String("example.com"),
+		},
+	}
+}
+
+func testRecords() []*privatezone.RecordForListRecordsOutput {
+	return []*privatezone.RecordForListRecordsOutput{
+		{
+			RecordID: volcengine.String("record-1"),
+			Host:     volcengine.String("www"),
+			Type:     volcengine.String("A"),
+			Value:    volcengine.String("1.2.3.4"),
+		},
+	}
+}
+
+func TestCachedPrivateZoneAPI(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(testZones(), nil).Once()
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(testRecords(), nil).Twice()
+	mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, int64(123), "record-1").Return(nil).Once()
+
+	now := time.Now()
+	c := newCachedPrivateZoneAPI(mockAPI, time.Minute, "", logrus.StandardLogger())
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		zones, err := c.ListPrivateZones(ctx, "vpc-123")
+		assert.NoError(t, err)
+		assert.Len(t, zones, 1)
+		records, err := c.GetPrivateZoneRecords(ctx, 123)
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+	}
+	// Filtered queries are served from the cached records
+	records, err := c.GetPrivateZoneRecordsByHostType(ctx, 123, "www", "A")
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	records, err = c.GetPrivateZoneRecordsByHostType(ctx, 123, "www", "TXT")
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	// A write invalidates the records of the zone
+	assert.NoError(t, c.DeletePrivateZoneRecordById(ctx, 123, "record-1"))
+	_, err = c.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+
+	// Filtered queries of zones not cached go to the API
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(456), "www", "A").Return(testRecords(), nil).Once()
+	records, err = c.GetPrivateZoneRecordsByHostType(ctx, 456, "www", "A")
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	// Expired entries are listed again
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(testZones(), nil).Once()
+	now = now.Add(2 * time.Minute)
+	_, err = c.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+	mockAPI.AssertNumberOfCalls(t, "ListPrivateZones", 2)
+}
+
+func TestCachedPrivateZoneAPISnapshot(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cache.json")
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(testZones(), nil).Once()
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(testRecords(), nil).Once()
+
+	ctx := context.Background()
+	c := newCachedPrivateZoneAPI(mockAPI, time.Hour, file, logrus.StandardLogger())
+	_, err := c.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+	_, err = c.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+	_, err = os.Stat(file)
+	assert.True(t, os.IsNotExist(err), "listings are only snapshotted by flush")
+	c.flush()
+
+	// A restarted cache serves the snapshot without calling the API
+	restartedAPI := new(MockPrivateZoneAPI)
+	restarted := newCachedPrivateZoneAPI(restartedAPI, time.Hour, file, logrus.StandardLogger())
+	zones, err := restarted.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", volcengine.StringValue(zones[0].ZoneName))
+	records, err := restarted.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", volcengine.StringValue(records[0].Value))
+	restartedAPI.AssertExpectations(t)
+
+	// Expired snapshots are ignored
+	expiredAPI := new(MockPrivateZoneAPI)
+	expiredAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(testRecords(), nil).Once()
+	expired := newCachedPrivateZoneAPI(expiredAPI, time.Hour, file, logrus.StandardLogger())
+	expired.zones, expired.records = map[string]*zonesCacheEntry{}, map[int64]*recordsCacheEntry{}
+	expired.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	assert.NoError(t, expired.load())
+	_, err = expired.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+	expiredAPI.AssertExpectations(t)
+}
+
+func TestCachedPrivateZoneAPIInvalidSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"corrupt":        `{"version":`,
+		"future version": `{"version": 2}`,
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, name+".json")
+			assert.NoError(t, os.WriteFile(file, []byte(content), 0600))
+			c := newCachedPrivateZoneAPI(new(MockPrivateZoneAPI), time.Hour, file, logrus.StandardLogger())
+			assert.Error(t, c.load())
+			assert.Empty(t, c.zones)
+			assert.Empty(t, c.records)
+		})
+	}
+
+	// Malformed records are dropped
+	file := filepath.Join(dir, "malformed.json")
+	content := `{"version": 1, "records": {"123": {"records": [{"Host": "www"}], "fetchedAt": "` + time.Now().Format(time.RFC3339) + `"}}}`
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	c := newCachedPrivateZoneAPI(new(MockPrivateZoneAPI), time.Hour, file, logrus.StandardLogger())
+	assert.Empty(t, c.records)
+
+	// A missing snapshot is not an error
+	c = newCachedPrivateZoneAPI(new(MockPrivateZoneAPI), time.Hour, filepath.Join(dir, "missing.json"), logrus.StandardLogger())
+	assert.NoError(t, c.load())
+}
+
+func TestCachedPrivateZoneAPIRefresh(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	refreshed := []*privatezone.RecordForListRecordsOutput{{
+		RecordID: volcengine.String("record-2"),
+		Host:     volcengine.String("www"),
+		Type:     volcengine.String("A"),
+		Value:    volcengine.String("5.6.7.8"),
+	}}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(testZones(), nil).Twice()
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(testRecords(), nil).Once()
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(refreshed, nil).Once()
+
+	now := time.Now()
+	c := newCachedPrivateZoneAPI(mockAPI, time.Hour, "", logrus.StandardLogger())
+	c.refreshAfter = time.Minute
+	c.now = func() time.Time { return now }
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := c.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+	records, err := c.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+	assert.Equal(t, "record-1", *records[0].RecordID)
+	c.flush()
+
+	// stale listings are served while they are refreshed, even after the request is done
+	now = now.Add(2 * time.Minute)
+	records, err = c.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+	assert.Equal(t, "record-1", *records[0].RecordID)
+	_, err = c.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+	cancel()
+	c.refreshes.Wait()
+	c.mu.Lock()
+	assert.True(t, c.dirty, "refreshed listings are snapshotted by the next flush")
+	c.mu.Unlock()
+
+	records, err = c.GetPrivateZoneRecords(context.Background(), 123)
+	assert.NoError(t, err)
+	assert.Equal(t, "record-2", *records[0].RecordID)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCachedPrivateZoneAPIRefreshDroppedAfterWrite(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	release := make(chan struct{})
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(testRecords(), nil).Once()
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Run(func(mock.Arguments) { <-release }).Return(testRecords(), nil).Once()
+	mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, int64(123), "record-1").Return(nil).Once()
+
+	now := time.Now()
+	c := newCachedPrivateZoneAPI(mockAPI, time.Hour, "", logrus.StandardLogger())
+	c.refreshAfter = time.Minute
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := c.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	_, err = c.GetPrivateZoneRecords(ctx, 123)
+	assert.NoError(t, err)
+
+	// the write lands while the refresh is listing, its result predates the write
+	assert.NoError(t, c.DeletePrivateZoneRecordById(ctx, 123, "record-1"))
+	close(release)
+	c.refreshes.Wait()
+
+	c.mu.Lock()
+	_, cached := c.records[123]
+	c.mu.Unlock()
+	assert.False(t, cached)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCachedPrivateZoneAPIListingDroppedAfterWrite(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	listing := make(chan struct{})
+	release := make(chan struct{})
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Run(func(mock.Arguments) {
+		close(listing)
+		<-release
+	}).Return(testRecords(), nil).Once()
+	mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, int64(123), "record-1").Return(nil).Once()
+
+	c := newCachedPrivateZoneAPI(mockAPI, time.Hour, "", logrus.StandardLogger())
+	ctx := context.Background()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		records, err := c.GetPrivateZoneRecords(ctx, 123)
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+	}()
+
+	// the write lands while the listing runs, its result predates the write
+	<-listing
+	assert.NoError(t, c.DeletePrivateZoneRecordById(ctx, 123, "record-1"))
+	close(release)
+	<-done
+
+	c.mu.Lock()
+	_, cached := c.records[123]
+	c.mu.Unlock()
+	assert.False(t, cached)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCachedPrivateZoneAPIFlush(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cache.json")
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(testZones(), nil).Once()
+
+	c := newCachedPrivateZoneAPI(mockAPI, time.Hour, file, logrus.StandardLogger())
+	_, err := c.ListPrivateZones(context.Background(), "vpc-123")
+	assert.NoError(t, err)
+	c.flush()
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.NotZero(t, info.Size())
+
+	// nothing changed, the snapshot is not written again
+	assert.NoError(t, os.Remove(file))
+	c.flush()
+	_, err = os.Stat(file)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,374 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"volcengine-provider/pkg/utils"
+
+	"github.com/sirupsen/logrus"
+	"github.com/volcengine/volcengine-go-sdk/service/dns"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/session"
+)
+
+// DNSMode selects the zones the provider manages.
+type DNSMode string
+
+const (
+	// DNSModePrivate manages the PrivateZone zones bound to the VPC.
+	DNSModePrivate DNSMode = "private"
+	// DNSModePublic manages the public CloudDNS zones of the account.
+	DNSModePublic DNSMode = "public"
+	// DNSModeBoth manages the private and the public zones.
+	DNSModeBoth DNSMode = "both"
+)
+
+// ParseDNSMode parses a DNSMode, an empty string is DNSModePrivate.
+func ParseDNSMode(s string) (DNSMode, error) {
+	switch mode := DNSMode(strings.ToLower(s)); mode {
+	case "":
+		return DNSModePrivate, nil
+	case DNSModePrivate, DNSModePublic, DNSModeBoth:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid dns mode %q, expected %s, %s or %s", s, DNSModePrivate, DNSModePublic, DNSModeBoth)
+}
+
+func (m DNSMode) private() bool {
+	return m == "" || m == DNSModePrivate || m == DNSModeBoth
+}
+
+func (m DNSMode) public() bool {
+	return m == DNSModePublic || m == DNSModeBoth
+}
+
+// cloudDNSClient contains the methods of the CloudDNS API used by CloudDNSWrapper.
+type cloudDNSClient interface {
+	ListZonesWithContext(ctx context.Context, input *dns.ListZonesInput, options ...request.Option) (*dns.ListZonesOutput, error)
+	ListRecordsWithContext(ctx context.Context, input *dns.ListRecordsInput, options ...request.Option) (*dns.ListRecordsOutput, error)
+	CreateRecordWithContext(ctx context.Context, input *dns.CreateRecordInput, options ...request.Option) (*dns.CreateRecordOutput, error)
+	UpdateRecordWithContext(ctx context.Context, input *dns.UpdateRecordInput, options ...request.Option) (*dns.UpdateRecordOutput, error)
+	UpdateRecordStatusWithContext(ctx context.Context, input *dns.UpdateRecordStatusInput, options ...request.Option) (*dns.UpdateRecordStatusOutput, error)
+	DeleteRecordWithContext(ctx context.Context, input *dns.DeleteRecordInput, options ...request.Option) (*dns.DeleteRecordOutput, error)
+}
+
+// CloudDNSWrapper manages the public CloudDNS zones of the account. It presents zones and records as their
+// privatezone counterparts, so the provider applies changes to public zones exactly as to private ones.
+type CloudDNSWrapper struct {
+	client cloudDNSClient
+	log    Logger
+	// translation of TXT values when matching records to delete
+	txt txtEscaping
+
+	retry      *RetryPolicy
+	headers    http.Header
+	readLimit  RateLimit
+	writeLimit RateLimit
+}
+
+var _ privateZoneAPI = &CloudDNSWrapper{}
+
+// CloudDNSOption configures a CloudDNSWrapper.
+type CloudDNSOption func(*CloudDNSWrapper)
+
+// WithCloudDNSLogger sets the logger used by the wrapper and the underlying SDK client.
+func WithCloudDNSLogger(logger Logger) CloudDNSOption {
+	return func(w *CloudDNSWrapper) {
+		w.log = logger
+	}
+}
+
+// WithCloudDNSRetryPolicy retries failed calls with the backoff of policy, nil keeps the SDK defaults.
+func WithCloudDNSRetryPolicy(policy *RetryPolicy) CloudDNSOption {
+	return func(w *CloudDNSWrapper) {
+		w.retry = policy
+	}
+}
+
+// WithCloudDNSRateLimits throttles list calls with read and mutating calls with write.
+func WithCloudDNSRateLimits(read, write RateLimit) CloudDNSOption {
+	return func(w *CloudDNSWrapper) {
+		w.readLimit = read
+		w.writeLimit = write
+	}
+}
+
+// WithCloudDNSHeaders attaches static headers to every request, e.g. for an OpenAPI gateway.
+func WithCloudDNSHeaders(headers http.Header) CloudDNSOption {
+	return func(w *CloudDNSWrapper) {
+		w.headers = headers
+	}
+}
+
+// NewCloudDNSWrapper creates a CloudDNS wrapper calling the API through endpoint.
+func NewCloudDNSWrapper(regionID, endpoint string, credentials *credentials.Credentials, options ...CloudDNSOption) (*CloudDNSWrapper, error) {
+	w := &CloudDNSWrapper{}
+	for _, option := range options {
+		option(w)
+	}
+	c := volcengine.NewConfig().
+		WithRegion(regionID).
+		WithCredentials(credentials).
+		WithEndpoint(endpoint).
+		WithLogger(NewLoggerAdapter(w.logger().WithField("client", "clouddns")))
+	if w.retry != nil {
+		request.WithRetryer(c, w.retry.retryer())
+	}
+	s, err := session.NewSession(c)
+	if err != nil {
+		w.logger().Errorf("Failed to create volcengine session: %v", err)
+		return nil, err
+	}
+	installStaticHeaders(&s.Handlers, w.headers)
+	s.Handlers.Build.PushBackNamed(retryCodesHandler())
+	installAPIMetrics(&s.Handlers)
+	installAPITracing(&s.Handlers)
+	// installed without limits too, so SetRateLimits can throttle the calls without a restart
+	w.client = newThrottledCloudDNSClient(dns.New(s), w.readLimit, w.writeLimit)
+	return w, nil
+}
+
+// SetRateLimits changes the limits of the read and write API calls.
+func (w *CloudDNSWrapper) SetRateLimits(read, write RateLimit) {
+	if throttled, ok := w.client.(*throttledCloudDNSClient); ok {
+		throttled.setRateLimits(read, write)
+	}
+}
+
+// logger returns the wrapper logger, falling back to the logrus standard logger.
+func (w *CloudDNSWrapper) logger() Logger {
+	if w.log == nil {
+		return logrus.StandardLogger()
+	}
+	return w.log
+}
+
+// requestLogger returns the logger with the correlation ID of the webhook request in ctx.
+func (w *CloudDNSWrapper) requestLogger(ctx context.Context) Logger {
+	return contextLogger(ctx, w.logger())
+}
+
+// ListPrivateZones returns the public zones of the account, public zones are not bound to a VPC so vpcID is ignored.
+func (w *CloudDNSWrapper) ListPrivateZones(ctx context.Context, _ string) ([]*privatezone.ZoneForListPrivateZonesOutput, error) {
+	zones, err := utils.QueryAll(ctx, defaultPageSize, func(ctx context.Context, pageNum, pageSize int) ([]*dns.ZoneForListZonesOutput, int, error) {
+		req := &dns.ListZonesInput{
+			PageNumber: volcengine.Int32(int32(pageNum)),
+			PageSize:   volcengine.Int32(int32(pageSize)),
+		}
+		resp, err := w.client.ListZonesWithContext(ctx, req)
+		w.requestLogger(ctx).Tracef("List clouddns zones: req: %s, resp: %s", req, resp)
+		if err != nil || resp.Metadata.Error != nil {
+			return nil, 0, fmt.Errorf("failed to list clouddns zones, err: %v, resp: %v", err, resp)
+		}
+		return resp.Zones, int(volcengine.Int32Value(resp.Total)), nil
+	})
+	if err != nil {
+		w.requestLogger(ctx).Errorf("Failed to list clouddns zones: %v", err)
+		return nil, err
+	}
+	res := make([]*privatezone.ZoneForListPrivateZonesOutput, 0, len(zones))
+	for _, zone := range zones {
+		res = append(res, &privatezone.ZoneForListPrivateZonesOutput{
+			ZID:         zone.ZID,
+			ZoneName:    zone.ZoneName,
+			RecordCount: zone.RecordCount,
+			Remark:      zone.Remark,
+			CreatedAt:   zone.CreatedAt,
+			UpdatedAt:   zone.UpdatedAt,
+		})
+	}
+	return res, nil
+}
+
+// GetPrivateZoneRecords returns the records of the public zone.
+func (w *CloudDNSWrapper) GetPrivateZoneRecords(ctx context.Context, zid int64) ([]*privatezone.RecordForListRecordsOutput, error) {
+	return w.listRecords(ctx, zid, "", "")
+}
+
+// GetPrivateZoneRecordsByHostType returns the records of the public zone with the host and type.
+func (w *CloudDNSWrapper) GetPrivateZoneRecordsByHostType(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	records, err := w.listRecords(ctx, zid, host, recordType)
+	if err != nil {
+		return nil, err
+	}
+	// keep exact matches only, in case the API falls back to a keyword search of the host
+	res := make([]*privatezone.RecordForListRecordsOutput, 0, len(records))
+	for _, record := range records {
+		if volcengine.StringValue(record.Host) == host && volcengine.StringValue(record.Type) == recordType {
+			res = append(res, record)
+		}
+	}
+	return res, nil
+}
+
+// listRecords lists the records of the zone, host and recordType filter the records when not empty.
+func (w *CloudDNSWrapper) listRecords(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	records, err := utils.QueryAll(ctx, defaultPageSize, func(ctx context.Context, pageNum, pageSize int) ([]*dns.RecordForListRecordsOutput, int, error) {
+		req := &dns.ListRecordsInput{
+			ZID:        &zid,
+			PageNumber: volcengine.Int32(int32(pageNum)),
+			PageSize:   volcengine.Int32(int32(pageSize)),
+		}
+		if host != "" {
+			req.Host = volcengine.String(host)
+			req.SearchMode = volcengine.String(exactSearchMode)
+		}
+		if recordType != "" {
+			req.Type = volcengine.String(recordType)
+		}
+		resp, err := w.client.ListRecordsWithContext(ctx, req)
+		w.requestLogger(ctx).Tracef("List clouddns records req: %s, resp: %+v", req, resp)
+		if err != nil || resp.Metadata.Error != nil {
+			return nil, 0, fmt.Errorf("failed to list clouddns records, err: %v, resp: %v", err, resp)
+		}
+		return resp.Records, int(volcengine.Int32Value(resp.TotalCount)), nil
+	})
+	if err != nil {
+		w.requestLogger(ctx).Errorf("Failed to list clouddns records: %v", err)
+		return nil, err
+	}
+	res := make([]*privatezone.RecordForListRecordsOutput, 0, len(records))
+	for _, record := range records {
+		res = append(res, &privatezone.RecordForListRecordsOutput{
+			ZID:       volcengine.Int32(int32(zid)),
+			RecordID:  record.RecordID,
+			Host:      record.Host,
+			Type:      record.Type,
+			Value:     record.Value,
+			TTL:       record.TTL,
+			Line:      record.Line,
+			Weight:    record.Weight,
+			Enable:    record.Enable,
+			Remark:    record.Remark,
+			CreatedAt: record.CreatedAt,
+			UpdatedAt: record.UpdatedAt,
+		})
+	}
+	return res, nil
+}
+
+// CreatePrivateZoneRecord creates a record in the public zone, a zero TTL uses the zone default.
+func (w *CloudDNSWrapper) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, host, recordType, target string, TTL, weight int32, line, remark string) error {
+	if remark == "" {
+		remark = defaultRecordRemark
+	}
+	req := &dns.CreateRecordInput{
+		ZID:    &zoneID,
+		Host:   &host,
+		Type:   &recordType,
+		Value:  &target,
+		Remark: &remark,
+	}
+	if TTL > 0 {
+		req.TTL = &TTL
+	}
+	if weight > 0 {
+		req.Weight = &weight
+	}
+	if line != "" {
+		req.Line = &line
+	}
+	resp, err := w.client.CreateRecordWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Create clouddns record request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return fmt.Errorf("failed to create clouddns record, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully created clouddns record %s %s %s in zone %d", host, recordType, target, zoneID)
+	return nil
+}
+
+// BatchCreatePrivateZoneRecord creates the records one by one, CloudDNS has no batch create.
+func (w *CloudDNSWrapper) BatchCreatePrivateZoneRecord(ctx context.Context, zoneID int64, records []*privatezone.RecordForBatchCreateRecordInput) error {
+	for _, record := range records {
+		err := w.CreatePrivateZoneRecord(ctx, zoneID, volcengine.StringValue(record.Host), volcengine.StringValue(record.Type),
+			volcengine.StringValue(record.Value), volcengine.Int32Value(record.TTL), volcengine.Int32Value(record.Weight), volcengine.StringValue(record.Line), volcengine.StringValue(record.Remark))
+		if err != nil {
+			w.requestLogger(ctx).Errorf("Failed to batch create clouddns record: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdatePrivateZoneRecord rewrites a record of the public zone, including its remark.
+func (w *CloudDNSWrapper) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL, weight int32, line, remark string) error {
+	req := &dns.UpdateRecordInput{
+		RecordID: &recordID,
+		Host:     &host,
+		Type:     &recordType,
+		Value:    &target,
+		Remark:   &remark,
+	}
+	if TTL > 0 {
+		req.TTL = &TTL
+	}
+	if weight > 0 {
+		req.Weight = &weight
+	}
+	if line != "" {
+		req.Line = &line
+	}
+	resp, err := w.client.UpdateRecordWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Update clouddns record request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return fmt.Errorf("failed to update clouddns record, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully updated clouddns record %s in zone %d", recordID, zoneID)
+	return nil
+}
+
+// BatchDeletePrivateZoneRecords deletes the records one by one, CloudDNS has no batch delete.
+func (w *CloudDNSWrapper) BatchDeletePrivateZoneRecords(ctx context.Context, zoneID int64, recordIDs []string) error {
+	for _, recordID := range recordIDs {
+		if err := w.DeletePrivateZoneRecordById(ctx, zoneID, recordID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeletePrivateZoneRecordById deletes a record of the public zone, record IDs are unique across zones.
+func (w *CloudDNSWrapper) DeletePrivateZoneRecordById(ctx context.Context, zoneID int64, recordID string) error {
+	req := &dns.DeleteRecordInput{RecordID: &recordID}
+	resp, err := w.client.DeleteRecordWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Delete clouddns record request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return fmt.Errorf("failed to delete clouddns record, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully deleted clouddns record %s in zone %d", recordID, zoneID)
+	return nil
+}
+
+// DisablePrivateZoneRecord pauses a record of the public zone. CloudDNS cannot change the remark of a record
+// without rewriting it, so the remark is not tagged and soft delete is not used for public zones.
+func (w *CloudDNSWrapper) DisablePrivateZoneRecord(ctx context.Context, zoneID int64, recordID, _ string) error {
+	req := &dns.UpdateRecordStatusInput{RecordID: &recordID, Enable: volcengine.Bool(false)}
+	resp, err := w.client.UpdateRecordStatusWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Disable clouddns record request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return fmt.Errorf("failed to disable clouddns record, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully disabled clouddns record %s in zone %d", recordID, zoneID)
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/dns"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/response"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeCloudDNS is an in-memory CloudDNS account implementing cloudDNSClient.
+type fakeCloudDNS struct {
+	zones   []*dns.ZoneForListZonesOutput
+	records map[int64][]*dns.RecordForListRecordsOutput
+	nextID  int
+	deleted []string
+}
+
+func newFakeCloudDNS(zones ...string) *fakeCloudDNS {
+	f := &fakeCloudDNS{records: map[int64][]*dns.RecordForListRecordsOutput{}}
+	for i, zone := range zones {
+		f.zones = append(f.zones, &dns.ZoneForListZonesOutput{ZID: volcengine.Int32(int32(i + 1)), ZoneName: volcengine.String(zone)})
+	}
+	return f
+}
+
+func (f *fakeCloudDNS) find(recordID string) *dns.RecordForListRecordsOutput {
+	for _, records := range f.records {
+		for _, record := range records {
+			if volcengine.StringValue(record.RecordID) == recordID {
+				return record
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeCloudDNS) ListZonesWithContext(_ context.Context, _ *dns.ListZonesInput, _ ...request.Option) (*dns.ListZonesOutput, error) {
+	return &dns.ListZonesOutput{Metadata: &response.ResponseMetadata{}, Zones: f.zones, Total: volcengine.Int32(int32(len(f.zones)))}, nil
+}
+
+func (f *fakeCloudDNS) ListRecordsWithContext(_ context.Context, input *dns.ListRecordsInput, _ ...request.Option) (*dns.ListRecordsOutput, error) {
+	var records []*dns.RecordForListRecordsOutput
+	for _, record := range f.records[volcengine.Int64Value(input.ZID)] {
+		if input.Host != nil && volcengine.StringValue(record.Host) != volcengine.StringValue(input.Host) {
+			continue
+		}
+		if input.Type != nil && volcengine.StringValue(record.Type) != volcengine.StringValue(input.Type) {
+			continue
+		}
+		records = append(records, record)
+	}
+	return &dns.ListRecordsOutput{Metadata: &response.ResponseMetadata{}, Records: records, TotalCount: volcengine.Int32(int32(len(records)))}, nil
+}
+
+func (f *fakeCloudDNS) CreateRecordWithContext(_ context.Context, input *dns.CreateRecordInput, _ ...request.Option) (*dns.CreateRecordOutput, error) {
+	f.nextID++
+	ttl := volcengine.Int32Value(input.TTL)
+	if ttl == 0 {
+		ttl = 600
+	}
+	zid := volcengine.Int64Value(input.ZID)
+	f.records[zid] = append(f.records[zid], &dns.RecordForListRecordsOutput{
+		RecordID: volcengine.String(fmt.Sprint(f.nextID)),
+		Host:     input.Host,
+		Type:     input.Type,
+		Value:    input.Value,
+		TTL:      volcengine.Int32(ttl),
+		Remark:   input.Remark,
+		Enable:   volcengine.Bool(true),
+	})
+	return &dns.CreateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+}
+
+func (f *fakeCloudDNS) UpdateRecordWithContext(_ context.Context, input *dns.UpdateRecordInput, _ ...request.Option) (*dns.UpdateRecordOutput, error) {
+	record := f.find(volcengine.StringValue(input.RecordID))
+	if record == nil {
+		return nil, fmt.Errorf("record %s not found", volcengine.StringValue(input.RecordID))
+	}
+	record.Value, record.Remark = input.Value, input.Remark
+	if input.TTL != nil {
+		record.TTL = input.TTL
+	}
+	return &dns.UpdateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+}
+
+func (f *fakeCloudDNS) UpdateRecordStatusWithContext(_ context.Context, input *dns.UpdateRecordStatusInput, _ ...request.Option) (*dns.UpdateRecordStatusOutput, error) {
+	if record := f.find(volcengine.StringValue(input.RecordID)); record != nil {
+		record.Enable = input.Enable
+	}
+	return &dns.UpdateRecordStatusOutput{Metadata: &response.ResponseMetadata{}}, nil
+}
+
+func (f *fakeCloudDNS) DeleteRecordWithContext(_ context.Context, input *dns.DeleteRecordInput, _ ...request.Option) (*dns.DeleteRecordOutput, error) {
+	recordID := volcengine.StringValue(input.RecordID)
+	for zid, records := range f.records {
+		for i, record := range records {
+			if volcengine.StringValue(record.RecordID) == recordID {
+				f.records[zid] = append(records[:i], records[i+1:]...)
+			}
+		}
+	}
+	f.deleted = append(f.deleted, recordID)
+	return &dns.DeleteRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+}
+
+func TestParseDNSMode(t *testing.T) {
+	for s, want := range map[string]DNSMode{"": DNSModePrivate, "private": DNSModePrivate, "Public": DNSModePublic, "both": DNSModeBoth} {
+		mode, err := ParseDNSMode(s)
+		assert.NoError(t, err)
+		assert.Equal(t, want, mode)
+	}
+	_, err := ParseDNSMode("global")
+	assert.Error(t, err)
+	assert.True(t, DNSModeBoth.private() && DNSModeBoth.public())
+	assert.False(t, DNSModePublic.private())
+	assert.False(t, DNSModePrivate.public())
+}
+
+func TestCloudDNSWrapper(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeCloudDNS("example.com")
+	w := &CloudDNSWrapper{client: fake}
+
+	zones, err := w.ListPrivateZones(ctx, "vpc-ignored")
+	assert.NoError(t, err)
+	assert.Len(t, zones, 1)
+	assert.Equal(t, int32(1), volcengine.Int32Value(zones[0].ZID))
+	assert.Equal(t, "example.com", volcengine.StringValue(zones[0].ZoneName))
+
+	assert.NoError(t, w.CreatePrivateZoneRecord(ctx, 1, "www", "A", "1.2.3.4", 0, 0, "", ""))
+	assert.NoError(t, w.CreatePrivateZoneRecord(ctx, 1, "www", "A", "1.2.3.5", 60, 0, "", ""))
+	records, err := w.GetPrivateZoneRecordsByHostType(ctx, 1, "www", "A")
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, int32(600), volcengine.Int32Value(records[0].TTL))
+	assert.Equal(t, int32(1), volcengine.Int32Value(records[0].ZID))
+	assert.Equal(t, defaultRecordRemark, volcengine.StringValue(records[0].Remark))
+
+	assert.NoError(t, w.UpdatePrivateZoneRecord(ctx, 1, "1", "www", "A", "1.2.3.6", 0, 0, "", "updated"))
+	assert.Equal(t, "1.2.3.6", volcengine.StringValue(fake.find("1").Value))
+	assert.Equal(t, int32(600), volcengine.Int32Value(fake.find("1").TTL))
+
+	assert.NoError(t, w.BatchDeletePrivateZoneRecords(ctx, 1, []string{"2"}))
+	assert.Equal(t, []string{"2"}, fake.deleted)
+
+	assert.NoError(t, w.DisablePrivateZoneRecord(ctx, 1, "1", "deleted"))
+	assert.False(t, volcengine.BoolValue(fake.find("1").Enable))
+	assert.Equal(t, "updated", volcengine.StringValue(fake.find("1").Remark))
+}
+
+func TestProviderBothDNSModes(t *testing.T) {
+	ctx := context.Background()
+	private := new(MockPrivateZoneAPI)
+	private.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	private.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.1"), TTL: volcengine.Int32(60), RecordID: volcengine.String("record-1")},
+	}, nil)
+
+	fake := newFakeCloudDNS("example.org")
+	provider := &Provider{
+		pzClient:    private,
+		privateZone: true,
+		vpcID:       "vpc-123",
+		publicZones: &Provider{pzClient: &CloudDNSWrapper{client: fake}, public: true},
+	}
+
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", "A", "1.2.3.4"),
+		endpoint.NewEndpoint("example.org", "CAA", `0 issue "letsencrypt.org"`),
+	}}
+	assert.NoError(t, provider.ApplyChanges(ctx, changes))
+	assert.Len(t, fake.records[1], 2)
+	// the public endpoints are not written to the private zones
+	private.AssertNotCalled(t, "BatchCreatePrivateZoneRecord", mock.Anything, mock.Anything, mock.Anything)
+
+	endpoints, err := provider.Records(ctx)
+	assert.NoError(t, err)
+	names := map[string]bool{}
+	for _, ep := range endpoints {
+		names[ep.DNSName+" "+ep.RecordType] = true
+	}
+	assert.True(t, names["www.example.org A"])
+	assert.True(t, names["example.org CAA"])
+	assert.True(t, names["www.example.com A"])
+}
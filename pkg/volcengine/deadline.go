@@ -0,0 +1,77 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// applyDeadlineKey carries the deadline of an ApplyChanges invocation in its context.
+type applyDeadlineKey struct{}
+
+// withApplyDeadline bounds the ApplyChanges invocation by timeout, API calls in flight at the deadline are cancelled
+// together with their retries. A zero timeout returns ctx unchanged.
+func (p *Provider) withApplyDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.applyTimeout <= 0 {
+		return ctx, func() {}
+	}
+	deadline := time.Now().Add(p.applyTimeout)
+	ctx = context.WithValue(ctx, applyDeadlineKey{}, deadline)
+	return context.WithDeadline(ctx, deadline)
+}
+
+// applyDeadlineExceeded returns an error when the ApplyChanges deadline passed, so no new API call is issued.
+func applyDeadlineExceeded(ctx context.Context) error {
+	deadline, ok := ctx.Value(applyDeadlineKey{}).(time.Time)
+	if !ok || time.Now().Before(deadline) {
+		return nil
+	}
+	return fmt.Errorf("deadline of %s exceeded, the remaining changes are left to the next sync", deadline.Format(time.RFC3339))
+}
+
+// pendingOnDeadline turns the error of a change phase into an ErrCodeDeadlineExceeded ChangeError once the deadline
+// passed, listing the endpoints of the phase that were not applied and those of the phases not started.
+func pendingOnDeadline(ctx context.Context, err error, pending ...[]*endpoint.Endpoint) error {
+	deadlineErr := applyDeadlineExceeded(ctx)
+	if err == nil || deadlineErr == nil {
+		return err
+	}
+	var changeErr *ChangeError
+	var endpoints []*endpoint.Endpoint
+	if errors.As(err, &changeErr) {
+		endpoints = append(endpoints, changeErr.Endpoints...)
+	}
+	for _, eps := range pending {
+		endpoints = append(endpoints, eps...)
+	}
+	return newChangeError(ErrCodeDeadlineExceeded, deadlineErr, endpoints...)
+}
+
+// unhandled returns the endpoints that are not in handled, in order.
+func unhandled(endpoints []*endpoint.Endpoint, handled map[*endpoint.Endpoint]bool) []*endpoint.Endpoint {
+	res := make([]*endpoint.Endpoint, 0, len(endpoints)-len(handled))
+	for _, ep := range endpoints {
+		if !handled[ep] {
+			res = append(res, ep)
+		}
+	}
+	return res
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestApplyChangesDeadline(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+		{ZID: volcengine.Int32(456), ZoneName: volcengine.String("other.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("1"), Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1")},
+	}, nil).Once()
+	// the deletes of the first zone use up the deadline
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"1"}).
+		Run(func(mock.Arguments) { time.Sleep(60 * time.Millisecond) }).Return(nil).Once()
+
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123", applyTimeout: 50 * time.Millisecond}
+	deleteA := endpoint.NewEndpoint("a.example.com", "A", "1.1.1.1")
+	deleteB := endpoint.NewEndpoint("b.other.com", "A", "2.2.2.2")
+	create := endpoint.NewEndpoint("c.example.com", "A", "3.3.3.3")
+	update := endpoint.NewEndpoint("d.example.com", "A", "4.4.4.4")
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete:    []*endpoint.Endpoint{deleteA, deleteB},
+		Create:    []*endpoint.Endpoint{create},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("d.example.com", "A", "4.4.4.3")},
+		UpdateNew: []*endpoint.Endpoint{update},
+	})
+
+	var changeErr *ChangeError
+	require.True(t, errors.As(err, &changeErr), "%v", err)
+	assert.Equal(t, ErrCodeDeadlineExceeded, changeErr.Code)
+	assert.Equal(t, []*endpoint.Endpoint{deleteB, create, update}, changeErr.Endpoints)
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "BatchCreatePrivateZoneRecord", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApplyChangesWithinDeadline(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.Anything).Return(nil).Once()
+
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123", applyTimeout: time.Minute}
+	err := p.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("c.example.com", "A", "3.3.3.3")}})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
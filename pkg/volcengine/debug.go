@@ -0,0 +1,189 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// DebugState is a snapshot of the internal state of the provider, served on /debug/state.
+type DebugState struct {
+	VPC        string                       `json:"vpc"`
+	Cache      *CacheState                  `json:"cache,omitempty"`
+	RateLimits map[string]*RateLimiterState `json:"rateLimits,omitempty"`
+	// LastChanges is the last plan passed to ApplyChanges and its result
+	LastChanges *AppliedChanges `json:"lastChanges,omitempty"`
+}
+
+// CacheState summarizes the zone and record listings held by the cache.
+type CacheState struct {
+	TTL          string                       `json:"ttl"`
+	RefreshAfter string                       `json:"refreshAfter,omitempty"`
+	Zones        map[string]*CachedZones      `json:"zones"`
+	Records      map[int64]*CachedRecordsInfo `json:"records"`
+	// Refreshing are the listings refreshed in the background right now
+	Refreshing []string `json:"refreshing,omitempty"`
+}
+
+// CachedZones are the cached zones of a VPC.
+type CachedZones struct {
+	Zones     []CachedZone `json:"zones"`
+	FetchedAt time.Time    `json:"fetchedAt"`
+	Fresh     bool         `json:"fresh"`
+}
+
+// CachedZone is a zone of a cached zone listing.
+type CachedZone struct {
+	ZID         int64  `json:"zid"`
+	Name        string `json:"name"`
+	RecordCount int32  `json:"recordCount"`
+}
+
+// CachedRecordsInfo summarizes the cached records of a zone.
+type CachedRecordsInfo struct {
+	Records   int       `json:"records"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Fresh     bool      `json:"fresh"`
+}
+
+// RateLimiterState is the configuration and the tokens available of a rate limiter.
+type RateLimiterState struct {
+	QPS    float64 `json:"qps"`
+	Burst  int     `json:"burst"`
+	Tokens float64 `json:"tokens"`
+}
+
+// AppliedChanges is a plan passed to ApplyChanges and its result.
+type AppliedChanges struct {
+	At        time.Time            `json:"at"`
+	Create    []*endpoint.Endpoint `json:"create"`
+	UpdateOld []*endpoint.Endpoint `json:"updateOld"`
+	UpdateNew []*endpoint.Endpoint `json:"updateNew"`
+	Delete    []*endpoint.Endpoint `json:"delete"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// recordChanges keeps the changes of an ApplyChanges for DebugState.
+func (p *Provider) recordChanges(changes *plan.Changes, err error) {
+	applied := &AppliedChanges{
+		At:        time.Now(),
+		Create:    changes.Create,
+		UpdateOld: changes.UpdateOld,
+		UpdateNew: changes.UpdateNew,
+		Delete:    changes.Delete,
+	}
+	if err != nil {
+		applied.Error = err.Error()
+	}
+	p.lastChanges.Store(applied)
+}
+
+// DebugState returns a snapshot of the zone and record cache, the rate limiters and the last applied plan.
+func (p *Provider) DebugState() *DebugState {
+	state := &DebugState{
+		VPC:         p.vpcID,
+		LastChanges: p.lastChanges.Load(),
+	}
+	api := p.pzClient
+	if dryRun, ok := api.(*dryRunAPI); ok {
+		api = dryRun.privateZoneAPI
+	}
+	if cache, ok := api.(*cachedPrivateZoneAPI); ok {
+		state.Cache = cache.state()
+		api = cache.privateZoneAPI
+	}
+	switch api := api.(type) {
+	case *PrivateZoneWrapper:
+		state.RateLimits = rateLimitStates(api, "", nil)
+	case *multiRegionAPI:
+		// limiters of each region, keyed by region/class
+		for _, r := range api.regions {
+			if wrapper, ok := r.api.(*PrivateZoneWrapper); ok {
+				state.RateLimits = rateLimitStates(wrapper, r.region+"/", state.RateLimits)
+			}
+		}
+	}
+	return state
+}
+
+// rateLimitStates adds the read and write limiters of the wrapper to states, their keys prefixed with prefix.
+func rateLimitStates(wrapper *PrivateZoneWrapper, prefix string, states map[string]*RateLimiterState) map[string]*RateLimiterState {
+	throttled := wrapper.throttled()
+	if throttled == nil {
+		return states
+	}
+	if states == nil {
+		states = make(map[string]*RateLimiterState)
+	}
+	if s := limiterState(throttled.read); s != nil {
+		states[prefix+"read"] = s
+	}
+	if s := limiterState(throttled.write); s != nil {
+		states[prefix+"write"] = s
+	}
+	return states
+}
+
+func limiterState(limiter *rate.Limiter) *RateLimiterState {
+	if limiter == nil || limiter.Limit() == rate.Inf {
+		return nil
+	}
+	return &RateLimiterState{
+		QPS:    float64(limiter.Limit()),
+		Burst:  limiter.Burst(),
+		Tokens: limiter.Tokens(),
+	}
+}
+
+// state summarizes the cached listings.
+func (c *cachedPrivateZoneAPI) state() *CacheState {
+	ttl, refreshAfter := c.settings()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state := &CacheState{
+		TTL:     ttl.String(),
+		Zones:   make(map[string]*CachedZones, len(c.zones)),
+		Records: make(map[int64]*CachedRecordsInfo, len(c.records)),
+	}
+	if refreshAfter > 0 {
+		state.RefreshAfter = refreshAfter.String()
+	}
+	for vpc, entry := range c.zones {
+		zones := make([]CachedZone, 0, len(entry.Zones))
+		for _, zone := range entry.Zones {
+			zones = append(zones, CachedZone{
+				ZID:         int64(volcengine.Int32Value(zone.ZID)),
+				Name:        volcengine.StringValue(zone.ZoneName),
+				RecordCount: volcengine.Int32Value(zone.RecordCount),
+			})
+		}
+		state.Zones[vpc] = &CachedZones{Zones: zones, FetchedAt: entry.FetchedAt, Fresh: c.fresh(entry.FetchedAt)}
+	}
+	for zid, entry := range c.records {
+		state.Records[zid] = &CachedRecordsInfo{Records: len(entry.Records), FetchedAt: entry.FetchedAt, Fresh: c.fresh(entry.FetchedAt)}
+	}
+	for key := range c.refreshing {
+		state.Refreshing = append(state.Refreshing, key)
+	}
+	sort.Strings(state.Refreshing)
+	return state
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestDebugState(t *testing.T) {
+	wrapper := &PrivateZoneWrapper{
+		client: &throttledClient{read: RateLimit{QPS: 10, Burst: 20}.limiter()},
+	}
+	now := time.Now()
+	cache := newCachedPrivateZoneAPI(wrapper, time.Minute, "", logrus.StandardLogger())
+	cache.now = func() time.Time { return now }
+	cache.zones["vpc-123"] = &zonesCacheEntry{Zones: testZones(), FetchedAt: now.Add(-10 * time.Second)}
+	cache.records[123] = &recordsCacheEntry{Records: testRecords(), FetchedAt: now.Add(-2 * time.Minute)}
+	p := &Provider{vpcID: "vpc-123", pzClient: cache}
+
+	state := p.DebugState()
+	assert.Equal(t, "vpc-123", state.VPC)
+	assert.Nil(t, state.LastChanges)
+	require.NotNil(t, state.Cache)
+	assert.Equal(t, "1m0s", state.Cache.TTL)
+	require.Contains(t, state.Cache.Zones, "vpc-123")
+	assert.Equal(t, []CachedZone{{ZID: 123, Name: "example.com"}}, state.Cache.Zones["vpc-123"].Zones)
+	assert.True(t, state.Cache.Zones["vpc-123"].Fresh)
+	require.Contains(t, state.Cache.Records, int64(123))
+	assert.Equal(t, 1, state.Cache.Records[123].Records)
+	assert.False(t, state.Cache.Records[123].Fresh)
+	require.Contains(t, state.RateLimits, "read")
+	assert.NotContains(t, state.RateLimits, "write")
+	assert.Equal(t, 10.0, state.RateLimits["read"].QPS)
+	assert.Equal(t, 20, state.RateLimits["read"].Burst)
+
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")}}
+	p.recordChanges(changes, errors.New("boom"))
+	state = p.DebugState()
+	require.NotNil(t, state.LastChanges)
+	assert.Equal(t, changes.Create, state.LastChanges.Create)
+	assert.Equal(t, "boom", state.LastChanges.Error)
+}
+
+func TestDebugStateWithoutCache(t *testing.T) {
+	p := &Provider{vpcID: "vpc-123", pzClient: new(MockPrivateZoneAPI)}
+	state := p.DebugState()
+	assert.Nil(t, state.Cache)
+	assert.Nil(t, state.RateLimits)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// updateKey identifies the endpoint of an update, UpdateOld and UpdateNew are paired by it.
+func updateKey(ep *endpoint.Endpoint) string {
+	return strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")) + "/" + ep.RecordType + "/" + ep.SetIdentifier
+}
+
+// skipUnchangedUpdates drops the new endpoints whose record set equals the old endpoint, e.g. when only the order of
+// the targets or labels not written to the zone changed, so they cost neither a record listing nor a write.
+// It compares UpdateNew with the UpdateOld endpoint external-dns planned it against, not with the records of the zone.
+// New endpoints without an old endpoint are kept.
+func (p *Provider) skipUnchangedUpdates(olds, news []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if len(olds) == 0 {
+		return news
+	}
+	previous := make(map[string]*endpoint.Endpoint, len(olds))
+	for _, ep := range olds {
+		if _, ok := previous[updateKey(ep)]; !ok {
+			previous[updateKey(ep)] = ep
+		}
+	}
+	changed := make([]*endpoint.Endpoint, 0, len(news))
+	for _, ep := range news {
+		if old, ok := previous[updateKey(ep)]; ok && p.sameRecordSet(old, ep) {
+			p.logger().Debugf("Skipping update of %s %s, its records did not change", ep.DNSName, ep.RecordType)
+			continue
+		}
+		changed = append(changed, ep)
+	}
+	return changed
+}
+
+// sameRecordSet reports whether both endpoints result in the same records: the same targets in any order, TTL,
+// weight, line and remark, so a change of the owner or resource label still reaches the remark of the records.
+func (p *Provider) sameRecordSet(a, b *endpoint.Endpoint) bool {
+	if a.RecordType != b.RecordType || len(a.Targets) != len(b.Targets) {
+		return false
+	}
+	if p.encodeRemark(a.Labels, a.SetIdentifier) != p.encodeRemark(b.Labels, b.SetIdentifier) {
+		return false
+	}
+	if p.recordTTL(a) != p.recordTTL(b) {
+		return false
+	}
+	weightA, errA := endpointWeight(a)
+	weightB, errB := endpointWeight(b)
+	if errA != nil || errB != nil || weightA != weightB {
+		return false
+	}
+	lineA, errA := endpointLine(a)
+	lineB, errB := endpointLine(b)
+	if errA != nil || errB != nil || lineA != lineB {
+		return false
+	}
+	matched := make([]bool, len(b.Targets))
+	for _, target := range a.Targets {
+		found := false
+		for i, other := range b.Targets {
+			if !matched[i] && p.sameDesiredTarget(a.RecordType, target, other) {
+				matched[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sameDesiredTarget compares two targets as they are written to the zone.
+func (p *Provider) sameDesiredTarget(recordType, a, b string) bool {
+	if recordType == endpoint.RecordTypeTXT {
+		return p.txt.escape(a) == p.txt.escape(b)
+	}
+	return sameTarget(recordType, a, b)
+}
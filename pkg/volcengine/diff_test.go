@@ -0,0 +1,86 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestSameRecordSet(t *testing.T) {
+	p := &Provider{}
+	base := endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2")
+	tests := []struct {
+		name  string
+		other *endpoint.Endpoint
+		same  bool
+	}{
+		{name: "reordered targets", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.2", "10.0.0.1"), same: true},
+		{name: "labels not in the remark", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2").WithLabel("aws/evaluate-target-health", "true"), same: true},
+		{name: "other owner", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2").WithLabel(endpoint.OwnerLabelKey, "b")},
+		{name: "other resource", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2").WithLabel(endpoint.ResourceLabelKey, "service/default/web")},
+		{name: "other target", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.3")},
+		{name: "fewer targets", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1")},
+		{name: "duplicated target", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.1")},
+		{name: "other ttl", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 60, "10.0.0.1", "10.0.0.2")},
+		{name: "weight", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2").WithProviderSpecific(ProviderSpecificWeight, "5")},
+		{name: "line", other: endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2").WithProviderSpecific(ProviderSpecificLine, "mobile")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, p.sameRecordSet(base, tt.other))
+		})
+	}
+
+	aaaa := endpoint.NewEndpoint("www.example.com", "AAAA", "2001:db8::1")
+	assert.True(t, p.sameRecordSet(aaaa, endpoint.NewEndpoint("www.example.com", "AAAA", "2001:0db8:0:0::1")))
+	txt := endpoint.NewEndpoint("a-www.example.com", "TXT", `"heritage=external-dns,external-dns/owner=default"`)
+	assert.True(t, p.sameRecordSet(txt, endpoint.NewEndpoint("a-www.example.com", "TXT", "heritage=external-dns,external-dns/owner=default")))
+}
+
+func TestApplyChangesSkipsUnchangedUpdates(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	// only the changed endpoint lists its records
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "api", "A").Return([]*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("2"), Host: volcengine.String("api"), Type: volcengine.String("A"), Value: volcengine.String("10.0.1.1"), TTL: volcengine.Int32(300)},
+	}, nil).Once()
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "2", "api", "A", "10.0.1.1", int32(60), int32(0), "", defaultRecordRemark).Return(nil).Once()
+
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2"),
+			endpoint.NewEndpointWithTTL("api.example.com", "A", 300, "10.0.1.1"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", "A", 60, "10.0.1.1"),
+			endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.2", "10.0.0.1"),
+		},
+	})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A")
+}
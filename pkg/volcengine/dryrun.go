@@ -0,0 +1,122 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+// dryRunAPI lists zones and records through the underlying API and only logs the writes it would perform while
+// active, when inactive the writes are passed to the underlying API.
+type dryRunAPI struct {
+	privateZoneAPI
+	log    Logger
+	active atomic.Bool
+
+	mu sync.Mutex
+	// zone names of the listed zones, to log writes by zone name
+	zones map[int64]string
+}
+
+var _ privateZoneAPI = &dryRunAPI{}
+
+func newDryRunAPI(api privateZoneAPI, log Logger) *dryRunAPI {
+	d := &dryRunAPI{privateZoneAPI: api, log: log, zones: make(map[int64]string)}
+	d.active.Store(true)
+	return d
+}
+
+func (d *dryRunAPI) ListPrivateZones(ctx context.Context, vpcID string) ([]*privatezone.ZoneForListPrivateZonesOutput, error) {
+	zones, err := d.privateZoneAPI.ListPrivateZones(ctx, vpcID)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, zone := range zones {
+		d.zones[int64(volcengine.Int32Value(zone.ZID))] = volcengine.StringValue(zone.ZoneName)
+	}
+	return zones, err
+}
+
+// zone returns the name of the zone, its ID when it was not listed.
+func (d *dryRunAPI) zone(zoneID int64) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if name, ok := d.zones[zoneID]; ok {
+		return name
+	}
+	return strconv.FormatInt(zoneID, 10)
+}
+
+func (d *dryRunAPI) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, domain, recordType, target string, TTL, weight int32, line, remark string) error {
+	if !d.active.Load() {
+		return d.privateZoneAPI.CreatePrivateZoneRecord(ctx, zoneID, domain, recordType, target, TTL, weight, line, remark)
+	}
+	contextLogger(ctx, d.log).Infof("Dry run: would create record zone: %s, host: %s, type: %s, value: %s, ttl: %d, weight: %d, line: %q, remark: %q",
+		d.zone(zoneID), domain, recordType, target, TTL, weight, line, remark)
+	return nil
+}
+
+func (d *dryRunAPI) BatchCreatePrivateZoneRecord(ctx context.Context, zoneID int64, records []*privatezone.RecordForBatchCreateRecordInput) error {
+	if !d.active.Load() {
+		return d.privateZoneAPI.BatchCreatePrivateZoneRecord(ctx, zoneID, records)
+	}
+	zone := d.zone(zoneID)
+	for _, record := range records {
+		contextLogger(ctx, d.log).Infof("Dry run: would create record zone: %s, host: %s, type: %s, value: %s, ttl: %d, weight: %d, line: %q, remark: %q",
+			zone, volcengine.StringValue(record.Host), volcengine.StringValue(record.Type), volcengine.StringValue(record.Value),
+			volcengine.Int32Value(record.TTL), volcengine.Int32Value(record.Weight), volcengine.StringValue(record.Line),
+			volcengine.StringValue(record.Remark))
+	}
+	return nil
+}
+
+func (d *dryRunAPI) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL, weight int32, line, remark string) error {
+	if !d.active.Load() {
+		return d.privateZoneAPI.UpdatePrivateZoneRecord(ctx, zoneID, recordID, host, recordType, target, TTL, weight, line, remark)
+	}
+	contextLogger(ctx, d.log).Infof("Dry run: would update record %s zone: %s, host: %s, type: %s, value: %s, ttl: %d, weight: %d, line: %q, remark: %q",
+		recordID, d.zone(zoneID), host, recordType, target, TTL, weight, line, remark)
+	return nil
+}
+
+func (d *dryRunAPI) BatchDeletePrivateZoneRecords(ctx context.Context, zoneID int64, recordIDs []string) error {
+	if !d.active.Load() {
+		return d.privateZoneAPI.BatchDeletePrivateZoneRecords(ctx, zoneID, recordIDs)
+	}
+	contextLogger(ctx, d.log).Infof("Dry run: would delete records %v zone: %s", recordIDs, d.zone(zoneID))
+	return nil
+}
+
+func (d *dryRunAPI) DeletePrivateZoneRecordById(ctx context.Context, zoneID int64, recordID string) error {
+	if !d.active.Load() {
+		return d.privateZoneAPI.DeletePrivateZoneRecordById(ctx, zoneID, recordID)
+	}
+	contextLogger(ctx, d.log).Infof("Dry run: would delete record %s zone: %s", recordID, d.zone(zoneID))
+	return nil
+}
+
+func (d *dryRunAPI) DisablePrivateZoneRecord(ctx context.Context, zoneID int64, recordID, remark string) error {
+	if !d.active.Load() {
+		return d.privateZoneAPI.DisablePrivateZoneRecord(ctx, zoneID, recordID, remark)
+	}
+	contextLogger(ctx, d.log).Infof("Dry run: would disable record %s zone: %s, remark: %q", recordID, d.zone(zoneID), remark)
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"volcengine-provider/pkg/utils"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestDryRunApplyChanges(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{ZID: volcengine.Int32(123), RecordID: volcengine.String("1"), Host: volcengine.String("old"), Type: volcengine.String("A"),
+			Value: volcengine.String("1.1.1.1"), TTL: volcengine.Int32(300)},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), mock.Anything, mock.Anything).Return(nil, nil)
+
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.InfoLevel)
+	provider := &Provider{pzClient: newDryRunAPI(mockAPI, logger), privateZone: true, vpcID: "vpc-123"}
+
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", "A", "2.2.2.2").
+			WithProviderSpecific(ProviderSpecificWeight, "20").WithProviderSpecific(ProviderSpecificLine, "telecom")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", "A", "1.1.1.1")},
+	})
+	assert.NoError(t, err)
+
+	// no mutation reaches the API
+	for _, method := range []string{"CreatePrivateZoneRecord", "BatchCreatePrivateZoneRecord", "UpdatePrivateZoneRecord",
+		"BatchDeletePrivateZoneRecords", "DeletePrivateZoneRecordById", "DisablePrivateZoneRecord"} {
+		for _, call := range mockAPI.Calls {
+			assert.NotEqual(t, method, call.Method)
+		}
+	}
+	var dryRun []string
+	for _, entry := range hook.AllEntries() {
+		dryRun = append(dryRun, entry.Message)
+	}
+	assert.Contains(t, dryRun, `Dry run: would create record zone: example.com, host: new, type: A, value: 2.2.2.2, ttl: 0, weight: 20, line: "telecom", remark: "managed by external-dns"`)
+	assert.Contains(t, dryRun, "Dry run: would delete records [1] zone: example.com")
+}
+
+func TestDryRunLogsRequestID(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+
+	logger, hook := test.NewNullLogger()
+	provider := &Provider{pzClient: newDryRunAPI(mockAPI, logger), privateZone: true, vpcID: "vpc-123", log: logger}
+	ctx := utils.WithRequestID(context.Background(), "sync-42")
+	err := provider.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", "A", "2.2.2.2")},
+	})
+	assert.NoError(t, err)
+	var dryRun int
+	for _, entry := range hook.AllEntries() {
+		assert.Equal(t, "sync-42", entry.Data[utils.RequestIDField], entry.Message)
+		if strings.HasPrefix(entry.Message, "Dry run:") {
+			dryRun++
+		}
+	}
+	assert.Equal(t, 1, dryRun)
+}
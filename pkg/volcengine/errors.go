@@ -0,0 +1,68 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Error codes of ChangeError.
+const (
+	ErrCodeListZonesFailed = "ListZonesFailed"
+	ErrCodeInvalidZone     = "InvalidZone"
+	ErrCodeCreateFailed    = "CreateFailed"
+	ErrCodeDeleteFailed    = "DeleteFailed"
+	ErrCodeUpdateFailed    = "UpdateFailed"
+	// ErrCodeMassDeletion refuses a batch deleting more records than the DeletionGuard allows.
+	ErrCodeMassDeletion = "MassDeletionRefused"
+	// ErrCodeProtected reports endpoints with protected names that were not deleted or updated.
+	ErrCodeProtected = "ProtectedRecord"
+	// ErrCodeDeadlineExceeded reports the endpoints left unapplied when an ApplyChanges ran out of time.
+	ErrCodeDeadlineExceeded = "DeadlineExceeded"
+	// ErrCodeInvalidTXT reports TXT endpoints that were not created or updated because of their values.
+	ErrCodeInvalidTXT = "InvalidTXTValue"
+	// ErrCodeUnsupportedRecordType reports endpoints of record types the managed zones do not accept, they were not applied.
+	ErrCodeUnsupportedRecordType = "UnsupportedRecordType"
+)
+
+// ChangeError is returned by ApplyChanges when a change could not be applied,
+// it carries the endpoints that failed so callers can report them.
+type ChangeError struct {
+	Code      string
+	Endpoints []*endpoint.Endpoint
+	Err       error
+}
+
+func newChangeError(code string, err error, endpoints ...*endpoint.Endpoint) *ChangeError {
+	return &ChangeError{Code: code, Endpoints: endpoints, Err: err}
+}
+
+func (e *ChangeError) Error() string {
+	names := make([]string, 0, len(e.Endpoints))
+	for _, ep := range e.Endpoints {
+		names = append(names, fmt.Sprintf("%s %s", ep.DNSName, ep.RecordType))
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("%s: %v", e.Code, e.Err)
+	}
+	return fmt.Sprintf("%s %v: %v", e.Code, names, e.Err)
+}
+
+func (e *ChangeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,315 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/yaml"
+)
+
+// ExportFormat is the file format of exported records.
+type ExportFormat string
+
+const (
+	ExportFormatJSON     ExportFormat = "json"
+	ExportFormatYAML     ExportFormat = "yaml"
+	ExportFormatZoneFile ExportFormat = "zonefile"
+)
+
+// ParseExportFormat parses json, yaml or zonefile.
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch f := ExportFormat(strings.ToLower(value)); f {
+	case ExportFormatJSON, ExportFormatYAML, ExportFormatZoneFile:
+		return f, nil
+	case "bind":
+		return ExportFormatZoneFile, nil
+	}
+	return "", fmt.Errorf("invalid format %q, must be %s, %s or %s", value, ExportFormatJSON, ExportFormatYAML, ExportFormatZoneFile)
+}
+
+// ExportedZone is a zone with its records in an export file.
+type ExportedZone struct {
+	Zone    string           `json:"zone"`
+	ZID     int64            `json:"zid,omitempty"`
+	Records []ExportedRecord `json:"records"`
+}
+
+// ExportedRecord is a record of an export file. A zero TTL or weight and an empty line keep the API defaults.
+type ExportedRecord struct {
+	Host     string `json:"host"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	TTL      int32  `json:"ttl,omitempty"`
+	Weight   int32  `json:"weight,omitempty"`
+	Line     string `json:"line,omitempty"`
+	Remark   string `json:"remark,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// ExportZone converts the records of a zone, ordered by host, type and value.
+func ExportZone(zoneName string, zoneID int64, records []*privatezone.RecordForListRecordsOutput) ExportedZone {
+	zone := ExportedZone{Zone: strings.TrimSuffix(zoneName, "."), ZID: zoneID, Records: make([]ExportedRecord, 0, len(records))}
+	for _, r := range records {
+		record := ExportedRecord{
+			Host:     volcengine.StringValue(r.Host),
+			Type:     volcengine.StringValue(r.Type),
+			Value:    volcengine.StringValue(r.Value),
+			TTL:      volcengine.Int32Value(r.TTL),
+			Line:     volcengine.StringValue(r.Line),
+			Remark:   volcengine.StringValue(r.Remark),
+			Disabled: r.Enable != nil && !*r.Enable,
+		}
+		if record.Line == defaultLine {
+			record.Line = ""
+		}
+		if weight := recordWeight(volcengine.Int32Value(r.Weight)); weight != defaultRecordWeight {
+			record.Weight = weight
+		}
+		zone.Records = append(zone.Records, record)
+	}
+	sort.SliceStable(zone.Records, func(i, j int) bool {
+		a, b := zone.Records[i], zone.Records[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Value < b.Value
+	})
+	return zone
+}
+
+// EncodeZones writes the zones in the format.
+func EncodeZones(w io.Writer, zones []ExportedZone, format ExportFormat) error {
+	switch format {
+	case ExportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(zones)
+	case ExportFormatYAML:
+		data, err := yaml.Marshal(zones)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case ExportFormatZoneFile:
+		return writeZoneFile(w, zones)
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// DecodeZones reads zones written in the format.
+func DecodeZones(r io.Reader, format ExportFormat) ([]ExportedZone, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var zones []ExportedZone
+	switch format {
+	case ExportFormatJSON:
+		err = json.Unmarshal(data, &zones)
+	case ExportFormatYAML:
+		err = yaml.UnmarshalStrict(data, &zones)
+	case ExportFormatZoneFile:
+		zones, err = parseZoneFile(string(data))
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		if zone.Zone == "" {
+			return nil, fmt.Errorf("a zone of the file has no name")
+		}
+	}
+	return zones, nil
+}
+
+// ImportUpdate is a record whose TTL, weight or remark an import changes.
+type ImportUpdate struct {
+	RecordID string
+	Old      ExportedRecord
+	New      ExportedRecord
+}
+
+// ImportPlan are the changes importing a zone file applies to the records of a zone.
+type ImportPlan struct {
+	Create []ExportedRecord
+	Update []ImportUpdate
+	// Delete are the records missing from the file, only planned when pruning
+	Delete []*privatezone.RecordForListRecordsOutput
+	// Skipped are disabled and unsupported records of the file, e.g. the SOA and NS records of other providers
+	Skipped []ExportedRecord
+}
+
+// Empty reports whether the plan changes nothing.
+func (p *ImportPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// importKey identifies a record by host, type, line and value, TXT values are compared verbatim.
+func importKey(host, recordType, line, value string) string {
+	switch recordType {
+	case endpoint.RecordTypeTXT:
+	case endpoint.RecordTypeSRV:
+		if srv, err := parseSRV(value); err == nil {
+			value = srv.String()
+		}
+	default:
+		value = normalizeTarget(recordType, value)
+	}
+	if line == "" {
+		line = defaultLine
+	}
+	value = TargetDotStrip.apply(recordType, value)
+	return strings.Join([]string{strings.ToLower(host), recordType, line, value}, "\x00")
+}
+
+// PlanImport compares the records of the file with the current records of the zone. Records of the file that are
+// missing are created, records whose TTL, weight or remark differ are updated, and with prune the current records
+// missing from the file are deleted.
+func PlanImport(zone ExportedZone, current []*privatezone.RecordForListRecordsOutput, prune bool) *ImportPlan {
+	plan := &ImportPlan{}
+	existing := make(map[string]*privatezone.RecordForListRecordsOutput, len(current))
+	for _, r := range current {
+		existing[importKey(volcengine.StringValue(r.Host), volcengine.StringValue(r.Type), volcengine.StringValue(r.Line), volcengine.StringValue(r.Value))] = r
+	}
+	seen := make(map[string]bool, len(zone.Records))
+	for _, record := range zone.Records {
+		// skipped records are seen too, pruning leaves their current records alone
+		key := importKey(record.Host, record.Type, record.Line, record.Value)
+		if record.Disabled || !supportedRecordTypes[record.Type] {
+			seen[key] = true
+			plan.Skipped = append(plan.Skipped, record)
+			continue
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		r, ok := existing[key]
+		if !ok {
+			plan.Create = append(plan.Create, record)
+			continue
+		}
+		old := ExportZone(zone.Zone, 0, []*privatezone.RecordForListRecordsOutput{r}).Records[0]
+		if (record.TTL != 0 && record.TTL != old.TTL) ||
+			(record.Weight != 0 && record.Weight != recordWeight(volcengine.Int32Value(r.Weight))) ||
+			(record.Remark != "" && record.Remark != old.Remark) {
+			plan.Update = append(plan.Update, ImportUpdate{RecordID: volcengine.StringValue(r.RecordID), Old: old, New: record})
+		}
+	}
+	if prune {
+		for _, r := range current {
+			key := importKey(volcengine.StringValue(r.Host), volcengine.StringValue(r.Type), volcengine.StringValue(r.Line), volcengine.StringValue(r.Value))
+			if !seen[key] {
+				plan.Delete = append(plan.Delete, r)
+			}
+		}
+	}
+	return plan
+}
+
+// String lists the changes of the plan, one per line, prefixed with +, ~ and -.
+func (p *ImportPlan) String() string {
+	var b strings.Builder
+	for _, r := range p.Create {
+		fmt.Fprintf(&b, "+ %s\n", r)
+	}
+	for _, u := range p.Update {
+		fmt.Fprintf(&b, "~ %s -> %s\n", u.Old, u.New.changes(u.Old))
+	}
+	for _, r := range p.Delete {
+		fmt.Fprintf(&b, "- %s\n", ExportZone("", 0, []*privatezone.RecordForListRecordsOutput{r}).Records[0])
+	}
+	return b.String()
+}
+
+// String formats the record as "host type value", followed by its TTL, weight and line when set.
+func (r ExportedRecord) String() string {
+	s := fmt.Sprintf("%s %s %s", r.Host, r.Type, r.Value)
+	if r.TTL != 0 {
+		s += fmt.Sprintf(" ttl=%d", r.TTL)
+	}
+	if r.Weight != 0 {
+		s += fmt.Sprintf(" weight=%d", r.Weight)
+	}
+	if r.Line != "" {
+		s += fmt.Sprintf(" line=%s", r.Line)
+	}
+	return s
+}
+
+// changes formats the TTL, weight and remark that differ from old.
+func (r ExportedRecord) changes(old ExportedRecord) string {
+	var changes []string
+	if r.TTL != 0 && r.TTL != old.TTL {
+		changes = append(changes, fmt.Sprintf("ttl=%d", r.TTL))
+	}
+	if r.Weight != 0 && recordWeight(r.Weight) != recordWeight(old.Weight) {
+		changes = append(changes, fmt.Sprintf("weight=%d", r.Weight))
+	}
+	if r.Remark != "" && r.Remark != old.Remark {
+		changes = append(changes, fmt.Sprintf("remark=%q", r.Remark))
+	}
+	return strings.Join(changes, " ")
+}
+
+// ApplyImport applies the plan to the zone, deletions first, and stops at the first failed call.
+func (w *PrivateZoneWrapper) ApplyImport(ctx context.Context, zoneID int64, plan *ImportPlan) error {
+	if len(plan.Delete) > 0 {
+		ids := make([]string, 0, len(plan.Delete))
+		for _, r := range plan.Delete {
+			ids = append(ids, volcengine.StringValue(r.RecordID))
+		}
+		if err := w.BatchDeletePrivateZoneRecords(ctx, zoneID, ids); err != nil {
+			return err
+		}
+	}
+	for _, u := range plan.Update {
+		r := u.New
+		// the update replaces the TTL and remark, keep the current ones unless the file sets others
+		ttl := r.TTL
+		if ttl == 0 {
+			ttl = u.Old.TTL
+		}
+		remark := r.Remark
+		if remark == "" {
+			remark = u.Old.Remark
+		}
+		if err := w.UpdatePrivateZoneRecord(ctx, zoneID, u.RecordID, u.Old.Host, r.Type, u.Old.Value, ttl, r.Weight, "", remark); err != nil {
+			return err
+		}
+	}
+	for _, r := range plan.Create {
+		if err := w.CreatePrivateZoneRecord(ctx, zoneID, r.Host, r.Type, r.Value, r.TTL, createWeight(r.Weight), r.Line, r.Remark); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/response"
+)
+
+func listedRecord(id, host, recordType, value string, ttl int32) *privatezone.RecordForListRecordsOutput {
+	return &privatezone.RecordForListRecordsOutput{
+		RecordID: volcengine.String(id),
+		Host:     volcengine.String(host),
+		Type:     volcengine.String(recordType),
+		Value:    volcengine.String(value),
+		TTL:      volcengine.Int32(ttl),
+		Weight:   volcengine.Int32(defaultRecordWeight),
+		Line:     volcengine.String(defaultLine),
+		Enable:   volcengine.Bool(true),
+	}
+}
+
+func exportedTestZone() ExportedZone {
+	mx := listedRecord("3", "@", "MX", "10 mail.example.com", 600)
+	mx.Weight = volcengine.Int32(5)
+	mx.Remark = volcengine.String(`primary "mx"`)
+	disabled := listedRecord("4", "old", "A", "10.0.0.9", 600)
+	disabled.Enable = volcengine.Bool(false)
+	return ExportZone("example.com.", 42, []*privatezone.RecordForListRecordsOutput{
+		listedRecord("1", "www", "A", "10.0.0.1", 300),
+		listedRecord("2", "alias", "CNAME", "www.example.com", 300),
+		mx,
+		disabled,
+		listedRecord("5", "txt", "TXT", encodeTXTValue(strings.Repeat("x", 300)+` "quoted" \`), 60),
+	})
+}
+
+func TestExportZone(t *testing.T) {
+	zone := exportedTestZone()
+	assert.Equal(t, "example.com", zone.Zone)
+	assert.Equal(t, []string{"@", "alias", "old", "txt", "www"}, []string{
+		zone.Records[0].Host, zone.Records[1].Host, zone.Records[2].Host, zone.Records[3].Host, zone.Records[4].Host,
+	})
+	assert.Equal(t, ExportedRecord{Host: "@", Type: "MX", Value: "10 mail.example.com", TTL: 600, Weight: 5, Remark: `primary "mx"`}, zone.Records[0])
+	assert.True(t, zone.Records[2].Disabled)
+}
+
+func TestEncodeDecodeZonesRoundTrip(t *testing.T) {
+	zones := []ExportedZone{exportedTestZone(), {Zone: "other.com", Records: []ExportedRecord{
+		{Host: "@", Type: "A", Value: "10.0.1.1", TTL: 600, Line: "mobile"},
+	}}}
+	for _, format := range []ExportFormat{ExportFormatJSON, ExportFormatYAML, ExportFormatZoneFile} {
+		var buf bytes.Buffer
+		require.NoError(t, EncodeZones(&buf, zones, format), format)
+		decoded, err := DecodeZones(&buf, format)
+		require.NoError(t, err, format)
+		want := zones
+		if format == ExportFormatZoneFile {
+			// disabled records are commented out of zone files
+			want = []ExportedZone{{Zone: "example.com", ZID: 42}, zones[1]}
+			for _, r := range zones[0].Records {
+				if !r.Disabled {
+					want[0].Records = append(want[0].Records, r)
+				}
+			}
+		}
+		assert.Equal(t, want, decoded, format)
+	}
+}
+
+func TestWriteZoneFile(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, EncodeZones(&buf, []ExportedZone{exportedTestZone()}, ExportFormatZoneFile))
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "$ORIGIN example.com.", lines[0])
+	assert.Equal(t, "; zid 42", lines[1])
+	assert.Equal(t, `@ 600 IN MX 10 mail.example.com. ; weight=5 remark="primary \"mx\""`, lines[2])
+	assert.Equal(t, "alias 300 IN CNAME www.example.com.", lines[3])
+	assert.Equal(t, "; disabled: old 600 IN A 10.0.0.9", lines[4])
+	assert.Equal(t, `txt 60 IN TXT "`+strings.Repeat("x", 255)+`" "`+strings.Repeat("x", 45)+` \"quoted\" \\"`, lines[5])
+}
+
+func TestParseZoneFile(t *testing.T) {
+	zones, err := DecodeZones(strings.NewReader(`; exported elsewhere
+$TTL 3600
+$ORIGIN example.com.
+@          IN SOA ns.example.com. admin.example.com. 1 7200 3600 1209600 3600
+           IN NS  ns.example.com.
+www        300 IN A 10.0.0.1 ; web
+           IN 300 A 10.0.0.2
+api.example.com. CNAME www
+mail       IN MX 10 mail.other.com.
+_sip._udp  SRV 10 5 5060 sip
+txt        TXT "v=spf1 " "-all"
+`), ExportFormatZoneFile)
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "example.com", zones[0].Zone)
+	assert.Equal(t, []ExportedRecord{
+		{Host: "@", Type: "SOA", Value: "ns.example.com. admin.example.com. 1 7200 3600 1209600 3600", TTL: 3600},
+		{Host: "@", Type: "NS", Value: "ns.example.com.", TTL: 3600},
+		{Host: "www", Type: "A", Value: "10.0.0.1", TTL: 300},
+		{Host: "www", Type: "A", Value: "10.0.0.2", TTL: 300},
+		{Host: "api", Type: "CNAME", Value: "www.example.com", TTL: 3600},
+		{Host: "mail", Type: "MX", Value: "10 mail.other.com", TTL: 3600},
+		{Host: "_sip._udp", Type: "SRV", Value: "10 5 5060 sip.example.com", TTL: 3600},
+		{Host: "txt", Type: "TXT", Value: "v=spf1 -all", TTL: 3600},
+	}, zones[0].Records)
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"www IN A 10.0.0.1":                              "line 1: record before $ORIGIN",
+		"$ORIGIN example.com.\n@ IN SOA ns. admin. (":    "line 2: multi-line records are not supported",
+		"$ORIGIN example.com.\ntxt TXT \"open":           "line 2: unterminated quoted string",
+		"$ORIGIN example.com.\nwww 300 IN":               "line 2: record without type and value",
+		"$ORIGIN example.com.\n$TTL 1h":                  `line 2: invalid TTL "1h"`,
+		"$ORIGIN example.com.\nwww A 1.1.1.1 ; weight=x": `line 2: invalid weight "x"`,
+	} {
+		_, err := DecodeZones(strings.NewReader(input), ExportFormatZoneFile)
+		assert.EqualError(t, err, want, input)
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	current := []*privatezone.RecordForListRecordsOutput{
+		listedRecord("1", "www", "A", "10.0.0.1", 300),
+		listedRecord("2", "alias", "CNAME", "www.example.com.", 300),
+		listedRecord("3", "stale", "A", "10.0.0.3", 300),
+		listedRecord("4", "mx", "MX", "10  mail.example.com", 300),
+		listedRecord("5", "off", "A", "10.0.0.5", 300),
+	}
+	zone := ExportedZone{Zone: "example.com", Records: []ExportedRecord{
+		{Host: "www", Type: "A", Value: "10.0.0.1", TTL: 300},
+		{Host: "WWW", Type: "A", Value: "10.0.0.1", TTL: 300},
+		{Host: "alias", Type: "CNAME", Value: "www.example.com", TTL: 600},
+		{Host: "mx", Type: "MX", Value: "10 mail.example.com.", Weight: 1},
+		{Host: "new", Type: "A", Value: "10.0.0.4"},
+		{Host: "off", Type: "A", Value: "10.0.0.5", Disabled: true},
+		{Host: "@", Type: "SOA", Value: "ns.example.com. admin.example.com. 1 7200 3600 1209600 3600"},
+	}}
+
+	plan := PlanImport(zone, current, false)
+	assert.Equal(t, []ExportedRecord{{Host: "new", Type: "A", Value: "10.0.0.4"}}, plan.Create)
+	require.Len(t, plan.Update, 1)
+	assert.Equal(t, "2", plan.Update[0].RecordID)
+	assert.Empty(t, plan.Delete)
+	assert.Len(t, plan.Skipped, 2)
+	assert.Equal(t, "+ new A 10.0.0.4\n~ alias CNAME www.example.com. ttl=300 -> ttl=600\n", plan.String())
+
+	// the record disabled in the file is not pruned
+	plan = PlanImport(zone, current, true)
+	require.Len(t, plan.Delete, 1)
+	assert.Equal(t, "3", volcengine.StringValue(plan.Delete[0].RecordID))
+	assert.False(t, plan.Empty())
+	assert.True(t, PlanImport(ExportZone("example.com", 0, current), current, true).Empty())
+}
+
+func TestApplyImport(t *testing.T) {
+	client := &MockClient{}
+	var calls []string
+	client.BatchDeleteRecordFunc = func(ctx context.Context, input *privatezone.BatchDeleteRecordInput) (*privatezone.BatchDeleteRecordOutput, error) {
+		calls = append(calls, "delete "+strings.Join(volcengine.StringValueSlice(input.RecordIDs), ","))
+		return &privatezone.BatchDeleteRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+	}
+	client.UpdateRecordFunc = func(ctx context.Context, input *privatezone.UpdateRecordInput) (*privatezone.UpdateRecordOutput, error) {
+		assert.Equal(t, "kept", volcengine.StringValue(input.Remark))
+		calls = append(calls, fmt.Sprintf("update %s ttl=%d", volcengine.StringValue(input.RecordID), volcengine.Int32Value(input.TTL)))
+		return &privatezone.UpdateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+	}
+	client.CreateRecordFunc = func(ctx context.Context, input *privatezone.CreateRecordInput) (*privatezone.CreateRecordOutput, error) {
+		assert.Nil(t, input.Weight)
+		calls = append(calls, "create "+volcengine.StringValue(input.Host))
+		return &privatezone.CreateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+	}
+	w := &PrivateZoneWrapper{client: client}
+	plan := &ImportPlan{
+		Create: []ExportedRecord{{Host: "new", Type: "A", Value: "10.0.0.4", Weight: defaultRecordWeight}},
+		Update: []ImportUpdate{
+			{RecordID: "2", Old: ExportedRecord{Host: "alias", Type: "CNAME", Value: "www.example.com", TTL: 300, Remark: "kept"}, New: ExportedRecord{Host: "alias", Type: "CNAME", Value: "www.example.com", TTL: 600}},
+			// the file sets no TTL, the current one is kept
+			{RecordID: "4", Old: ExportedRecord{Host: "mx", Type: "MX", Value: "10 mail.example.com.", TTL: 300, Remark: "kept"}, New: ExportedRecord{Host: "mx", Type: "MX", Value: "10 mail.example.com.", Weight: 5}},
+		},
+		Delete: []*privatezone.RecordForListRecordsOutput{listedRecord("3", "stale", "A", "10.0.0.3", 300)},
+	}
+	require.NoError(t, w.ApplyImport(context.Background(), 1, plan))
+	assert.Equal(t, []string{"delete 3", "update 2 ttl=600", "update 4 ttl=300", "create new"}, calls)
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+)
+
+// FileCredentialsProviderName is the provider name of credentials read from files.
+const FileCredentialsProviderName = "FileCredentialsProvider"
+
+// FileCredentialsProvider serves the access key and secret key read from the access-key and secret-key files of a
+// directory, e.g. a mounted Kubernetes Secret, and picks up changes to them.
+type FileCredentialsProvider struct {
+	dir string
+	log Logger
+
+	mu      sync.Mutex
+	value   credentials.Value
+	changed bool
+}
+
+// NewFileCredentials reads credentials from dir and watches it until ctx is done, the reloads are logged to log.
+func NewFileCredentials(ctx context.Context, dir string, log Logger) (*credentials.Credentials, error) {
+	p := &FileCredentialsProvider{dir: dir, log: log}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch credentials dir %s: %w", dir, err)
+	}
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to watch credentials dir %s: %w", dir, err)
+	}
+	go p.watch(ctx, watcher)
+	return credentials.NewCredentials(p), nil
+}
+
+// watch reloads the credentials on changes in the directory. Kubernetes updates a mounted Secret by swapping the
+// ..data symlink, so any event in the directory may change the files.
+func (p *FileCredentialsProvider) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if err := p.load(); err != nil {
+				p.log.Warnf("Failed to reload credentials, keep using the last loaded credentials: %v", err)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			p.log.Warnf("Error watching credentials dir %s: %v", p.dir, err)
+		}
+	}
+}
+
+// load reads the credential files, credentials that did not change are kept.
+func (p *FileCredentialsProvider) load() error {
+	accessKey, err := readCredentialFile(p.dir, secretAccessKeyKey)
+	if err != nil {
+		return err
+	}
+	secretKey, err := readCredentialFile(p.dir, secretSecretKeyKey)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if accessKey == p.value.AccessKeyID && secretKey == p.value.SecretAccessKey {
+		return nil
+	}
+	p.value = credentials.Value{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+		ProviderName:    FileCredentialsProviderName,
+	}
+	p.changed = true
+	p.log.Infof("Loaded credentials from %s with access_key=%s", p.dir, MaskSecret(accessKey))
+	return nil
+}
+
+// readCredentialFile returns the trimmed content of the file name in dir, which must not be empty.
+func readCredentialFile(dir, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read credentials: %w", err)
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("credentials file %s is empty", filepath.Join(dir, name))
+	}
+	return value, nil
+}
+
+// Retrieve returns the credentials last read from the files.
+// Implementation for credentials.Provider
+func (p *FileCredentialsProvider) Retrieve() (credentials.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changed = false
+	return p.value, nil
+}
+
+// IsExpired reports whether the files changed since the last Retrieve.
+// Implementation for credentials.Provider
+func (p *FileCredentialsProvider) IsExpired() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.changed
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSecretVolume writes the files like a mounted Kubernetes Secret, into a timestamped directory that the ..data
+// symlink is swapped to.
+func writeSecretVolume(t *testing.T, dir string, files map[string]string) {
+	data, err := os.MkdirTemp(dir, "..data_")
+	require.NoError(t, err)
+	for name, value := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(data, name), []byte(value), 0o600))
+		if _, err := os.Lstat(filepath.Join(dir, name)); os.IsNotExist(err) {
+			require.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)))
+		}
+	}
+	tmp := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(filepath.Base(data), tmp))
+	require.NoError(t, os.Rename(tmp, filepath.Join(dir, "..data")))
+}
+
+func TestFileCredentials(t *testing.T) {
+	dir := t.TempDir()
+	writeSecretVolume(t, dir, map[string]string{secretAccessKeyKey: "AKLT1\n", secretSecretKeyKey: "secret1\n"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	creds, err := NewFileCredentials(ctx, dir, logrus.StandardLogger())
+	require.NoError(t, err)
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "AKLT1", value.AccessKeyID)
+	assert.Equal(t, "secret1", value.SecretAccessKey)
+	assert.Equal(t, FileCredentialsProviderName, value.ProviderName)
+
+	// A rotated secret is used without a restart
+	writeSecretVolume(t, dir, map[string]string{secretAccessKeyKey: "AKLT2", secretSecretKeyKey: "secret2"})
+	assert.Eventually(t, func() bool {
+		value, err := creds.Get()
+		return err == nil && value.AccessKeyID == "AKLT2" && value.SecretAccessKey == "secret2"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A broken update keeps the last loaded credentials
+	writeSecretVolume(t, dir, map[string]string{secretAccessKeyKey: "", secretSecretKeyKey: "secret3"})
+	time.Sleep(100 * time.Millisecond)
+	value, err = creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "AKLT2", value.AccessKeyID)
+	assert.Equal(t, "secret2", value.SecretAccessKey)
+}
+
+func TestFileCredentialsMissing(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, secretAccessKeyKey), []byte("AKLT1"), 0o600))
+
+	_, err := NewFileCredentials(context.Background(), dir, logrus.StandardLogger())
+	assert.ErrorContains(t, err, "failed to read credentials")
+	assert.ErrorContains(t, err, secretSecretKeyKey)
+}
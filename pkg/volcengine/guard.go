@@ -0,0 +1,84 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// DeletionGuard refuses ApplyChanges batches deleting more than MaxPercent of the managed records
+// or more than MaxRecords records, zero values disable the respective limit.
+type DeletionGuard struct {
+	MaxPercent float64
+	MaxRecords int
+	// Force applies such batches anyway, they are still logged.
+	Force bool
+}
+
+func (g DeletionGuard) enabled() bool {
+	return g.MaxPercent > 0 || g.MaxRecords > 0
+}
+
+// checkMassDeletion counts the records deleted by endpoints against the records listed in the managed zones, the
+// RecordCount of a zone may be stale or include soft-deleted records.
+func (p *Provider) checkMassDeletion(ctx context.Context, zones []*privatezone.ZoneForListPrivateZonesOutput, zoneMap provider.ZoneIDName, deletes []*endpoint.Endpoint) error {
+	if !p.deletionGuard.enabled() || len(deletes) == 0 {
+		return nil
+	}
+	managed := 0
+	if p.deletionGuard.MaxPercent > 0 {
+		for _, zone := range zones {
+			if !p.domainFilter.Match(volcengine.StringValue(zone.ZoneName)) {
+				continue
+			}
+			records, err := p.pzClient.GetPrivateZoneRecords(ctx, int64(volcengine.Int32Value(zone.ZID)))
+			if err != nil {
+				return newChangeError(ErrCodeDeleteFailed, fmt.Errorf("failed to count the records of zone %s: %w",
+					volcengine.StringValue(zone.ZoneName), err), deletes...)
+			}
+			managed += len(removeTombstones(records))
+		}
+	}
+	deleted := 0
+	for _, ep := range deletes {
+		if zone, _ := zoneMap.FindZone(ep.DNSName); zone != "" {
+			deleted += len(ep.Targets)
+		}
+	}
+
+	var reason string
+	switch {
+	case p.deletionGuard.MaxRecords > 0 && deleted > p.deletionGuard.MaxRecords:
+		reason = fmt.Sprintf("%d records would be deleted, more than the limit of %d", deleted, p.deletionGuard.MaxRecords)
+	case p.deletionGuard.MaxPercent > 0 && managed > 0 && float64(deleted)*100 > p.deletionGuard.MaxPercent*float64(managed):
+		reason = fmt.Sprintf("%d of %d managed records would be deleted, more than the limit of %g%%", deleted, managed, p.deletionGuard.MaxPercent)
+	default:
+		return nil
+	}
+	if p.deletionGuard.Force {
+		p.logger().Warnf("MASS DELETION FORCED: %s, applying anyway because deletions are forced", reason)
+		return nil
+	}
+	p.logger().Errorf("MASS DELETION REFUSED: %s, no changes were applied. Check the external-dns sources and filters, "+
+		"or force the deletion if it is intended", reason)
+	return newChangeError(ErrCodeMassDeletion, fmt.Errorf("refused to apply changes: %s", reason), deletes...)
+}
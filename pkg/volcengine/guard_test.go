@@ -0,0 +1,90 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestProviderDeletionGuard(t *testing.T) {
+	zones := []*privatezone.ZoneForListPrivateZonesOutput{
+		// the counts of the zones are stale, the listed records count
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com"), RecordCount: volcengine.Int32(100)},
+		{ZID: volcengine.Int32(456), ZoneName: volcengine.String("other.com"), RecordCount: volcengine.Int32(0)},
+	}
+	deletes := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", "A", "1.1.1.1", "2.2.2.2"),
+		endpoint.NewEndpoint("b.example.com", "A", "3.3.3.3"),
+		endpoint.NewEndpoint("c.unmanaged.com", "A", "4.4.4.4"),
+	}
+	records := []*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("1"), Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1")},
+		{RecordID: volcengine.String("2"), Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("2.2.2.2")},
+		{RecordID: volcengine.String("3"), Host: volcengine.String("b"), Type: volcengine.String("A"), Value: volcengine.String("3.3.3.3")},
+		{RecordID: volcengine.String("4"), Host: volcengine.String("b"), Type: volcengine.String("A"), Value: volcengine.String("3.3.3.4")},
+	}
+	var otherRecords []*privatezone.RecordForListRecordsOutput
+	for i := 0; i < 60; i++ {
+		otherRecords = append(otherRecords, &privatezone.RecordForListRecordsOutput{RecordID: volcengine.String(fmt.Sprintf("o%d", i)),
+			Host: volcengine.String(fmt.Sprintf("h%d", i)), Type: volcengine.String("A"), Value: volcengine.String("5.5.5.5")})
+	}
+
+	tests := []struct {
+		name         string
+		guard        DeletionGuard
+		domainFilter []string
+		refused      bool
+	}{
+		{name: "disabled", guard: DeletionGuard{}},
+		{name: "below percent", guard: DeletionGuard{MaxPercent: 5}},
+		{name: "above percent of filtered zones", guard: DeletionGuard{MaxPercent: 5}, domainFilter: []string{"example.com"}, refused: true},
+		{name: "at record limit", guard: DeletionGuard{MaxRecords: 3}},
+		{name: "above record limit", guard: DeletionGuard{MaxRecords: 2}, refused: true},
+		{name: "forced", guard: DeletionGuard{MaxRecords: 2, Force: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPrivateZoneAPI)
+			mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(zones, nil)
+			mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(records, nil)
+			mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(456)).Return(otherRecords, nil)
+			mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"1", "2", "3"}).Return(nil)
+
+			p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123", deletionGuard: tt.guard}
+			p.domainFilter.Filters = tt.domainFilter
+			err := p.ApplyChanges(context.Background(), &plan.Changes{Delete: deletes})
+			if !tt.refused {
+				assert.NoError(t, err)
+				mockAPI.AssertNumberOfCalls(t, "BatchDeletePrivateZoneRecords", 1)
+				return
+			}
+			var changeErr *ChangeError
+			assert.True(t, errors.As(err, &changeErr))
+			assert.Equal(t, ErrCodeMassDeletion, changeErr.Code)
+			mockAPI.AssertNotCalled(t, "BatchDeletePrivateZoneRecords", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+)
+
+// headersHandlerName names the request handler attaching the configured headers.
+const headersHandlerName = "volcengine-provider.StaticHeaders"
+
+// ParseHeaders parses comma separated Name=value pairs, e.g. X-Gateway-Token=abc,X-Tenant-Id=t1.
+func ParseHeaders(value string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || !validHeaderName(name) {
+			return nil, fmt.Errorf("invalid header %q, expected Name=value", pair)
+		}
+		headers.Add(name, strings.TrimSpace(val))
+	}
+	return headers, nil
+}
+
+// HeaderNames returns the sorted header names, for logging without the values.
+func HeaderNames(headers http.Header) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validHeaderName(name string) bool {
+	for _, c := range name {
+		if c <= ' ' || c >= 0x7f || strings.ContainsRune("\"(),/:;<=>?@[\\]{}", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// installStaticHeaders sets the headers on every request of the handlers, nothing is installed without headers.
+func installStaticHeaders(handlers *request.Handlers, headers http.Header) {
+	if len(headers) > 0 {
+		handlers.Build.PushBackNamed(staticHeadersHandler(headers))
+	}
+}
+
+// staticHeadersHandler sets headers on every request, it runs while building so the headers are signed.
+func staticHeadersHandler(headers http.Header) request.NamedHandler {
+	return request.NamedHandler{
+		Name: headersHandlerName,
+		Fn: func(r *request.Request) {
+			for name, values := range headers {
+				r.HTTPRequest.Header[textproto.CanonicalMIMEHeaderKey(name)] = append([]string(nil), values...)
+			}
+		},
+	}
+}
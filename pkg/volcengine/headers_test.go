@@ -0,0 +1,94 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+)
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders(" X-Gateway-Token=abc=, x-tenant-id = t1 ,,")
+	require.NoError(t, err)
+	assert.Equal(t, "abc=", headers.Get("X-Gateway-Token"))
+	assert.Equal(t, "t1", headers.Get("X-Tenant-Id"))
+	assert.Equal(t, []string{"X-Gateway-Token", "X-Tenant-Id"}, HeaderNames(headers))
+
+	headers, err = ParseHeaders("")
+	require.NoError(t, err)
+	assert.Empty(t, headers)
+
+	for _, invalid := range []string{"X-Token", "=abc", "X Token=abc", "X:Token=abc"} {
+		_, err := ParseHeaders(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPrivateZoneHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1"},"Result":{"Zones":[],"Total":0}}`))
+	}))
+	defer server.Close()
+
+	headers, err := ParseHeaders("X-Gateway-Token=abc,X-Tenant-Id=t1")
+	require.NoError(t, err)
+	wrapper, err := NewPrivateZoneWrapper("cn-beijing", server.URL, credentials.NewStaticCredentials("ak", "sk", ""),
+		WithPrivateZoneHeaders(headers))
+	require.NoError(t, err)
+
+	_, err = wrapper.ListPrivateZones(context.Background(), "vpc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", got.Get("X-Gateway-Token"))
+	assert.Equal(t, "t1", got.Get("X-Tenant-Id"))
+	assert.NotEmpty(t, got.Get("Authorization"))
+}
+
+func TestCloudDNSAndKMSHeaders(t *testing.T) {
+	var got []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1"},"Result":{"Zones":[],"Total":0,"Plaintext":"YQ=="}}`))
+	}))
+	defer server.Close()
+
+	headers, err := ParseHeaders("X-Gateway-Token=abc")
+	require.NoError(t, err)
+	creds := credentials.NewStaticCredentials("ak", "sk", "")
+	clouddns, err := NewCloudDNSWrapper("cn-beijing", server.URL, creds, WithCloudDNSHeaders(headers))
+	require.NoError(t, err)
+	_, err = clouddns.ListPrivateZones(context.Background(), "")
+	require.NoError(t, err)
+
+	decrypter, err := NewKMSDecrypter("cn-beijing", server.URL, creds, headers)
+	require.NoError(t, err)
+	_, err = decrypter.Decrypt(context.Background(), "kms://cipher")
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	for _, h := range got {
+		assert.Equal(t, "abc", h.Get("X-Gateway-Token"))
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+)
+
+const (
+	// InstanceMetadataProviderName is the provider name of instance role credentials.
+	InstanceMetadataProviderName = "InstanceMetadataProvider"
+
+	defaultMetadataEndpoint = "http://100.96.0.96"
+	metadataCredentialsPath = "/volcstack/latest/iam/security_credentials/"
+	metadataTimeFormat      = time.RFC3339
+)
+
+// InstanceMetadataProvider retrieves the credentials of the instance role from the ECS metadata service.
+type InstanceMetadataProvider struct {
+	credentials.Expiry
+
+	// Endpoint of the metadata service, defaults to http://100.96.0.96.
+	Endpoint string
+	// RoleName is the instance role, it is discovered from the metadata service when empty.
+	RoleName string
+	// ExpiryWindow refreshes the credentials this long before they expire.
+	ExpiryWindow time.Duration
+	Client       *http.Client
+}
+
+type metadataCredentials struct {
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	ExpiredTime     string
+}
+
+// NewInstanceMetadataCredentials returns credentials of the given instance role, an empty role is discovered.
+func NewInstanceMetadataCredentials(roleName string) *credentials.Credentials {
+	return credentials.NewExpireAbleCredentials(&InstanceMetadataProvider{
+		RoleName:     roleName,
+		ExpiryWindow: 5 * time.Minute,
+	})
+}
+
+// Retrieve fetches the instance role credentials.
+// Implementation for credentials.Provider
+func (p *InstanceMetadataProvider) Retrieve() (credentials.Value, error) {
+	role := p.RoleName
+	if role == "" {
+		body, err := p.get(metadataCredentialsPath)
+		if err != nil {
+			return credentials.Value{ProviderName: InstanceMetadataProviderName}, err
+		}
+		role = strings.TrimSpace(strings.SplitN(string(body), "\n", 2)[0])
+		if role == "" {
+			return credentials.Value{ProviderName: InstanceMetadataProviderName}, fmt.Errorf("no role is attached to the instance")
+		}
+	}
+	body, err := p.get(metadataCredentialsPath + role)
+	if err != nil {
+		return credentials.Value{ProviderName: InstanceMetadataProviderName}, err
+	}
+	var creds metadataCredentials
+	if err := json.Unmarshal(body, &creds); err != nil {
+		return credentials.Value{ProviderName: InstanceMetadataProviderName}, fmt.Errorf("failed to parse instance role credentials: %v", err)
+	}
+	expiration, err := time.Parse(metadataTimeFormat, creds.ExpiredTime)
+	if err != nil {
+		return credentials.Value{ProviderName: InstanceMetadataProviderName}, fmt.Errorf("failed to parse instance role credentials expiration %q: %v", creds.ExpiredTime, err)
+	}
+	p.SetExpiration(expiration, p.ExpiryWindow)
+
+	return credentials.Value{
+		AccessKeyID:     creds.AccessKeyId,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		ProviderName:    InstanceMetadataProviderName,
+	}, nil
+}
+
+func (p *InstanceMetadataProvider) get(path string) ([]byte, error) {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = defaultMetadataEndpoint
+	}
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	resp, err := client.Get(endpoint + path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query instance metadata: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query instance metadata %s: status %d, body: %s", path, resp.StatusCode, string(body))
+	}
+	return body, nil
+}
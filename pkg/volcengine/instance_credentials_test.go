@@ -0,0 +1,67 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceMetadataProviderRetrieve(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case metadataCredentialsPath:
+			fmt.Fprint(w, "dns-role\n")
+		case metadataCredentialsPath + "dns-role":
+			fmt.Fprintf(w, `{"AccessKeyId":"ak","SecretAccessKey":"sk","SessionToken":"token","ExpiredTime":%q}`, expiration)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &InstanceMetadataProvider{Endpoint: server.URL}
+	value, err := p.Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", value.AccessKeyID)
+	assert.Equal(t, "sk", value.SecretAccessKey)
+	assert.Equal(t, "token", value.SessionToken)
+	assert.False(t, p.IsExpired())
+
+	p = &InstanceMetadataProvider{Endpoint: server.URL, RoleName: "missing"}
+	_, err = p.Retrieve()
+	assert.Error(t, err)
+}
+
+func TestInstanceMetadataProviderRefresh(t *testing.T) {
+	expiration := time.Now().Add(2 * time.Minute).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"AccessKeyId":"ak","SecretAccessKey":"sk","SessionToken":"token","ExpiredTime":%q}`, expiration)
+	}))
+	defer server.Close()
+
+	// Credentials expiring within the expiry window are refreshed on the next call
+	p := &InstanceMetadataProvider{Endpoint: server.URL, RoleName: "dns-role", ExpiryWindow: 5 * time.Minute}
+	_, err := p.Retrieve()
+	assert.NoError(t, err)
+	assert.True(t, p.IsExpired())
+}
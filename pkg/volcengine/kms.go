@@ -0,0 +1,80 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/service/kms"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/session"
+)
+
+// KMSPrefix marks a configuration value as KMS ciphertext, e.g. kms://<CiphertextBlob>.
+const KMSPrefix = "kms://"
+
+// IsKMSCiphertext reports whether value is KMS ciphertext.
+func IsKMSCiphertext(value string) bool {
+	return strings.HasPrefix(value, KMSPrefix)
+}
+
+// kmsClient is an interface that contains only the methods actually used by KMSDecrypter
+type kmsClient interface {
+	DecryptWithContext(ctx context.Context, input *kms.DecryptInput, options ...request.Option) (*kms.DecryptOutput, error)
+}
+
+// KMSDecrypter decrypts KMS ciphertext configuration values.
+type KMSDecrypter struct {
+	client kmsClient
+}
+
+// NewKMSDecrypter creates a new KMS decrypter, the headers are attached to every request, e.g. for an OpenAPI gateway.
+func NewKMSDecrypter(regionID, kmsEndpoint string, credentials *credentials.Credentials, headers http.Header) (*KMSDecrypter, error) {
+	c := volcengine.NewConfig().
+		WithRegion(regionID).
+		WithCredentials(credentials).
+		WithEndpoint(kmsEndpoint)
+	s, err := session.NewSession(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create volcengine session: %v", err)
+	}
+	installStaticHeaders(&s.Handlers, headers)
+	return &KMSDecrypter{client: kms.New(s)}, nil
+}
+
+// Decrypt returns the plaintext of a kms:// value, other values are returned unchanged.
+func (d *KMSDecrypter) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsKMSCiphertext(value) {
+		return value, nil
+	}
+	resp, err := d.client.DecryptWithContext(ctx, &kms.DecryptInput{
+		CiphertextBlob: volcengine.String(strings.TrimPrefix(value, KMSPrefix)),
+	})
+	if err != nil || resp.Metadata.Error != nil {
+		return "", fmt.Errorf("failed to decrypt kms value, err: %v, resp: %v", err, resp)
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(volcengine.StringValue(resp.Plaintext))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode kms plaintext: %v", err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/volcengine-go-sdk/service/kms"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/response"
+)
+
+type mockKMSClient struct {
+	decrypt func(input *kms.DecryptInput) (*kms.DecryptOutput, error)
+}
+
+func (m *mockKMSClient) DecryptWithContext(ctx context.Context, input *kms.DecryptInput, options ...request.Option) (*kms.DecryptOutput, error) {
+	return m.decrypt(input)
+}
+
+func TestKMSDecrypterDecrypt(t *testing.T) {
+	d := &KMSDecrypter{client: &mockKMSClient{
+		decrypt: func(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+			if volcengine.StringValue(input.CiphertextBlob) != "cipher" {
+				return nil, errors.New("unexpected ciphertext")
+			}
+			return &kms.DecryptOutput{
+				Metadata:  &response.ResponseMetadata{},
+				Plaintext: volcengine.String(base64.StdEncoding.EncodeToString([]byte("AKLTsecret"))),
+			}, nil
+		},
+	}}
+
+	plaintext, err := d.Decrypt(context.Background(), "kms://cipher")
+	assert.NoError(t, err)
+	assert.Equal(t, "AKLTsecret", plaintext)
+
+	plaintext, err = d.Decrypt(context.Background(), "plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", plaintext)
+
+	_, err = d.Decrypt(context.Background(), "kms://other")
+	assert.Error(t, err)
+}
+
+func TestIsKMSCiphertext(t *testing.T) {
+	assert.True(t, IsKMSCiphertext("kms://abc"))
+	assert.False(t, IsKMSCiphertext("abc"))
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// maxRemarkLength is the longest remark accepted by privatezone records.
+	maxRemarkLength = 255
+	remarkSeparator = "; "
+	// setIdentifierKey holds the endpoint SetIdentifier in the record remark.
+	setIdentifierKey = "set-identifier"
+	// maxSetIdentifierLength leaves room for the labels in the remark.
+	maxSetIdentifierLength = 64
+)
+
+// remarkLabelKeys are the endpoint labels preserved in the record remark, in encoding order.
+var remarkLabelKeys = []string{endpoint.OwnerLabelKey, endpoint.ResourceLabelKey}
+
+// encodeRemarkHead appends the set identifier and the preserved endpoint labels to head,
+// e.g. "managed by external-dns; set-identifier=blue; owner=default; resource=service/default/nginx".
+// Labels that would push the remark over maxRemarkLength are dropped, the set identifier is always kept.
+func encodeRemarkHead(head string, labels endpoint.Labels, setIdentifier string) string {
+	remark := head
+	if setIdentifier != "" {
+		remark += remarkSeparator + setIdentifierKey + "=" + setIdentifier
+	}
+	for _, key := range remarkLabelKeys {
+		value, ok := labels[key]
+		if !ok || value == "" || strings.Contains(value, remarkSeparator) {
+			continue
+		}
+		part := remarkSeparator + key + "=" + value
+		if len(remark)+len(part) > maxRemarkLength {
+			continue
+		}
+		remark += part
+	}
+	return remark
+}
+
+// mergeRemark returns the managed remark written by encodeRemark followed by the parts of the existing remark
+// encodeRemark does not write, so context added to a record by hand survives updates. Parts that would push the
+// remark over maxRemarkLength are dropped.
+func mergeRemark(existing, managed string) string {
+	remark := managed
+	for _, part := range strings.Split(existing, remarkSeparator) {
+		if part == "" || isManagedRemarkPart(part) {
+			continue
+		}
+		if len(remark)+len(remarkSeparator)+len(part) > maxRemarkLength {
+			continue
+		}
+		remark += remarkSeparator + part
+	}
+	return remark
+}
+
+// isManagedRemarkPart reports whether encodeRemark writes the remark part.
+func isManagedRemarkPart(part string) bool {
+	if isManagedRemarkHead(part) {
+		return true
+	}
+	key, _, ok := strings.Cut(part, "=")
+	if !ok {
+		return false
+	}
+	if key == setIdentifierKey {
+		return true
+	}
+	for _, k := range remarkLabelKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// remarkSetIdentifier returns the set identifier written by encodeRemark, or "" if there is none.
+func remarkSetIdentifier(remark string) string {
+	parts := strings.Split(remark, remarkSeparator)
+	if len(parts) < 2 || !isManagedRemarkHead(parts[0]) {
+		return ""
+	}
+	for _, part := range parts[1:] {
+		if key, value, ok := strings.Cut(part, "="); ok && key == setIdentifierKey {
+			return value
+		}
+	}
+	return ""
+}
+
+// filterSetIdentifier returns the records of the endpoint with the set identifier.
+func filterSetIdentifier(records []*privatezone.RecordForListRecordsOutput, setIdentifier string) []*privatezone.RecordForListRecordsOutput {
+	res := make([]*privatezone.RecordForListRecordsOutput, 0, len(records))
+	for _, record := range records {
+		if remarkSetIdentifier(volcengine.StringValue(record.Remark)) == setIdentifier {
+			res = append(res, record)
+		}
+	}
+	return res
+}
+
+// decodeRemark extracts the endpoint labels written by encodeRemark, it returns nil if there are none.
+func decodeRemark(remark string) endpoint.Labels {
+	parts := strings.Split(remark, remarkSeparator)
+	if len(parts) < 2 || !isManagedRemarkHead(parts[0]) {
+		return nil
+	}
+	labels := endpoint.Labels{}
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		for _, k := range remarkLabelKeys {
+			if k == key {
+				labels[key] = value
+				break
+			}
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
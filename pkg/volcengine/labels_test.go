@@ -0,0 +1,116 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestEncodeRemark(t *testing.T) {
+	cases := []struct {
+		name     string
+		labels   endpoint.Labels
+		expected string
+	}{{
+		name:     "no labels",
+		labels:   nil,
+		expected: defaultRecordRemark,
+	}, {
+		name: "owner and resource",
+		labels: endpoint.Labels{
+			endpoint.OwnerLabelKey:    "default",
+			endpoint.ResourceLabelKey: "service/default/nginx",
+			"unknown":                 "ignored",
+		},
+		expected: "managed by external-dns; owner=default; resource=service/default/nginx",
+	}, {
+		name: "label too long",
+		labels: endpoint.Labels{
+			endpoint.OwnerLabelKey:    "default",
+			endpoint.ResourceLabelKey: strings.Repeat("a", maxRemarkLength),
+		},
+		expected: "managed by external-dns; owner=default",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, (&Provider{}).encodeRemark(tc.labels, ""))
+		})
+	}
+}
+
+func TestDecodeRemark(t *testing.T) {
+	labels := endpoint.Labels{
+		endpoint.OwnerLabelKey:    "default",
+		endpoint.ResourceLabelKey: "ingress/default/nginx",
+	}
+	assert.Equal(t, labels, decodeRemark((&Provider{}).encodeRemark(labels, "")))
+	assert.Nil(t, decodeRemark(defaultRecordRemark))
+	assert.Nil(t, decodeRemark("edited by hand; owner=someone"))
+}
+
+func TestRemarkSetIdentifier(t *testing.T) {
+	labels := endpoint.Labels{endpoint.OwnerLabelKey: "default"}
+	remark := (&Provider{}).encodeRemark(labels, "blue")
+	assert.Equal(t, "managed by external-dns; set-identifier=blue; owner=default", remark)
+	assert.Equal(t, "blue", remarkSetIdentifier(remark))
+	assert.Equal(t, labels, decodeRemark(remark))
+	assert.Equal(t, "", remarkSetIdentifier((&Provider{}).encodeRemark(labels, "")))
+	assert.Equal(t, "", remarkSetIdentifier("edited by hand; set-identifier=blue"))
+
+	// the set identifier is kept when labels are dropped
+	remark = (&Provider{}).encodeRemark(endpoint.Labels{endpoint.ResourceLabelKey: strings.Repeat("a", maxRemarkLength)}, "green")
+	assert.Equal(t, "managed by external-dns; set-identifier=green", remark)
+}
+
+func TestMergeRemark(t *testing.T) {
+	managed := "managed by external-dns; owner=default"
+	cases := []struct {
+		name     string
+		existing string
+		expected string
+	}{{
+		name:     "no remark",
+		existing: "",
+		expected: managed,
+	}, {
+		name:     "managed parts are replaced",
+		existing: "managed by external-dns; owner=old; set-identifier=blue",
+		expected: managed,
+	}, {
+		name:     "added context is kept",
+		existing: "managed by external-dns; owner=old; ticket OPS-1; contact=dns-team",
+		expected: managed + "; ticket OPS-1; contact=dns-team",
+	}, {
+		name:     "remark replaced by hand",
+		existing: "legacy endpoint, do not remove",
+		expected: managed + "; legacy endpoint, do not remove",
+	}, {
+		name:     "context too long",
+		existing: "short; " + strings.Repeat("a", maxRemarkLength),
+		expected: managed + "; short",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mergeRemark(tc.existing, managed))
+		})
+	}
+}
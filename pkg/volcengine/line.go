@@ -0,0 +1,93 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// ProviderSpecificLine sets the resolution line of the records of an endpoint, e.g. a region or ISP line of
+	// intelligent resolution. Endpoints of the same name on several lines need distinct set identifiers.
+	ProviderSpecificLine = "volcengine-privatezone/line"
+	// providerSpecificWebhookLine is the property of the external-dns.alpha.kubernetes.io/webhook-line annotation.
+	providerSpecificWebhookLine = "webhook/line"
+
+	// defaultLine resolves for every client that no other line matches.
+	defaultLine   = "default"
+	maxLineLength = 64
+)
+
+// endpointLine returns the resolution line of the endpoint records, empty for the default line.
+func endpointLine(ep *endpoint.Endpoint) (string, error) {
+	line, ok := lineProperty.get(ep)
+	if !ok || line == defaultLine {
+		return "", nil
+	}
+	if err := validateLine(line); err != nil {
+		return "", fmt.Errorf("invalid line of %s: %v", ep.DNSName, err)
+	}
+	return line, nil
+}
+
+// validateLine checks a line name: letters, digits, '-' and '_'.
+func validateLine(line string) error {
+	if line == "" || len(line) > maxLineLength {
+		return fmt.Errorf("line %q must have 1 to %d characters", line, maxLineLength)
+	}
+	for _, c := range line {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return fmt.Errorf("line %q contains invalid character %q", line, c)
+		}
+	}
+	return nil
+}
+
+// recordLine returns the line of a listed record, empty for the default line.
+func recordLine(line string) string {
+	if line == defaultLine {
+		return ""
+	}
+	return line
+}
+
+// updateLine returns the line to update the record with, empty keeps the line of the record.
+func updateLine(record *privatezone.RecordForListRecordsOutput, line string) string {
+	if recordLine(volcengine.StringValue(record.Line)) == line {
+		return ""
+	}
+	if line == "" {
+		return defaultLine
+	}
+	return line
+}
+
+// adjustLine sets the line of the endpoint the way Records returns it: under ProviderSpecificLine,
+// and not at all for the default line.
+func adjustLine(ep *endpoint.Endpoint) {
+	line, err := endpointLine(ep)
+	if err != nil {
+		// invalid lines are reported when the endpoint is applied
+		return
+	}
+	lineProperty.set(ep, line)
+}
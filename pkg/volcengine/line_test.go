@@ -0,0 +1,100 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestEndpointLine(t *testing.T) {
+	line, err := endpointLine(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"))
+	require.NoError(t, err)
+	assert.Empty(t, line)
+
+	line, err = endpointLine(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificLine, defaultLine))
+	require.NoError(t, err)
+	assert.Empty(t, line)
+
+	line, err = endpointLine(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificLine, "cn-beijing"))
+	require.NoError(t, err)
+	assert.Equal(t, "cn-beijing", line)
+
+	for _, value := range []string{"", "north china", "line/1"} {
+		ep := endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificLine, value)
+		assert.ErrorContains(t, validateEndpoint(ep, false), "invalid line", value)
+	}
+}
+
+func TestZoneRecordsToEndpointsLine(t *testing.T) {
+	zone := &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")}
+	records := []*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), Line: volcengine.String(defaultLine)},
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.5"), Line: volcengine.String("telecom"),
+			Remark: volcengine.String((&Provider{}).encodeRemark(nil, "telecom"))},
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.6"), Line: volcengine.String("unicom"),
+			Remark: volcengine.String((&Provider{}).encodeRemark(nil, "unicom"))},
+	}
+
+	// the records of every line are an endpoint of their own, not merged targets of one endpoint
+	endpoints := zoneRecordsToEndpoints(zone, records, false, TargetDotPreserve, txtEscaping{})
+	require.Len(t, endpoints, 3)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, endpoints[0].Targets)
+	assert.Empty(t, endpoints[0].ProviderSpecific)
+	assert.Equal(t, "telecom", endpoints[1].SetIdentifier)
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: ProviderSpecificLine, Value: "telecom"}}, endpoints[1].ProviderSpecific)
+	assert.Equal(t, endpoint.Targets{"1.2.3.6"}, endpoints[2].Targets)
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: ProviderSpecificLine, Value: "unicom"}}, endpoints[2].ProviderSpecific)
+}
+
+func TestCreateAndUpdateRecordLine(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI}
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 2 &&
+			volcengine.StringValue(records[0].Line) == "telecom" &&
+			records[1].Line == nil
+	})).Return(nil)
+	err := p.createPrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithSetIdentifier("telecom").WithProviderSpecific(ProviderSpecificLine, "telecom"),
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.5"),
+	})
+	require.NoError(t, err)
+
+	records := []*privatezone.RecordForListRecordsOutput{{
+		RecordID: volcengine.String("r1"),
+		Host:     volcengine.String("www"),
+		Type:     volcengine.String("A"),
+		Value:    volcengine.String("1.2.3.4"),
+		TTL:      volcengine.Int32(300),
+		Line:     volcengine.String("telecom"),
+	}}
+	// moved to the default line, which has to be set explicitly
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "r1", "www", "A", "1.2.3.4", int32(300), int32(0), defaultLine, mock.Anything).Return(nil).Once()
+	p.updateRecordSet(ctx, 123, "www", endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"), records)
+
+	// the line is unchanged, nothing is written
+	p.updateRecordSet(ctx, 123, "www", endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificLine, "telecom"), records)
+	mockAPI.AssertExpectations(t)
+}
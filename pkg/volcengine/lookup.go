@@ -0,0 +1,144 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// ZoneMatch is the private zone a DNS name maps to and the host of its records in that zone.
+type ZoneMatch struct {
+	ZID      int64
+	ZoneName string
+	Host     string
+}
+
+// MatchZone returns the zone whose name is the longest suffix of dnsName, the same way changes are mapped to zones.
+// ok is false when no zone matches.
+func MatchZone(zones []*privatezone.ZoneForListPrivateZonesOutput, dnsName string) (match ZoneMatch, ok bool) {
+	zoneMap := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneMap.Add(strconv.FormatInt(int64(volcengine.Int32Value(zone.ZID)), 10), volcengine.StringValue(zone.ZoneName))
+	}
+	dnsName = normalizeDomain(dnsName)
+	zid, zoneName := zoneMap.FindZone(dnsName)
+	if zid == "" {
+		return ZoneMatch{}, false
+	}
+	match.ZID, _ = strconv.ParseInt(zid, 10, 64)
+	match.ZoneName = zoneName
+	match.Host, _ = splitDNSName(dnsName, zoneName)
+	return match, true
+}
+
+// RecordValues returns the values of the enabled records with the host and type, soft deleted records are skipped.
+func RecordValues(records []*privatezone.RecordForListRecordsOutput, host, recordType string) []string {
+	var values []string
+	for _, record := range removeTombstones(records) {
+		if volcengine.StringValue(record.Host) != host || volcengine.StringValue(record.Type) != recordType {
+			continue
+		}
+		if record.Enable != nil && !*record.Enable {
+			continue
+		}
+		values = append(values, volcengine.StringValue(record.Value))
+	}
+	return values
+}
+
+// ManagedName is a name and record type with records written by the webhook, and the values of its enabled records.
+type ManagedName struct {
+	Name   string
+	Host   string
+	Type   string
+	Values []string
+}
+
+// ManagedNames returns the names and types of the zone with at least one enabled record written by the webhook,
+// ordered by name and type. The values of every enabled record of the name and type are returned, as a resolver
+// answers them all whoever wrote them.
+func ManagedNames(zoneName string, records []*privatezone.RecordForListRecordsOutput) []ManagedName {
+	records = removeTombstones(records)
+	seen := make(map[string]bool)
+	var names []ManagedName
+	for _, record := range records {
+		if record.Enable != nil && !*record.Enable || !IsManagedRemark(volcengine.StringValue(record.Remark)) {
+			continue
+		}
+		host, recordType := volcengine.StringValue(record.Host), volcengine.StringValue(record.Type)
+		if seen[recordType+":"+host] {
+			continue
+		}
+		seen[recordType+":"+host] = true
+		names = append(names, ManagedName{
+			Name:   getDNSName(host, zoneName),
+			Host:   host,
+			Type:   recordType,
+			Values: RecordValues(records, host, recordType),
+		})
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Name != names[j].Name {
+			return names[i].Name < names[j].Name
+		}
+		return names[i].Type < names[j].Type
+	})
+	return names
+}
+
+// CompareRecordValues compares record values of the zone with the values a resolver answered,
+// after normalizing both sides: case and trailing dots of names, and quotes of TXT values.
+// It returns the expected values missing from the answer and the answered values not in the zone.
+func CompareRecordValues(recordType string, expected, answered []string) (missing, unexpected []string) {
+	want := make(map[string]bool, len(expected))
+	for _, value := range expected {
+		want[normalizeRecordValue(recordType, value)] = true
+	}
+	got := make(map[string]bool, len(answered))
+	for _, value := range answered {
+		value = normalizeRecordValue(recordType, value)
+		got[value] = true
+		if !want[value] {
+			unexpected = append(unexpected, value)
+		}
+	}
+	for value := range want {
+		if !got[value] {
+			missing = append(missing, value)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unexpected)
+	return missing, unexpected
+}
+
+func normalizeRecordValue(recordType, value string) string {
+	value = strings.TrimSpace(value)
+	switch recordType {
+	case "TXT":
+		return strings.Trim(value, "\"")
+	case "A", "AAAA":
+		return value
+	default:
+		return strings.ToLower(normalizeDomain(value))
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+func TestMatchZone(t *testing.T) {
+	zones := []*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(1), ZoneName: volcengine.String("example.internal")},
+		{ZID: volcengine.Int32(2), ZoneName: volcengine.String("foo.example.internal")},
+	}
+
+	match, ok := MatchZone(zones, "www.foo.example.internal")
+	assert.True(t, ok)
+	assert.Equal(t, ZoneMatch{ZID: 2, ZoneName: "foo.example.internal", Host: "www"}, match)
+
+	match, ok = MatchZone(zones, "a.b.example.internal.")
+	assert.True(t, ok)
+	assert.Equal(t, ZoneMatch{ZID: 1, ZoneName: "example.internal", Host: "a.b"}, match)
+
+	match, ok = MatchZone(zones, "example.internal")
+	assert.True(t, ok)
+	assert.Equal(t, "@", match.Host)
+
+	_, ok = MatchZone(zones, "www.other.internal")
+	assert.False(t, ok)
+}
+
+func TestRecordValues(t *testing.T) {
+	records := []*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.1")},
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.2"), Enable: volcengine.Bool(false)},
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.3"), Remark: volcengine.String("deleted-at=2025-01-01T00:00:00Z")},
+		{Host: volcengine.String("www"), Type: volcengine.String("TXT"), Value: volcengine.String("heritage=external-dns")},
+		{Host: volcengine.String("api"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.4")},
+	}
+	assert.Equal(t, []string{"10.0.0.1"}, RecordValues(records, "www", "A"))
+}
+
+func TestCompareRecordValues(t *testing.T) {
+	missing, unexpected := CompareRecordValues("A", []string{"10.0.0.1", "10.0.0.2"}, []string{"10.0.0.2", "10.0.0.3"})
+	assert.Equal(t, []string{"10.0.0.1"}, missing)
+	assert.Equal(t, []string{"10.0.0.3"}, unexpected)
+
+	missing, unexpected = CompareRecordValues("CNAME", []string{"Target.Example.com"}, []string{"target.example.com."})
+	assert.Empty(t, missing)
+	assert.Empty(t, unexpected)
+
+	missing, unexpected = CompareRecordValues("TXT", []string{"\"heritage=external-dns\""}, []string{"heritage=external-dns"})
+	assert.Empty(t, missing)
+	assert.Empty(t, unexpected)
+}
+
+func TestManagedNames(t *testing.T) {
+	record := func(host, recordType, value, remark string, enable bool) *privatezone.RecordForListRecordsOutput {
+		return &privatezone.RecordForListRecordsOutput{
+			Host:   volcengine.String(host),
+			Type:   volcengine.String(recordType),
+			Value:  volcengine.String(value),
+			Remark: volcengine.String(remark),
+			Enable: volcengine.Bool(enable),
+		}
+	}
+	names := ManagedNames("example.internal", []*privatezone.RecordForListRecordsOutput{
+		record("www", "A", "1.1.1.1", defaultRecordRemark, true),
+		record("www", "A", "2.2.2.2", "", true),
+		record("www", "A", "3.3.3.3", defaultRecordRemark, false),
+		record("@", "TXT", "heritage=external-dns", (&Provider{}).encodeRemark(nil, "blue"), true),
+		record("legacy", "A", "4.4.4.4", "created by hand", true),
+		record("off", "A", "5.5.5.5", defaultRecordRemark, false),
+	})
+	assert.Equal(t, []ManagedName{
+		{Name: "example.internal", Host: "@", Type: "TXT", Values: []string{"heritage=external-dns"}},
+		{Name: "www.example.internal", Host: "www", Type: "A", Values: []string{"1.1.1.1", "2.2.2.2"}},
+	}, names)
+}
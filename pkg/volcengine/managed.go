@@ -0,0 +1,52 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ManagedRecordGuard restricts deletes and updates to the records written by external-dns, whose remark starts with
+// the default record remark, so records created by hand or by other tools in a shared zone are never touched.
+type ManagedRecordGuard struct {
+	Enabled bool
+	// Owner additionally requires the owner label written to the remark to match, e.g. the external-dns
+	// --txt-owner-id, so instances sharing a zone leave the records of each other alone.
+	Owner string
+}
+
+// manages reports whether the guard allows the record to be deleted or updated.
+func (g ManagedRecordGuard) manages(record *privatezone.RecordForListRecordsOutput) bool {
+	if !g.Enabled {
+		return true
+	}
+	remark := volcengine.StringValue(record.Remark)
+	if first, _, _ := strings.Cut(remark, remarkSeparator); !isManagedRemarkHead(first) {
+		return false
+	}
+	return g.Owner == "" || decodeRemark(remark)[endpoint.OwnerLabelKey] == g.Owner
+}
+
+// skipUnmanaged logs that the record is left alone because the guard does not allow the action.
+func (p *Provider) skipUnmanaged(action string, zoneID int64, record *privatezone.RecordForListRecordsOutput) {
+	p.logger().Warnf("Skipping %s of record %s not managed by external-dns: host: %s, type: %s, value: %s, remark: %q, zid: %d",
+		action, volcengine.StringValue(record.RecordID), volcengine.StringValue(record.Host), volcengine.StringValue(record.Type),
+		volcengine.StringValue(record.Value), volcengine.StringValue(record.Remark), zoneID)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestManagedRecordGuard(t *testing.T) {
+	record := func(remark string) *privatezone.RecordForListRecordsOutput {
+		return &privatezone.RecordForListRecordsOutput{Remark: volcengine.String(remark)}
+	}
+	owned := (&Provider{}).encodeRemark(endpoint.Labels{endpoint.OwnerLabelKey: "cluster-a"}, "")
+
+	assert.True(t, ManagedRecordGuard{}.manages(record("created by hand")))
+	guard := ManagedRecordGuard{Enabled: true}
+	assert.True(t, guard.manages(record(defaultRecordRemark)))
+	assert.True(t, guard.manages(record(owned)))
+	assert.False(t, guard.manages(record("created by hand")))
+	assert.False(t, guard.manages(record("")))
+	assert.False(t, guard.manages(record("note: "+defaultRecordRemark)))
+
+	guard.Owner = "cluster-a"
+	assert.True(t, guard.manages(record(owned)))
+	assert.True(t, guard.manages(record(tombstoneRemark(owned, time.Now()))))
+	assert.False(t, guard.manages(record(defaultRecordRemark)))
+	assert.False(t, guard.manages(record((&Provider{}).encodeRemark(endpoint.Labels{endpoint.OwnerLabelKey: "cluster-b"}, ""))))
+}
+
+func TestProviderManagedRecordGuard(t *testing.T) {
+	records := []*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("1"), Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1"),
+			Remark: volcengine.String(defaultRecordRemark)},
+		{RecordID: volcengine.String("2"), Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("2.2.2.2"),
+			Remark: volcengine.String("created by hand")},
+		{RecordID: volcengine.String("3"), Host: volcengine.String("api"), Type: volcengine.String("A"), Value: volcengine.String("3.3.3.3"),
+			TTL: volcengine.Int32(600), Remark: volcengine.String("created by hand")},
+		{RecordID: volcengine.String("4"), Host: volcengine.String("api"), Type: volcengine.String("A"), Value: volcengine.String("4.4.4.4"),
+			TTL: volcengine.Int32(600), Remark: volcengine.String("created by hand")},
+	}
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(records, nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "api", "A").Return(records[2:], nil)
+	// only the managed record is deleted
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"1"}).Return(nil).Once()
+	// the hand made records are neither rewritten to the new target nor updated, the new target is created
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "api", "A", "5.5.5.5", int32(60), int32(0), "", defaultRecordRemark).Return(nil).Once()
+
+	c := defaultConfig()
+	WithManagedRecordGuard("")(c)
+	provider, err := newProvider(c)
+	require.NoError(t, err)
+	provider.pzClient, provider.privateZone, provider.vpcID = mockAPI, true, "vpc-123"
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.1.1.1", "2.2.2.2")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("api.example.com", "A", 600, "3.3.3.3", "4.4.4.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("api.example.com", "A", 60, "3.3.3.3", "5.5.5.5")},
+	})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "UpdatePrivateZoneRecord", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAPI.AssertNotCalled(t, "DeletePrivateZoneRecordById", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// metricsHandlerName names the request handler observing the API call metrics.
+const metricsHandlerName = "volcengine-provider.Metrics"
+
+const metricsNamespace = "volcengine_privatezone"
+
+var (
+	// zonesDiscovered is the number of private zones bound to the VPC in the last listing, before domain filters.
+	zonesDiscovered = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "zones",
+		Help:      "Number of private zones bound to the VPC in the last listing.",
+	}, []string{"vpc"})
+
+	// zoneSyncDuration is the time it takes to list and convert the records of one zone in Records.
+	zoneSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "zone_sync_duration_seconds",
+		Help:      "Time to list the records of a zone and convert them to endpoints for external-dns.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"zone", "result"})
+
+	// recordsManaged is the number of record targets returned to external-dns by the last Records.
+	recordsManaged = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "records",
+		Help:      "Number of records returned to external-dns by the last listing.",
+	}, []string{"vpc"})
+
+	// applyFailures counts the failed ApplyChanges by ChangeError code.
+	applyFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "apply_failures_total",
+		Help:      "Number of failed syncs by error code.",
+	}, []string{"code"})
+
+	// throttledCalls counts the API calls that waited for the read or write rate limiter.
+	throttledCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "throttled_calls_total",
+		Help:      "Number of API calls held back by the read or write rate limit.",
+	}, []string{"class"})
+
+	// apiCalls counts the completed API calls, including their retries, by service, operation and result.
+	apiCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_calls_total",
+		Help:      "Number of Volcengine API calls by operation and result.",
+	}, []string{"service", "operation", "result"})
+
+	// apiCallDuration is the time of the API calls, including their retries.
+	apiCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_call_duration_seconds",
+		Help:      "Time of Volcengine API calls by operation.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"service", "operation"})
+
+	// apiErrors counts the failed API calls by service, operation and API error code.
+	apiErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_errors_total",
+		Help:      "Number of failed Volcengine API calls by operation and error code.",
+	}, []string{"service", "operation", "code"})
+
+	// recordChanges counts the records created, updated and deleted by ApplyChanges.
+	recordChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "record_changes_total",
+		Help:      "Number of endpoints created, updated or deleted by the webhook.",
+	}, []string{"action"})
+)
+
+func init() {
+	prometheus.MustRegister(zonesDiscovered, zoneSyncDuration, recordsManaged, applyFailures, throttledCalls,
+		apiCalls, apiCallDuration, apiErrors, recordChanges)
+}
+
+// installAPIMetrics observes every completed API call of the handlers, after its retries.
+func installAPIMetrics(handlers *request.Handlers) {
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: metricsHandlerName,
+		Fn: func(req *request.Request) {
+			service, operation := req.ClientInfo.ServiceName, ""
+			if req.Operation != nil {
+				operation = req.Operation.Name
+			}
+			apiCallDuration.WithLabelValues(service, operation).Observe(time.Since(req.Time).Seconds())
+			if req.Error == nil {
+				apiCalls.WithLabelValues(service, operation, "success").Inc()
+				return
+			}
+			apiCalls.WithLabelValues(service, operation, "error").Inc()
+			code := "Unknown"
+			var apiErr volcengineerr.Error
+			if errors.As(req.Error, &apiErr) {
+				code = apiErr.Code()
+			}
+			apiErrors.WithLabelValues(service, operation, code).Inc()
+		},
+	})
+}
+
+// observeRecordChanges counts n endpoints applied by action.
+func observeRecordChanges(action string, n int) {
+	if n > 0 {
+		recordChanges.WithLabelValues(action).Add(float64(n))
+	}
+}
+
+// observeZones records the number of zones listed for the VPC.
+func observeZones(vpc string, zones int) {
+	zonesDiscovered.WithLabelValues(vpc).Set(float64(zones))
+}
+
+// observeZoneSync records the duration of a zone listing and conversion started at start.
+func observeZoneSync(zone string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	zoneSyncDuration.WithLabelValues(zone, result).Observe(time.Since(start).Seconds())
+}
+
+// observeRecords records the number of record targets of the endpoints returned for the VPC.
+func observeRecords(vpc string, endpoints []*endpoint.Endpoint) {
+	n := 0
+	for _, ep := range endpoints {
+		n += len(ep.Targets)
+	}
+	recordsManaged.WithLabelValues(vpc).Set(float64(n))
+}
+
+// observeApplyFailure counts a failed ApplyChanges by the code of its ChangeError.
+func observeApplyFailure(err error) {
+	if err == nil {
+		return
+	}
+	code := "InternalError"
+	var changeErr *ChangeError
+	if errors.As(err, &changeErr) {
+		code = changeErr.Code
+	}
+	applyFailures.WithLabelValues(code).Inc()
+}
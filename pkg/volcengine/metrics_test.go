@@ -0,0 +1,109 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/client/metadata"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestRecordsMetrics(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-metrics").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(1), ZoneName: volcengine.String("metrics-a.com")},
+		{ZID: volcengine.Int32(2), ZoneName: volcengine.String("metrics-b.com")},
+		{ZID: volcengine.Int32(3), ZoneName: volcengine.String("filtered.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(1)).Return([]*privatezone.RecordForListRecordsOutput{}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(2)).Return([]*privatezone.RecordForListRecordsOutput(nil), errors.New("boom"))
+
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-metrics"}
+	p.domainFilter.Filters = []string{"metrics-a.com", "metrics-b.com"}
+	_, err := p.Records(context.Background())
+	assert.Error(t, err)
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(zonesDiscovered.WithLabelValues("vpc-metrics")))
+	assert.Equal(t, uint64(1), sampleCount(t, zoneSyncDuration.WithLabelValues("metrics-a.com", "success")))
+	assert.Equal(t, uint64(1), sampleCount(t, zoneSyncDuration.WithLabelValues("metrics-b.com", "error")))
+	assert.False(t, zoneSyncDuration.DeleteLabelValues("filtered.com", "success"), "filtered zones are not listed")
+}
+
+func sampleCount(t *testing.T, o prometheus.Observer) uint64 {
+	m := &dto.Metric{}
+	require.NoError(t, o.(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestSyncMetrics(t *testing.T) {
+	observeRecords("vpc-sync", []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.sync.com", "A", "1.1.1.1", "2.2.2.2"),
+		endpoint.NewEndpoint("b.sync.com", "CNAME", "a.sync.com"),
+	})
+	assert.Equal(t, float64(3), testutil.ToFloat64(recordsManaged.WithLabelValues("vpc-sync")))
+
+	before := testutil.ToFloat64(applyFailures.WithLabelValues(ErrCodeCreateFailed))
+	observeApplyFailure(nil)
+	observeApplyFailure(newChangeError(ErrCodeCreateFailed, errors.New("boom")))
+	assert.Equal(t, before+1, testutil.ToFloat64(applyFailures.WithLabelValues(ErrCodeCreateFailed)))
+
+	before = testutil.ToFloat64(throttledCalls.WithLabelValues("write"))
+	limiter := RateLimit{QPS: 1000, Burst: 1}.limiter()
+	require.NoError(t, wait(context.Background(), limiter, "write"))
+	require.NoError(t, wait(context.Background(), limiter, "write"))
+	assert.Equal(t, before+1, testutil.ToFloat64(throttledCalls.WithLabelValues("write")))
+}
+
+func TestAPIMetrics(t *testing.T) {
+	handlers := request.Handlers{}
+	installAPIMetrics(&handlers)
+	call := func(err error) {
+		handlers.Complete.Run(&request.Request{
+			ClientInfo: metadata.ClientInfo{ServiceName: "privatezone"},
+			Operation:  &request.Operation{Name: "ListRecords"},
+			Time:       time.Now(),
+			Error:      err,
+		})
+	}
+	success := testutil.ToFloat64(apiCalls.WithLabelValues("privatezone", "ListRecords", "success"))
+	failed := testutil.ToFloat64(apiCalls.WithLabelValues("privatezone", "ListRecords", "error"))
+	throttled := testutil.ToFloat64(apiErrors.WithLabelValues("privatezone", "ListRecords", "Throttling"))
+
+	call(nil)
+	call(volcengineerr.New("Throttling", "slow down", nil))
+	assert.Equal(t, success+1, testutil.ToFloat64(apiCalls.WithLabelValues("privatezone", "ListRecords", "success")))
+	assert.Equal(t, failed+1, testutil.ToFloat64(apiCalls.WithLabelValues("privatezone", "ListRecords", "error")))
+	assert.Equal(t, throttled+1, testutil.ToFloat64(apiErrors.WithLabelValues("privatezone", "ListRecords", "Throttling")))
+
+	before := testutil.ToFloat64(recordChanges.WithLabelValues("create"))
+	observeRecordChanges("create", 3)
+	observeRecordChanges("create", 0)
+	assert.Equal(t, before+3, testutil.ToFloat64(recordChanges.WithLabelValues("create")))
+}
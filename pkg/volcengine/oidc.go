@@ -0,0 +1,59 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// CheckOIDCTokenFile checks that the OIDC token file exists, is readable and holds a JWT that has not expired,
+// so a broken projected service account token mount is reported before the STS calls start failing.
+// Tokens without an exp claim are not checked for expiry.
+func CheckOIDCTokenFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("oidc token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return fmt.Errorf("oidc token file %s is empty", path)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("oidc token file %s does not hold a JWT", path)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return fmt.Errorf("oidc token file %s: invalid JWT payload: %w", path, err)
+	}
+	var claims struct {
+		Exp *int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return fmt.Errorf("oidc token file %s: invalid JWT claims: %w", path, err)
+	}
+	if claims.Exp != nil {
+		if exp := time.Unix(*claims.Exp, 0); !time.Now().Before(exp) {
+			return fmt.Errorf("oidc token in %s expired at %s", path, exp.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeToken(t *testing.T, claims string) string {
+	t.Helper()
+	enc := base64.RawURLEncoding
+	token := enc.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".signature"
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte(token+"\n"), 0o600))
+	return path
+}
+
+func TestCheckOIDCTokenFile(t *testing.T) {
+	valid := writeToken(t, fmt.Sprintf(`{"sub":"system:serviceaccount:default:external-dns","exp":%d}`, time.Now().Add(time.Hour).Unix()))
+	assert.NoError(t, CheckOIDCTokenFile(valid))
+
+	assert.NoError(t, CheckOIDCTokenFile(writeToken(t, `{"sub":"external-dns"}`)))
+
+	expired := writeToken(t, fmt.Sprintf(`{"exp":%d}`, time.Now().Add(-time.Minute).Unix()))
+	assert.ErrorContains(t, CheckOIDCTokenFile(expired), "expired at")
+
+	assert.ErrorContains(t, CheckOIDCTokenFile(filepath.Join(t.TempDir(), "missing")), "no such file")
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	assert.ErrorContains(t, CheckOIDCTokenFile(empty), "is empty")
+
+	notJWT := filepath.Join(t.TempDir(), "opaque")
+	require.NoError(t, os.WriteFile(notJWT, []byte("opaque-token"), 0o600))
+	assert.ErrorContains(t, CheckOIDCTokenFile(notJWT), "does not hold a JWT")
+
+	assert.ErrorContains(t, CheckOIDCTokenFile(writeToken(t, `not json`)), "invalid JWT claims")
+}
@@ -16,8 +16,10 @@
 package volcengine
 
 import (
+	"net/http"
 	"strings"
-	
+	"time"
+
 	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
 )
 
@@ -29,12 +31,33 @@ func WithPrivateZone(region, vpcId string) Option {
 	}
 }
 
+// WithRegions manages the private zones of several regions in one provider, the first region replaces the region
+// of WithPrivateZone when that is empty.
+func WithRegions(regions ...Region) Option {
+	return func(c *Config) {
+		c.Regions = regions
+	}
+}
+
 func WithPrivateZoneEndpoint(endpoint string) Option {
 	return func(c *Config) {
 		c.PrivateZoneEndpoint = endpoint
 	}
 }
 
+// WithDNSMode selects whether the private zones, the public CloudDNS zones or both are managed.
+func WithDNSMode(mode DNSMode) Option {
+	return func(c *Config) {
+		c.DNSMode = mode
+	}
+}
+
+func WithCloudDNSEndpoint(endpoint string) Option {
+	return func(c *Config) {
+		c.CloudDNSEndpoint = endpoint
+	}
+}
+
 func WithStaticCredentials(accessKey, secretKey string) Option {
 	return func(c *Config) {
 		c.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, "")
@@ -42,18 +65,45 @@ func WithStaticCredentials(accessKey, secretKey string) Option {
 }
 
 func WithOIDCCredentials(stsEndpoint, oidcRoleTrn, oidcTokenFilePath string) Option {
-	if stsEndpoint == "" {
-		stsEndpoint = defaultStsEndpoint
+	return func(c *Config) {
+		c.Credentials = credentials.NewCredentials(newOIDCProvider(stsEndpoint, oidcRoleTrn, oidcTokenFilePath))
 	}
+}
+
+// WithInstanceRoleCredentials uses the credentials of the ECS instance role from the instance metadata service,
+// refreshed before they expire. An empty roleName uses the role attached to the instance.
+func WithInstanceRoleCredentials(roleName string) Option {
 	return func(c *Config) {
-		p := credentials.NewOIDCCredentialsProviderFromEnv()
-		p.OIDCTokenFilePath = oidcTokenFilePath
-		p.RoleTrn = oidcRoleTrn
-		p.Endpoint = stsEndpoint
-		p.RoleSessionName = "external-dns"
+		c.Credentials = NewInstanceMetadataCredentials(roleName)
+	}
+}
+
+// WithAssumeRole assumes the role through STS AssumeRole with the configured credentials and uses the role
+// credentials instead, renewed before they expire, e.g. for a role of the account owning the zones.
+// An empty sessionName defaults to external-dns, an empty stsEndpoint to DefaultStsEndpoint.
+func WithAssumeRole(roleTrn, sessionName, stsEndpoint string) Option {
+	return func(c *Config) {
+		c.AssumeRole = &AssumeRole{RoleTrn: roleTrn, SessionName: sessionName, StsEndpoint: stsEndpoint}
+	}
+}
+
+// WithCredentials sets credentials built by the caller, e.g. NewSecretCredentials.
+func WithCredentials(credentials *credentials.Credentials) Option {
+	return func(c *Config) {
+		c.Credentials = credentials
+	}
+}
 
-		c.Credentials = credentials.NewCredentials(p)
+func newOIDCProvider(stsEndpoint, oidcRoleTrn, oidcTokenFilePath string) *credentials.OIDCCredentialsProvider {
+	if stsEndpoint == "" {
+		stsEndpoint = DefaultStsEndpoint
 	}
+	p := credentials.NewOIDCCredentialsProviderFromEnv()
+	p.OIDCTokenFilePath = oidcTokenFilePath
+	p.RoleTrn = oidcRoleTrn
+	p.Endpoint = stsEndpoint
+	p.RoleSessionName = "external-dns"
+	return p
 }
 
 func WithDomainFilter(domainFilter string) Option {
@@ -61,3 +111,205 @@ func WithDomainFilter(domainFilter string) Option {
 		c.DomainFilter = strings.Split(domainFilter, ",")
 	}
 }
+
+// WithExcludeDomains excludes the comma separated domains, and their subdomains, from the managed zones.
+func WithExcludeDomains(excludeDomains string) Option {
+	return func(c *Config) {
+		c.ExcludeDomains = strings.Split(excludeDomains, ",")
+	}
+}
+
+// WithLogger sets the logger used by the provider and its API clients instead of the logrus standard logger.
+func WithLogger(logger Logger) Option {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+// WithSoftDelete disables deleted records instead of removing them, tombstones are kept for retention.
+func WithSoftDelete(retention time.Duration) Option {
+	return func(c *Config) {
+		c.SoftDelete = true
+		if retention > 0 {
+			c.TombstoneRetention = retention
+		}
+	}
+}
+
+// WithCache serves zone and record listings from memory for ttl, and snapshots them to file when it is set.
+func WithCache(ttl time.Duration, file string) Option {
+	return func(c *Config) {
+		c.CacheTTL = ttl
+		c.CacheFile = file
+	}
+}
+
+// WithCacheRefresh serves cached listings older than refreshAfter while refreshing them in the background,
+// so Records does not wait for the API until the cache TTL expired.
+func WithCacheRefresh(refreshAfter time.Duration) Option {
+	return func(c *Config) {
+		c.CacheRefreshAfter = refreshAfter
+	}
+}
+
+// WithDryRun logs the record writes of ApplyChanges instead of performing them.
+func WithDryRun() Option {
+	return func(c *Config) {
+		c.DryRun = true
+	}
+}
+
+// WithRetryPolicy retries failed API calls with the backoff of policy instead of the SDK defaults.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Config) {
+		c.RetryPolicy = &policy
+	}
+}
+
+// WithRateLimits throttles list API calls with read and mutating API calls with write.
+func WithRateLimits(read, write RateLimit) Option {
+	return func(c *Config) {
+		c.ReadRateLimit = read
+		c.WriteRateLimit = write
+	}
+}
+
+// WithDeletionGuard refuses ApplyChanges batches deleting more records than guard allows.
+func WithDeletionGuard(guard DeletionGuard) Option {
+	return func(c *Config) {
+		c.DeletionGuard = guard
+	}
+}
+
+// WithMaxConcurrentZoneQueries lists the records of at most n zones at the same time.
+func WithMaxConcurrentZoneQueries(n int) Option {
+	return func(c *Config) {
+		c.MaxConcurrentZoneQueries = n
+	}
+}
+
+// WithMaxConcurrentZoneWrites allows at most n mutating API calls in flight per zone, 0 is unlimited.
+func WithMaxConcurrentZoneWrites(n int) Option {
+	return func(c *Config) {
+		c.MaxConcurrentZoneWrites = n
+	}
+}
+
+// WithChangeBudget applies at most budget record creates and deletes per ApplyChanges, the rest is deferred.
+func WithChangeBudget(budget int) Option {
+	return func(c *Config) {
+		c.ChangeBudget = budget
+	}
+}
+
+// WithApplyChangesTimeout stops issuing API calls once an ApplyChanges ran for timeout, the remaining changes are
+// left to the next sync.
+func WithApplyChangesTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.ApplyChangesTimeout = timeout
+	}
+}
+
+// WithProtectedNames never deletes or overwrites records of the names, which may be shell patterns like *.example.com.
+func WithProtectedNames(names ...string) Option {
+	return func(c *Config) {
+		c.ProtectedNames = append(c.ProtectedNames, names...)
+	}
+}
+
+// WithManagedRecordGuard only deletes and updates records whose remark marks them as written by external-dns, and with
+// a non-empty owner only those written by the instance with the owner id.
+func WithManagedRecordGuard(owner string) Option {
+	return func(c *Config) {
+		c.ManagedRecordGuard = ManagedRecordGuard{Enabled: true, Owner: owner}
+	}
+}
+
+// WithRecordRemark writes remarks starting with the text/template, e.g.
+// "managed by external-dns cluster={{.Cluster}} owner={{.Owner}}", so clusters sharing a zone can tell their records
+// apart. The template must render a remark starting with "managed by external-dns".
+func WithRecordRemark(template, cluster string) Option {
+	return func(c *Config) {
+		c.RecordRemark = template
+		c.ClusterName = cluster
+	}
+}
+
+// WithRecorder writes every PrivateZone API request and response, sanitized, to the recorder.
+func WithRecorder(recorder *Recorder) Option {
+	return func(c *Config) {
+		c.Recorder = recorder
+	}
+}
+
+// WithIncludeDisabledRecords returns disabled records from Records annotated with ProviderSpecificDisabledTargets,
+// by default they are skipped.
+func WithIncludeDisabledRecords() Option {
+	return func(c *Config) {
+		c.IncludeDisabledRecords = true
+	}
+}
+
+// WithHiddenRegistryOwner hides the TXT registry records of the external-dns owner id from Records,
+// e.g. when the registry is managed outside of the webhook.
+func WithHiddenRegistryOwner(owner string) Option {
+	return func(c *Config) {
+		c.HiddenRegistryOwner = owner
+	}
+}
+
+// WithDefaultTTLs sets the TTLs of the records of endpoints without a TTL, by record type.
+func WithDefaultTTLs(ttls DefaultTTLs) Option {
+	return func(c *Config) {
+		c.DefaultTTLs = ttls
+	}
+}
+
+// WithTTLPolicy sets the TTL of the records of endpoints without a TTL and type default, and clamps every TTL written
+// to the range of the policy.
+func WithTTLPolicy(policy TTLPolicy) Option {
+	return func(c *Config) {
+		c.TTLs = policy
+	}
+}
+
+// WithZoneTTLPolicies overrides the TTL policy for the records of zones.
+func WithZoneTTLPolicies(zones ZoneTTLPolicies) Option {
+	return func(c *Config) {
+		c.ZoneTTLs = zones
+	}
+}
+
+// WithTargetDotPolicy sets the trailing dot of CNAME, MX, SRV and PTR targets, see TargetDotPolicy.
+func WithTargetDotPolicy(policy TargetDotPolicy) Option {
+	return func(c *Config) {
+		c.TargetDotPolicy = policy
+	}
+}
+
+// WithTXTEscapeMode sets how the quotes of TXT values are translated, see TXTEscapeMode.
+func WithTXTEscapeMode(mode TXTEscapeMode) Option {
+	return func(c *Config) {
+		c.TXTEscapeMode = mode
+	}
+}
+
+// WithTXTRegistryPrefixes replaces the prefixes recognizing the TXT registry values quoted in TXTEscapeAuto mode,
+// e.g. when the registry values do not start with DefaultTXTRegistryPrefix. Empty prefixes are ignored.
+func WithTXTRegistryPrefixes(prefixes ...string) Option {
+	return func(c *Config) {
+		c.TXTRegistryPrefixes = nil
+		for _, prefix := range prefixes {
+			if prefix = strings.TrimSpace(prefix); prefix != "" {
+				c.TXTRegistryPrefixes = append(c.TXTRegistryPrefixes, prefix)
+			}
+		}
+	}
+}
+
+// WithHeaders attaches static headers to every PrivateZone API request, e.g. gateway auth tokens or tenant IDs.
+func WithHeaders(headers http.Header) Option {
+	return func(c *Config) {
+		c.Headers = headers
+	}
+}
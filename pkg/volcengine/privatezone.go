@@ -16,17 +16,26 @@
 package volcengine
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
+	"volcengine-provider/pkg/utils"
+
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
 	"github.com/volcengine/volcengine-go-sdk/volcengine"
 	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
 	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
 	"github.com/volcengine/volcengine-go-sdk/volcengine/session"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
 )
 
 var (
@@ -36,6 +45,9 @@ var (
 	nullHostPrivateZone = "@"
 
 	defaultRecordRemark = "managed by external-dns"
+
+	// exactSearchMode makes ListRecords match the host exactly instead of by keyword
+	exactSearchMode = "exact"
 )
 
 type Record struct {
@@ -43,16 +55,27 @@ type Record struct {
 	Type   string `json:"type"`
 	TTL    int    `json:"ttl"`
 	Target string `json:"target"`
+	Remark string `json:"remark,omitempty"`
+	// Disabled records are not resolved
+	Disabled bool `json:"disabled,omitempty"`
+	// SetIdentifier of the endpoint the record belongs to
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// Weight of the record when the zone load balances weighted records
+	Weight int `json:"weight,omitempty"`
+	// Line the record resolves on, empty for the default line
+	Line string `json:"line,omitempty"`
 }
 
 type privateZoneAPI interface {
 	ListPrivateZones(ctx context.Context, vpcID string) ([]*privatezone.ZoneForListPrivateZonesOutput, error)
 	GetPrivateZoneRecords(ctx context.Context, zid int64) ([]*privatezone.RecordForListRecordsOutput, error)
-	CreatePrivateZoneRecord(ctx context.Context, zoneID int64, domain, recordType, target string, TTL int32) error
+	GetPrivateZoneRecordsByHostType(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error)
+	CreatePrivateZoneRecord(ctx context.Context, zoneID int64, domain, recordType, target string, TTL, weight int32, line, remark string) error
 	BatchCreatePrivateZoneRecord(ctx context.Context, zoneID int64, records []*privatezone.RecordForBatchCreateRecordInput) error
-	UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL int32) error
-	DeletePrivateZoneRecord(ctx context.Context, zoneID int64, host, recordType string, targets []string) error
+	UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL, weight int32, line, remark string) error
+	BatchDeletePrivateZoneRecords(ctx context.Context, zoneID int64, recordIDs []string) error
 	DeletePrivateZoneRecordById(ctx context.Context, zoneID int64, recordID string) error
+	DisablePrivateZoneRecord(ctx context.Context, zoneID int64, recordID, remark string) error
 }
 
 var _ privateZoneAPI = &PrivateZoneWrapper{}
@@ -66,50 +89,190 @@ type privateZoneClient interface {
 	BatchCreateRecordWithContext(ctx context.Context, input *privatezone.BatchCreateRecordInput, options ...request.Option) (*privatezone.BatchCreateRecordOutput, error)
 	BatchDeleteRecordWithContext(ctx context.Context, input *privatezone.BatchDeleteRecordInput, options ...request.Option) (*privatezone.BatchDeleteRecordOutput, error)
 	DeleteRecordWithContext(ctx context.Context, input *privatezone.DeleteRecordInput, options ...request.Option) (*privatezone.DeleteRecordOutput, error)
+	QueryPrivateZoneWithContext(ctx context.Context, input *privatezone.QueryPrivateZoneInput, options ...request.Option) (*privatezone.QueryPrivateZoneOutput, error)
+	UpdatePrivateZoneWithContext(ctx context.Context, input *privatezone.UpdatePrivateZoneInput, options ...request.Option) (*privatezone.UpdatePrivateZoneOutput, error)
+	CreatePrivateZoneWithContext(ctx context.Context, input *privatezone.CreatePrivateZoneInput, options ...request.Option) (*privatezone.CreatePrivateZoneOutput, error)
+	DeletePrivateZoneWithContext(ctx context.Context, input *privatezone.DeletePrivateZoneInput, options ...request.Option) (*privatezone.DeletePrivateZoneOutput, error)
+	IncBindVPCWithContext(ctx context.Context, input *privatezone.IncBindVPCInput, options ...request.Option) (*privatezone.IncBindVPCOutput, error)
 }
 
 // PrivateZoneWrapper is a wrapper for the privatezone API.
 type PrivateZoneWrapper struct {
 	// The client for the privatezone API.
 	client privateZoneClient
+	log    Logger
+	// throttling of list and mutating API calls
+	readLimit  RateLimit
+	writeLimit RateLimit
+	// mutating calls in flight per zone, 0 is unlimited
+	zoneWriteConcurrency int
+	// headers attached to every request
+	headers http.Header
+	// records every request and response when set
+	recorder *Recorder
+	// retry of failed calls, the SDK default retryer when nil
+	retry *RetryPolicy
+	// translation of TXT values when matching records to delete
+	txt txtEscaping
+}
+
+// PrivateZoneOption configures a PrivateZoneWrapper.
+type PrivateZoneOption func(*PrivateZoneWrapper)
+
+// WithPrivateZoneLogger sets the logger used by the wrapper and the underlying SDK client.
+func WithPrivateZoneLogger(logger Logger) PrivateZoneOption {
+	return func(w *PrivateZoneWrapper) {
+		w.log = logger
+	}
+}
+
+// WithPrivateZoneTXTEscaping sets how TXT targets are matched against stored values, defaults to TXTEscapeAuto
+// recognizing registry values by DefaultTXTRegistryPrefix.
+func WithPrivateZoneTXTEscaping(mode TXTEscapeMode, registryPrefixes []string) PrivateZoneOption {
+	return func(w *PrivateZoneWrapper) {
+		w.txt = txtEscaping{mode: mode, prefixes: registryPrefixes}
+	}
+}
+
+// WithPrivateZoneRateLimits throttles list calls with read and mutating calls with write.
+func WithPrivateZoneRateLimits(read, write RateLimit) PrivateZoneOption {
+	return func(w *PrivateZoneWrapper) {
+		w.readLimit = read
+		w.writeLimit = write
+	}
+}
+
+// WithPrivateZoneWriteConcurrency allows at most n mutating calls in flight per zone, 0 is unlimited.
+func WithPrivateZoneWriteConcurrency(n int) PrivateZoneOption {
+	return func(w *PrivateZoneWrapper) {
+		w.zoneWriteConcurrency = n
+	}
+}
+
+// WithPrivateZoneHeaders attaches static headers to every request, e.g. for an OpenAPI gateway.
+func WithPrivateZoneHeaders(headers http.Header) PrivateZoneOption {
+	return func(w *PrivateZoneWrapper) {
+		w.headers = headers
+	}
+}
+
+// WithPrivateZoneRetryPolicy retries failed calls with the backoff of policy, nil keeps the SDK defaults.
+func WithPrivateZoneRetryPolicy(policy *RetryPolicy) PrivateZoneOption {
+	return func(w *PrivateZoneWrapper) {
+		w.retry = policy
+	}
+}
+
+// WithPrivateZoneRecorder writes every request and response to the recorder.
+func WithPrivateZoneRecorder(recorder *Recorder) PrivateZoneOption {
+	return func(w *PrivateZoneWrapper) {
+		w.recorder = recorder
+	}
 }
 
 // NewPrivateZoneWrapper creates a new PrivateZone wrapper.
-func NewPrivateZoneWrapper(regionID, pvzEndpoint string, credentials *credentials.Credentials) (*PrivateZoneWrapper, error) {
+func NewPrivateZoneWrapper(regionID, pvzEndpoint string, credentials *credentials.Credentials, options ...PrivateZoneOption) (*PrivateZoneWrapper, error) {
+	w := &PrivateZoneWrapper{
+		log: logrus.StandardLogger(),
+	}
+	for _, option := range options {
+		option(w)
+	}
 	c := volcengine.NewConfig().
 		WithRegion(regionID).
 		WithCredentials(credentials).
 		WithEndpoint(pvzEndpoint).
-		WithLogger(NewLoggerAdapter(logrus.StandardLogger().WithField("client", "privatezone")))
+		WithLogger(NewLoggerAdapter(w.log.WithField("client", "privatezone")))
+	if w.retry != nil {
+		request.WithRetryer(c, w.retry.retryer())
+	}
 	s, err := session.NewSession(c)
 	if err != nil {
-		logrus.Errorf("Failed to create volcengine session: %v", err)
+		w.log.Errorf("Failed to create volcengine session: %v", err)
 		return nil, err
 	}
-	pc := privatezone.New(s)
+	installStaticHeaders(&s.Handlers, w.headers)
+	s.Handlers.Build.PushBackNamed(retryCodesHandler())
+	installAPIMetrics(&s.Handlers)
+	installAPITracing(&s.Handlers)
+	if w.recorder != nil {
+		w.recorder.install(&s.Handlers, w.logger())
+	}
+	w.client = privatezone.New(s)
+	// installed without limits too, so SetRateLimits can throttle the calls without a restart
+	w.client = newThrottledClient(w.client, w.readLimit, w.writeLimit)
+	if w.zoneWriteConcurrency > 0 {
+		// outside of the throttling, so calls waiting for a zone do not hold rate limiter tokens
+		w.client = newZoneLimitedClient(w.client, w.zoneWriteConcurrency)
+	}
+
+	return w, nil
+}
+
+// throttled returns the client throttling the API calls, nil when the calls are not throttled.
+func (w *PrivateZoneWrapper) throttled() *throttledClient {
+	client := w.client
+	if limited, ok := client.(*zoneLimitedClient); ok {
+		client = limited.client
+	}
+	throttled, _ := client.(*throttledClient)
+	return throttled
+}
+
+// SetRateLimits changes the limits of the read and write API calls.
+func (w *PrivateZoneWrapper) SetRateLimits(read, write RateLimit) {
+	if throttled := w.throttled(); throttled != nil {
+		throttled.setRateLimits(read, write)
+	}
+}
+
+// logger returns the wrapper logger, falling back to the logrus standard logger.
+func (w *PrivateZoneWrapper) logger() Logger {
+	if w.log == nil {
+		return logrus.StandardLogger()
+	}
+	return w.log
+}
+
+// requestLogger returns the logger with the correlation ID of the webhook request in ctx.
+func (w *PrivateZoneWrapper) requestLogger(ctx context.Context) Logger {
+	return contextLogger(ctx, w.logger())
+}
 
-	return &PrivateZoneWrapper{
-		client: pc,
-	}, nil
+// newClientToken returns the idempotency token of a create call, retries of the call by the SDK
+// send the same token so a request retried after a timeout does not create the records twice.
+func newClientToken() string {
+	return uuid.NewString()
 }
 
-// CreatePrivateZoneRecord creates a new private zone record.
-func (w *PrivateZoneWrapper) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, host, recordType, target string, TTL int32) error {
+// CreatePrivateZoneRecord creates a new private zone record, an empty remark falls back to the default remark
+// a zero weight to the default weight and an empty line to the default line.
+func (w *PrivateZoneWrapper) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, host, recordType, target string, TTL, weight int32, line, remark string) error {
+	if remark == "" {
+		remark = defaultRecordRemark
+	}
 	request := &privatezone.CreateRecordInput{
-		Host:   &host,
-		Type:   &recordType,
-		Value:  &target,
-		ZID:    &zoneID,
-		TTL:    &TTL,
-		Remark: volcengine.String(defaultRecordRemark),
+		Host:        &host,
+		Type:        &recordType,
+		Value:       &target,
+		ZID:         &zoneID,
+		TTL:         &TTL,
+		Remark:      &remark,
+		ClientToken: volcengine.String(newClientToken()),
+	}
+	if weight > 0 {
+		request.Weight = &weight
+	}
+	if line != "" {
+		request.Line = &line
 	}
 	resp, err := w.client.CreateRecordWithContext(ctx, request)
-	logrus.Tracef("Create record request: %+v, resp: %+v", request, resp)
+	w.requestLogger(ctx).Tracef("Create record request: %+v, resp: %+v", request, resp)
 	if err != nil || resp.Metadata.Error != nil {
 		return fmt.Errorf("failed to create privatezone record, err: %v, resp: %v", err, resp)
 	}
 
-	logrus.Infof("Successfully created volcengine record: %+v", resp)
+	w.requestLogger(ctx).Infof("Successfully created volcengine record: %+v", resp)
 	return nil
 }
 
@@ -118,36 +281,39 @@ func (w *PrivateZoneWrapper) CreatePrivateZoneRecord(ctx context.Context, zoneID
 //   - TTL will use first record's TTL.
 //   - Remark can be set in every record.
 func (w *PrivateZoneWrapper) BatchCreatePrivateZoneRecord(ctx context.Context, zoneID int64, records []*privatezone.RecordForBatchCreateRecordInput) error {
-	_, err := BatchForEach(records, defaultBatchSize, func(partialRecords []*privatezone.RecordForBatchCreateRecordInput) ([]*string, error) {
+	_, err := utils.BatchForEach(ctx, records, defaultBatchSize, func(ctx context.Context, partialRecords []*privatezone.RecordForBatchCreateRecordInput) ([]*string, error) {
 		req := &privatezone.BatchCreateRecordInput{
-			Records: partialRecords,
-			ZID:     &zoneID,
+			Records:     partialRecords,
+			ZID:         &zoneID,
+			ClientToken: volcengine.String(newClientToken()),
 		}
 		reqs, err := json.Marshal(req)
 		if err != nil {
-			logrus.Errorf("Failed to marshal batch create record req: %v", err)
+			w.requestLogger(ctx).Errorf("Failed to marshal batch create record req: %v", err)
 			return nil, err
 		}
 
 		resp, err := w.client.BatchCreateRecordWithContext(ctx, req)
-		logrus.Tracef("Batch create record req: %s, resp: %s", string(reqs), resp)
+		w.requestLogger(ctx).Tracef("Batch create record req: %s, resp: %s", string(reqs), resp)
 		if err != nil || resp.Metadata.Error != nil {
 			// directly print resp avoid Metadata is nil
 			return nil, fmt.Errorf("failed to batch create privatezone record, err: %v, resp: %v", err, resp)
 		}
 
-		logrus.Infof("Successfully batch created privatezone record: %s", resp.String())
+		w.requestLogger(ctx).Infof("Successfully batch created privatezone record: %s", resp.String())
 		return resp.RecordIDs, nil
 	})
 	if err != nil {
-		logrus.Errorf("Failed to batch create privatezone record: %v", err)
+		w.requestLogger(ctx).Errorf("Failed to batch create privatezone record: %v", err)
 		return err
 	}
 
 	return nil
 }
 
-func (w *PrivateZoneWrapper) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL int32) error {
+// UpdatePrivateZoneRecord rewrites a private zone record, the API replaces the remark too so the caller passes
+// the remark to keep, a zero weight and an empty line keep the weight and line of the record.
+func (w *PrivateZoneWrapper) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL, weight int32, line, remark string) error {
 	req := &privatezone.UpdateRecordInput{
 		RecordID: &recordID,
 		Host:     &host,
@@ -155,13 +321,20 @@ func (w *PrivateZoneWrapper) UpdatePrivateZoneRecord(ctx context.Context, zoneID
 		Value:    &target,
 		ZID:      &zoneID,
 		TTL:      &TTL,
+		Remark:   &remark,
+	}
+	if weight > 0 {
+		req.Weight = &weight
+	}
+	if line != "" {
+		req.Line = &line
 	}
 	resp, err := w.client.UpdateRecordWithContext(ctx, req)
-	logrus.Tracef("Update record request: %+v, resp: %+v", req, resp)
+	w.requestLogger(ctx).Tracef("Update record request: %+v, resp: %+v", req, resp)
 	if err != nil || resp.Metadata.Error != nil {
 		return fmt.Errorf("failed to update privatezone record, err: %v, resp: %v", err, resp)
 	}
-	logrus.Infof("Successfully updated volcengine record: %+v", resp)
+	w.requestLogger(ctx).Infof("Successfully updated volcengine record: %+v", resp)
 	return nil
 }
 
@@ -171,64 +344,87 @@ func (w *PrivateZoneWrapper) DeletePrivateZoneRecordById(ctx context.Context, zo
 		ZID:      &zoneID,
 	}
 	resp, err := w.client.DeleteRecordWithContext(ctx, req)
-	logrus.Tracef("Delete record request: %+v, resp: %+v", req, resp)
+	w.requestLogger(ctx).Tracef("Delete record request: %+v, resp: %+v", req, resp)
+	if err == nil && resp.Metadata.Error != nil {
+		err = volcengineerr.New(resp.Metadata.Error.Code, resp.Metadata.Error.Message, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete privatezone record, err: %w, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully deleted volcengine record: %+v", resp)
+	return nil
+}
+
+// DisablePrivateZoneRecord disables a private zone record and replaces its remark, the record is kept.
+func (w *PrivateZoneWrapper) DisablePrivateZoneRecord(ctx context.Context, zoneID int64, recordID, remark string) error {
+	req := &privatezone.UpdateRecordInput{
+		RecordID: &recordID,
+		ZID:      &zoneID,
+		Enable:   volcengine.Bool(false),
+		Remark:   &remark,
+	}
+	resp, err := w.client.UpdateRecordWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Disable record request: %+v, resp: %+v", req, resp)
 	if err != nil || resp.Metadata.Error != nil {
-		return fmt.Errorf("failed to delete privatezone record, err: %v, resp: %v", err, resp)
+		return fmt.Errorf("failed to disable privatezone record, err: %v, resp: %v", err, resp)
 	}
-	logrus.Infof("Successfully deleted volcengine record: %+v", resp)
+	w.requestLogger(ctx).Infof("Successfully disabled volcengine record: %+v", resp)
 	return nil
 }
 
 // DeletePrivateZoneRecord deletes a private zone record.
 // multiple targets will to delete multiple records with same value
 func (w *PrivateZoneWrapper) DeletePrivateZoneRecord(ctx context.Context, zoneID int64, host, recordType string, targets []string) error {
-	records, err := w.GetPrivateZoneRecords(ctx, zoneID)
+	records, err := w.GetPrivateZoneRecordsByHostType(ctx, zoneID, host, recordType)
 	if err != nil {
 		return err
 	}
 	recordIDs := make([]string, 0)
-	found := false
 	for _, record := range records {
-		if host == volcengine.StringValue(record.Host) &&
-			recordType == volcengine.StringValue(record.Type) {
-			value := volcengine.StringValue(record.Value)
-			if volcengine.StringValue(record.Type) == "TXT" {
-				value = unescapeTXTRecordValue(value)
-				logrus.Tracef("Unescape txt record value: (%s), host: %s, zid: %d", value, host, zoneID)
-			}
-			if volcengine.StringValue(record.Type) == "CNAME" {
-				value = normalizeDomain(value)
-				logrus.Tracef("Clean cname target: (%s), host: %s, zid: %d", value, host, zoneID)
-			}
-
-			for _, target := range targets {
-				if target == value {
-					recordIDs = append(recordIDs, volcengine.StringValue(record.RecordID))
-					found = true
-					break
-				}
-			}
-			if !found {
-				logrus.Debugf("Not found record bacause different value: host: %s, type: %s, value: %s, expectTargets: %v", host, recordType, value, targets)
-			}
+		if matchRecord(record, host, recordType, targets, w.txt) {
+			recordIDs = append(recordIDs, volcengine.StringValue(record.RecordID))
+			continue
+		}
+		if host == volcengine.StringValue(record.Host) && recordType == volcengine.StringValue(record.Type) {
+			w.requestLogger(ctx).Debugf("Not found record bacause different value: host: %s, type: %s, value: %s, expectTargets: %v", host, recordType, volcengine.StringValue(record.Value), targets)
 		}
 	}
 	if len(recordIDs) == 0 {
-		logrus.Errorf("Not found record to delete.  zid: %d, host: %s, recordType %s, targes: %v", zoneID, host, recordType, targets)
+		w.requestLogger(ctx).Errorf("Not found record to delete.  zid: %d, host: %s, recordType %s, targes: %v", zoneID, host, recordType, targets)
 		return nil
 	}
 
-	return w.batchDeletePrivateZoneRecord(ctx, zoneID, recordIDs)
+	return w.BatchDeletePrivateZoneRecords(ctx, zoneID, recordIDs)
+}
+
+// DeletePrivateZoneRecordSet deletes every record with the host and type.
+// The API deletes records by ID only, so the IDs are looked up with a listing filtered to the record set
+// instead of enumerating the zone.
+func (w *PrivateZoneWrapper) DeletePrivateZoneRecordSet(ctx context.Context, zoneID int64, host, recordType string) error {
+	records, err := w.GetPrivateZoneRecordsByHostType(ctx, zoneID, host, recordType)
+	if err != nil {
+		return err
+	}
+	recordIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		recordIDs = append(recordIDs, volcengine.StringValue(record.RecordID))
+	}
+	if len(recordIDs) == 0 {
+		w.requestLogger(ctx).Infof("No record to delete. zid: %d, host: %s, recordType %s", zoneID, host, recordType)
+		return nil
+	}
+	return w.BatchDeletePrivateZoneRecords(ctx, zoneID, recordIDs)
 }
 
-func (w *PrivateZoneWrapper) batchDeletePrivateZoneRecord(ctx context.Context, zoneID int64, recordIDs []string) error {
-	_, err := BatchForEach(recordIDs, defaultBatchSize, func(ids []string) ([]string, error) {
+// BatchDeletePrivateZoneRecords deletes the records by ID, in batches of defaultBatchSize.
+func (w *PrivateZoneWrapper) BatchDeletePrivateZoneRecords(ctx context.Context, zoneID int64, recordIDs []string) error {
+	_, err := utils.BatchForEach(ctx, recordIDs, defaultBatchSize, func(ctx context.Context, ids []string) ([]string, error) {
 		req := &privatezone.BatchDeleteRecordInput{
 			RecordIDs: volcengine.StringSlice(ids),
 			ZID:       &zoneID,
 		}
 		resp, err := w.client.BatchDeleteRecordWithContext(ctx, req)
-		logrus.Tracef("Batch delete record req: %s, resp: %s", req, resp)
+		w.requestLogger(ctx).Tracef("Batch delete record req: %s, resp: %s", req, resp)
 		if err != nil || resp.Metadata.Error != nil {
 			return nil, fmt.Errorf("failed to delete privatezone records, err: %v, resp: %v", err, resp)
 		}
@@ -236,40 +432,108 @@ func (w *PrivateZoneWrapper) batchDeletePrivateZoneRecord(ctx context.Context, z
 		return ids, nil
 	})
 	if err != nil {
-		logrus.Errorf("Failed to batch delete privatezone record: %v", err)
+		w.requestLogger(ctx).Errorf("Failed to batch delete privatezone record: %v", err)
 		return err
 	}
 
-	logrus.Infof("Successfully batch deleted privatezone record, zid: %d, records: %v", zoneID, recordIDs)
+	w.requestLogger(ctx).Infof("Successfully batch deleted privatezone record, zid: %d, records: %v", zoneID, recordIDs)
 	return nil
 }
 
 // GetPrivateZoneRecords returns the list of private zone records.
 func (w *PrivateZoneWrapper) GetPrivateZoneRecords(ctx context.Context, zid int64) ([]*privatezone.RecordForListRecordsOutput, error) {
-	res, err := QueryAll(defaultPageSize, func(pageNum, pageSize int) ([]*privatezone.RecordForListRecordsOutput, int, error) {
+	return w.listRecords(ctx, zid, "", "")
+}
+
+// GetPrivateZoneRecordsByHostType returns the records of the zone with the host and type,
+// filtered by the API so only a handful of records are listed.
+func (w *PrivateZoneWrapper) GetPrivateZoneRecordsByHostType(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	records, err := w.listRecords(ctx, zid, host, recordType)
+	if err != nil {
+		return nil, err
+	}
+	// keep exact matches only, in case the API falls back to a keyword search of the host
+	res := make([]*privatezone.RecordForListRecordsOutput, 0, len(records))
+	for _, record := range records {
+		if volcengine.StringValue(record.Host) == host && volcengine.StringValue(record.Type) == recordType {
+			res = append(res, record)
+		}
+	}
+	return res, nil
+}
+
+// listRecords lists the records of the zone, host and recordType filter the records when not empty.
+func (w *PrivateZoneWrapper) listRecords(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	res, err := utils.QueryAll(ctx, defaultPageSize, func(ctx context.Context, pageNum, pageSize int) ([]*privatezone.RecordForListRecordsOutput, int, error) {
 		req := privatezone.ListRecordsInput{
 			ZID:        &zid,
 			PageSize:   volcengine.String(strconv.FormatInt(int64(pageSize), 10)),
 			PageNumber: volcengine.Int32(int32(pageNum)),
 		}
+		if host != "" {
+			req.Host = volcengine.String(host)
+			req.SearchMode = volcengine.String(exactSearchMode)
+		}
+		if recordType != "" {
+			req.Type = volcengine.String(recordType)
+		}
 		resp, err := w.client.ListRecordsWithContext(ctx, &req)
-		logrus.Tracef("List records req: %s, resp: %+v", req, resp)
+		w.requestLogger(ctx).Tracef("List records req: %s, resp: %+v", req, resp)
 		if err != nil || resp.Metadata.Error != nil {
 			return nil, 0, fmt.Errorf("failed to list privatezone records, err: %v, resp: %v", err, resp)
 		}
 		return resp.Records, int(volcengine.Int32Value(resp.Total)), nil
 	})
 	if err != nil {
-		logrus.Errorf("Failed to list privatezone records: %v", err)
+		w.requestLogger(ctx).Errorf("Failed to list privatezone records: %v", err)
 		return nil, err
 	}
 
-	logrus.Debugf("Successfully list privatezone records: %+v", res)
+	w.requestLogger(ctx).Debugf("Successfully list privatezone records: %+v", res)
 	return res, nil
 }
 
+// ListPrivateZones returns the zones bound to the VPC, all zones of the account when vpcID is empty. A comma separated
+// list of VPCs returns the zones bound to any of them, zones bound to several VPCs once.
 func (w *PrivateZoneWrapper) ListPrivateZones(ctx context.Context, vpcID string) ([]*privatezone.ZoneForListPrivateZonesOutput, error) {
-	zones, err := QueryAll(defaultPageSize, func(pageNum, pageSize int) ([]*privatezone.ZoneForListPrivateZonesOutput, int, error) {
+	vpcs := splitVPCs(vpcID)
+	switch len(vpcs) {
+	case 0:
+		return w.listPrivateZones(ctx, "")
+	case 1:
+		return w.listPrivateZones(ctx, vpcs[0])
+	}
+	var res []*privatezone.ZoneForListPrivateZonesOutput
+	seen := make(map[int32]bool)
+	for _, vpc := range vpcs {
+		zones, err := w.listPrivateZones(ctx, vpc)
+		if err != nil {
+			return nil, err
+		}
+		for _, zone := range zones {
+			if zid := volcengine.Int32Value(zone.ZID); !seen[zid] {
+				seen[zid] = true
+				res = append(res, zone)
+			}
+		}
+	}
+	return res, nil
+}
+
+// splitVPCs returns the VPCs of a comma separated list, without blanks and duplicates.
+func splitVPCs(vpcIDs string) []string {
+	var vpcs []string
+	for _, vpc := range strings.Split(vpcIDs, ",") {
+		if vpc = strings.TrimSpace(vpc); vpc != "" && !slices.Contains(vpcs, vpc) {
+			vpcs = append(vpcs, vpc)
+		}
+	}
+	return vpcs
+}
+
+// listPrivateZones returns the zones bound to a single VPC, all zones when vpcID is empty.
+func (w *PrivateZoneWrapper) listPrivateZones(ctx context.Context, vpcID string) ([]*privatezone.ZoneForListPrivateZonesOutput, error) {
+	zones, err := utils.QueryAll(ctx, defaultPageSize, func(ctx context.Context, pageNum, pageSize int) ([]*privatezone.ZoneForListPrivateZonesOutput, int, error) {
 		req := &privatezone.ListPrivateZonesInput{
 			PageSize:   volcengine.Int32(int32(pageSize)),
 			PageNumber: volcengine.Int32(int32(pageNum)),
@@ -281,17 +545,153 @@ func (w *PrivateZoneWrapper) ListPrivateZones(ctx context.Context, vpcID string)
 			}(),
 		}
 		resp, err := w.client.ListPrivateZonesWithContext(ctx, req)
-		logrus.Tracef("List volcengine zones: req: %s, resp: %s", req, resp)
+		w.requestLogger(ctx).Tracef("List volcengine zones: req: %s, resp: %s", req, resp)
 		if err != nil || resp.Metadata.Error != nil {
 			return nil, 0, fmt.Errorf("failed to list volcengine privatezones, err: %v, resp: %v", err, resp)
 		}
 		return resp.Zones, int(volcengine.Int32Value(resp.Total)), nil
 	})
 	if err != nil {
-		logrus.Errorf("Failed to list volcengine privatezones: %v", err)
+		w.requestLogger(ctx).Errorf("Failed to list volcengine privatezones: %v", err)
 		return nil, err
 	}
 
-	logrus.Debugf("Successfully list volcengine privatezones: %+v", zones)
+	w.requestLogger(ctx).Debugf("Successfully list volcengine privatezones: %+v", zones)
 	return zones, nil
 }
+
+// ZoneSettings are the zone options changed by UpdatePrivateZone, nil fields are left unchanged.
+type ZoneSettings struct {
+	// RecursionMode resolves names missing from the zone through public DNS
+	RecursionMode *bool
+	// LoadBalance answers with weighted records of the same host and type
+	LoadBalance *bool
+	Remark      *string
+}
+
+// ZoneDetails are the details and settings of a zone, with the LoadBalance setting the SDK output does not decode.
+type ZoneDetails struct {
+	*privatezone.QueryPrivateZoneOutput
+	// LoadBalance answers with weighted records of the same host and type, nil when the response does not have it
+	LoadBalance *bool
+}
+
+// QueryPrivateZone returns the details and settings of a zone.
+func (w *PrivateZoneWrapper) QueryPrivateZone(ctx context.Context, zoneID int64) (*ZoneDetails, error) {
+	req := &privatezone.QueryPrivateZoneInput{
+		ZID: &zoneID,
+	}
+	details := &ZoneDetails{}
+	resp, err := w.client.QueryPrivateZoneWithContext(ctx, req, decodeLoadBalance(&details.LoadBalance))
+	w.requestLogger(ctx).Tracef("Query zone request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return nil, fmt.Errorf("failed to query privatezone, err: %v, resp: %v", err, resp)
+	}
+	details.QueryPrivateZoneOutput = resp
+	return details, nil
+}
+
+// decodeLoadBalance reads the LoadBalance setting from the body of a QueryPrivateZone response into loadBalance,
+// before the SDK decodes the body into its output.
+func decodeLoadBalance(loadBalance **bool) request.Option {
+	return func(r *request.Request) {
+		r.Handlers.Unmarshal.PushFront(func(r *request.Request) {
+			if r.HTTPResponse == nil || r.HTTPResponse.Body == nil {
+				return
+			}
+			body, err := io.ReadAll(r.HTTPResponse.Body)
+			_ = r.HTTPResponse.Body.Close()
+			r.HTTPResponse.Body = io.NopCloser(bytes.NewReader(body))
+			if err != nil {
+				return
+			}
+			var resp struct {
+				Result struct {
+					LoadBalance *bool
+				}
+			}
+			if json.Unmarshal(body, &resp) == nil {
+				*loadBalance = resp.Result.LoadBalance
+			}
+		})
+	}
+}
+
+// UpdatePrivateZone changes the settings of a zone.
+func (w *PrivateZoneWrapper) UpdatePrivateZone(ctx context.Context, zoneID int64, settings ZoneSettings) error {
+	req := &privatezone.UpdatePrivateZoneInput{
+		ZID:           &zoneID,
+		RecursionMode: settings.RecursionMode,
+		LoadBalance:   settings.LoadBalance,
+		Remark:        settings.Remark,
+	}
+	resp, err := w.client.UpdatePrivateZoneWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Update zone request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return fmt.Errorf("failed to update privatezone, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully updated volcengine privatezone: %+v", resp)
+	return nil
+}
+
+// CreatePrivateZone creates a zone bound to the VPCs of region and returns its id.
+func (w *PrivateZoneWrapper) CreatePrivateZone(ctx context.Context, zoneName, region string, vpcIDs []string, remark string) (int64, error) {
+	req := &privatezone.CreatePrivateZoneInput{
+		ClientToken: volcengine.String(newClientToken()),
+		ZoneName:    volcengine.String(zoneName),
+		Remark:      volcengine.String(remark),
+	}
+	for _, vpc := range vpcIDs {
+		req.Vpcs = append(req.Vpcs, &privatezone.VpcForCreatePrivateZoneInput{Region: volcengine.String(region), VpcId: volcengine.String(vpc)})
+	}
+	resp, err := w.client.CreatePrivateZoneWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Create zone request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return 0, fmt.Errorf("failed to create privatezone, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully created volcengine privatezone %s: %d", zoneName, volcengine.Int64Value(resp.ZID))
+	return volcengine.Int64Value(resp.ZID), nil
+}
+
+// DeletePrivateZone deletes a zone, a zone still holding records is only deleted with force.
+func (w *PrivateZoneWrapper) DeletePrivateZone(ctx context.Context, zoneID int64, force bool) error {
+	req := &privatezone.DeletePrivateZoneInput{
+		ZID:             &zoneID,
+		DeleteWhenEmpty: volcengine.Bool(!force),
+	}
+	resp, err := w.client.DeletePrivateZoneWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Delete zone request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return fmt.Errorf("failed to delete privatezone, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully deleted volcengine privatezone: %d", zoneID)
+	return nil
+}
+
+// BindVPC binds the VPCs of region to a zone, keeping its other bindings.
+func (w *PrivateZoneWrapper) BindVPC(ctx context.Context, zoneID int64, region string, vpcIDs []string) error {
+	req := &privatezone.IncBindVPCInput{ZID: &zoneID}
+	for _, vpc := range vpcIDs {
+		req.Binds = append(req.Binds, &privatezone.BindForIncBindVPCInput{Region: volcengine.String(region), VpcId: volcengine.String(vpc)})
+	}
+	return w.incBindVPC(ctx, req)
+}
+
+// UnbindVPC unbinds the VPCs of region from a zone, keeping its other bindings.
+func (w *PrivateZoneWrapper) UnbindVPC(ctx context.Context, zoneID int64, region string, vpcIDs []string) error {
+	req := &privatezone.IncBindVPCInput{ZID: &zoneID}
+	for _, vpc := range vpcIDs {
+		req.Unbinds = append(req.Unbinds, &privatezone.UnbindForIncBindVPCInput{Region: volcengine.String(region), VpcId: volcengine.String(vpc)})
+	}
+	return w.incBindVPC(ctx, req)
+}
+
+func (w *PrivateZoneWrapper) incBindVPC(ctx context.Context, req *privatezone.IncBindVPCInput) error {
+	resp, err := w.client.IncBindVPCWithContext(ctx, req)
+	w.requestLogger(ctx).Tracef("Bind vpc request: %+v, resp: %+v", req, resp)
+	if err != nil || resp.Metadata.Error != nil {
+		return fmt.Errorf("failed to change vpc bindings of privatezone, err: %v, resp: %v", err, resp)
+	}
+	w.requestLogger(ctx).Infof("Successfully changed vpc bindings of volcengine privatezone: %d", volcengine.Int64Value(req.ZID))
+	return nil
+}
@@ -35,6 +35,11 @@ type MockClient struct {
 	BatchDeleteRecordFunc func(ctx context.Context, input *privatezone.BatchDeleteRecordInput) (*privatezone.BatchDeleteRecordOutput, error)
 	UpdateRecordFunc      func(ctx context.Context, input *privatezone.UpdateRecordInput) (*privatezone.UpdateRecordOutput, error)
 	DeleteRecordFunc      func(ctx context.Context, input *privatezone.DeleteRecordInput) (*privatezone.DeleteRecordOutput, error)
+	QueryPrivateZoneFunc  func(ctx context.Context, input *privatezone.QueryPrivateZoneInput) (*privatezone.QueryPrivateZoneOutput, error)
+	UpdatePrivateZoneFunc func(ctx context.Context, input *privatezone.UpdatePrivateZoneInput) (*privatezone.UpdatePrivateZoneOutput, error)
+	CreatePrivateZoneFunc func(ctx context.Context, input *privatezone.CreatePrivateZoneInput) (*privatezone.CreatePrivateZoneOutput, error)
+	DeletePrivateZoneFunc func(ctx context.Context, input *privatezone.DeletePrivateZoneInput) (*privatezone.DeletePrivateZoneOutput, error)
+	IncBindVPCFunc        func(ctx context.Context, input *privatezone.IncBindVPCInput) (*privatezone.IncBindVPCOutput, error)
 }
 
 // Implement necessary methods to match the privateZoneClient interface
@@ -87,6 +92,41 @@ func (m *MockClient) DeleteRecordWithContext(ctx context.Context, input *private
 	return nil, nil
 }
 
+func (m *MockClient) QueryPrivateZoneWithContext(ctx context.Context, input *privatezone.QueryPrivateZoneInput, options ...request.Option) (*privatezone.QueryPrivateZoneOutput, error) {
+	if m.QueryPrivateZoneFunc != nil {
+		return m.QueryPrivateZoneFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) UpdatePrivateZoneWithContext(ctx context.Context, input *privatezone.UpdatePrivateZoneInput, options ...request.Option) (*privatezone.UpdatePrivateZoneOutput, error) {
+	if m.UpdatePrivateZoneFunc != nil {
+		return m.UpdatePrivateZoneFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreatePrivateZoneWithContext(ctx context.Context, input *privatezone.CreatePrivateZoneInput, options ...request.Option) (*privatezone.CreatePrivateZoneOutput, error) {
+	if m.CreatePrivateZoneFunc != nil {
+		return m.CreatePrivateZoneFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeletePrivateZoneWithContext(ctx context.Context, input *privatezone.DeletePrivateZoneInput, options ...request.Option) (*privatezone.DeletePrivateZoneOutput, error) {
+	if m.DeletePrivateZoneFunc != nil {
+		return m.DeletePrivateZoneFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) IncBindVPCWithContext(ctx context.Context, input *privatezone.IncBindVPCInput, options ...request.Option) (*privatezone.IncBindVPCOutput, error) {
+	if m.IncBindVPCFunc != nil {
+		return m.IncBindVPCFunc(ctx, input)
+	}
+	return nil, nil
+}
+
 func TestCreatePrivateZoneRecord(t *testing.T) {
 	// Create a mock client
 	mockClient := &MockClient{}
@@ -109,7 +149,7 @@ func TestCreatePrivateZoneRecord(t *testing.T) {
 	wrapper := &PrivateZoneWrapper{client: mockClient}
 
 	// Call the method
-	err := wrapper.CreatePrivateZoneRecord(context.Background(), 123, "www", "A", "1.2.3.4", 60)
+	err := wrapper.CreatePrivateZoneRecord(context.Background(), 123, "www", "A", "1.2.3.4", 60, 0, "", "")
 
 	// Verify results
 	assert.NoError(t, err)
@@ -205,3 +245,206 @@ func TestDeletePrivateZoneRecord(t *testing.T) {
 	// 验证结果
 	assert.NoError(t, err)
 }
+
+func TestGetPrivateZoneRecordsByHostType(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.ListRecordsFunc = func(ctx context.Context, input *privatezone.ListRecordsInput) (*privatezone.ListRecordsOutput, error) {
+		assert.Equal(t, int64(123), *input.ZID)
+		assert.Equal(t, "www", volcengine.StringValue(input.Host))
+		assert.Equal(t, "A", volcengine.StringValue(input.Type))
+		assert.Equal(t, "exact", volcengine.StringValue(input.SearchMode))
+		return &privatezone.ListRecordsOutput{
+			Records: []*privatezone.RecordForListRecordsOutput{
+				{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), RecordID: volcengine.String("record-1")},
+				{Host: volcengine.String("www2"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.5"), RecordID: volcengine.String("record-2")},
+			},
+			Metadata: &response.ResponseMetadata{},
+			Total:    volcengine.Int32(2),
+		}, nil
+	}
+	wrapper := &PrivateZoneWrapper{client: mockClient}
+
+	records, err := wrapper.GetPrivateZoneRecordsByHostType(context.Background(), 123, "www", "A")
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "record-1", *records[0].RecordID)
+}
+
+func TestDeletePrivateZoneRecordSet(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.ListRecordsFunc = func(ctx context.Context, input *privatezone.ListRecordsInput) (*privatezone.ListRecordsOutput, error) {
+		assert.Equal(t, "www", volcengine.StringValue(input.Host))
+		assert.Equal(t, "A", volcengine.StringValue(input.Type))
+		return &privatezone.ListRecordsOutput{
+			Records: []*privatezone.RecordForListRecordsOutput{
+				{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), RecordID: volcengine.String("record-1")},
+				{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.5"), RecordID: volcengine.String("record-2")},
+			},
+			Metadata: &response.ResponseMetadata{},
+			Total:    volcengine.Int32(2),
+		}, nil
+	}
+	var deleted []string
+	mockClient.BatchDeleteRecordFunc = func(ctx context.Context, input *privatezone.BatchDeleteRecordInput) (*privatezone.BatchDeleteRecordOutput, error) {
+		deleted = append(deleted, volcengine.StringValueSlice(input.RecordIDs)...)
+		return &privatezone.BatchDeleteRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+	}
+	wrapper := &PrivateZoneWrapper{client: mockClient}
+
+	assert.NoError(t, wrapper.DeletePrivateZoneRecordSet(context.Background(), 123, "www", "A"))
+	assert.Equal(t, []string{"record-1", "record-2"}, deleted)
+}
+
+func TestCreateClientToken(t *testing.T) {
+	var tokens []string
+	mockClient := &MockClient{
+		CreateRecordFunc: func(ctx context.Context, input *privatezone.CreateRecordInput) (*privatezone.CreateRecordOutput, error) {
+			tokens = append(tokens, volcengine.StringValue(input.ClientToken))
+			return &privatezone.CreateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+		BatchCreateRecordFunc: func(ctx context.Context, input *privatezone.BatchCreateRecordInput) (*privatezone.BatchCreateRecordOutput, error) {
+			tokens = append(tokens, volcengine.StringValue(input.ClientToken))
+			return &privatezone.BatchCreateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+	}
+	wrapper := &PrivateZoneWrapper{client: mockClient}
+
+	assert.NoError(t, wrapper.CreatePrivateZoneRecord(context.Background(), 123, "www", "A", "1.2.3.4", 60, 0, "", ""))
+	assert.NoError(t, wrapper.CreatePrivateZoneRecord(context.Background(), 123, "www", "A", "1.2.3.4", 60, 0, "", ""))
+	assert.NoError(t, wrapper.BatchCreatePrivateZoneRecord(context.Background(), 123, []*privatezone.RecordForBatchCreateRecordInput{
+		{Host: volcengine.String("api"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.5")},
+	}))
+	assert.Len(t, tokens, 3)
+	for i, token := range tokens {
+		assert.NotEmpty(t, token)
+		assert.NotContains(t, tokens[:i], token)
+	}
+}
+
+func TestUpdatePrivateZone(t *testing.T) {
+	var got *privatezone.UpdatePrivateZoneInput
+	mockClient := &MockClient{
+		UpdatePrivateZoneFunc: func(ctx context.Context, input *privatezone.UpdatePrivateZoneInput) (*privatezone.UpdatePrivateZoneOutput, error) {
+			got = input
+			return &privatezone.UpdatePrivateZoneOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+		QueryPrivateZoneFunc: func(ctx context.Context, input *privatezone.QueryPrivateZoneInput) (*privatezone.QueryPrivateZoneOutput, error) {
+			return &privatezone.QueryPrivateZoneOutput{
+				Metadata: &response.ResponseMetadata{Error: &response.Error{Code: "InvalidZone.NotFound"}},
+			}, nil
+		},
+	}
+	wrapper := &PrivateZoneWrapper{client: mockClient}
+
+	assert.NoError(t, wrapper.UpdatePrivateZone(context.Background(), 123, ZoneSettings{RecursionMode: volcengine.Bool(true)}))
+	assert.Equal(t, int64(123), volcengine.Int64Value(got.ZID))
+	assert.True(t, volcengine.BoolValue(got.RecursionMode))
+	assert.Nil(t, got.LoadBalance)
+	assert.Nil(t, got.Remark)
+
+	_, err := wrapper.QueryPrivateZone(context.Background(), 123)
+	assert.Error(t, err)
+}
+
+func TestCreateAndDeletePrivateZone(t *testing.T) {
+	var created *privatezone.CreatePrivateZoneInput
+	var deleted []*privatezone.DeletePrivateZoneInput
+	mockClient := &MockClient{
+		CreatePrivateZoneFunc: func(ctx context.Context, input *privatezone.CreatePrivateZoneInput) (*privatezone.CreatePrivateZoneOutput, error) {
+			created = input
+			return &privatezone.CreatePrivateZoneOutput{Metadata: &response.ResponseMetadata{}, ZID: volcengine.Int64(456)}, nil
+		},
+		DeletePrivateZoneFunc: func(ctx context.Context, input *privatezone.DeletePrivateZoneInput) (*privatezone.DeletePrivateZoneOutput, error) {
+			deleted = append(deleted, input)
+			if volcengine.BoolValue(input.DeleteWhenEmpty) {
+				return &privatezone.DeletePrivateZoneOutput{
+					Metadata: &response.ResponseMetadata{Error: &response.Error{Code: "InvalidZone.NotEmpty"}},
+				}, nil
+			}
+			return &privatezone.DeletePrivateZoneOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+	}
+	wrapper := &PrivateZoneWrapper{client: mockClient}
+
+	zid, err := wrapper.CreatePrivateZone(context.Background(), "example.com", "cn-beijing", []string{"vpc-a", "vpc-b"}, "dns")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(456), zid)
+	assert.Equal(t, "example.com", volcengine.StringValue(created.ZoneName))
+	assert.Equal(t, "dns", volcengine.StringValue(created.Remark))
+	assert.NotEmpty(t, volcengine.StringValue(created.ClientToken))
+	if assert.Len(t, created.Vpcs, 2) {
+		assert.Equal(t, "vpc-b", volcengine.StringValue(created.Vpcs[1].VpcId))
+		assert.Equal(t, "cn-beijing", volcengine.StringValue(created.Vpcs[1].Region))
+	}
+
+	// Zones holding records are only deleted with force
+	assert.ErrorContains(t, wrapper.DeletePrivateZone(context.Background(), 456, false), "InvalidZone.NotEmpty")
+	assert.NoError(t, wrapper.DeletePrivateZone(context.Background(), 456, true))
+	assert.Len(t, deleted, 2)
+	assert.Equal(t, int64(456), volcengine.Int64Value(deleted[1].ZID))
+}
+
+func TestBindAndUnbindVPC(t *testing.T) {
+	var got []*privatezone.IncBindVPCInput
+	mockClient := &MockClient{
+		IncBindVPCFunc: func(ctx context.Context, input *privatezone.IncBindVPCInput) (*privatezone.IncBindVPCOutput, error) {
+			got = append(got, input)
+			return &privatezone.IncBindVPCOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+	}
+	wrapper := &PrivateZoneWrapper{client: mockClient}
+
+	assert.NoError(t, wrapper.BindVPC(context.Background(), 123, "cn-shanghai", []string{"vpc-a"}))
+	assert.NoError(t, wrapper.UnbindVPC(context.Background(), 123, "cn-beijing", []string{"vpc-b"}))
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, int64(123), volcengine.Int64Value(got[0].ZID))
+		if assert.Len(t, got[0].Binds, 1) {
+			assert.Equal(t, "vpc-a", volcengine.StringValue(got[0].Binds[0].VpcId))
+			assert.Equal(t, "cn-shanghai", volcengine.StringValue(got[0].Binds[0].Region))
+		}
+		assert.Empty(t, got[0].Unbinds)
+		if assert.Len(t, got[1].Unbinds, 1) {
+			assert.Equal(t, "vpc-b", volcengine.StringValue(got[1].Unbinds[0].VpcId))
+		}
+		assert.Empty(t, got[1].Binds)
+	}
+}
+
+func TestListPrivateZonesMultipleVPCs(t *testing.T) {
+	zone := func(zid int32, name string) *privatezone.ZoneForListPrivateZonesOutput {
+		return &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(zid), ZoneName: volcengine.String(name)}
+	}
+	byVPC := map[string][]*privatezone.ZoneForListPrivateZonesOutput{
+		"vpc-a": {zone(1, "a.com"), zone(3, "shared.com")},
+		"vpc-b": {zone(2, "b.com"), zone(3, "shared.com")},
+	}
+	var listed []string
+	w := &PrivateZoneWrapper{client: &MockClient{
+		ListPrivateZonesFunc: func(ctx context.Context, input *privatezone.ListPrivateZonesInput) (*privatezone.ListPrivateZonesOutput, error) {
+			vpc := volcengine.StringValue(input.VpcID)
+			listed = append(listed, vpc)
+			return &privatezone.ListPrivateZonesOutput{
+				Metadata: &response.ResponseMetadata{},
+				Zones:    byVPC[vpc],
+				Total:    volcengine.Int32(int32(len(byVPC[vpc]))),
+			}, nil
+		},
+	}}
+
+	zones, err := w.ListPrivateZones(context.Background(), " vpc-a, vpc-b,vpc-a,")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vpc-a", "vpc-b"}, listed)
+	var names []string
+	for _, z := range zones {
+		names = append(names, volcengine.StringValue(z.ZoneName))
+	}
+	assert.Equal(t, []string{"a.com", "shared.com", "b.com"}, names)
+	assert.Equal(t, []string{"vpc-a", "vpc-b"}, splitVPCs("vpc-a,,vpc-b , vpc-a"))
+
+	// a single VPC is listed trimmed too
+	listed = nil
+	zones, err = w.ListPrivateZones(context.Background(), " vpc-b ,")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vpc-b"}, listed)
+	assert.Len(t, zones, 2)
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// protectedNames are names or shell patterns, e.g. vpn.example.internal or *.core.example.internal,
+// whose records are never deleted or overwritten.
+type protectedNames []string
+
+func newProtectedNames(patterns []string) (protectedNames, error) {
+	res := make(protectedNames, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(normalizeDomain(strings.TrimSpace(pattern)))
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid protected name %q: %v", pattern, err)
+		}
+		res = append(res, pattern)
+	}
+	return res, nil
+}
+
+func (n protectedNames) match(dnsName string) bool {
+	dnsName = strings.ToLower(normalizeDomain(dnsName))
+	for _, pattern := range n {
+		if ok, _ := path.Match(pattern, dnsName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// skipProtected drops the endpoints with protected names and returns them separately.
+func (p *Provider) skipProtected(action string, endpoints []*endpoint.Endpoint) (allowed, protected []*endpoint.Endpoint) {
+	if len(p.protected) == 0 {
+		return endpoints, nil
+	}
+	allowed = make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if p.protected.match(ep.DNSName) {
+			p.logger().Errorf("Refusing to %s protected endpoint: '%s' type: '%s'", action, ep.DNSName, ep.RecordType)
+			protected = append(protected, ep)
+			continue
+		}
+		allowed = append(allowed, ep)
+	}
+	return allowed, protected
+}
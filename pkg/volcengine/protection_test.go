@@ -0,0 +1,76 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestProtectedNames(t *testing.T) {
+	names, err := newProtectedNames([]string{" VPN.example.internal. ", "*.core.example.internal", ""})
+	require.NoError(t, err)
+	assert.Len(t, names, 2)
+
+	assert.True(t, names.match("vpn.example.internal"))
+	assert.True(t, names.match("Vpn.Example.Internal."))
+	assert.True(t, names.match("db.core.example.internal"))
+	assert.False(t, names.match("core.example.internal"))
+	assert.False(t, names.match("www.example.internal"))
+
+	_, err = newProtectedNames([]string{"[vpn.example.internal"})
+	assert.Error(t, err)
+}
+
+func TestProviderProtectedNames(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.internal")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("1"), Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1")},
+		{RecordID: volcengine.String("2"), Host: volcengine.String("vpn"), Type: volcengine.String("A"), Value: volcengine.String("2.2.2.2")},
+	}, nil).Once()
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"1"}).Return(nil).Once()
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.Anything).Return(nil).Once()
+
+	names, err := newProtectedNames([]string{"vpn.example.internal"})
+	require.NoError(t, err)
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123", protected: names}
+	vpn := endpoint.NewEndpoint("vpn.example.internal", "A", "2.2.2.2")
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("vpn.example.internal", "A", "3.3.3.3")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.internal", "A", "1.1.1.1"), vpn},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("vpn.example.internal", "A", "4.4.4.4")},
+	})
+
+	var changeErr *ChangeError
+	require.True(t, errors.As(err, &changeErr))
+	assert.Equal(t, ErrCodeProtected, changeErr.Code)
+	assert.Len(t, changeErr.Endpoints, 2)
+	assert.Equal(t, vpn, changeErr.Endpoints[0])
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "GetPrivateZoneRecordsByHostType", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
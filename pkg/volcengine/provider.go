@@ -17,21 +17,39 @@ package volcengine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"volcengine-provider/pkg/utils"
 
 	"github.com/sirupsen/logrus"
 	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
 	"github.com/volcengine/volcengine-go-sdk/volcengine"
 	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
 const (
-	defaultEndpoint    = "open.volcengineapi.com"
-	defaultStsEndpoint = "sts.volcengineapi.com"
+	// DefaultEndpoint is the default OpenAPI endpoint for privatezone.
+	DefaultEndpoint = "open.volcengineapi.com"
+	// DefaultStsEndpoint is the default OpenAPI endpoint for sts.
+	DefaultStsEndpoint = "sts.volcengineapi.com"
+
+	// DefaultMaxConcurrentZoneQueries bounds how many zones are listed at the same time.
+	DefaultMaxConcurrentZoneQueries = 8
+
+	// ProviderSpecificDisabledTargets lists the targets of disabled records when they are included in Records.
+	ProviderSpecificDisabledTargets = "volcengine/disabled-targets"
 )
 
 // Provider is a provider for Volcengine.
@@ -39,10 +57,64 @@ type Provider struct {
 	provider.BaseProvider
 
 	domainFilter endpoint.DomainFilter
+	log          Logger
 	// private zone
 	vpcID       string
 	privateZone bool
 	pzClient    privateZoneAPI
+	credentials *credentials.Credentials
+	// zones listed at the same time by Records, defaults to DefaultMaxConcurrentZoneQueries
+	maxConcurrentZoneQueries int
+	// return disabled records from Records, annotated with ProviderSpecificDisabledTargets
+	includeDisabled bool
+	// soft delete
+	softDelete         bool
+	tombstoneRetention time.Duration
+	// refuses batches deleting too many records
+	deletionGuard DeletionGuard
+	// record creates and deletes applied per ApplyChanges, 0 is unlimited
+	changeBudget int
+	// names that are never deleted or overwritten
+	protected protectedNames
+	// restricts deletes and updates to the records written by external-dns
+	managedGuard ManagedRecordGuard
+	// template of the first remark part, nil writes the default record remark
+	remark *recordRemark
+	// deadline of one ApplyChanges, 0 is unlimited
+	applyTimeout time.Duration
+	// translation of TXT values between external-dns and privatezone
+	txt txtEscaping
+	// trailing dot of the names in CNAME, MX, SRV and PTR targets
+	targetDot TargetDotPolicy
+	// TTLs of the records of endpoints without a TTL, by record type
+	defaultTTLs DefaultTTLs
+	// default TTL and TTL range of the records, overridden per zone by zoneTTLs
+	ttls     TTLPolicy
+	zoneTTLs ZoneTTLPolicies
+	// owner whose TXT registry records are not returned from Records
+	hiddenRegistryOwner string
+	// the zones are public CloudDNS zones, which accept the publicOnlyRecordTypes
+	public bool
+	// provider of the public CloudDNS zones in DNSModePublic and DNSModeBoth, it applies changes with the same logic
+	// as this provider through the CloudDNS API
+	publicZones *Provider
+	// last plan passed to ApplyChanges, for DebugState
+	lastChanges atomic.Pointer[AppliedChanges]
+	// ProviderSpecificDisabledTargets of the endpoints last returned by Records, by updateKey
+	disabledTargets atomic.Pointer[map[string]string]
+}
+
+// Logger is the logging interface used by the provider, *logrus.Logger and *logrus.Entry satisfy it.
+type Logger interface {
+	logrus.Ext1FieldLogger
+}
+
+// contextLogger adds the correlation ID of the webhook request in ctx, if any, to the entries of l.
+func contextLogger(ctx context.Context, l Logger) Logger {
+	if id := utils.RequestID(ctx); id != "" {
+		return l.WithField(utils.RequestIDField, id)
+	}
+	return l
 }
 
 type Option func(*Config)
@@ -52,15 +124,92 @@ type Config struct {
 	RegionID     string
 	Credentials  *credentials.Credentials
 	DomainFilter []string
+	// ExcludeDomains are subdomains of DomainFilter, or of all zones, that are not managed.
+	ExcludeDomains []string
+	// Logger receives all provider and API client logs, defaults to the logrus standard logger.
+	Logger Logger
+	// AssumeRole replaces Credentials with the credentials of the role, assumed with Credentials, when set.
+	AssumeRole *AssumeRole
 	// private zone
-	PrivateZone         bool
+	PrivateZone bool
+	// Regions whose private zones are managed together, RegionID only when empty. The zones of all regions are
+	// merged and the records of a zone are changed in the region it was listed in.
+	Regions []Region
+	// VpcId is the VPC, or comma separated VPCs, whose bound zones are managed.
 	VpcId               string
 	PrivateZoneEndpoint string
+	// SoftDelete disables deleted records and tags them with the deletion time instead of removing them,
+	// tombstones older than TombstoneRetention are purged by RunTombstoneGC.
+	SoftDelete         bool
+	TombstoneRetention time.Duration
+	// CacheTTL serves zone and record listings from memory for this long, 0 disables the cache.
+	// CacheFile persists the cache across restarts.
+	CacheTTL  time.Duration
+	CacheFile string
+	// CacheRefreshAfter serves cached listings older than this while refreshing them in the background,
+	// 0 refreshes them only once CacheTTL expired.
+	CacheRefreshAfter time.Duration
+	// ReadRateLimit throttles list calls, WriteRateLimit throttles mutating calls.
+	ReadRateLimit  RateLimit
+	WriteRateLimit RateLimit
+	// DryRun lists zones and records but only logs the record writes of ApplyChanges.
+	DryRun bool
+	// RetryPolicy retries failed API calls, the SDK default retryer when nil.
+	RetryPolicy *RetryPolicy
+	// MaxConcurrentZoneQueries bounds how many zones Records lists at the same time,
+	// defaults to DefaultMaxConcurrentZoneQueries.
+	MaxConcurrentZoneQueries int
+	// MaxConcurrentZoneWrites bounds the mutating calls in flight per zone, independent of the calls to other zones.
+	// 0 is unlimited.
+	MaxConcurrentZoneWrites int
+	// IncludeDisabledRecords returns disabled records from Records, annotated with ProviderSpecificDisabledTargets,
+	// instead of skipping them.
+	IncludeDisabledRecords bool
+	// DeletionGuard refuses ApplyChanges batches that would delete too many of the managed records.
+	DeletionGuard DeletionGuard
+	// ChangeBudget caps the record creates and deletes of an ApplyChanges, the rest is deferred to later syncs.
+	ChangeBudget int
+	// ProtectedNames are names or shell patterns whose records are never deleted or overwritten.
+	ProtectedNames []string
+	// ManagedRecordGuard leaves the records not written by external-dns alone on deletes and updates.
+	ManagedRecordGuard ManagedRecordGuard
+	// Headers are attached to every PrivateZone, CloudDNS and STS AssumeRole API request, e.g. for an OpenAPI gateway.
+	Headers http.Header
+	// Recorder writes every PrivateZone API request and response to a file for debugging.
+	Recorder *Recorder
+	// TXTEscapeMode controls the quoting of TXT values, defaults to TXTEscapeAuto.
+	TXTEscapeMode TXTEscapeMode
+	// ApplyChangesTimeout bounds one ApplyChanges, no API call is issued after it and the remaining changes are
+	// reported with ErrCodeDeadlineExceeded. 0 is unlimited.
+	ApplyChangesTimeout time.Duration
+	// TXTRegistryPrefixes recognize the TXT registry values quoted in TXTEscapeAuto mode,
+	// defaults to DefaultTXTRegistryPrefix.
+	TXTRegistryPrefixes []string
+	// TargetDotPolicy controls the trailing dot of CNAME, MX, SRV and PTR targets, defaults to TargetDotCNAME.
+	TargetDotPolicy TargetDotPolicy
+	// DefaultTTLs are the TTLs of the records of endpoints without a TTL, by record type.
+	DefaultTTLs DefaultTTLs
+	// TTLs is the default TTL and the TTL range of the records, ZoneTTLs override them per zone.
+	TTLs     TTLPolicy
+	ZoneTTLs ZoneTTLPolicies
+	// HiddenRegistryOwner is an external-dns owner id whose TXT registry records are not returned from Records.
+	HiddenRegistryOwner string
+	// DNSMode selects private zones, public CloudDNS zones or both, defaults to DNSModePrivate.
+	DNSMode DNSMode
+	// CloudDNSEndpoint is the OpenAPI endpoint of CloudDNS, defaults to DefaultEndpoint.
+	CloudDNSEndpoint string
+	// RecordRemark is a text/template of the first part of the record remarks, see RecordRemarkData,
+	// defaults to "managed by external-dns". ClusterName is the Cluster of the template data.
+	RecordRemark string
+	ClusterName  string
 }
 
 func defaultConfig() *Config {
 	return &Config{
-		PrivateZoneEndpoint: defaultEndpoint,
+		PrivateZoneEndpoint: DefaultEndpoint,
+		CloudDNSEndpoint:    DefaultEndpoint,
+		Logger:              logrus.StandardLogger(),
+		TombstoneRetention:  DefaultTombstoneRetention,
 	}
 }
 
@@ -71,36 +220,218 @@ func NewVolcengineProvider(options []Option) (*Provider, error) {
 	for _, option := range options {
 		option(c)
 	}
-	p := &Provider{
-		vpcID:       c.VpcId,
-		privateZone: c.PrivateZone,
+	if len(c.Regions) > 0 && c.RegionID == "" {
+		c.RegionID = c.Regions[0].ID
 	}
-	// private zone, only support private zone now
+	if c.AssumeRole != nil {
+		role := *c.AssumeRole
+		if role.Headers == nil {
+			role.Headers = c.Headers
+		}
+		if c.Credentials, err = NewAssumeRoleCredentials(c.Credentials, c.RegionID, role, c.Logger.WithField("component", "assume-role")); err != nil {
+			return nil, err
+		}
+	}
+	p, err := newProvider(c)
+	if err != nil {
+		return nil, err
+	}
+	p.privateZone = c.PrivateZone && c.DNSMode.private()
 	if p.privateZone {
-		p.pzClient, err = NewPrivateZoneWrapper(c.RegionID, c.PrivateZoneEndpoint, c.Credentials)
+		if len(c.Regions) > 1 {
+			regions := make([]regionAPI, 0, len(c.Regions))
+			for _, region := range c.Regions {
+				wrapper, err := newPrivateZoneWrapper(c, region)
+				if err != nil {
+					return nil, err
+				}
+				regions = append(regions, regionAPI{region: region.ID, api: wrapper})
+			}
+			p.pzClient = newMultiRegionAPI(regions, p.vpcID)
+		} else {
+			region := Region{ID: c.RegionID}
+			if len(c.Regions) == 1 {
+				region = c.Regions[0]
+			}
+			if p.pzClient, err = newPrivateZoneWrapper(c, region); err != nil {
+				return nil, err
+			}
+		}
+		if c.CacheTTL > 0 {
+			cache := newCachedPrivateZoneAPI(p.pzClient, c.CacheTTL, c.CacheFile, c.Logger.WithField("component", "cache"))
+			cache.refreshAfter = c.CacheRefreshAfter
+			p.pzClient = cache
+		}
+	}
+	if c.DNSMode.public() {
+		wrapper, err := NewCloudDNSWrapper(c.RegionID, c.CloudDNSEndpoint, c.Credentials,
+			WithCloudDNSLogger(c.Logger.WithField("component", "clouddns")),
+			WithCloudDNSRetryPolicy(c.RetryPolicy),
+			WithCloudDNSRateLimits(c.ReadRateLimit, c.WriteRateLimit),
+			WithCloudDNSHeaders(c.Headers))
 		if err != nil {
-			return nil, fmt.Errorf("failed to create private zone wrapper: %v", err)
+			return nil, fmt.Errorf("failed to create clouddns wrapper: %v", err)
+		}
+		wrapper.txt = p.txt
+		if p.publicZones, err = newProvider(c); err != nil {
+			return nil, err
 		}
+		p.publicZones.pzClient = wrapper
+		if c.CacheTTL > 0 {
+			// no snapshot, zone IDs of CloudDNS and privatezone would collide in one file
+			cache := newCachedPrivateZoneAPI(wrapper, c.CacheTTL, "", c.Logger.WithField("component", "clouddns-cache"))
+			cache.refreshAfter = c.CacheRefreshAfter
+			p.publicZones.pzClient = cache
+		}
+		p.publicZones.public = true
+		p.publicZones.vpcID = ""
+		// CloudDNS cannot tag a disabled record, deleted public records are removed
+		p.publicZones.softDelete = false
+	}
+	// installed when off too, so SetDryRun can switch it on without a restart
+	if p.pzClient != nil {
+		p.pzClient = newDryRunAPI(p.pzClient, c.Logger.WithField("component", "dry-run"))
 	}
-	if len(c.DomainFilter) > 0 {
-		p.domainFilter.Filters = append(p.domainFilter.Filters, c.DomainFilter...)
+	if p.publicZones != nil {
+		p.publicZones.pzClient = newDryRunAPI(p.publicZones.pzClient, c.Logger.WithField("component", "dry-run"))
 	}
+	p.SetDryRun(c.DryRun)
+	return p, nil
+}
 
+// newPrivateZoneWrapper returns the PrivateZone API client of the region, at the endpoint of the region or the
+// configured endpoint.
+func newPrivateZoneWrapper(c *Config, region Region) (*PrivateZoneWrapper, error) {
+	endpoint, logger := c.PrivateZoneEndpoint, c.Logger.WithField("component", "privatezone")
+	if region.Endpoint != "" {
+		endpoint = region.Endpoint
+	}
+	if len(c.Regions) > 1 {
+		logger = logger.WithField("region", region.ID)
+	}
+	wrapper, err := NewPrivateZoneWrapper(region.ID, endpoint, c.Credentials,
+		WithPrivateZoneLogger(logger),
+		WithPrivateZoneRateLimits(c.ReadRateLimit, c.WriteRateLimit),
+		WithPrivateZoneWriteConcurrency(c.MaxConcurrentZoneWrites),
+		WithPrivateZoneHeaders(c.Headers),
+		WithPrivateZoneRecorder(c.Recorder),
+		WithPrivateZoneRetryPolicy(c.RetryPolicy),
+		WithPrivateZoneTXTEscaping(c.TXTEscapeMode, c.TXTRegistryPrefixes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create private zone wrapper of region %s: %v", region.ID, err)
+	}
+	return wrapper, nil
+}
+
+// newProvider returns a provider with the change settings of c and without an API client.
+func newProvider(c *Config) (*Provider, error) {
+	p := &Provider{
+		vpcID:       strings.Join(splitVPCs(c.VpcId), ","),
+		credentials: c.Credentials,
+		log:         c.Logger,
+
+		includeDisabled:     c.IncludeDisabledRecords,
+		softDelete:          c.SoftDelete,
+		tombstoneRetention:  c.TombstoneRetention,
+		deletionGuard:       c.DeletionGuard,
+		managedGuard:        c.ManagedRecordGuard,
+		changeBudget:        c.ChangeBudget,
+		applyTimeout:        c.ApplyChangesTimeout,
+		txt:                 txtEscaping{mode: c.TXTEscapeMode, prefixes: c.TXTRegistryPrefixes},
+		targetDot:           c.TargetDotPolicy,
+		defaultTTLs:         c.DefaultTTLs,
+		ttls:                c.TTLs,
+		zoneTTLs:            c.ZoneTTLs,
+		hiddenRegistryOwner: c.HiddenRegistryOwner,
+
+		maxConcurrentZoneQueries: c.MaxConcurrentZoneQueries,
+	}
+	if err := p.ttls.Validate(); err != nil {
+		return nil, err
+	}
+	var err error
+	if p.protected, err = newProtectedNames(c.ProtectedNames); err != nil {
+		return nil, err
+	}
+	if p.remark, err = newRecordRemark(c.RecordRemark, c.ClusterName); err != nil {
+		return nil, err
+	}
+	if len(c.DomainFilter) > 0 || len(c.ExcludeDomains) > 0 {
+		p.domainFilter = *endpoint.NewDomainFilterWithExclusions(c.DomainFilter, c.ExcludeDomains)
+	}
 	return p, nil
 }
 
+// logger returns the provider logger, falling back to the logrus standard logger.
+func (p *Provider) logger() Logger {
+	if p.log == nil {
+		return logrus.StandardLogger()
+	}
+	return p.log
+}
+
+// requestLogger returns the provider logger with the correlation ID of the webhook request in ctx, so the logs of one
+// ApplyChanges can be traced across the API calls it made.
+func (p *Provider) requestLogger(ctx context.Context) Logger {
+	return contextLogger(ctx, p.logger())
+}
+
+// Credentials returns the credentials of the API calls, e.g. for other Volcengine services.
+func (p *Provider) Credentials() *credentials.Credentials {
+	return p.credentials
+}
+
 func (p *Provider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return &p.domainFilter
 }
 
+// filterDomains drops the endpoints outside the domain filter, e.g. names of excluded subdomains.
+func (p *Provider) filterDomains(action string, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if !p.domainFilter.IsConfigured() {
+		return endpoints
+	}
+	res := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if !p.domainFilter.Match(ep.DNSName) {
+			p.logger().Debugf("Skip %s of %s %s by domainFilter", action, ep.DNSName, ep.RecordType)
+			continue
+		}
+		res = append(res, ep)
+	}
+	return res
+}
+
 // Records returns the list of endpoints for the provider.
 // Implementation for provider.Provider
 func (p *Provider) Records(ctx context.Context) (endpoints []*endpoint.Endpoint, err error) {
-	logrus.Infof("List Volcengine records, vpc: %s, privatezone:%t", p.vpcID, p.privateZone)
+	p.requestLogger(ctx).Infof("List Volcengine records, vpc: %s, privatezone:%t", p.vpcID, p.privateZone)
+	ctx, span := startSpan(ctx, "Provider.Records", attribute.String("volcengine.vpc", p.vpcID))
+	defer func() {
+		span.SetAttributes(attribute.Int("volcengine.endpoints", len(endpoints)))
+		endSpan(span, err)
+	}()
+	defer p.FlushCache()
 	if p.privateZone {
-		return p.listRecordsByVPC(ctx, p.vpcID)
+		if endpoints, err = p.listRecordsByVPC(ctx, p.vpcID); err != nil {
+			return nil, err
+		}
 	}
-	return endpoints, err
+	if p.publicZones != nil {
+		public, err := p.publicZones.listRecordsByVPC(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, public...)
+	}
+	if !p.privateZone && p.publicZones == nil {
+		return endpoints, nil
+	}
+	endpoints = p.hideRegistryRecords(p.filterDomains("list", endpoints))
+	if p.includeDisabled {
+		p.rememberDisabledTargets(endpoints)
+	}
+	observeRecords(p.vpcID, endpoints)
+	return endpoints, nil
 }
 
 // ApplyChanges applies the given changes to the provider.
@@ -110,20 +441,47 @@ func (p *Provider) ApplyChanges(ctx context.Context, changes *plan.Changes) erro
 		// No op skip
 		return nil
 	}
+	if !p.privateZone && p.publicZones == nil {
+		return nil
+	}
+	ctx, span := startSpan(ctx, "Provider.ApplyChanges",
+		attribute.String("volcengine.vpc", p.vpcID),
+		attribute.Int("volcengine.creates", len(changes.Create)),
+		attribute.Int("volcengine.updates", len(changes.UpdateNew)),
+		attribute.Int("volcengine.deletes", len(changes.Delete)),
+	)
+	ctx, cancel := p.withApplyDeadline(ctx)
+	defer cancel()
+	defer p.FlushCache()
+	supported, unsupported := p.skipUnsupportedRecordTypes(ctx, changes)
+	var errs []error
 	if p.privateZone {
-		return p.applyChangesForPrivateZone(ctx, changes)
+		errs = append(errs, p.applyChangesForPrivateZone(ctx, supported))
 	}
-	return nil
+	if p.publicZones != nil {
+		// each side only applies the endpoints of its own zones
+		errs = append(errs, p.publicZones.applyChangesForPrivateZone(ctx, supported))
+	}
+	if len(unsupported) > 0 {
+		errs = append(errs, newChangeError(ErrCodeUnsupportedRecordType,
+			errors.New("the record types are not supported by the managed zones"), unsupported...))
+	}
+	err := errors.Join(errs...)
+	p.recordChanges(changes, err)
+	observeApplyFailure(err)
+	endSpan(span, err)
+	return err
 }
 
 func (p *Provider) applyChangesForPrivateZone(ctx context.Context, changes *plan.Changes) error {
-	logrus.Infof("ApplyChanges to Volcengine Private Zone: %++v", *changes)
+	p.requestLogger(ctx).Infof("ApplyChanges to Volcengine Private Zone: %++v", *changes)
 
 	// step1: get all private zones bind to vpc
 	vpcZones, err := p.pzClient.ListPrivateZones(ctx, p.vpcID)
 	if err != nil {
-		return err
+		return newChangeError(ErrCodeListZonesFailed, err)
 	}
+	observeZones(p.vpcID, len(vpcZones))
 	zoneNameIDMapper := provider.ZoneIDName{}
 	for _, zoneinfo := range vpcZones {
 		zid := *zoneinfo.ZID
@@ -136,28 +494,55 @@ func (p *Provider) applyChangesForPrivateZone(ctx context.Context, changes *plan
 
 	toCreate = append(toCreate, changes.Create...)
 	toDelete = append(toDelete, changes.Delete...)
-	toUpdate = append(toUpdate, changes.UpdateNew...)
+	toUpdate = append(toUpdate, p.skipUnchangedUpdates(changes.UpdateOld, changes.UpdateNew)...)
+
+	toCreate = p.filterDomains("create", toCreate)
+	toDelete = p.filterDomains("delete", toDelete)
+	toUpdate = p.filterDomains("update", toUpdate)
+
+	toDelete, protectedDeletes := p.skipProtected("delete", toDelete)
+	toUpdate, protectedUpdates := p.skipProtected("update", toUpdate)
+	protected := append(protectedDeletes, protectedUpdates...)
+
+	toCreate, invalidCreates, createErrs := p.skipInvalidTXT("create", toCreate)
+	toUpdate, invalidUpdates, updateErrs := p.skipInvalidTXT("update", toUpdate)
+	invalid := append(invalidCreates, invalidUpdates...)
+
+	if err := p.checkMassDeletion(ctx, vpcZones, zoneNameIDMapper, toDelete); err != nil {
+		return err
+	}
+	toDelete, toCreate = p.applyChangeBudget(toDelete, toCreate)
 
 	if len(toDelete) > 0 {
 		if err := p.deletePrivateZoneRecords(ctx, zoneNameIDMapper, toDelete); err != nil {
-			return err
+			return pendingOnDeadline(ctx, err, toCreate, toUpdate)
 		}
+		observeRecordChanges("delete", len(toDelete))
 	}
 
 	if len(toCreate) > 0 {
 		if err := p.createPrivateZoneRecords(ctx, zoneNameIDMapper, toCreate); err != nil {
-			return err
+			return pendingOnDeadline(ctx, err, toUpdate)
 		}
+		observeRecordChanges("create", len(toCreate))
 	}
 
 	// support update records sometime avoid DNS return NXDOMAIN during update
 	if len(toUpdate) > 0 {
 		if err := p.updatePrivateZoneRecords(ctx, zoneNameIDMapper, toUpdate); err != nil {
-			return err
+			return pendingOnDeadline(ctx, err)
 		}
+		observeRecordChanges("update", len(toUpdate))
 	}
 
-	return nil
+	var errs []error
+	if len(protected) > 0 {
+		errs = append(errs, newChangeError(ErrCodeProtected, fmt.Errorf("protected names are never deleted or overwritten"), protected...))
+	}
+	if len(invalid) > 0 {
+		errs = append(errs, newChangeError(ErrCodeInvalidTXT, errors.Join(append(createErrs, updateErrs...)...), invalid...))
+	}
+	return errors.Join(errs...)
 }
 
 // listRecordsByVPC returns the list of private zones for the given VPC.
@@ -165,109 +550,256 @@ func (p *Provider) listRecordsByVPC(ctx context.Context, vpc string) (endpoints
 	// step 1: get all private zones bind to vpc
 	vpcZones, err := p.pzClient.ListPrivateZones(ctx, vpc)
 	if err != nil {
-		logrus.Errorf("Failed to list volcengine privatezones: %v", err)
+		p.requestLogger(ctx).Errorf("Failed to list volcengine privatezones: %v", err)
 		return nil, err
 	}
+	observeZones(vpc, len(vpcZones))
 
-	// step 2: get all record with private zone
-	for _, zone := range vpcZones {
+	// step 2: get all record with private zone, zones are listed concurrently
+	zoneEndpoints := make([][]*endpoint.Endpoint, len(vpcZones))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(p.zoneQueryConcurrency())
+	for i, zone := range vpcZones {
 		if p.domainFilter.IsConfigured() && !p.domainFilter.Match(volcengine.StringValue(zone.ZoneName)) {
-			logrus.Debugf("Skip zone %s by domainFilter", volcengine.StringValue(zone.ZoneName))
+			p.requestLogger(ctx).Debugf("Skip zone %s by domainFilter", volcengine.StringValue(zone.ZoneName))
 			continue
 		}
-		records, err := p.pzClient.GetPrivateZoneRecords(ctx, int64(volcengine.Int32Value(zone.ZID)))
-		if err != nil {
-			logrus.Errorf("Failed to get privatezone records: %v", err)
-			return nil, err
-		}
+		g.Go(func() error {
+			zid := int64(volcengine.Int32Value(zone.ZID))
+			zctx, span := startSpan(gctx, "Provider.ListZoneRecords", zoneAttributes(zid, volcengine.StringValue(zone.ZoneName))...)
+			start := time.Now()
+			records, err := p.pzClient.GetPrivateZoneRecords(zctx, zid)
+			span.SetAttributes(attribute.Int("volcengine.records", len(records)))
+			endSpan(span, err)
+			if err != nil {
+				observeZoneSync(volcengine.StringValue(zone.ZoneName), start, err)
+				p.requestLogger(ctx).Errorf("Failed to get privatezone records: %v", err)
+				return err
+			}
+			zoneEndpoints[i] = zoneRecordsToEndpoints(zone, records, p.includeDisabled, p.targetDot, p.txt)
+			observeZoneSync(volcengine.StringValue(zone.ZoneName), start, nil)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	// keep the order of the zones
+	endpoints = p.mergeZoneEndpoints(vpcZones, zoneEndpoints)
 
-		if len(records) == 0 {
+	p.requestLogger(ctx).Debugf("Returned Volcengine Private Zone records: %+v", endpoints)
+	return endpoints, nil
+}
+
+// zoneQueryConcurrency returns how many zones are listed at the same time.
+func (p *Provider) zoneQueryConcurrency() int {
+	if p.maxConcurrentZoneQueries <= 0 {
+		return DefaultMaxConcurrentZoneQueries
+	}
+	return p.maxConcurrentZoneQueries
+}
+
+// zoneRecordsToEndpoints converts the records of the zone to one endpoint per name, type and set identifier with all targets,
+// duplicated values are merged and the lowest TTL of the records is used.
+// Disabled records are skipped unless includeDisabled is set, then their targets are listed in
+// the ProviderSpecificDisabledTargets property. Names in targets follow targetDot, weights and lines other than the
+// default are returned in the ProviderSpecificWeight and ProviderSpecificLine properties. TXT values split into several
+// strings are returned joined.
+func zoneRecordsToEndpoints(zone *privatezone.ZoneForListPrivateZonesOutput, records []*privatezone.RecordForListRecordsOutput, includeDisabled bool, targetDot TargetDotPolicy, txt txtEscaping) []*endpoint.Endpoint {
+	var endpoints []*endpoint.Endpoint
+	recordsMap := groupPrivateZoneRecords(removeTombstones(records))
+	for _, recordList := range recordsMap {
+		var (
+			record          Record
+			ttl             int
+			targets         = make([]string, 0)
+			disabledTargets = make([]string, 0)
+			seen            = make(map[string]bool)
+		)
+		for _, r := range recordList {
+			r.Target = targetDot.apply(r.Type, r.Target)
+			if r.Type == endpoint.RecordTypeTXT {
+				r.Target = txt.decode(r.Target)
+			}
+			if r.Disabled {
+				if !includeDisabled {
+					continue
+				}
+				disabledTargets = append(disabledTargets, r.Target)
+			}
+			if len(targets) == 0 {
+				record = r
+			}
+			if r.TTL > 0 && (ttl == 0 || r.TTL < ttl) {
+				ttl = r.TTL
+			}
+			if seen[r.Target] {
+				continue
+			}
+			seen[r.Target] = true
+			targets = append(targets, r.Target)
+		}
+		if len(targets) == 0 {
 			continue
 		}
-		// step 3: convert record to endpoint, merge targets with same host and type
-		recordsMap := groupPrivateZoneRecords(records)
-		for _, recordList := range recordsMap {
-			record := recordList[0]
-			dnsName := getDNSName(record.Host, *zone.ZoneName)
-			ttl := record.TTL
-			targets := make([]string, 0)
-			for _, r := range recordList {
-				target := r.Target
-				//if record.Type == "TXT" {
-				//	target = unescapeTXTRecordValue(target)
-				//	logrus.Debugf("Unescaped TXT record target: (%s)", target)
-				//}
-				targets = append(targets, target)
-			}
-			// Domain: record.Host + "." + zoneInfo.ZoneName
-			// Type:  record.Type
-			// Target: record.Value
-			// TTL: record.TTL
-			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(dnsName, record.Type, endpoint.TTL(ttl), targets...))
+		sort.Strings(targets)
+		dnsName := getDNSName(strings.ToLower(record.Host), *zone.ZoneName)
+		// Domain: record.Host + "." + zoneInfo.ZoneName
+		// Type:  record.Type
+		// Target: record.Value
+		// TTL: record.TTL
+		ep := endpoint.NewEndpointWithTTL(dnsName, record.Type, endpoint.TTL(ttl), targets...)
+		if targetDot == TargetDotAppend {
+			// NewEndpointWithTTL strips the trailing dot of targets
+			ep.Targets = targets
+		}
+		for key, value := range decodeRemark(record.Remark) {
+			ep.Labels[key] = value
 		}
+		if record.SetIdentifier != "" {
+			ep.WithSetIdentifier(record.SetIdentifier)
+		}
+		if weight := recordWeight(int32(record.Weight)); weight != defaultRecordWeight {
+			ep.WithProviderSpecific(ProviderSpecificWeight, strconv.Itoa(int(weight)))
+		}
+		if record.Line != "" {
+			ep.WithProviderSpecific(ProviderSpecificLine, record.Line)
+		}
+		if len(disabledTargets) > 0 {
+			sort.Strings(disabledTargets)
+			ep.WithProviderSpecific(ProviderSpecificDisabledTargets, strings.Join(disabledTargets, ","))
+		}
+		endpoints = append(endpoints, ep)
 	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].DNSName != endpoints[j].DNSName {
+			return endpoints[i].DNSName < endpoints[j].DNSName
+		}
+		if endpoints[i].RecordType != endpoints[j].RecordType {
+			return endpoints[i].RecordType < endpoints[j].RecordType
+		}
+		return endpoints[i].SetIdentifier < endpoints[j].SetIdentifier
+	})
+	return endpoints
+}
 
-	logrus.Debugf("Returned Volcengine Private Zone records: %+v", endpoints)
-	return endpoints, nil
+// mergeZoneEndpoints returns one endpoint per name and type, given the endpoints of each zone.
+// A name present in nested zones, like a.b.example.com in example.com and b.example.com,
+// is taken from the longest zone, which is the zone it resolves from and changes are applied to.
+func (p *Provider) mergeZoneEndpoints(zones []*privatezone.ZoneForListPrivateZonesOutput, zoneEndpoints [][]*endpoint.Endpoint) []*endpoint.Endpoint {
+	type owner struct {
+		index    int
+		zoneName string
+	}
+	owners := make(map[string]owner)
+	var endpoints []*endpoint.Endpoint
+	for i, eps := range zoneEndpoints {
+		zoneName := volcengine.StringValue(zones[i].ZoneName)
+		for _, ep := range eps {
+			key := ep.RecordType + ":" + ep.DNSName + ":" + ep.SetIdentifier
+			prev, ok := owners[key]
+			if !ok {
+				owners[key] = owner{index: len(endpoints), zoneName: zoneName}
+				endpoints = append(endpoints, ep)
+				continue
+			}
+			if len(zoneName) > len(prev.zoneName) {
+				p.logger().Debugf("Endpoint %s %s of zone %s shadows the one of zone %s", ep.DNSName, ep.RecordType, zoneName, prev.zoneName)
+				endpoints[prev.index] = ep
+				owners[key] = owner{index: prev.index, zoneName: zoneName}
+				continue
+			}
+			p.logger().Debugf("Endpoint %s %s of zone %s is shadowed by the one of zone %s", ep.DNSName, ep.RecordType, zoneName, prev.zoneName)
+		}
+	}
+	return endpoints
 }
 
 func (p *Provider) createPrivateZoneRecords(ctx context.Context, zones provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
 	if len(endpoints) == 0 {
-		logrus.Info("No endpoints to create")
+		p.requestLogger(ctx).Info("No endpoints to create")
 		return nil
 	}
 
-	endpointsByZone := separateCreateChange(zones, endpoints)
+	endpointsByZone := p.separateCreateChange(zones, endpoints)
 	recordsMap := make(map[int64][]*privatezone.RecordForBatchCreateRecordInput)
+	endpointsMap := make(map[int64][]*endpoint.Endpoint)
 	for zid, ep := range endpointsByZone {
 		zidInt, err := strconv.ParseInt(zid, 10, 64)
 		if err != nil {
-			logrus.Errorf("Failed to parse zid: %s", zid)
-			return err
+			p.requestLogger(ctx).Errorf("Failed to parse zid: %s", zid)
+			return newChangeError(ErrCodeInvalidZone, err, ep...)
 		}
 		recordsMap[zidInt] = make([]*privatezone.RecordForBatchCreateRecordInput, 0)
+		endpointsMap[zidInt] = ep
 
 		for _, record := range ep {
+			if err := validateEndpoint(record, p.public); err != nil {
+				p.requestLogger(ctx).Errorf("Skipping DNS creation of invalid endpoint: %v", err)
+				continue
+			}
+			weight, _ := endpointWeight(record)
+			line, _ := endpointLine(record)
 			for _, target := range record.Targets {
 				host, domain := splitDNSName(record.DNSName, zones[zid])
 				if domain == "" {
-					logrus.Errorf("Failed to parse domain: %s, zoneId: %d, zoneName: %s", record.DNSName, zidInt, zones[zid])
+					p.requestLogger(ctx).Errorf("Failed to parse domain: %s, zoneId: %d, zoneName: %s", record.DNSName, zidInt, zones[zid])
 					continue
 				}
 				value := target // Create a local variable copy
 				if record.RecordType == "TXT" {
-					value = escapeTXTRecordValue(value)
-					logrus.Tracef("Escape txt record for zone with value (%s), host: %s, zid: %d", value, host, zidInt)
+					value = p.txt.escape(value)
+					p.requestLogger(ctx).Tracef("Escape txt record for zone with value (%s), host: %s, zid: %d", value, host, zidInt)
 				}
+				value = normalizeTarget(record.RecordType, value)
+				value = p.targetDot.write(record.RecordType, value)
 				var ttl *int32
-				if record.RecordTTL > 0 {
-					ttlInt32 := int32(record.RecordTTL)
+				if recordTTL := p.recordTTL(record); recordTTL > 0 {
+					ttlInt32 := int32(recordTTL)
 					ttl = &ttlInt32
 				}
-				recordsMap[zidInt] = append(recordsMap[zidInt], &privatezone.RecordForBatchCreateRecordInput{
+				input := &privatezone.RecordForBatchCreateRecordInput{
 					Host:   &host,
 					Type:   &record.RecordType,
 					Value:  &value, // Use the address of the local variable
 					TTL:    ttl,
-					Remark: volcengine.String(defaultRecordRemark),
-				})
+					Remark: volcengine.String(p.encodeRemark(record.Labels, record.SetIdentifier)),
+				}
+				if weight := createWeight(weight); weight > 0 {
+					input.Weight = &weight
+				}
+				if line != "" {
+					input.Line = volcengine.String(line)
+				}
+				recordsMap[zidInt] = append(recordsMap[zidInt], input)
 			}
 		}
 	}
+	handled := make(map[*endpoint.Endpoint]bool, len(endpoints))
 	for zid, records := range recordsMap {
 		if len(records) == 0 {
 			continue
 		}
-		if err := p.pzClient.BatchCreatePrivateZoneRecord(ctx, zid, records); err != nil {
-			logrus.Errorf("Failed to batch create private zone record: %s", err)
-			return err
+		if err := applyDeadlineExceeded(ctx); err != nil {
+			return newChangeError(ErrCodeDeadlineExceeded, err, unhandled(endpoints, handled)...)
+		}
+		for _, ep := range endpointsMap[zid] {
+			handled[ep] = true
+		}
+		zctx, span := startSpan(ctx, "Provider.CreateZoneRecords", append(zoneAttributes(zid, zones[strconv.FormatInt(zid, 10)]),
+			attribute.Int("volcengine.records", len(records)))...)
+		err := p.pzClient.BatchCreatePrivateZoneRecord(zctx, zid, records)
+		endSpan(span, err)
+		if err != nil {
+			p.requestLogger(ctx).Errorf("Failed to batch create private zone record: %s", err)
+			return newChangeError(ErrCodeCreateFailed, err, endpointsMap[zid]...)
 		}
 	}
 	return nil
 }
 
 // separateCreateChange separates a multi-zone change into a single change per zone.
-func separateCreateChange(zoneMap provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
+func (p *Provider) separateCreateChange(zoneMap provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
 	createsByZone := make(map[string][]*endpoint.Endpoint, len(zoneMap))
 	for zid := range zoneMap {
 		createsByZone[zid] = make([]*endpoint.Endpoint, 0)
@@ -276,140 +808,189 @@ func separateCreateChange(zoneMap provider.ZoneIDName, endpoints []*endpoint.End
 		zone, zoneName := zoneMap.FindZone(ep.DNSName)
 		if zone != "" {
 			createsByZone[zone] = append(createsByZone[zone], ep)
-			logrus.Debugf("Adding DNS creation of endpoint: '%s' type: '%s', zoneId: %s, zoneName: %s", ep.DNSName, ep.RecordType, zone, zoneName)
+			p.logger().Debugf("Adding DNS creation of endpoint: '%s' type: '%s', zoneId: %s, zoneName: %s", ep.DNSName, ep.RecordType, zone, zoneName)
 			continue
 		}
-		logrus.Debugf("Skipping DNS creation of endpoint: '%s' type: '%s', it does not match against Domain filters", ep.DNSName, ep.RecordType)
+		p.logger().Debugf("Skipping DNS creation of endpoint: '%s' type: '%s', it does not match against Domain filters", ep.DNSName, ep.RecordType)
 	}
 
 	return createsByZone
 }
 
+// deletePrivateZoneRecords deletes the records of the endpoints zone by zone, in the order the zones first appear,
+// so a sync costs a listing and a batch delete per zone instead of calls per endpoint, see deleteZoneRecords.
 func (p *Provider) deletePrivateZoneRecords(ctx context.Context, zoneMap provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
+	var zones []string
 	deletesByZone := make(map[string][]*endpoint.Endpoint, len(zoneMap))
-	for _, z := range zoneMap {
-		deletesByZone[z] = make([]*endpoint.Endpoint, 0)
-	}
 	for _, ep := range endpoints {
 		// match longest zone name, private zone use longest zone name override short zone name
 		zone, zoneName := zoneMap.FindZone(ep.DNSName)
 		if zone != "" {
+			if _, ok := deletesByZone[zone]; !ok {
+				zones = append(zones, zone)
+			}
 			deletesByZone[zone] = append(deletesByZone[zone], ep)
-			logrus.Debugf("Adding DNS deletion of endpoint: '%s' type: '%s', zoneId: %s, zoneName: %s", ep.DNSName, ep.RecordType, zone, zoneName)
+			p.requestLogger(ctx).Debugf("Adding DNS deletion of endpoint: '%s' type: '%s', zoneId: %s, zoneName: %s", ep.DNSName, ep.RecordType, zone, zoneName)
 			continue
 		}
-		logrus.Debugf("Skipping DNS deletion of endpoint: '%s' type: '%s', it does not match against Domain filters", ep.DNSName, ep.RecordType)
+		p.requestLogger(ctx).Debugf("Skipping DNS deletion of endpoint: '%s' type: '%s', it does not match against Domain filters", ep.DNSName, ep.RecordType)
 	}
-	for zone, deletes := range deletesByZone {
-		if len(deletes) == 0 {
-			continue
+	handled := make(map[*endpoint.Endpoint]bool, len(endpoints))
+	for _, zone := range zones {
+		deletes := deletesByZone[zone]
+		if err := applyDeadlineExceeded(ctx); err != nil {
+			return newChangeError(ErrCodeDeadlineExceeded, err, unhandled(endpoints, handled)...)
 		}
 		zidInt, err := strconv.ParseInt(zone, 10, 64)
 		if err != nil {
-			logrus.Errorf("Failed to parse zid: %s", zone)
-			return err
+			p.requestLogger(ctx).Errorf("Failed to parse zid: %s", zone)
+			return newChangeError(ErrCodeInvalidZone, err, deletes...)
 		}
 		for _, ep := range deletes {
-			zoneName := zoneMap[zone]
-			host, domain := splitDNSName(ep.DNSName, zoneName)
-			logrus.Debugf("Deleting DNS record: '%s' type: '%s', zoneId: %s, zoneName: %s, host: %s, domain: %s", ep.DNSName, ep.RecordType, zone, zoneName, host, domain)
-			if err := p.pzClient.DeletePrivateZoneRecord(ctx, zidInt, host, ep.RecordType, ep.Targets); err != nil {
-				logrus.Errorf("Failed to delete private zone record: %s", err)
-				return err
-			}
+			handled[ep] = true
+			p.requestLogger(ctx).Debugf("Deleting DNS record: '%s' type: '%s', zoneId: %s, zoneName: %s", ep.DNSName, ep.RecordType, zone, zoneMap[zone])
+		}
+		if err := p.deleteZoneRecords(ctx, zidInt, zoneMap[zone], deletes); err != nil {
+			p.requestLogger(ctx).Errorf("Failed to delete private zone records: %s", err)
+			return newChangeError(ErrCodeDeleteFailed, err, deletes...)
 		}
 	}
 	return nil
 }
 
+// updatePrivateZoneRecords updates the records of the endpoints in place, see updateRecordSet.
 func (p *Provider) updatePrivateZoneRecords(ctx context.Context, zoneMap provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
-	for _, ep := range endpoints {
+	for i, ep := range endpoints {
+		if err := applyDeadlineExceeded(ctx); err != nil {
+			return newChangeError(ErrCodeDeadlineExceeded, err, endpoints[i:]...)
+		}
+		if err := validateEndpoint(ep, p.public); err != nil {
+			p.requestLogger(ctx).Errorf("Skipping DNS update of invalid endpoint: %v", err)
+			continue
+		}
 		// match the longest zone name, private zone use the longest zone name override short zone name
 		zid, zoneName := zoneMap.FindZone(ep.DNSName)
 		if zid == "" {
-			logrus.Debugf("Skipping DNS update of endpoint: '%s' type: '%s', it does not match against Domain filters", ep.DNSName, ep.RecordType)
+			p.requestLogger(ctx).Debugf("Skipping DNS update of endpoint: '%s' type: '%s', it does not match against Domain filters", ep.DNSName, ep.RecordType)
 			continue
 		}
 		host, _ := splitDNSName(ep.DNSName, zoneName)
 		zidInt, err := strconv.ParseInt(zid, 10, 64)
 		if err != nil {
-			logrus.Errorf("Failed to parse zid: %s", zid)
-			return err
+			p.requestLogger(ctx).Errorf("Failed to parse zid: %s", zid)
+			return newChangeError(ErrCodeInvalidZone, err, ep)
 		}
-		zoneRecords, err := p.pzClient.GetPrivateZoneRecords(ctx, zidInt)
+		zctx, span := startSpan(ctx, "Provider.UpdateRecordSet", append(zoneAttributes(zidInt, zoneName),
+			attribute.String("dns.name", ep.DNSName), attribute.String("dns.type", ep.RecordType))...)
+		zoneRecords, err := p.pzClient.GetPrivateZoneRecordsByHostType(zctx, zidInt, host, ep.RecordType)
 		if err != nil {
-			logrus.Errorf("Failed to get private zone records: %s", err)
-			return err
+			endSpan(span, err)
+			p.requestLogger(ctx).Errorf("Failed to get private zone records: %s", err)
+			return newChangeError(ErrCodeUpdateFailed, err, ep)
 		}
-		// update record ttl only if record type is A, AAAA, CNAME, TXT
-		// delete record if not found in endpoint targets
-		for _, record := range zoneRecords {
-			if volcengine.StringValue(record.Host) != host || volcengine.StringValue(record.Type) != ep.RecordType {
-				continue
-			}
-			value := volcengine.StringValue(record.Value)
-			if volcengine.StringValue(record.Type) == "TXT" {
-				value = unescapeTXTRecordValue(value)
-			}
-			if volcengine.StringValue(record.Type) == "CNAME" {
-				value = normalizeDomain(value)
-			}
-			found := false
-			for _, target := range ep.Targets {
-				// Find matched record to delete
-				if value == target {
-					found = true
-					break
-				}
+		// records of other set identifiers belong to other endpoints
+		zoneRecords = filterSetIdentifier(removeTombstones(zoneRecords), ep.SetIdentifier)
+		p.updateRecordSet(zctx, zidInt, host, ep, zoneRecords)
+		endSpan(span, nil)
+	}
+	return nil
+}
+
+// updateRecordSet converges the records of the endpoint host and type to its targets without a resolution gap:
+// records of a target are kept and get the endpoint TTL, weight and line, records of removed targets are rewritten in place to the
+// new targets with UpdateRecord, only the surplus is created and, once the new targets exist, deleted. Disabled records of
+// removed targets are not rewritten, UpdateRecord would leave the new target disabled.
+// Failed calls are logged and left to the next sync.
+func (p *Provider) updateRecordSet(ctx context.Context, zid int64, host string, ep *endpoint.Endpoint, zoneRecords []*privatezone.RecordForListRecordsOutput) {
+	ttl := p.recordTTL(ep)
+	weight, _ := endpointWeight(ep)
+	line, _ := endpointLine(ep)
+	remark := p.encodeRemark(ep.Labels, ep.SetIdentifier)
+	matched := make(map[string]bool, len(ep.Targets))
+	var stale, disabled []*privatezone.RecordForListRecordsOutput
+	for _, record := range zoneRecords {
+		if volcengine.StringValue(record.Host) != host || volcengine.StringValue(record.Type) != ep.RecordType {
+			continue
+		}
+		value := volcengine.StringValue(record.Value)
+		if ep.RecordType == "TXT" {
+			value = p.txt.unescape(value)
+		}
+		target, found := "", false
+		for _, t := range ep.Targets {
+			if !matched[t] && sameTarget(ep.RecordType, value, t) {
+				target, found = t, true
+				break
 			}
+		}
+		if !p.managedGuard.manages(record) {
+			// the record keeps its value, TTL and remark, a matching target is not created again
 			if found {
-				if ep.RecordTTL.IsConfigured() && int64(ep.RecordTTL) != int64(volcengine.Int32Value(record.TTL)) {
-					// Update record ttl only
-					err := p.pzClient.UpdatePrivateZoneRecord(ctx, int64(volcengine.Int32Value(record.ZID)), volcengine.StringValue(record.RecordID),
-						volcengine.StringValue(record.Host), volcengine.StringValue(record.Type), volcengine.StringValue(record.Value), int32(ep.RecordTTL))
-					if err != nil {
-						logrus.Errorf("Failed to update private zone record: %s", err)
-						// continue to next record
-						continue
-					}
-				}
+				matched[target] = true
 			} else {
-				err := p.pzClient.DeletePrivateZoneRecordById(ctx, int64(volcengine.Int32Value(record.ZID)), volcengine.StringValue(record.RecordID))
-				if err != nil {
-					logrus.Errorf("Failed to delete private zone record: %s", err)
-					// continue to next record
-					continue
-				}
+				p.skipUnmanaged("update", zid, record)
 			}
+			continue
 		}
-		// create record if not found in private zone records
-		for _, target := range ep.Targets {
-			if ep.RecordType == "TXT" {
-				target = escapeTXTRecordValue(target)
+		if !found {
+			if record.Enable != nil && !*record.Enable {
+				// UpdateRecord cannot enable it, the rewritten target would not resolve
+				p.requestLogger(ctx).Infof("Not rewriting disabled record %s of %s in place, it is deleted and the new target created",
+					volcengine.StringValue(record.RecordID), ep.DNSName)
+				disabled = append(disabled, record)
+				continue
 			}
-			if ep.RecordType == "CNAME" {
-				target = completeCNAMEValue(target)
+			stale = append(stale, record)
+			continue
+		}
+		matched[target] = true
+		// update record ttl, weight and line only if they changed
+		recordTTL := volcengine.Int32Value(record.TTL)
+		ttlChanged := ttl.IsConfigured() && int64(ttl) != int64(recordTTL)
+		if ttlChanged || updateWeight(record, weight) != 0 || updateLine(record, line) != "" {
+			if ttl.IsConfigured() {
+				recordTTL = int32(ttl)
 			}
-			found := false
-			for _, record := range zoneRecords {
-				if volcengine.StringValue(record.Host) != host || volcengine.StringValue(record.Type) != ep.RecordType {
-					continue
-				}
-				// Find matched record to delete
-				if volcengine.StringValue(record.Value) == target {
-					found = true
-					break
-				}
+			if err := p.pzClient.UpdatePrivateZoneRecord(ctx, zid, volcengine.StringValue(record.RecordID), host, ep.RecordType,
+				volcengine.StringValue(record.Value), recordTTL, updateWeight(record, weight), updateLine(record, line), mergeRemark(volcengine.StringValue(record.Remark), remark)); err != nil {
+				p.requestLogger(ctx).Errorf("Failed to update private zone record: %s", err)
 			}
-			if !found {
-				err := p.pzClient.CreatePrivateZoneRecord(ctx, zidInt, host, ep.RecordType, target, int32(ep.RecordTTL))
-				if err != nil {
-					logrus.Errorf("Failed to create private zone record: %s", err)
-					// continue to next record
-					continue
-				}
+		}
+	}
+
+	for _, target := range ep.Targets {
+		if matched[target] {
+			continue
+		}
+		matched[target] = true
+		value := target
+		if ep.RecordType == "TXT" {
+			value = p.txt.escape(value)
+		}
+		value = normalizeTarget(ep.RecordType, value)
+		value = p.targetDot.write(ep.RecordType, value)
+		if len(stale) == 0 {
+			if err := p.pzClient.CreatePrivateZoneRecord(ctx, zid, host, ep.RecordType, value, int32(ttl), createWeight(weight), line, remark); err != nil {
+				p.requestLogger(ctx).Errorf("Failed to create private zone record: %s", err)
 			}
+			continue
+		}
+		// rewrite a record of a removed target, so the name keeps resolving
+		record := stale[0]
+		stale = stale[1:]
+		recordTTL := volcengine.Int32Value(record.TTL)
+		if ttl.IsConfigured() {
+			recordTTL = int32(ttl)
+		}
+		if err := p.pzClient.UpdatePrivateZoneRecord(ctx, zid, volcengine.StringValue(record.RecordID), host, ep.RecordType,
+			value, recordTTL, updateWeight(record, weight), updateLine(record, line), mergeRemark(volcengine.StringValue(record.Remark), remark)); err != nil {
+			p.requestLogger(ctx).Errorf("Failed to update private zone record: %s", err)
+		}
+	}
+
+	for _, record := range append(stale, disabled...) {
+		if err := p.deleteRecord(ctx, zid, record); err != nil {
+			p.requestLogger(ctx).Errorf("Failed to delete private zone record: %s", err)
 		}
 	}
-	return nil
 }
@@ -17,11 +17,18 @@ package volcengine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
 	"github.com/volcengine/volcengine-go-sdk/volcengine"
 	"sigs.k8s.io/external-dns/endpoint"
@@ -43,8 +50,13 @@ func (m *MockPrivateZoneAPI) GetPrivateZoneRecords(ctx context.Context, zid int6
 	return args.Get(0).([]*privatezone.RecordForListRecordsOutput), args.Error(1)
 }
 
-func (m *MockPrivateZoneAPI) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, domain, recordType, target string, TTL int32) error {
-	args := m.Called(ctx, zoneID, domain, recordType, target, TTL)
+func (m *MockPrivateZoneAPI) GetPrivateZoneRecordsByHostType(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	args := m.Called(ctx, zid, host, recordType)
+	return args.Get(0).([]*privatezone.RecordForListRecordsOutput), args.Error(1)
+}
+
+func (m *MockPrivateZoneAPI) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, domain, recordType, target string, TTL, weight int32, line, remark string) error {
+	args := m.Called(ctx, zoneID, domain, recordType, target, TTL, weight, line, remark)
 	return args.Error(0)
 }
 
@@ -53,13 +65,13 @@ func (m *MockPrivateZoneAPI) BatchCreatePrivateZoneRecord(ctx context.Context, z
 	return args.Error(0)
 }
 
-func (m *MockPrivateZoneAPI) DeletePrivateZoneRecord(ctx context.Context, zoneID int64, host string, recordType string, targets []string) error {
-	args := m.Called(ctx, zoneID, host, recordType, targets)
+func (m *MockPrivateZoneAPI) BatchDeletePrivateZoneRecords(ctx context.Context, zoneID int64, recordIDs []string) error {
+	args := m.Called(ctx, zoneID, recordIDs)
 	return args.Error(0)
 }
 
-func (m *MockPrivateZoneAPI) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL int32) error {
-	args := m.Called(ctx, zoneID, recordID, host, recordType, target, TTL)
+func (m *MockPrivateZoneAPI) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL, weight int32, line, remark string) error {
+	args := m.Called(ctx, zoneID, recordID, host, recordType, target, TTL, weight, line, remark)
 	return args.Error(0)
 }
 
@@ -68,6 +80,11 @@ func (m *MockPrivateZoneAPI) DeletePrivateZoneRecordById(ctx context.Context, zo
 	return args.Error(0)
 }
 
+func (m *MockPrivateZoneAPI) DisablePrivateZoneRecord(ctx context.Context, zoneID int64, recordID, remark string) error {
+	args := m.Called(ctx, zoneID, recordID, remark)
+	return args.Error(0)
+}
+
 func TestNewVolcengineProvider(t *testing.T) {
 	// Test successful Provider creation
 	options := []Option{
@@ -84,6 +101,25 @@ func TestNewVolcengineProvider(t *testing.T) {
 	assert.True(t, provider.privateZone)
 }
 
+func TestNewVolcengineProviderPublicCache(t *testing.T) {
+	provider, err := NewVolcengineProvider([]Option{
+		WithPrivateZone("cn-beijing", "vpc-123456"),
+		WithDNSMode(DNSModePublic),
+		WithCache(time.Minute, ""),
+	})
+	assert.NoError(t, err)
+	assert.False(t, provider.privateZone)
+	assert.NotNil(t, provider.publicZones)
+	dryRun, ok := provider.publicZones.pzClient.(*dryRunAPI)
+	require.True(t, ok, "public zone writes can be switched to dry-run")
+	assert.False(t, dryRun.active.Load())
+	cache, ok := dryRun.privateZoneAPI.(*cachedPrivateZoneAPI)
+	assert.True(t, ok, "public zone listings are cached")
+	if ok {
+		assert.IsType(t, &CloudDNSWrapper{}, cache.privateZoneAPI)
+	}
+}
+
 func TestProviderRecords(t *testing.T) {
 	// Create a mock privateZoneAPI
 	mockAPI := new(MockPrivateZoneAPI)
@@ -107,6 +143,7 @@ func TestProviderRecords(t *testing.T) {
 			Value:    volcengine.String("1.2.3.4"),
 			TTL:      volcengine.Int32(60),
 			RecordID: volcengine.String("record-1"),
+			Remark:   volcengine.String("managed by external-dns; owner=default"),
 		},
 	}
 
@@ -134,11 +171,198 @@ func TestProviderRecords(t *testing.T) {
 	assert.Equal(t, "A", endpoints[0].RecordType)
 	assert.Equal(t, "1.2.3.4", endpoints[0].Targets[0])
 	assert.Equal(t, endpoint.TTL(60), endpoints[0].RecordTTL)
+	assert.Equal(t, "default", endpoints[0].Labels[endpoint.OwnerLabelKey])
 
 	// Verify mock methods were called
 	mockAPI.AssertExpectations(t)
 }
 
+func TestProviderRecordsConcurrentZones(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	var mockZones []*privatezone.ZoneForListPrivateZonesOutput
+	for i := int32(1); i <= 20; i++ {
+		zoneName := fmt.Sprintf("zone%d.com", i)
+		mockZones = append(mockZones, &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(i), ZoneName: volcengine.String(zoneName)})
+		mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(i)).Return([]*privatezone.RecordForListRecordsOutput{
+			{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4")},
+		}, nil)
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(mockZones, nil)
+
+	provider := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123", maxConcurrentZoneQueries: 4}
+	endpoints, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 20)
+	// endpoints keep the order of the zones
+	for i, ep := range endpoints {
+		assert.Equal(t, fmt.Sprintf("www.zone%d.com", i+1), ep.DNSName)
+	}
+
+	// a failed zone fails the listing
+	failingAPI := new(MockPrivateZoneAPI)
+	failingAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(mockZones[:2], nil)
+	failingAPI.On("GetPrivateZoneRecords", mock.Anything, int64(1)).Return([]*privatezone.RecordForListRecordsOutput{}, nil)
+	failingAPI.On("GetPrivateZoneRecords", mock.Anything, int64(2)).Return([]*privatezone.RecordForListRecordsOutput{}, errors.New("API error"))
+	provider.pzClient = failingAPI
+	_, err = provider.Records(context.Background())
+	assert.EqualError(t, err, "API error")
+}
+
+func TestProviderRecordsZoneQueryLimit(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	var mockZones []*privatezone.ZoneForListPrivateZonesOutput
+	var inFlight, maxInFlight atomic.Int32
+	for i := int32(1); i <= 12; i++ {
+		mockZones = append(mockZones, &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(i), ZoneName: volcengine.String(fmt.Sprintf("zone%d.com", i))})
+		mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(i)).Run(func(mock.Arguments) {
+			n := inFlight.Add(1)
+			for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+			}
+			time.Sleep(10 * time.Millisecond)
+			inFlight.Add(-1)
+		}).Return([]*privatezone.RecordForListRecordsOutput{}, nil)
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(mockZones, nil)
+
+	c := defaultConfig()
+	WithMaxConcurrentZoneQueries(3)(c)
+	provider, err := newProvider(c)
+	require.NoError(t, err)
+	provider.pzClient, provider.privateZone, provider.vpcID = mockAPI, true, "vpc-123"
+	_, err = provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	mockAPI.AssertNumberOfCalls(t, "GetPrivateZoneRecords", 12)
+
+	provider, err = newProvider(defaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMaxConcurrentZoneQueries, provider.zoneQueryConcurrency())
+}
+
+func TestProviderRecordsDisabled(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), Enable: volcengine.Bool(true)},
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.5"), Enable: volcengine.Bool(false)},
+		{Host: volcengine.String("off"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.6"), Enable: volcengine.Bool(false)},
+	}, nil)
+
+	// Disabled records are skipped by default
+	provider := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+	endpoints, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, endpoints[0].Targets)
+	assert.Empty(t, endpoints[0].ProviderSpecific)
+
+	// Included disabled records are annotated
+	provider.includeDisabled = true
+	endpoints, err = provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 2)
+	for _, ep := range endpoints {
+		disabled, ok := ep.GetProviderSpecificProperty(ProviderSpecificDisabledTargets)
+		assert.True(t, ok)
+		switch ep.DNSName {
+		case "www.example.com":
+			assert.Equal(t, endpoint.Targets{"1.2.3.4", "1.2.3.5"}, ep.Targets)
+			assert.Equal(t, "1.2.3.5", disabled)
+		case "off.example.com":
+			assert.Equal(t, "1.2.3.6", disabled)
+		default:
+			t.Errorf("unexpected endpoint %s", ep.DNSName)
+		}
+	}
+}
+
+func TestProviderRecordsGrouped(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(1), ZoneName: volcengine.String("example.com")},
+		{ZID: volcengine.Int32(2), ZoneName: volcengine.String("b.example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(1)).Return([]*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.5"), TTL: volcengine.Int32(300)},
+		{Host: volcengine.String("WWW"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), TTL: volcengine.Int32(60)},
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), TTL: volcengine.Int32(60)},
+		{Host: volcengine.String("a.b"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.1")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(2)).Return([]*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.2")},
+	}, nil)
+
+	provider := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+	endpoints, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 2)
+
+	// one endpoint per name and type, with deduplicated sorted targets and the lowest TTL
+	assert.Equal(t, "www.example.com", endpoints[1].DNSName)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4", "1.2.3.5"}, endpoints[1].Targets)
+	assert.Equal(t, endpoint.TTL(60), endpoints[1].RecordTTL)
+
+	// the record of the longest zone wins
+	assert.Equal(t, "a.b.example.com", endpoints[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"10.0.0.2"}, endpoints[0].Targets)
+}
+
+func TestProviderSetIdentifier(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	records := []*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("record-1"), ZID: volcengine.Int32(123), Host: volcengine.String("www"), Type: volcengine.String("A"),
+			Value: volcengine.String("1.2.3.4"), Remark: volcengine.String((&Provider{}).encodeRemark(nil, "blue"))},
+		{RecordID: volcengine.String("record-2"), ZID: volcengine.Int32(123), Host: volcengine.String("www"), Type: volcengine.String("A"),
+			Value: volcengine.String("5.6.7.8"), Remark: volcengine.String((&Provider{}).encodeRemark(nil, "green"))},
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(records, nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return(records, nil)
+
+	provider := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+	endpoints, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 2)
+	assert.Equal(t, "blue", endpoints[0].SetIdentifier)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, endpoints[0].Targets)
+	assert.Equal(t, "green", endpoints[1].SetIdentifier)
+
+	// Updating the blue endpoint rewrites its record and leaves the green records alone
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "1.2.3.5", int32(0), int32(0), "", (&Provider{}).encodeRemark(nil, "blue")).Return(nil).Once()
+	blue := endpoint.NewEndpoint("www.example.com", "A", "1.2.3.5").WithSetIdentifier("blue")
+	assert.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{UpdateNew: []*endpoint.Endpoint{blue}}))
+
+	// Deleting the green endpoint deletes its records by ID
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"record-2"}).Return(nil).Once()
+	green := endpoint.NewEndpoint("www.example.com", "A", "5.6.7.8").WithSetIdentifier("green")
+	assert.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{Delete: []*endpoint.Endpoint{green}}))
+
+	mockAPI.AssertExpectations(t)
+}
+
+func TestProviderInjectedLogger(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{}, nil)
+
+	p, err := NewVolcengineProvider([]Option{WithLogger(logger)})
+	assert.NoError(t, err)
+	p.pzClient = mockAPI
+	p.privateZone = true
+	p.vpcID = "vpc-123"
+
+	_, err = p.Records(context.Background())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hook.AllEntries())
+	assert.Equal(t, "List Volcengine records, vpc: vpc-123, privatezone:true", hook.AllEntries()[0].Message)
+}
+
 func TestProviderApplyChanges(t *testing.T) {
 	// Create a mock privateZoneAPI
 	mockAPI := new(MockPrivateZoneAPI)
@@ -160,7 +384,10 @@ func TestProviderApplyChanges(t *testing.T) {
 
 	// Mock create and delete record responses
 	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	mockAPI.On("DeletePrivateZoneRecord", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("record-1"), Host: volcengine.String("old"), Type: volcengine.String("A"), Value: volcengine.String("5.6.7.8")},
+	}, nil)
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"record-1"}).Return(nil)
 
 	// Create Provider and inject mock API
 	provider := &Provider{
@@ -179,6 +406,105 @@ func TestProviderApplyChanges(t *testing.T) {
 	mockAPI.AssertExpectations(t)
 }
 
+func TestProviderApplyChangesError(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	failed := endpoint.NewEndpoint("old.example.com", "A", "5.6.7.8")
+	changes := &plan.Changes{
+		Delete: []*endpoint.Endpoint{failed},
+	}
+	mockZones := []*privatezone.ZoneForListPrivateZonesOutput{
+		{
+			ZID:      volcengine.Int32(123),
+			ZoneName: volcengine.String("example.com"),
+		},
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(mockZones, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("record-1"), Host: volcengine.String("old"), Type: volcengine.String("A"), Value: volcengine.String("5.6.7.8")},
+	}, nil)
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"record-1"}).Return(errors.New("API error"))
+
+	provider := &Provider{
+		vpcID:       "vpc-123",
+		privateZone: true,
+		pzClient:    mockAPI,
+	}
+	err := provider.ApplyChanges(context.Background(), changes)
+
+	var changeErr *ChangeError
+	assert.ErrorAs(t, err, &changeErr)
+	assert.Equal(t, ErrCodeDeleteFailed, changeErr.Code)
+	assert.Equal(t, []*endpoint.Endpoint{failed}, changeErr.Endpoints)
+	assert.EqualError(t, changeErr.Err, "API error")
+	assert.Equal(t, "DeleteFailed [old.example.com A]: API error", err.Error())
+}
+
+func TestProviderApplyChangesUnsupportedRecordType(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	unsupported := endpoint.NewEndpoint("foo.example.com", "FOO", "1.2.3.4")
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", "A", "1.2.3.4"), unsupported},
+	}
+	mockZones := []*privatezone.ZoneForListPrivateZonesOutput{
+		{
+			ZID:      volcengine.Int32(123),
+			ZoneName: volcengine.String("example.com"),
+		},
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(mockZones, nil)
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 1 && *records[0].Type == "A"
+	})).Return(nil)
+
+	provider := &Provider{
+		vpcID:       "vpc-123",
+		privateZone: true,
+		pzClient:    mockAPI,
+	}
+	err := provider.ApplyChanges(context.Background(), changes)
+
+	var changeErr *ChangeError
+	assert.ErrorAs(t, err, &changeErr)
+	assert.Equal(t, ErrCodeUnsupportedRecordType, changeErr.Code)
+	assert.Equal(t, []*endpoint.Endpoint{unsupported}, changeErr.Endpoints)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestProviderApplyChangesBatchesDeletesByZone(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+		{ZID: volcengine.Int32(456), ZoneName: volcengine.String("other.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("1"), Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1")},
+		{RecordID: volcengine.String("2"), Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("2.2.2.2")},
+		{RecordID: volcengine.String("3"), Host: volcengine.String("b"), Type: volcengine.String("CNAME"), Value: volcengine.String("a.example.com.")},
+		{RecordID: volcengine.String("4"), Host: volcengine.String("c"), Type: volcengine.String("A"), Value: volcengine.String("3.3.3.3")},
+		// the records of another set identifier and tombstones are left alone
+		{RecordID: volcengine.String("6"), Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1"),
+			Remark: volcengine.String((&Provider{}).encodeRemark(nil, "blue"))},
+		{RecordID: volcengine.String("7"), Host: volcengine.String("a"), Type: volcengine.String("A"), Value: volcengine.String("2.2.2.2"),
+			Remark: volcengine.String(tombstoneRemark(defaultRecordRemark, time.Now()))},
+	}, nil).Once()
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(456)).Return([]*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("5"), Host: volcengine.String("@"), Type: volcengine.String("A"), Value: volcengine.String("4.4.4.4")},
+	}, nil).Once()
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"1", "2", "3"}).Return(nil).Once()
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(456), []string{"5"}).Return(nil).Once()
+
+	provider := &Provider{vpcID: "vpc-123", privateZone: true, pzClient: mockAPI}
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{Delete: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", "A", "1.1.1.1", "2.2.2.2"),
+		endpoint.NewEndpoint("other.com", "A", "4.4.4.4"),
+		endpoint.NewEndpoint("b.example.com", "CNAME", "a.example.com"),
+		endpoint.NewEndpoint("missing.example.com", "A", "5.5.5.5"),
+	}})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "GetPrivateZoneRecordsByHostType", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestProviderApplyChangesNil(t *testing.T) {
 	// Create Provider
 	provider := &Provider{}
@@ -221,24 +547,23 @@ func TestUpdatePrivateZoneRecords(t *testing.T) {
 			ZID:      volcengine.Int32(123),
 		},
 	}
-	mockAPI.On("GetPrivateZoneRecords", ctx, int64(123)).Return(mockRecords, nil)
-	mockAPI.On("UpdatePrivateZoneRecord", ctx, int64(123), "record-1", "www", "A", "1.2.3.4", int32(60)).Return(nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return(mockRecords, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "1.2.3.4", int32(60), int32(0), "", defaultRecordRemark).Return(nil)
 
-	// Test Scenario 2: Successfully delete old record and create new record
+	// Test Scenario 2: Successfully rewrite the old record to the new target, keeping its TTL
 	endpoint2 := endpoint.NewEndpoint("www.example.com", "A", "5.6.7.8")
-	mockAPI.On("GetPrivateZoneRecords", ctx, int64(123)).Return(mockRecords, nil)
-	mockAPI.On("DeletePrivateZoneRecordById", ctx, int64(123), "record-1").Return(nil)
-	mockAPI.On("CreatePrivateZoneRecord", ctx, int64(123), "www", "A", "5.6.7.8", int32(0)).Return(nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return(mockRecords, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "5.6.7.8", int32(300), int32(0), "", defaultRecordRemark).Return(nil)
 
 	// Test Scenario 3: Successfully create record
 	endpoint3 := endpoint.NewEndpoint("new.example.com", "A", "9.10.11.12")
 	emptyRecords := []*privatezone.RecordForListRecordsOutput{}
-	mockAPI.On("GetPrivateZoneRecords", ctx, int64(123)).Return(emptyRecords, nil)
-	mockAPI.On("CreatePrivateZoneRecord", ctx, int64(123), "new", "A", "9.10.11.12", int32(0)).Return(nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "new", "A").Return(emptyRecords, nil)
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "new", "A", "9.10.11.12", int32(0), int32(0), "", defaultRecordRemark).Return(nil)
 
 	// Test Scenario 4: Handle case with no matching zone
 	endpoint4 := endpoint.NewEndpoint("www.unknown.com", "A", "1.2.3.4")
-	//mockAPI.On("GetPrivateZoneRecords", ctx, int64(0)).Return(nil, errors.New("should not be called"))
+	//mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(0), "www", "A").Return(nil, errors.New("should not be called"))
 
 	// Execute Test Scenario 1
 	err := provider.updatePrivateZoneRecords(ctx, zoneMap, []*endpoint.Endpoint{endpoint1})
@@ -284,7 +609,7 @@ func TestUpdatePrivateZoneRecordsErrorCases(t *testing.T) {
 	validZoneMap := map[string]string{
 		"123": "example.com",
 	}
-	mockAPI.On("GetPrivateZoneRecords", ctx, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{}, errors.New("API error"))
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return([]*privatezone.RecordForListRecordsOutput{}, errors.New("API error"))
 	err = provider.updatePrivateZoneRecords(ctx, validZoneMap, []*endpoint.Endpoint{ep})
 	assert.Error(t, err)
 	mockAPI.ExpectedCalls = nil
@@ -302,9 +627,10 @@ func TestUpdatePrivateZoneRecordsErrorCases(t *testing.T) {
 	}
 	endpointWithTTL := endpoint.NewEndpointWithTTL("www.example.com", "A", endpoint.TTL(60), "1.2.3.4")
 	endpointWithTTL2 := endpoint.NewEndpointWithTTL("app.example.com", "A", endpoint.TTL(60), "1.2.3.4")
-	mockAPI.On("GetPrivateZoneRecords", ctx, int64(123)).Return(mockRecords, nil)
-	mockAPI.On("UpdatePrivateZoneRecord", ctx, int64(123), "record-1", "www", "A", "1.2.3.4", int32(60)).Return(errors.New("Update error"))
-	mockAPI.On("CreatePrivateZoneRecord", ctx, int64(123), "app", "A", "1.2.3.4", int32(60)).Return(nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return(mockRecords, nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "app", "A").Return([]*privatezone.RecordForListRecordsOutput{}, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "1.2.3.4", int32(60), int32(0), "", defaultRecordRemark).Return(errors.New("Update error"))
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "app", "A", "1.2.3.4", int32(60), int32(0), "", defaultRecordRemark).Return(nil)
 	// Ensure the entire process continues even if update fails
 	err = provider.updatePrivateZoneRecords(ctx, validZoneMap, []*endpoint.Endpoint{endpointWithTTL, endpointWithTTL2})
 	assert.NoError(t, err) // Although individual update failed, the overall method should continue and return nil
@@ -331,15 +657,15 @@ func TestUpdatePrivateZoneRecordsWithSpecialTypes(t *testing.T) {
 	// Test TXT record type
 	txtEndpoint := endpoint.NewEndpoint("txt.example.com", "TXT", "\"heritage=text value\"")
 	emptyRecords := []*privatezone.RecordForListRecordsOutput{}
-	mockAPI.On("GetPrivateZoneRecords", ctx, int64(123)).Return(emptyRecords, nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "txt", "TXT").Return(emptyRecords, nil)
 	// Note: TXT record values will be unescaped
-	mockAPI.On("CreatePrivateZoneRecord", ctx, int64(123), "txt", "TXT", "heritage=text value", int32(0)).Return(nil)
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "txt", "TXT", "heritage=text value", int32(0), int32(0), "", defaultRecordRemark).Return(nil)
 
 	// Test CNAME record type
 	cnameEndpoint := endpoint.NewEndpoint("cname.example.com", "CNAME", "target.example.com")
-	mockAPI.On("GetPrivateZoneRecords", ctx, int64(123)).Return(emptyRecords, nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "cname", "CNAME").Return(emptyRecords, nil)
 	// Note: CNAME record values may be processed (adding dots, etc.)
-	mockAPI.On("CreatePrivateZoneRecord", ctx, int64(123), "cname", "CNAME", "target.example.com.", int32(0)).Return(nil)
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "cname", "CNAME", "target.example.com.", int32(0), int32(0), "", defaultRecordRemark).Return(nil)
 
 	// Execute tests
 	err := provider.updatePrivateZoneRecords(ctx, zoneMap, []*endpoint.Endpoint{txtEndpoint})
@@ -351,3 +677,114 @@ func TestUpdatePrivateZoneRecordsWithSpecialTypes(t *testing.T) {
 	// Verify all mock methods were called correctly
 	mockAPI.AssertExpectations(t)
 }
+
+func TestUpdatePrivateZoneRecordsKeepsRemark(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI}
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return([]*privatezone.RecordForListRecordsOutput{{
+		ZID:      volcengine.Int32(123),
+		RecordID: volcengine.String("record-1"),
+		Host:     volcengine.String("www"),
+		Type:     volcengine.String("A"),
+		Value:    volcengine.String("1.2.3.4"),
+		TTL:      volcengine.Int32(600),
+		Remark:   volcengine.String("managed by external-dns; owner=default; see OPS-1"),
+	}}, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "1.2.3.4", int32(60), int32(0), "",
+		"managed by external-dns; owner=default; see OPS-1").Return(nil)
+
+	ep := endpoint.NewEndpointWithTTL("www.example.com", "A", 60, "1.2.3.4")
+	ep.Labels = endpoint.Labels{endpoint.OwnerLabelKey: "default"}
+	err := p.updatePrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{ep})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestUpdatePrivateZoneRecordsInPlace(t *testing.T) {
+	ctx := context.Background()
+	record := func(id, value string) *privatezone.RecordForListRecordsOutput {
+		return &privatezone.RecordForListRecordsOutput{ZID: volcengine.Int32(123), RecordID: volcengine.String(id),
+			Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String(value), TTL: volcengine.Int32(300)}
+	}
+	zoneMap := map[string]string{"123": "example.com"}
+
+	// Removed targets are rewritten to new ones, only the surplus is created
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI}
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return([]*privatezone.RecordForListRecordsOutput{
+		record("record-1", "1.1.1.1"), record("record-2", "2.2.2.2"),
+	}, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "3.3.3.3", int32(300), int32(0), "", defaultRecordRemark).Return(nil).Once()
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "www", "A", "4.4.4.4", int32(0), int32(0), "", defaultRecordRemark).Return(nil).Once()
+	err := p.updatePrivateZoneRecords(ctx, zoneMap, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "2.2.2.2", "3.3.3.3", "4.4.4.4")})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "DeletePrivateZoneRecordById", mock.Anything, mock.Anything, mock.Anything)
+
+	// Records of removed targets without a replacement are deleted
+	mockAPI = new(MockPrivateZoneAPI)
+	p = &Provider{pzClient: mockAPI}
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return([]*privatezone.RecordForListRecordsOutput{
+		record("record-1", "1.1.1.1"), record("record-2", "2.2.2.2"), record("record-3", "3.3.3.3"),
+	}, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "1.1.1.1", int32(60), int32(0), "", defaultRecordRemark).Return(nil).Once()
+	mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, int64(123), "record-2").Return(nil).Once()
+	mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, int64(123), "record-3").Return(nil).Once()
+	err = p.updatePrivateZoneRecords(ctx, zoneMap, []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.com", "A", 60, "1.1.1.1")})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "CreatePrivateZoneRecord", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Disabled records are not rewritten, the update would leave the new target disabled
+	disabled := record("record-1", "1.1.1.1")
+	disabled.Enable = volcengine.Bool(false)
+	mockAPI = new(MockPrivateZoneAPI)
+	p = &Provider{pzClient: mockAPI}
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return([]*privatezone.RecordForListRecordsOutput{
+		disabled, record("record-2", "2.2.2.2"),
+	}, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-2", "www", "A", "3.3.3.3", int32(300), int32(0), "", defaultRecordRemark).Return(nil).Once()
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "www", "A", "4.4.4.4", int32(0), int32(0), "", defaultRecordRemark).Return(nil).Once()
+	mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, int64(123), "record-1").Return(nil).Once()
+	err = p.updatePrivateZoneRecords(ctx, zoneMap, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "3.3.3.3", "4.4.4.4")})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestProviderExcludeDomains(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+		{ZID: volcengine.Int32(456), ZoneName: volcengine.String("other.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), RecordID: volcengine.String("1")},
+		{Host: volcengine.String("db.internal"), Type: volcengine.String("A"), Value: volcengine.String("10.0.0.1"), RecordID: volcengine.String("2")},
+	}, nil)
+
+	c := defaultConfig()
+	WithDomainFilter("example.com")(c)
+	WithExcludeDomains("internal.example.com")(c)
+	provider, err := newProvider(c)
+	assert.NoError(t, err)
+	provider.pzClient, provider.privateZone, provider.vpcID = mockAPI, true, "vpc-123"
+
+	filter, err := json.Marshal(provider.GetDomainFilter())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"include":["example.com"],"exclude":["internal.example.com"]}`, string(filter))
+
+	endpoints, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "www.example.com", endpoints[0].DNSName)
+
+	// changes of excluded names are not applied
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.internal.example.com", "A", "10.0.0.2")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("db.internal.example.com", "A", "10.0.0.1")},
+	})
+	assert.NoError(t, err)
+	mockAPI.AssertNotCalled(t, "BatchCreatePrivateZoneRecord", mock.Anything, mock.Anything, mock.Anything)
+	mockAPI.AssertNotCalled(t, "BatchDeletePrivateZoneRecords", mock.Anything, mock.Anything, mock.Anything)
+}
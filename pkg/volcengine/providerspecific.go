@@ -0,0 +1,50 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import "sigs.k8s.io/external-dns/endpoint"
+
+// providerSpecificProperty is a record setting of an endpoint, set as the volcengine-privatezone/ property or,
+// through the external-dns.alpha.kubernetes.io/webhook- annotation, as the webhook/ property.
+type providerSpecificProperty struct {
+	name    string
+	webhook string
+}
+
+var (
+	lineProperty   = providerSpecificProperty{name: ProviderSpecificLine, webhook: providerSpecificWebhookLine}
+	weightProperty = providerSpecificProperty{name: ProviderSpecificWeight, webhook: providerSpecificWebhookWeight}
+)
+
+// get returns the value of the property, the volcengine-privatezone/ property wins over the annotation.
+func (p providerSpecificProperty) get(ep *endpoint.Endpoint) (string, bool) {
+	value, ok := ep.GetProviderSpecificProperty(p.name)
+	if !ok {
+		value, ok = ep.GetProviderSpecificProperty(p.webhook)
+	}
+	return value, ok
+}
+
+// set sets the property the way Records returns it: under the volcengine-privatezone/ name, and not at all for an
+// empty value, the default of the record.
+func (p providerSpecificProperty) set(ep *endpoint.Endpoint, value string) {
+	ep.DeleteProviderSpecificProperty(p.webhook)
+	if value == "" {
+		ep.DeleteProviderSpecificProperty(p.name)
+		return
+	}
+	ep.SetProviderSpecificProperty(p.name, value)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestProviderSpecificProperty(t *testing.T) {
+	property := providerSpecificProperty{name: "volcengine-privatezone/test", webhook: "webhook/test"}
+
+	_, ok := property.get(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"))
+	assert.False(t, ok)
+
+	ep := endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific("webhook/test", "annotation")
+	value, ok := property.get(ep)
+	assert.True(t, ok)
+	assert.Equal(t, "annotation", value)
+
+	ep.WithProviderSpecific("volcengine-privatezone/test", "property")
+	value, _ = property.get(ep)
+	assert.Equal(t, "property", value, "the volcengine-privatezone/ property wins over the annotation")
+
+	property.set(ep, "set")
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: "volcengine-privatezone/test", Value: "set"}}, ep.ProviderSpecific)
+
+	property.set(ep, "")
+	assert.Empty(t, ep.ProviderSpecific)
+}
+
+func TestAdjustEndpointsProviderSpecific(t *testing.T) {
+	p := &Provider{}
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("blue.example.com", "A", "1.2.3.4").
+			WithProviderSpecific(providerSpecificWebhookWeight, "80").WithProviderSpecific(providerSpecificWebhookLine, "telecom"),
+		endpoint.NewEndpoint("green.example.com", "A", "1.2.3.5").
+			WithProviderSpecific(ProviderSpecificWeight, "1").WithProviderSpecific(ProviderSpecificLine, defaultLine),
+		endpoint.NewEndpoint("red.example.com", "A", "1.2.3.6").WithProviderSpecific(ProviderSpecificWeight, "heavy"),
+	})
+	require.NoError(t, err)
+	require.Len(t, adjusted, 3)
+	assert.ElementsMatch(t, endpoint.ProviderSpecific{
+		{Name: ProviderSpecificWeight, Value: "80"},
+		{Name: ProviderSpecificLine, Value: "telecom"},
+	}, adjusted[0].ProviderSpecific)
+	assert.Empty(t, adjusted[1].ProviderSpecific, "the default weight and line are not returned by Records")
+	// invalid values are kept and reported when the endpoint is applied
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: ProviderSpecificWeight, Value: "heavy"}}, adjusted[2].ProviderSpecific)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+)
+
+// recorderHandlerName names the request handlers capturing and writing interactions.
+const recorderHandlerName = "volcengine-provider.Recorder"
+
+// sensitiveFields are masked in recorded inputs and outputs, compared case-insensitively.
+var sensitiveFields = map[string]bool{
+	"accesskey":       true,
+	"accesskeyid":     true,
+	"secretkey":       true,
+	"secretaccesskey": true,
+	"sessiontoken":    true,
+	"plaintext":       true,
+	"password":        true,
+}
+
+// Interaction is one recorded API call, written as a line of the recorder file.
+// Headers are not recorded since they carry the signature and any configured gateway tokens.
+type Interaction struct {
+	Time       time.Time       `json:"time"`
+	Service    string          `json:"service"`
+	Action     string          `json:"action"`
+	Input      json.RawMessage `json:"input,omitempty"`
+	Output     json.RawMessage `json:"output,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	StatusCode int             `json:"statusCode,omitempty"`
+	RequestID  string          `json:"requestId,omitempty"`
+	Retries    int             `json:"retries,omitempty"`
+	DurationMs int64           `json:"durationMs"`
+}
+
+// Recorder appends every API request and response to a JSONL file, for capturing sync bugs in production.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+	// inputs captured before the SDK replaces the params of JSON requests with the body
+	inputs sync.Map
+}
+
+// NewRecorder opens file for appending interactions, it is only readable by the owner.
+func NewRecorder(file string) (*Recorder, error) {
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recorder file: %v", err)
+	}
+	return &Recorder{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Close closes the recorder file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// Record appends an interaction to the file.
+func (r *Recorder) Record(interaction *Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(interaction)
+}
+
+// install captures the input of every request before it is built and records it once completed, after its retries.
+// Failures to record are logged to log.
+func (r *Recorder) install(handlers *request.Handlers, log Logger) {
+	handlers.Build.PushFrontNamed(request.NamedHandler{
+		Name: recorderHandlerName,
+		Fn: func(req *request.Request) {
+			r.inputs.Store(req, sanitizedJSON(req.Params))
+		},
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: recorderHandlerName,
+		Fn: func(req *request.Request) {
+			interaction := &Interaction{
+				Time:       req.Time,
+				Service:    req.ClientInfo.ServiceName,
+				RequestID:  req.RequestID,
+				Retries:    req.RetryCount,
+				DurationMs: time.Since(req.Time).Milliseconds(),
+			}
+			if input, ok := r.inputs.LoadAndDelete(req); ok {
+				interaction.Input = input.(json.RawMessage)
+			}
+			if req.Operation != nil {
+				interaction.Action = req.Operation.Name
+			}
+			if req.HTTPResponse != nil {
+				interaction.StatusCode = req.HTTPResponse.StatusCode
+			}
+			if req.Error != nil {
+				interaction.Error = req.Error.Error()
+			} else {
+				interaction.Output = sanitizedJSON(req.Data)
+			}
+			if err := r.Record(interaction); err != nil {
+				contextLogger(req.Context(), log).Errorf("Failed to record %s interaction: %v", interaction.Action, err)
+			}
+		},
+	})
+}
+
+// sanitizedJSON encodes value with the sensitiveFields masked, nil when it cannot be encoded.
+func sanitizedJSON(value interface{}) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	data, err = json.Marshal(sanitize(decoded))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func sanitize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				if s, ok := field.(string); ok {
+					v[key] = MaskSecret(s)
+					continue
+				}
+			}
+			v[key] = sanitize(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = sanitize(item)
+		}
+	}
+	return value
+}
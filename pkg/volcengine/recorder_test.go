@@ -0,0 +1,93 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+)
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("Action") == "CreateRecord" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"2","Error":{"Code":"InvalidParameter","Message":"bad host"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1"},"Result":{"Zones":[{"ZID":123,"ZoneName":"example.com"}],"Total":1}}`))
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "api.jsonl")
+	recorder, err := NewRecorder(file)
+	require.NoError(t, err)
+	headers, err := ParseHeaders("X-Gateway-Token=gateway-secret")
+	require.NoError(t, err)
+	wrapper, err := NewPrivateZoneWrapper("cn-beijing", server.URL, credentials.NewStaticCredentials("ak", "sk", ""),
+		WithPrivateZoneRecorder(recorder), WithPrivateZoneHeaders(headers))
+	require.NoError(t, err)
+
+	_, err = wrapper.ListPrivateZones(context.Background(), "vpc-1")
+	require.NoError(t, err)
+	assert.Error(t, wrapper.CreatePrivateZoneRecord(context.Background(), 123, "www", "A", "1.2.3.4", 60, 0, "", ""))
+	require.NoError(t, recorder.Close())
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "gateway-secret")
+	var interactions []Interaction
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var interaction Interaction
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &interaction))
+		interactions = append(interactions, interaction)
+	}
+	require.Len(t, interactions, 2)
+
+	assert.Equal(t, "ListPrivateZones", interactions[0].Action)
+	assert.Equal(t, "private_zone", interactions[0].Service)
+	assert.Equal(t, http.StatusOK, interactions[0].StatusCode)
+	assert.JSONEq(t, `{"VpcID":"vpc-1","PageNumber":1,"PageSize":100}`, string(interactions[0].Input))
+	assert.Contains(t, string(interactions[0].Output), `"ZoneName":"example.com"`)
+
+	assert.Equal(t, "CreateRecord", interactions[1].Action)
+	assert.Equal(t, http.StatusBadRequest, interactions[1].StatusCode)
+	assert.Contains(t, interactions[1].Error, "InvalidParameter")
+	assert.Contains(t, string(interactions[1].Input), `"Host":"www"`)
+	assert.Empty(t, interactions[1].Output)
+}
+
+func TestSanitizedJSON(t *testing.T) {
+	input := map[string]interface{}{
+		"AccessKeyId": "AKLTabcdefghijkl",
+		"Nested":      []interface{}{map[string]interface{}{"SecretKey": "short"}},
+		"Host":        "www",
+	}
+	assert.JSONEq(t, `{"AccessKeyId":"AKLT********ijkl","Nested":[{"SecretKey":"****"}],"Host":"www"}`, string(sanitizedJSON(input)))
+	assert.Nil(t, sanitizedJSON(nil))
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+)
+
+// RecordSet is the records of one host, type and set identifier, the unit external-dns reasons about as an endpoint.
+type RecordSet struct {
+	Host          string
+	Type          string
+	SetIdentifier string
+	// Records sorted by value
+	Records []Record
+}
+
+// TTLs returns the distinct TTLs of the records in ascending order, more than one means the record set drifted.
+func (s *RecordSet) TTLs() []int {
+	seen := make(map[int]bool)
+	ttls := make([]int, 0, 1)
+	for _, r := range s.Records {
+		if !seen[r.TTL] {
+			seen[r.TTL] = true
+			ttls = append(ttls, r.TTL)
+		}
+	}
+	sort.Ints(ttls)
+	return ttls
+}
+
+// GroupRecordSets groups the records of a zone into record sets ordered by host, type and set identifier.
+func GroupRecordSets(records []*privatezone.RecordForListRecordsOutput) []*RecordSet {
+	grouped := groupPrivateZoneRecords(records)
+	sets := make([]*RecordSet, 0, len(grouped))
+	for _, group := range grouped {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Target < group[j].Target
+		})
+		sets = append(sets, &RecordSet{
+			Host:          strings.ToLower(group[0].Host),
+			Type:          group[0].Type,
+			SetIdentifier: group[0].SetIdentifier,
+			Records:       group,
+		})
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Host != sets[j].Host {
+			return sets[i].Host < sets[j].Host
+		}
+		if sets[i].Type != sets[j].Type {
+			return sets[i].Type < sets[j].Type
+		}
+		return sets[i].SetIdentifier < sets[j].SetIdentifier
+	})
+	return sets
+}
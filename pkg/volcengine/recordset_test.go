@@ -0,0 +1,66 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+func TestGroupRecordSets(t *testing.T) {
+	record := func(id, host, recordType, value string, ttl, weight int32, remark string) *privatezone.RecordForListRecordsOutput {
+		return &privatezone.RecordForListRecordsOutput{
+			RecordID: volcengine.String(id),
+			Host:     volcengine.String(host),
+			Type:     volcengine.String(recordType),
+			Value:    volcengine.String(value),
+			TTL:      volcengine.Int32(ttl),
+			Weight:   volcengine.Int32(weight),
+			Remark:   volcengine.String(remark),
+			Enable:   volcengine.Bool(true),
+		}
+	}
+	records := []*privatezone.RecordForListRecordsOutput{
+		record("1", "www", "A", "10.0.0.2", 300, 20, ""),
+		record("2", "api", "CNAME", "lb.example.com", 600, 0, ""),
+		record("3", "WWW", "A", "10.0.0.1", 60, 10, ""),
+		record("4", "www", "A", "10.0.0.9", 300, 0, (&Provider{}).encodeRemark(nil, "blue")),
+		record("5", "www", "TXT", "heritage=external-dns", 300, 0, ""),
+	}
+	records[1].Enable = volcengine.Bool(false)
+
+	sets := GroupRecordSets(records)
+	require.Len(t, sets, 4)
+	assert.Equal(t, "api", sets[0].Host)
+	assert.True(t, sets[0].Records[0].Disabled)
+
+	www := sets[1]
+	assert.Equal(t, "www", www.Host)
+	assert.Equal(t, "A", www.Type)
+	assert.Empty(t, www.SetIdentifier)
+	require.Len(t, www.Records, 2)
+	assert.Equal(t, "10.0.0.1", www.Records[0].Target)
+	assert.Equal(t, 10, www.Records[0].Weight)
+	assert.Equal(t, []int{60, 300}, www.TTLs(), "drifted TTLs are listed")
+
+	assert.Equal(t, "blue", sets[2].SetIdentifier)
+	assert.Equal(t, []int{300}, sets[2].TTLs())
+	assert.Equal(t, "TXT", sets[3].Type)
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+// Region is a region whose private zones are managed.
+type Region struct {
+	ID string
+	// Endpoint is the PrivateZone OpenAPI endpoint of the region, the configured endpoint when empty.
+	Endpoint string
+}
+
+// ParseRegions parses comma separated regions, each optionally followed by its endpoint as REGION=ENDPOINT, e.g.
+// cn-beijing,cn-shanghai=open.cn-shanghai.volcengineapi.com.
+func ParseRegions(value string) ([]Region, error) {
+	var regions []Region
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, endpoint, found := strings.Cut(entry, "=")
+		id, endpoint = strings.TrimSpace(id), strings.TrimSpace(endpoint)
+		if id == "" || (found && endpoint == "") {
+			return nil, fmt.Errorf("invalid region %q, expected REGION or REGION=ENDPOINT", entry)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("region %s is given twice", id)
+		}
+		seen[id] = true
+		regions = append(regions, Region{ID: id, Endpoint: endpoint})
+	}
+	return regions, nil
+}
+
+// regionAPI is the PrivateZone API of a region.
+type regionAPI struct {
+	region string
+	api    privateZoneAPI
+}
+
+// multiRegionAPI merges the zones listed in several regions and sends the calls of a zone to the region it was
+// listed in. A zone listed in several regions is served by the first of them.
+type multiRegionAPI struct {
+	regions []regionAPI
+
+	mu sync.Mutex
+	// region index of every listed zone
+	zoneRegions map[int64]int
+	// VPCs of the last listing, the configured VPCs before, listed to find the region of a zone not listed yet
+	vpcID string
+}
+
+var _ privateZoneAPI = &multiRegionAPI{}
+
+func newMultiRegionAPI(regions []regionAPI, vpcID string) *multiRegionAPI {
+	return &multiRegionAPI{regions: regions, zoneRegions: make(map[int64]int), vpcID: vpcID}
+}
+
+// ListPrivateZones returns the zones bound to the VPCs in every region, it fails when any region fails so zones
+// of an unavailable region are not taken for deleted.
+func (m *multiRegionAPI) ListPrivateZones(ctx context.Context, vpcID string) ([]*privatezone.ZoneForListPrivateZonesOutput, error) {
+	var res []*privatezone.ZoneForListPrivateZonesOutput
+	zoneRegions := make(map[int64]int)
+	for i, r := range m.regions {
+		zones, err := r.api.ListPrivateZones(ctx, vpcID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list private zones of region %s: %w", r.region, err)
+		}
+		for _, zone := range zones {
+			zid := int64(volcengine.Int32Value(zone.ZID))
+			if _, ok := zoneRegions[zid]; !ok {
+				zoneRegions[zid] = i
+				res = append(res, zone)
+			}
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for zid, i := range zoneRegions {
+		m.zoneRegions[zid] = i
+	}
+	m.vpcID = vpcID
+	return res, nil
+}
+
+// zoneAPI returns the API of the region of the zone, listing the zones when it is not known, e.g. because the zones
+// were served from the cache snapshot.
+func (m *multiRegionAPI) zoneAPI(ctx context.Context, zid int64) (privateZoneAPI, error) {
+	m.mu.Lock()
+	i, ok := m.zoneRegions[zid]
+	vpcID := m.vpcID
+	m.mu.Unlock()
+	if ok {
+		return m.regions[i].api, nil
+	}
+	if _, err := m.ListPrivateZones(ctx, vpcID); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	i, ok = m.zoneRegions[zid]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("zone %d is not bound to vpc %q in any of the regions %s", zid, vpcID, m.regionIDs())
+	}
+	return m.regions[i].api, nil
+}
+
+func (m *multiRegionAPI) regionIDs() string {
+	ids := make([]string, 0, len(m.regions))
+	for _, r := range m.regions {
+		ids = append(ids, r.region)
+	}
+	return strings.Join(ids, ",")
+}
+
+func (m *multiRegionAPI) GetPrivateZoneRecords(ctx context.Context, zid int64) ([]*privatezone.RecordForListRecordsOutput, error) {
+	api, err := m.zoneAPI(ctx, zid)
+	if err != nil {
+		return nil, err
+	}
+	return api.GetPrivateZoneRecords(ctx, zid)
+}
+
+func (m *multiRegionAPI) GetPrivateZoneRecordsByHostType(ctx context.Context, zid int64, host, recordType string) ([]*privatezone.RecordForListRecordsOutput, error) {
+	api, err := m.zoneAPI(ctx, zid)
+	if err != nil {
+		return nil, err
+	}
+	return api.GetPrivateZoneRecordsByHostType(ctx, zid, host, recordType)
+}
+
+func (m *multiRegionAPI) CreatePrivateZoneRecord(ctx context.Context, zoneID int64, domain, recordType, target string, TTL, weight int32, line, remark string) error {
+	api, err := m.zoneAPI(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	return api.CreatePrivateZoneRecord(ctx, zoneID, domain, recordType, target, TTL, weight, line, remark)
+}
+
+func (m *multiRegionAPI) BatchCreatePrivateZoneRecord(ctx context.Context, zoneID int64, records []*privatezone.RecordForBatchCreateRecordInput) error {
+	api, err := m.zoneAPI(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	return api.BatchCreatePrivateZoneRecord(ctx, zoneID, records)
+}
+
+func (m *multiRegionAPI) UpdatePrivateZoneRecord(ctx context.Context, zoneID int64, recordID string, host, recordType, target string, TTL, weight int32, line, remark string) error {
+	api, err := m.zoneAPI(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	return api.UpdatePrivateZoneRecord(ctx, zoneID, recordID, host, recordType, target, TTL, weight, line, remark)
+}
+
+func (m *multiRegionAPI) BatchDeletePrivateZoneRecords(ctx context.Context, zoneID int64, recordIDs []string) error {
+	api, err := m.zoneAPI(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	return api.BatchDeletePrivateZoneRecords(ctx, zoneID, recordIDs)
+}
+
+func (m *multiRegionAPI) DeletePrivateZoneRecordById(ctx context.Context, zoneID int64, recordID string) error {
+	api, err := m.zoneAPI(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	return api.DeletePrivateZoneRecordById(ctx, zoneID, recordID)
+}
+
+func (m *multiRegionAPI) DisablePrivateZoneRecord(ctx context.Context, zoneID int64, recordID, remark string) error {
+	api, err := m.zoneAPI(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	return api.DisablePrivateZoneRecord(ctx, zoneID, recordID, remark)
+}
+
+// SetRateLimits changes the limits of the API calls of every region, each region has its own limiters.
+func (m *multiRegionAPI) SetRateLimits(read, write RateLimit) {
+	for _, r := range m.regions {
+		if wrapper, ok := r.api.(*PrivateZoneWrapper); ok {
+			wrapper.SetRateLimits(read, write)
+		}
+	}
+}
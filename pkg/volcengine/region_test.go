@@ -0,0 +1,117 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+func TestParseRegions(t *testing.T) {
+	regions, err := ParseRegions(" cn-beijing, cn-shanghai = open.cn-shanghai.volcengineapi.com ,")
+	require.NoError(t, err)
+	assert.Equal(t, []Region{{ID: "cn-beijing"}, {ID: "cn-shanghai", Endpoint: "open.cn-shanghai.volcengineapi.com"}}, regions)
+
+	for _, value := range []string{"=open.volcengineapi.com", "cn-beijing=", "cn-beijing,cn-beijing=open.volcengineapi.com"} {
+		_, err := ParseRegions(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func testZone(zid int32, name string) *privatezone.ZoneForListPrivateZonesOutput {
+	return &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(zid), ZoneName: volcengine.String(name)}
+}
+
+func TestMultiRegionAPI(t *testing.T) {
+	beijing, shanghai := new(MockPrivateZoneAPI), new(MockPrivateZoneAPI)
+	beijing.On("ListPrivateZones", mock.Anything, "vpc-a,vpc-b").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		testZone(1, "bj.example.com"), testZone(3, "global.example.com")}, nil)
+	shanghai.On("ListPrivateZones", mock.Anything, "vpc-a,vpc-b").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		testZone(2, "sh.example.com"), testZone(3, "global.example.com")}, nil)
+	beijing.On("GetPrivateZoneRecords", mock.Anything, int64(3)).Return(testRecords(), nil).Once()
+	shanghai.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(2), []string{"record-1"}).Return(nil).Once()
+	api := newMultiRegionAPI([]regionAPI{{region: "cn-beijing", api: beijing}, {region: "cn-shanghai", api: shanghai}}, "vpc-a,vpc-b")
+	ctx := context.Background()
+
+	// zones of every region, a zone listed in both regions once
+	zones, err := api.ListPrivateZones(ctx, "vpc-a,vpc-b")
+	require.NoError(t, err)
+	assert.Equal(t, []*privatezone.ZoneForListPrivateZonesOutput{
+		testZone(1, "bj.example.com"), testZone(3, "global.example.com"), testZone(2, "sh.example.com")}, zones)
+
+	// calls of a zone go to the region it was listed in first
+	_, err = api.GetPrivateZoneRecords(ctx, 3)
+	assert.NoError(t, err)
+	assert.NoError(t, api.BatchDeletePrivateZoneRecords(ctx, 2, []string{"record-1"}))
+
+	// unknown zones are looked up by listing the zones again
+	err = api.DeletePrivateZoneRecordById(ctx, 4, "record-1")
+	assert.ErrorContains(t, err, `zone 4 is not bound to vpc "vpc-a,vpc-b" in any of the regions cn-beijing,cn-shanghai`)
+	beijing.AssertNumberOfCalls(t, "ListPrivateZones", 2)
+	beijing.AssertExpectations(t)
+	shanghai.AssertExpectations(t)
+}
+
+func TestMultiRegionAPIRoutesCachedZones(t *testing.T) {
+	beijing, shanghai := new(MockPrivateZoneAPI), new(MockPrivateZoneAPI)
+	beijing.On("ListPrivateZones", mock.Anything, "vpc-a").Return([]*privatezone.ZoneForListPrivateZonesOutput{testZone(1, "bj.example.com")}, nil).Once()
+	shanghai.On("ListPrivateZones", mock.Anything, "vpc-a").Return([]*privatezone.ZoneForListPrivateZonesOutput{testZone(2, "sh.example.com")}, nil).Once()
+	shanghai.On("GetPrivateZoneRecords", mock.Anything, int64(2)).Return(testRecords(), nil).Twice()
+	api := newMultiRegionAPI([]regionAPI{{region: "cn-beijing", api: beijing}, {region: "cn-shanghai", api: shanghai}}, "vpc-a")
+	ctx := context.Background()
+
+	// the zones come from a cache snapshot, the region of the zone is found with the configured VPCs once
+	for i := 0; i < 2; i++ {
+		records, err := api.GetPrivateZoneRecords(ctx, 2)
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+	}
+	beijing.AssertExpectations(t)
+	shanghai.AssertExpectations(t)
+}
+
+func TestMultiRegionAPIListError(t *testing.T) {
+	beijing, shanghai := new(MockPrivateZoneAPI), new(MockPrivateZoneAPI)
+	beijing.On("ListPrivateZones", mock.Anything, "vpc-a").Return([]*privatezone.ZoneForListPrivateZonesOutput{testZone(1, "bj.example.com")}, nil)
+	shanghai.On("ListPrivateZones", mock.Anything, "vpc-a").Return([]*privatezone.ZoneForListPrivateZonesOutput(nil), errors.New("ServiceUnavailable"))
+	api := newMultiRegionAPI([]regionAPI{{region: "cn-beijing", api: beijing}, {region: "cn-shanghai", api: shanghai}}, "vpc-a")
+
+	// the zones of an unavailable region are not dropped from the listing
+	_, err := api.ListPrivateZones(context.Background(), "vpc-a")
+	assert.ErrorContains(t, err, "failed to list private zones of region cn-shanghai: ServiceUnavailable")
+}
+
+func TestNewVolcengineProviderRegions(t *testing.T) {
+	provider, err := NewVolcengineProvider([]Option{
+		WithPrivateZone("", "vpc-a"),
+		WithRegions(Region{ID: "cn-beijing"}, Region{ID: "cn-shanghai", Endpoint: "open.cn-shanghai.volcengineapi.com"}),
+	})
+	require.NoError(t, err)
+	api, ok := uncachedAPI(provider.pzClient).(*multiRegionAPI)
+	require.True(t, ok)
+	assert.Equal(t, "cn-beijing,cn-shanghai", api.regionIDs())
+	assert.Equal(t, "vpc-a", api.vpcID)
+
+	provider.SetRateLimits(RateLimit{QPS: 5}, RateLimit{})
+	assert.Contains(t, provider.DebugState().RateLimits, "cn-shanghai/read")
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	registryHeritage = "heritage=external-dns"
+	registryOwnerKey = "external-dns/" + endpoint.OwnerLabelKey
+)
+
+// registryOwner returns the owner of an external-dns TXT registry value, e.g. "default" of
+// "heritage=external-dns,external-dns/owner=default,external-dns/resource=service/default/nginx".
+func registryOwner(value string) (string, bool) {
+	value = strings.Trim(value, `"`)
+	parts := strings.Split(value, ",")
+	if len(parts) == 0 || parts[0] != registryHeritage {
+		return "", false
+	}
+	for _, part := range parts[1:] {
+		if key, owner, ok := strings.Cut(part, "="); ok && key == registryOwnerKey {
+			return owner, true
+		}
+	}
+	return "", false
+}
+
+// ownedRegistryRecord reports whether the endpoint is a TXT registry record of the owner, every target
+// must be a registry value of the owner.
+func ownedRegistryRecord(ep *endpoint.Endpoint, owner string) bool {
+	if ep.RecordType != endpoint.RecordTypeTXT || len(ep.Targets) == 0 {
+		return false
+	}
+	for _, target := range ep.Targets {
+		if o, ok := registryOwner(target); !ok || o != owner {
+			return false
+		}
+	}
+	return true
+}
+
+// hideRegistryRecords removes the TXT registry records of the hidden registry owner from the endpoints.
+func (p *Provider) hideRegistryRecords(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if p.hiddenRegistryOwner == "" {
+		return endpoints
+	}
+	visible := endpoints[:0]
+	for _, ep := range endpoints {
+		if ownedRegistryRecord(ep, p.hiddenRegistryOwner) {
+			p.logger().Debugf("Hiding TXT registry record %s of owner %s", ep.DNSName, p.hiddenRegistryOwner)
+			continue
+		}
+		visible = append(visible, ep)
+	}
+	return visible
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestRegistryOwner(t *testing.T) {
+	owner, ok := registryOwner(`"heritage=external-dns,external-dns/owner=default,external-dns/resource=service/default/nginx"`)
+	assert.True(t, ok)
+	assert.Equal(t, "default", owner)
+
+	owner, ok = registryOwner("heritage=external-dns,external-dns/owner=team-a")
+	assert.True(t, ok)
+	assert.Equal(t, "team-a", owner)
+
+	for _, value := range []string{"", "v=spf1 -all", "heritage=external-dns", "heritage=other,external-dns/owner=default"} {
+		_, ok := registryOwner(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestHideRegistryRecords(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	zone := &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")}
+	record := func(host, recordType, value string) *privatezone.RecordForListRecordsOutput {
+		return &privatezone.RecordForListRecordsOutput{
+			Host:  volcengine.String(host),
+			Type:  volcengine.String(recordType),
+			Value: volcengine.String(value),
+			TTL:   volcengine.Int32(300),
+		}
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{zone}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		record("www", "A", "1.2.3.4"),
+		record("a-www", "TXT", "heritage=external-dns,external-dns/owner=default,external-dns/resource=service/default/www"),
+		record("a-api", "TXT", "heritage=external-dns,external-dns/owner=team-b"),
+		record("spf", "TXT", "v=spf1 -all"),
+	}, nil)
+
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123", hiddenRegistryOwner: "default"}
+	endpoints, err := p.Records(ctx)
+	require.NoError(t, err)
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName+"/"+ep.RecordType)
+	}
+	assert.ElementsMatch(t, []string{"www.example.com/A", "a-api.example.com/TXT", "spf.example.com/TXT"}, names)
+
+	p.hiddenRegistryOwner = ""
+	endpoints, err = p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 4)
+}
+
+func TestOwnedRegistryRecordMixedTargets(t *testing.T) {
+	ep := endpoint.NewEndpoint("a-www.example.com", "TXT", "heritage=external-dns,external-dns/owner=default", "other")
+	assert.False(t, ownedRegistryRecord(ep, "default"))
+	assert.False(t, ownedRegistryRecord(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"), "default"))
+}
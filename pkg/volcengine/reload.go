@@ -0,0 +1,75 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"errors"
+	"time"
+)
+
+// zoneProviders returns the provider of the private zones and the one of the public zones, when managed.
+func (p *Provider) zoneProviders() []*Provider {
+	providers := []*Provider{p}
+	if p.publicZones != nil {
+		providers = append(providers, p.publicZones)
+	}
+	return providers
+}
+
+// SetDryRun switches dry-run on or off, the writes issued afterwards, also by a running sync, follow it.
+func (p *Provider) SetDryRun(enabled bool) {
+	for _, zones := range p.zoneProviders() {
+		if dryRun, ok := zones.pzClient.(*dryRunAPI); ok {
+			dryRun.active.Store(enabled)
+		}
+	}
+}
+
+// SetCacheTTL changes how long listings are cached and after which age they are refreshed in the background. The
+// cache must have been enabled with WithCache, a zero ttl then expires every listing.
+func (p *Provider) SetCacheTTL(ttl, refreshAfter time.Duration) error {
+	changed := false
+	for _, zones := range p.zoneProviders() {
+		api := zones.pzClient
+		if dryRun, ok := api.(*dryRunAPI); ok {
+			api = dryRun.privateZoneAPI
+		}
+		if cache, ok := api.(*cachedPrivateZoneAPI); ok {
+			cache.setTTL(ttl, refreshAfter)
+			changed = true
+		}
+	}
+	if !changed && ttl > 0 {
+		return errors.New("the cache was not enabled at startup, set cache_ttl and restart the webhook")
+	}
+	return nil
+}
+
+// SetRateLimits changes the limits of the read and write PrivateZone API calls, of every region, and of the
+// CloudDNS API calls.
+func (p *Provider) SetRateLimits(read, write RateLimit) {
+	switch api := uncachedAPI(p.pzClient).(type) {
+	case *PrivateZoneWrapper:
+		api.SetRateLimits(read, write)
+	case *CloudDNSWrapper:
+		api.SetRateLimits(read, write)
+	case *multiRegionAPI:
+		api.SetRateLimits(read, write)
+	}
+	if p.publicZones != nil {
+		p.publicZones.SetRateLimits(read, write)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/time/rate"
+)
+
+func TestSetDryRun(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("BatchDeletePrivateZoneRecords", mock.Anything, int64(123), []string{"2"}).Return(nil).Once()
+	dryRun := newDryRunAPI(mockAPI, logrus.StandardLogger())
+	provider := &Provider{pzClient: dryRun, publicZones: &Provider{pzClient: newDryRunAPI(new(MockPrivateZoneAPI), logrus.StandardLogger())}}
+	ctx := context.Background()
+
+	assert.NoError(t, provider.pzClient.BatchDeletePrivateZoneRecords(ctx, 123, []string{"1"}))
+	provider.SetDryRun(false)
+	assert.False(t, isDryRun(provider.pzClient))
+	assert.False(t, isDryRun(provider.publicZones.pzClient))
+	assert.NoError(t, provider.pzClient.BatchDeletePrivateZoneRecords(ctx, 123, []string{"2"}))
+	provider.SetDryRun(true)
+	assert.True(t, isDryRun(provider.pzClient))
+	assert.NoError(t, provider.pzClient.BatchDeletePrivateZoneRecords(ctx, 123, []string{"3"}))
+
+	// only the write made while dry-run was off reached the API
+	mockAPI.AssertExpectations(t)
+}
+
+func TestSetCacheTTL(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(testZones(), nil).Twice()
+	now := time.Now()
+	cache := newCachedPrivateZoneAPI(mockAPI, time.Hour, "", logrus.StandardLogger())
+	cache.now = func() time.Time { return now }
+	provider := &Provider{pzClient: newDryRunAPI(cache, logrus.StandardLogger()), vpcID: "vpc-123"}
+	ctx := context.Background()
+
+	_, err := provider.pzClient.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	_, err = provider.pzClient.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+
+	// the cached listing expires by the new ttl
+	assert.NoError(t, provider.SetCacheTTL(time.Minute, 30*time.Second))
+	assert.Equal(t, "1m0s", cache.state().TTL)
+	assert.Equal(t, "30s", cache.state().RefreshAfter)
+	_, err = provider.pzClient.ListPrivateZones(ctx, "vpc-123")
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+
+	uncached := &Provider{pzClient: newDryRunAPI(new(MockPrivateZoneAPI), logrus.StandardLogger())}
+	assert.ErrorContains(t, uncached.SetCacheTTL(time.Minute, 0), "cache was not enabled at startup")
+	assert.NoError(t, uncached.SetCacheTTL(0, 0))
+}
+
+func TestSetRateLimits(t *testing.T) {
+	wrapper := &PrivateZoneWrapper{client: newZoneLimitedClient(newThrottledClient(&MockClient{}, RateLimit{}, RateLimit{QPS: 1}), 1)}
+	provider := &Provider{pzClient: newDryRunAPI(wrapper, logrus.StandardLogger())}
+	throttled := wrapper.throttled()
+	assert.Equal(t, rate.Inf, throttled.read.Limit())
+	assert.Equal(t, rate.Limit(1), throttled.write.Limit())
+
+	provider.SetRateLimits(RateLimit{QPS: 5, Burst: 10}, RateLimit{})
+	assert.Equal(t, rate.Limit(5), throttled.read.Limit())
+	assert.Equal(t, 10, throttled.read.Burst())
+	assert.Equal(t, rate.Inf, throttled.write.Limit())
+	assert.Equal(t, map[string]*RateLimiterState{"read": limiterState(throttled.read)}, provider.DebugState().RateLimits)
+}
+
+func TestSetRateLimitsCloudDNS(t *testing.T) {
+	throttled := newThrottledCloudDNSClient(nil, RateLimit{}, RateLimit{QPS: 1})
+	provider := &Provider{
+		pzClient:    newDryRunAPI(new(MockPrivateZoneAPI), logrus.StandardLogger()),
+		publicZones: &Provider{pzClient: newDryRunAPI(&CloudDNSWrapper{client: throttled}, logrus.StandardLogger())},
+	}
+	assert.Equal(t, rate.Inf, throttled.read.Limit())
+	assert.Equal(t, rate.Limit(1), throttled.write.Limit())
+
+	provider.SetRateLimits(RateLimit{QPS: 5, Burst: 10}, RateLimit{})
+	assert.Equal(t, rate.Limit(5), throttled.read.Limit())
+	assert.Equal(t, 10, throttled.read.Burst())
+	assert.Equal(t, rate.Inf, throttled.write.Limit())
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// RecordRemarkData is the data of the record remark template.
+type RecordRemarkData struct {
+	// Cluster is the configured cluster name.
+	Cluster string
+	// Owner and Resource are the owner and resource labels of the endpoint, empty if it has none.
+	Owner    string
+	Resource string
+}
+
+// recordRemark renders the first part of the remarks written by the provider, which replaces the default record
+// remark, e.g. "managed by external-dns cluster={{.Cluster}} owner={{.Owner}}".
+type recordRemark struct {
+	tmpl    *template.Template
+	cluster string
+}
+
+// newRecordRemark parses the remark template, an empty template writes the default record remark. The template must
+// render a remark starting with the default record remark, so the records stay recognized as managed.
+func newRecordRemark(text, cluster string) (*recordRemark, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("remark").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record remark template %q: %v", text, err)
+	}
+	r := &recordRemark{tmpl: tmpl, cluster: cluster}
+	sample := endpoint.Labels{endpoint.OwnerLabelKey: "default", endpoint.ResourceLabelKey: "service/default/nginx"}
+	if _, err := r.render(sample); err != nil {
+		return nil, fmt.Errorf("invalid record remark template %q: %v", text, err)
+	}
+	return r, nil
+}
+
+// render executes the template with the endpoint labels and validates the remark.
+func (r *recordRemark) render(labels endpoint.Labels) (string, error) {
+	var buf bytes.Buffer
+	data := RecordRemarkData{
+		Cluster:  r.cluster,
+		Owner:    labels[endpoint.OwnerLabelKey],
+		Resource: labels[endpoint.ResourceLabelKey],
+	}
+	if err := r.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	remark := strings.TrimSpace(buf.String())
+	switch {
+	case !isManagedRemarkHead(remark):
+		return "", fmt.Errorf("remark %q does not start with %q", remark, defaultRecordRemark)
+	case strings.Contains(remark, remarkSeparator):
+		return "", fmt.Errorf("remark %q contains the separator %q", remark, remarkSeparator)
+	case len(remark)+len(remarkSeparator+setIdentifierKey+"=")+maxSetIdentifierLength > maxRemarkLength:
+		return "", fmt.Errorf("remark %q leaves no room for the set identifier", remark)
+	}
+	return remark, nil
+}
+
+// isManagedRemarkHead reports whether the first part of a remark marks the record as written by external-dns,
+// either the default record remark or a remark rendered from a template.
+func isManagedRemarkHead(part string) bool {
+	return part == defaultRecordRemark || strings.HasPrefix(part, defaultRecordRemark+" ")
+}
+
+// encodeRemark encodes the remark of the endpoint, starting with the remark rendered from the template if one is
+// configured. Endpoints whose labels do not render a valid remark fall back to the default record remark.
+func (p *Provider) encodeRemark(labels endpoint.Labels, setIdentifier string) string {
+	head := defaultRecordRemark
+	if p.remark != nil {
+		rendered, err := p.remark.render(labels)
+		if err != nil {
+			p.logger().Warnf("Failed to render record remark, using %q: %v", defaultRecordRemark, err)
+		} else {
+			head = rendered
+		}
+	}
+	return encodeRemarkHead(head, labels, setIdentifier)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestNewRecordRemark(t *testing.T) {
+	r, err := newRecordRemark("", "prod")
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	r, err = newRecordRemark("managed by external-dns cluster={{.Cluster}} owner={{.Owner}}", "prod")
+	require.NoError(t, err)
+	remark, err := r.render(endpoint.Labels{endpoint.OwnerLabelKey: "team-a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "managed by external-dns cluster=prod owner=team-a", remark)
+
+	for _, text := range []string{
+		"cluster={{.Cluster}}",
+		"managed by external-dns{{.Cluster}}",
+		"managed by external-dns; cluster={{.Cluster}}",
+		"managed by external-dns {{.Namespace}}",
+		"managed by external-dns {{.Cluster",
+		"managed by external-dns " + strings.Repeat("x", maxRemarkLength-100),
+	} {
+		_, err := newRecordRemark(text, "prod")
+		assert.Error(t, err, text)
+	}
+}
+
+func TestProviderEncodeRemark(t *testing.T) {
+	p := &Provider{}
+	labels := endpoint.Labels{endpoint.OwnerLabelKey: "team-a"}
+	assert.Equal(t, "managed by external-dns; set-identifier=blue; owner=team-a", p.encodeRemark(labels, "blue"))
+
+	var err error
+	p.remark, err = newRecordRemark("managed by external-dns cluster={{.Cluster}} owner={{.Owner}}", "prod")
+	require.NoError(t, err)
+	remark := p.encodeRemark(labels, "blue")
+	assert.Equal(t, "managed by external-dns cluster=prod owner=team-a; set-identifier=blue; owner=team-a", remark)
+	assert.Equal(t, "blue", remarkSetIdentifier(remark))
+	assert.Equal(t, endpoint.Labels{endpoint.OwnerLabelKey: "team-a"}, decodeRemark(remark))
+	assert.True(t, IsManagedRemark(remark))
+	assert.True(t, ManagedRecordGuard{Enabled: true, Owner: "team-a"}.manages(&privatezone.RecordForListRecordsOutput{Remark: &remark}))
+	// the rendered part of another template is replaced, context added by hand is kept
+	assert.Equal(t, remark+"; see OPS-123", mergeRemark("managed by external-dns cluster=staging; see OPS-123", remark))
+
+	// labels rendering the separator fall back to the default remark
+	labels = endpoint.Labels{endpoint.OwnerLabelKey: "a; b"}
+	assert.Equal(t, defaultRecordRemark, p.encodeRemark(labels, ""))
+}
+
+func TestProviderRecordRemark(t *testing.T) {
+	const remark = "managed by external-dns cluster=prod owner=team-a; owner=team-a"
+	records := []*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("1"), Host: volcengine.String("api"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1"),
+			TTL: volcengine.Int32(600), Remark: volcengine.String(defaultRecordRemark)},
+	}
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "api", "A").Return(records, nil)
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 1 && volcengine.StringValue(records[0].Remark) == remark
+	})).Return(nil).Once()
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "1", "api", "A", "1.1.1.1", int32(60), int32(0), "", remark).Return(nil).Once()
+
+	c := defaultConfig()
+	WithRecordRemark("managed by external-dns cluster={{.Cluster}} owner={{.Owner}}", "prod")(c)
+	provider, err := newProvider(c)
+	require.NoError(t, err)
+	provider.pzClient, provider.privateZone, provider.vpcID = mockAPI, true, "vpc-123"
+	labels := endpoint.Labels{endpoint.OwnerLabelKey: "team-a"}
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "2.2.2.2").WithLabel(endpoint.OwnerLabelKey, "team-a")},
+		UpdateOld: []*endpoint.Endpoint{{DNSName: "api.example.com", RecordType: "A", RecordTTL: 600, Targets: endpoint.Targets{"1.1.1.1"}, Labels: labels}},
+		UpdateNew: []*endpoint.Endpoint{{DNSName: "api.example.com", RecordType: "A", RecordTTL: 60, Targets: endpoint.Targets{"1.1.1.1"}, Labels: labels}},
+	})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+
+	c.RecordRemark = "cluster={{.Cluster}}"
+	_, err = newProvider(c)
+	assert.Error(t, err)
+}
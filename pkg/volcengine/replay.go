@@ -0,0 +1,206 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+)
+
+// replayIgnoredFields differ between a recording and its replay and are not compared.
+var replayIgnoredFields = []string{"ClientToken"}
+
+// LoadInteractions reads the interactions of a Recorder file.
+func LoadInteractions(file string) ([]*Interaction, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var interactions []*Interaction
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("invalid interaction at %s:%d: %v", file, line, err)
+		}
+		interactions = append(interactions, &interaction)
+	}
+	return interactions, scanner.Err()
+}
+
+// replayClient answers every call with the first unused recorded interaction of the same action and input,
+// so regression tests can be built from the api_record_file captured during an incident.
+type replayClient struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+var _ privateZoneClient = &replayClient{}
+
+// NewReplayWrapper returns a PrivateZoneWrapper answering from the interactions recorded in file instead of the API.
+// Calls without a matching interaction fail.
+func NewReplayWrapper(file string, options ...PrivateZoneOption) (*PrivateZoneWrapper, error) {
+	interactions, err := LoadInteractions(file)
+	if err != nil {
+		return nil, err
+	}
+	w := &PrivateZoneWrapper{
+		log:    logrus.StandardLogger(),
+		client: &replayClient{interactions: interactions, used: make([]bool, len(interactions))},
+	}
+	for _, option := range options {
+		option(w)
+	}
+	return w, nil
+}
+
+// UnusedInteractions returns the recorded interactions a replay wrapper has not answered with yet.
+func UnusedInteractions(w *PrivateZoneWrapper) []*Interaction {
+	c, ok := w.client.(*replayClient)
+	if !ok {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var res []*Interaction
+	for i, interaction := range c.interactions {
+		if !c.used[i] {
+			res = append(res, interaction)
+		}
+	}
+	return res
+}
+
+func (c *replayClient) next(action string, input interface{}) (*Interaction, error) {
+	want, err := comparableJSON(sanitizedJSON(input))
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, interaction := range c.interactions {
+		if c.used[i] || interaction.Action != action {
+			continue
+		}
+		got, err := comparableJSON(interaction.Input)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.DeepEqual(want, got) {
+			c.used[i] = true
+			return interaction, nil
+		}
+	}
+	return nil, fmt.Errorf("no recorded %s interaction with input %s", action, sanitizedJSON(input))
+}
+
+func comparableJSON(data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("invalid recorded input: %v", err)
+	}
+	if m, ok := value.(map[string]interface{}); ok {
+		for _, field := range replayIgnoredFields {
+			delete(m, field)
+		}
+	}
+	return value, nil
+}
+
+// replay decodes the recorded output of the interaction matching action and input into output.
+func replay[O any](c *replayClient, action string, input interface{}) (*O, error) {
+	interaction, err := c.next(action, input)
+	if err != nil {
+		return nil, err
+	}
+	if interaction.Error != "" {
+		return nil, errors.New(interaction.Error)
+	}
+	output := new(O)
+	if len(interaction.Output) > 0 {
+		if err := json.Unmarshal(interaction.Output, output); err != nil {
+			return nil, fmt.Errorf("invalid recorded %s output: %v", action, err)
+		}
+	}
+	return output, nil
+}
+
+func (c *replayClient) ListPrivateZonesWithContext(_ context.Context, input *privatezone.ListPrivateZonesInput, _ ...request.Option) (*privatezone.ListPrivateZonesOutput, error) {
+	return replay[privatezone.ListPrivateZonesOutput](c, "ListPrivateZones", input)
+}
+
+func (c *replayClient) ListRecordsWithContext(_ context.Context, input *privatezone.ListRecordsInput, _ ...request.Option) (*privatezone.ListRecordsOutput, error) {
+	return replay[privatezone.ListRecordsOutput](c, "ListRecords", input)
+}
+
+func (c *replayClient) CreateRecordWithContext(_ context.Context, input *privatezone.CreateRecordInput, _ ...request.Option) (*privatezone.CreateRecordOutput, error) {
+	return replay[privatezone.CreateRecordOutput](c, "CreateRecord", input)
+}
+
+func (c *replayClient) UpdateRecordWithContext(_ context.Context, input *privatezone.UpdateRecordInput, _ ...request.Option) (*privatezone.UpdateRecordOutput, error) {
+	return replay[privatezone.UpdateRecordOutput](c, "UpdateRecord", input)
+}
+
+func (c *replayClient) BatchCreateRecordWithContext(_ context.Context, input *privatezone.BatchCreateRecordInput, _ ...request.Option) (*privatezone.BatchCreateRecordOutput, error) {
+	return replay[privatezone.BatchCreateRecordOutput](c, "BatchCreateRecord", input)
+}
+
+func (c *replayClient) BatchDeleteRecordWithContext(_ context.Context, input *privatezone.BatchDeleteRecordInput, _ ...request.Option) (*privatezone.BatchDeleteRecordOutput, error) {
+	return replay[privatezone.BatchDeleteRecordOutput](c, "BatchDeleteRecord", input)
+}
+
+func (c *replayClient) DeleteRecordWithContext(_ context.Context, input *privatezone.DeleteRecordInput, _ ...request.Option) (*privatezone.DeleteRecordOutput, error) {
+	return replay[privatezone.DeleteRecordOutput](c, "DeleteRecord", input)
+}
+
+func (c *replayClient) QueryPrivateZoneWithContext(_ context.Context, input *privatezone.QueryPrivateZoneInput, _ ...request.Option) (*privatezone.QueryPrivateZoneOutput, error) {
+	return replay[privatezone.QueryPrivateZoneOutput](c, "QueryPrivateZone", input)
+}
+
+func (c *replayClient) UpdatePrivateZoneWithContext(_ context.Context, input *privatezone.UpdatePrivateZoneInput, _ ...request.Option) (*privatezone.UpdatePrivateZoneOutput, error) {
+	return replay[privatezone.UpdatePrivateZoneOutput](c, "UpdatePrivateZone", input)
+}
+
+func (c *replayClient) CreatePrivateZoneWithContext(_ context.Context, input *privatezone.CreatePrivateZoneInput, _ ...request.Option) (*privatezone.CreatePrivateZoneOutput, error) {
+	return replay[privatezone.CreatePrivateZoneOutput](c, "CreatePrivateZone", input)
+}
+
+func (c *replayClient) DeletePrivateZoneWithContext(_ context.Context, input *privatezone.DeletePrivateZoneInput, _ ...request.Option) (*privatezone.DeletePrivateZoneOutput, error) {
+	return replay[privatezone.DeletePrivateZoneOutput](c, "DeletePrivateZone", input)
+}
+
+func (c *replayClient) IncBindVPCWithContext(_ context.Context, input *privatezone.IncBindVPCInput, _ ...request.Option) (*privatezone.IncBindVPCOutput, error) {
+	return replay[privatezone.IncBindVPCOutput](c, "IncBindVPC", input)
+}
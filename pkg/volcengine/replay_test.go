@@ -0,0 +1,68 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// testdata/replay-update.jsonl was recorded with api_record_file while updating www.example.com from 10.0.0.1 to 10.0.0.2.
+func TestReplayUpdate(t *testing.T) {
+	wrapper, err := NewReplayWrapper("testdata/replay-update.jsonl")
+	require.NoError(t, err)
+	p := &Provider{pzClient: wrapper, privateZone: true, vpcID: "vpc-1"}
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "www.example.com", endpoints[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"10.0.0.1"}, endpoints[0].Targets)
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.1")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "10.0.0.2")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, UnusedInteractions(wrapper))
+}
+
+func TestReplayUnrecordedCall(t *testing.T) {
+	wrapper, err := NewReplayWrapper("testdata/replay-update.jsonl")
+	require.NoError(t, err)
+
+	_, err = wrapper.ListPrivateZones(context.Background(), "vpc-2")
+	assert.ErrorContains(t, err, "no recorded ListPrivateZones interaction")
+
+	// Each interaction answers one call
+	_, err = wrapper.ListPrivateZones(context.Background(), "vpc-1")
+	assert.NoError(t, err)
+	_, err = wrapper.ListPrivateZones(context.Background(), "vpc-1")
+	assert.NoError(t, err)
+	_, err = wrapper.ListPrivateZones(context.Background(), "vpc-1")
+	assert.Error(t, err)
+	assert.Len(t, UnusedInteractions(wrapper), 3)
+}
+
+func TestLoadInteractionsInvalid(t *testing.T) {
+	_, err := LoadInteractions("testdata/missing.jsonl")
+	assert.Error(t, err)
+}
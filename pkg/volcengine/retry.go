@@ -0,0 +1,72 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine/client"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+)
+
+// retryCodesHandlerName names the request handler classifying Volcengine error codes for retries.
+const retryCodesHandlerName = "volcengine-provider.RetryCodes"
+
+// throttleErrorCodes are the Volcengine error codes of throttled calls, retried after the longer throttle backoff.
+var throttleErrorCodes = []string{"FlowLimitExceeded", "AccountFlowLimitExceeded", "Throttling", "Throttling.User", "RequestLimitExceeded"}
+
+// retryableErrorCodes are the Volcengine error codes of transient server errors.
+var retryableErrorCodes = []string{"InternalError", "InternalServiceError", "InternalServiceTimeout", "ServiceUnavailable"}
+
+// RetryPolicy retries failed API calls with exponential backoff and jitter. Throttled calls, HTTP 429 and 502-504,
+// and transient server errors, HTTP 500, are retried, other errors fail right away.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries.
+	MaxRetries int
+	// MinDelay is the backoff of the first retry, doubled for every further retry.
+	MinDelay time.Duration
+	// MaxDelay caps the backoff of a retry.
+	MaxDelay time.Duration
+}
+
+// retryer returns the SDK retryer of the policy, throttled calls wait at least the SDK throttle delay.
+func (r RetryPolicy) retryer() request.Retryer {
+	minThrottle := client.DefaultRetryerMinThrottleDelay
+	if r.MinDelay > minThrottle {
+		minThrottle = r.MinDelay
+	}
+	if r.MaxDelay > 0 && minThrottle > r.MaxDelay {
+		minThrottle = r.MaxDelay
+	}
+	return client.DefaultRetryer{
+		NumMaxRetries:    r.MaxRetries,
+		MinRetryDelay:    r.MinDelay,
+		MaxRetryDelay:    r.MaxDelay,
+		MinThrottleDelay: minThrottle,
+		MaxThrottleDelay: r.MaxDelay,
+	}
+}
+
+// retryCodesHandler makes the SDK retryer recognize the Volcengine throttling and server error codes.
+func retryCodesHandler() request.NamedHandler {
+	return request.NamedHandler{
+		Name: retryCodesHandlerName,
+		Fn: func(r *request.Request) {
+			r.ThrottleErrorCodes = append(r.ThrottleErrorCodes, throttleErrorCodes...)
+			r.RetryErrorCodes = append(r.RetryErrorCodes, retryableErrorCodes...)
+		},
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/client"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+)
+
+func TestRetryPolicyRetryer(t *testing.T) {
+	r := RetryPolicy{MaxRetries: 5, MinDelay: 100 * time.Millisecond, MaxDelay: 10 * time.Second}.retryer().(client.DefaultRetryer)
+	assert.Equal(t, 5, r.MaxRetries())
+	assert.Equal(t, 100*time.Millisecond, r.MinRetryDelay)
+	assert.Equal(t, client.DefaultRetryerMinThrottleDelay, r.MinThrottleDelay)
+	assert.Equal(t, 10*time.Second, r.MaxThrottleDelay)
+
+	r = RetryPolicy{MaxRetries: 1, MinDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}.retryer().(client.DefaultRetryer)
+	assert.Equal(t, 50*time.Millisecond, r.MinThrottleDelay, "the throttle delay is capped by MaxDelay")
+}
+
+// flakyPrivateZone answers with the status and error code until failures calls failed, then lists no zones.
+func flakyPrivateZone(failures int32, status int, code string, calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1","Action":"ListPrivateZones","Error":{"Code":"` + code + `","Message":"try again"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"2","Action":"ListPrivateZones"},"Result":{"Zones":[],"Total":0}}`))
+	}))
+}
+
+func TestPrivateZoneWrapperRetries(t *testing.T) {
+	policy := &RetryPolicy{MaxRetries: 2, MinDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	for _, tc := range []struct {
+		name    string
+		status  int
+		code    string
+		failing int32
+		calls   int32
+		wantErr bool
+	}{
+		{name: "throttled", status: http.StatusBadRequest, code: "FlowLimitExceeded", failing: 2, calls: 3},
+		{name: "server error", status: http.StatusInternalServerError, code: "InternalError", failing: 1, calls: 2},
+		{name: "too many retries", status: http.StatusServiceUnavailable, code: "ServiceUnavailable", failing: 5, calls: 3, wantErr: true},
+		{name: "not retryable", status: http.StatusBadRequest, code: "InvalidParameter", failing: 5, calls: 1, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := flakyPrivateZone(tc.failing, tc.status, tc.code, &calls)
+			defer server.Close()
+
+			w, err := NewPrivateZoneWrapper("cn-beijing", server.URL, credentials.NewStaticCredentials("ak", "sk", ""),
+				WithPrivateZoneRetryPolicy(policy))
+			require.NoError(t, err)
+			_, err = w.ListPrivateZones(context.Background(), "vpc-1")
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.calls, calls.Load())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// SecretCredentialsProviderName is the provider name of credentials read from a Kubernetes Secret.
+	SecretCredentialsProviderName = "SecretCredentialsProvider"
+
+	// Keys read from the Secret, matching the keys used by the helm chart.
+	secretAccessKeyKey     = "access-key"
+	secretSecretKeyKey     = "secret-key"
+	secretOIDCRoleTrnKey   = "oidc-role-trn"
+	secretOIDCTokenFileKey = "oidc-token-file"
+)
+
+// SecretCredentialsProvider serves credentials from a Kubernetes Secret and picks up changes to it.
+// The Secret holds either access-key and secret-key, or oidc-role-trn and oidc-token-file.
+type SecretCredentialsProvider struct {
+	namespace   string
+	name        string
+	stsEndpoint string
+	log         Logger
+
+	mu      sync.Mutex
+	data    map[string][]byte
+	changed bool
+	oidc    *credentials.OIDCCredentialsProvider
+}
+
+// NewSecretCredentials reads credentials from the Secret namespace/name and watches it until ctx is done, the
+// changes of the Secret are logged to log.
+func NewSecretCredentials(ctx context.Context, client kubernetes.Interface, namespace, name, stsEndpoint string, log Logger) (*credentials.Credentials, error) {
+	p := &SecretCredentialsProvider{
+		namespace:   namespace,
+		name:        name,
+		stsEndpoint: stsEndpoint,
+		log:         log,
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+			o.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}))
+	informer := factory.Core().V1().Secrets().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			p.update(obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			p.update(obj)
+		},
+		DeleteFunc: func(interface{}) {
+			log.Warnf("Credentials secret %s/%s was deleted, keep using the last known credentials", namespace, name)
+		},
+	}); err != nil {
+		return nil, err
+	}
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return nil, fmt.Errorf("failed to sync credentials secret %s/%s", namespace, name)
+	}
+	if _, exists, err := informer.GetStore().GetByKey(namespace + "/" + name); err != nil || !exists {
+		return nil, fmt.Errorf("credentials secret %s/%s not found: %v", namespace, name, err)
+	}
+
+	return credentials.NewCredentials(p), nil
+}
+
+func (p *SecretCredentialsProvider) update(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.data = secret.Data
+	p.changed = true
+	p.oidc = nil
+	p.log.Infof("Loaded credentials from secret %s/%s, resourceVersion: %s", p.namespace, p.name, secret.ResourceVersion)
+}
+
+// Retrieve returns the credentials currently held in the Secret.
+// Implementation for credentials.Provider
+func (p *SecretCredentialsProvider) Retrieve() (credentials.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changed = false
+
+	accessKey, secretKey := string(p.data[secretAccessKeyKey]), string(p.data[secretSecretKeyKey])
+	if accessKey != "" && secretKey != "" {
+		return credentials.Value{
+			AccessKeyID:     accessKey,
+			SecretAccessKey: secretKey,
+			ProviderName:    SecretCredentialsProviderName,
+		}, nil
+	}
+	roleTrn, tokenFile := string(p.data[secretOIDCRoleTrnKey]), string(p.data[secretOIDCTokenFileKey])
+	if roleTrn != "" && tokenFile != "" {
+		if p.oidc == nil {
+			p.oidc = newOIDCProvider(p.stsEndpoint, roleTrn, tokenFile)
+		}
+		return p.oidc.Retrieve()
+	}
+	return credentials.Value{ProviderName: SecretCredentialsProviderName},
+		fmt.Errorf("secret %s/%s has neither %s/%s nor %s/%s", p.namespace, p.name,
+			secretAccessKeyKey, secretSecretKeyKey, secretOIDCRoleTrnKey, secretOIDCTokenFileKey)
+}
+
+// IsExpired reports whether the Secret changed since the last Retrieve, or the OIDC credentials expired.
+// Implementation for credentials.Provider
+func (p *SecretCredentialsProvider) IsExpired() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.changed {
+		return true
+	}
+	return p.oidc != nil && p.oidc.IsExpired()
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretCredentials(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "volc", Namespace: "kube-system"},
+		Data: map[string][]byte{
+			secretAccessKeyKey: []byte("ak1"),
+			secretSecretKeyKey: []byte("sk1"),
+		},
+	}
+	client := fake.NewSimpleClientset(secret)
+
+	creds, err := NewSecretCredentials(ctx, client, "kube-system", "volc", "", logrus.StandardLogger())
+	assert.NoError(t, err)
+	value, err := creds.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "ak1", value.AccessKeyID)
+	assert.Equal(t, "sk1", value.SecretAccessKey)
+
+	// rotate the secret and expect the new value to be picked up
+	secret = secret.DeepCopy()
+	secret.Data[secretAccessKeyKey] = []byte("ak2")
+	_, err = client.CoreV1().Secrets("kube-system").Update(ctx, secret, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		value, err := creds.Get()
+		return err == nil && value.AccessKeyID == "ak2"
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestSecretCredentialsNotFound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := NewSecretCredentials(ctx, fake.NewSimpleClientset(), "kube-system", "missing", "", logrus.StandardLogger())
+	assert.Error(t, err)
+}
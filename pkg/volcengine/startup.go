@@ -0,0 +1,170 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+)
+
+// Startup checks of StartupError, in the order ValidateStartup runs them.
+const (
+	StartupCheckCredentials     = "credentials"
+	StartupCheckZones           = "zones"
+	StartupCheckDomainFilter    = "domain_filter"
+	StartupCheckWritePermission = "write_permission"
+)
+
+// probeRecordID is a record ID no record has, deleting it checks the write permission without changing records.
+const probeRecordID = "0"
+
+// permissionDeniedCodes are the API error codes of calls the credentials are not allowed to make.
+var permissionDeniedCodes = map[string]bool{"AccessDenied": true, "Forbidden": true}
+
+// StartupError is returned by ValidateStartup with the check that failed.
+type StartupError struct {
+	Check string
+	Err   error
+}
+
+func (e *StartupError) Error() string {
+	return fmt.Sprintf("startup check %s failed: %v", e.Check, e.Err)
+}
+
+func (e *StartupError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateStartup verifies that the webhook can work before it serves external-dns: the credentials are obtained,
+// the zones of the VPC are listed, every domain filter matches one of them and the credentials may delete records,
+// checked by deleting a record ID no record has. The write permission is not checked in dry-run mode. The public
+// zones are checked too when managed.
+func (p *Provider) ValidateStartup(ctx context.Context) error {
+	if p.credentials != nil {
+		if _, err := p.credentials.Get(); err != nil {
+			return &StartupError{Check: StartupCheckCredentials, Err: err}
+		}
+	}
+	if p.pzClient != nil {
+		if err := p.validateZones(ctx); err != nil {
+			return err
+		}
+	}
+	if p.publicZones != nil {
+		return p.publicZones.ValidateStartup(ctx)
+	}
+	return nil
+}
+
+// validateZones runs the zone, domain filter and write permission checks of ValidateStartup.
+func (p *Provider) validateZones(ctx context.Context) error {
+	kind, scope := "private", fmt.Sprintf("bound to vpc %q", p.vpcID)
+	if p.public {
+		kind, scope = "public", "in the account"
+	}
+	api := uncachedAPI(p.pzClient)
+	zones, err := api.ListPrivateZones(ctx, p.vpcID)
+	if err != nil {
+		return &StartupError{Check: StartupCheckZones,
+			Err: fmt.Errorf("failed to list %s zones, check the access key and the list permission: %w", kind, err)}
+	}
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, strings.TrimSuffix(strings.ToLower(volcengine.StringValue(zone.ZoneName)), "."))
+	}
+	probeZone := int64(-1)
+	for _, filter := range p.domainFilter.Filters {
+		if filter = strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(filter)), "."), "."); filter == "" {
+			continue
+		}
+		i := zoneOfFilter(filter, names)
+		if i < 0 {
+			return &StartupError{Check: StartupCheckDomainFilter,
+				Err: fmt.Errorf("no %s zone %s contains the domain filter %s, zones: %s", kind, scope, filter, strings.Join(names, ","))}
+		}
+		if probeZone < 0 {
+			probeZone = int64(volcengine.Int32Value(zones[i].ZID))
+		}
+	}
+	if len(zones) == 0 {
+		// without a domain filter the zones may be created later, the webhook then serves an empty record set
+		p.logger().Warnf("No %s zones are %s yet, skipping the write permission check", kind, scope)
+		return nil
+	}
+	if probeZone < 0 {
+		probeZone = int64(volcengine.Int32Value(zones[0].ZID))
+	}
+	if isDryRun(p.pzClient) {
+		return nil
+	}
+	if err := checkWritePermission(ctx, api, probeZone); err != nil {
+		return &StartupError{Check: StartupCheckWritePermission,
+			Err: fmt.Errorf("the credentials may not change the records of zone %d: %w", probeZone, err)}
+	}
+	return nil
+}
+
+// zoneOfFilter returns the index of the first zone holding names of the domain filter: the zone is the filtered
+// domain, a subdomain of it or a parent domain of it. It returns -1 when no zone does.
+func zoneOfFilter(filter string, zones []string) int {
+	for i, zone := range zones {
+		if zone == filter || strings.HasSuffix(zone, "."+filter) || strings.HasSuffix(filter, "."+zone) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isDryRun reports whether the API client only logs the record changes at the moment.
+func isDryRun(api privateZoneAPI) bool {
+	for {
+		switch a := api.(type) {
+		case *dryRunAPI:
+			return a.active.Load()
+		case *cachedPrivateZoneAPI:
+			api = a.privateZoneAPI
+		default:
+			return false
+		}
+	}
+}
+
+// checkWritePermission deletes a record ID no record has from the zone through the API, so the call is throttled and
+// recorded like the other writes. The API refuses it with a permission error when the credentials may not delete
+// records and with another error, e.g. for the missing record, when they may.
+func checkWritePermission(ctx context.Context, api privateZoneAPI, zid int64) error {
+	err := api.DeletePrivateZoneRecordById(ctx, zid, probeRecordID)
+	if err != nil && isPermissionDenied(err) {
+		return err
+	}
+	return nil
+}
+
+// isPermissionDenied reports whether an API call failed because the credentials may not make it.
+func isPermissionDenied(err error) bool {
+	var reqErr volcengineerr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusForbidden {
+		return true
+	}
+	var apiErr volcengineerr.Error
+	return errors.As(err, &apiErr) && permissionDeniedCodes[apiErr.Code()]
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestValidateStartup(t *testing.T) {
+	zones := []*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(1), ZoneName: volcengine.String("other.com")},
+		{ZID: volcengine.Int32(2), ZoneName: volcengine.String("example.com")},
+	}
+	tests := []struct {
+		name      string
+		filters   []string
+		zones     []*privatezone.ZoneForListPrivateZonesOutput
+		listErr   error
+		writeErr  error
+		dryRun    bool
+		wantCheck string
+		wantProbe []int64
+	}{
+		{name: "valid", filters: []string{"example.com"}, zones: zones, wantProbe: []int64{2}},
+		{name: "filter of a subdomain", filters: []string{"app.example.com."}, zones: zones, wantProbe: []int64{2}},
+		{name: "no filter", zones: zones, wantProbe: []int64{1}},
+		{name: "list fails", listErr: errors.New("InvalidAccessKey"), wantCheck: StartupCheckZones},
+		{name: "no zones yet", wantProbe: []int64{}},
+		{name: "filter without any zone", filters: []string{"example.com"}, wantCheck: StartupCheckDomainFilter},
+		{name: "filter without zone", filters: []string{"example.com", "missing.org"}, zones: zones, wantCheck: StartupCheckDomainFilter},
+		{name: "write denied", filters: []string{"example.com"}, zones: zones, writeErr: volcengineerr.New("AccessDenied", "not allowed", nil),
+			wantCheck: StartupCheckWritePermission, wantProbe: []int64{2}},
+		{name: "write allowed", zones: zones, writeErr: volcengineerr.New("RecordNotFound", "record 0 not found", nil), wantProbe: []int64{1}},
+		{name: "dry run skips write check", zones: zones, writeErr: volcengineerr.New("AccessDenied", "not allowed", nil), dryRun: true, wantProbe: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPrivateZoneAPI)
+			mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(tt.zones, tt.listErr).Once()
+			probed := []int64{}
+			mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, mock.Anything, probeRecordID).Return(tt.writeErr).
+				Run(func(args mock.Arguments) { probed = append(probed, args.Get(1).(int64)) }).Maybe()
+			provider := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+			if tt.dryRun {
+				provider.pzClient = newDryRunAPI(mockAPI, logrus.StandardLogger())
+			}
+			if len(tt.filters) > 0 {
+				provider.domainFilter = *endpoint.NewDomainFilter(tt.filters)
+			}
+
+			err := provider.ValidateStartup(context.Background())
+			if tt.wantCheck == "" {
+				assert.NoError(t, err)
+			} else {
+				var startupErr *StartupError
+				if assert.ErrorAs(t, err, &startupErr) {
+					assert.Equal(t, tt.wantCheck, startupErr.Check)
+				}
+			}
+			if tt.wantProbe != nil {
+				assert.Equal(t, tt.wantProbe, probed)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidateStartupPublicZones(t *testing.T) {
+	publicAPI := new(MockPrivateZoneAPI)
+	publicAPI.On("ListPrivateZones", mock.Anything, "").Return([]*privatezone.ZoneForListPrivateZonesOutput(nil), nil).Once()
+	provider := &Provider{publicZones: &Provider{pzClient: publicAPI, public: true,
+		domainFilter: *endpoint.NewDomainFilter([]string{"example.com"})}}
+
+	err := provider.ValidateStartup(context.Background())
+	assert.ErrorContains(t, err, "startup check domain_filter failed: no public zone in the account contains the domain filter example.com")
+	publicAPI.AssertExpectations(t)
+}
+
+func TestCheckWritePermission(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "missing record", err: volcengineerr.New("RecordNotFound", "record 0 not found", nil)},
+		{name: "denied code", err: volcengineerr.New("AccessDenied", "not allowed", nil), wantErr: true},
+		{name: "forbidden status", err: volcengineerr.NewRequestFailure(volcengineerr.New("Unknown", "", nil), 403, "req-1"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			mockClient.DeleteRecordFunc = func(ctx context.Context, input *privatezone.DeleteRecordInput) (*privatezone.DeleteRecordOutput, error) {
+				assert.Equal(t, int64(123), volcengine.Int64Value(input.ZID))
+				assert.Equal(t, probeRecordID, volcengine.StringValue(input.RecordID))
+				return nil, tt.err
+			}
+			wrapper := &PrivateZoneWrapper{client: mockClient}
+
+			err := checkWritePermission(context.Background(), wrapper, 123)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
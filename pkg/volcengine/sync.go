@@ -0,0 +1,208 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultSyncRecordTypes are the record types a sync manages unless given, the default of external-dns.
+var DefaultSyncRecordTypes = []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+
+// endpointFile is a file of desired endpoints: a list of endpoints, an object with an endpoints list or a
+// DNSEndpoint resource with spec.endpoints.
+type endpointFile struct {
+	Endpoints []*endpoint.Endpoint `json:"endpoints,omitempty"`
+	Spec      struct {
+		Endpoints []*endpoint.Endpoint `json:"endpoints,omitempty"`
+	} `json:"spec"`
+}
+
+// ReadEndpoints reads desired endpoints as YAML or JSON, a list of endpoints in the external-dns format, an object
+// with an endpoints list or a DNSEndpoint resource.
+func ReadEndpoints(r io.Reader) ([]*endpoint.Endpoint, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []*endpoint.Endpoint
+	switch data = bytes.TrimSpace(data); {
+	case bytes.HasPrefix(data, []byte("[")):
+		err = yaml.UnmarshalStrict(data, &endpoints)
+	case bytes.HasPrefix(data, []byte("{")):
+		var file endpointFile
+		if err = yaml.Unmarshal(data, &file); err == nil {
+			endpoints = append(file.Endpoints, file.Spec.Endpoints...)
+		}
+	case bytes.Equal(data, []byte("null")):
+	default:
+		err = fmt.Errorf("expected a list of endpoints or an object with endpoints")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, ep := range endpoints {
+		if ep == nil || ep.DNSName == "" || ep.RecordType == "" || len(ep.Targets) == 0 {
+			return nil, fmt.Errorf("endpoint %d needs a dnsName, a recordType and targets", i)
+		}
+		ep.RecordType = strings.ToUpper(ep.RecordType)
+	}
+	return endpoints, nil
+}
+
+// PlanSync calculates the changes moving the records of the provider of recordTypes to desired under the
+// external-dns policy, sync, upsert-only or create-only. desired is adjusted like external-dns adjusts the endpoints
+// of its sources. Only records written by external-dns, of the owner of the managed record guard when set, are
+// planned for deletion, so records made by hand or by other clusters survive a sync.
+func (p *Provider) PlanSync(ctx context.Context, desired []*endpoint.Endpoint, policy string, recordTypes []string) (*plan.Changes, error) {
+	pol, ok := plan.Policies[policy]
+	if !ok {
+		return nil, fmt.Errorf("invalid policy %q, expected sync, upsert-only or create-only", policy)
+	}
+	desired, err := p.AdjustEndpoints(desired)
+	if err != nil {
+		return nil, err
+	}
+	current, err := p.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	calculated := (&plan.Plan{
+		Current:        current,
+		Desired:        desired,
+		Policies:       []plan.Policy{pol},
+		ManagedRecords: recordTypes,
+	}).Calculate()
+	changes := calculated.Changes
+	if len(changes.Delete) > 0 {
+		if changes.Delete, err = p.managedDeletions(ctx, changes.Delete); err != nil {
+			return nil, err
+		}
+	}
+	sortEndpoints(changes.Create)
+	sortEndpoints(changes.Delete)
+	sort.Sort(updatePairs{changes.UpdateOld, changes.UpdateNew})
+	return changes, nil
+}
+
+// managedDeletions returns the deletions whose records were all written by external-dns.
+func (p *Provider) managedDeletions(ctx context.Context, deletions []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	managed, err := p.managedEndpointKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*endpoint.Endpoint, 0, len(deletions))
+	for _, ep := range deletions {
+		if !managed[endpointKey(ep.DNSName, ep.RecordType, ep.SetIdentifier)] {
+			p.requestLogger(ctx).Warnf("Skipping deletion of %s not managed by external-dns", ep)
+			continue
+		}
+		res = append(res, ep)
+	}
+	return res, nil
+}
+
+// managedEndpointKeys lists the records of the zones and reports for the name, type and set identifier of every
+// endpoint whether all its records have a managed remark of the owner of the managed record guard, if any.
+func (p *Provider) managedEndpointKeys(ctx context.Context) (map[string]bool, error) {
+	guard := ManagedRecordGuard{Enabled: true, Owner: p.managedGuard.Owner}
+	keys := make(map[string]bool)
+	for _, zones := range p.zoneProviders() {
+		if zones == p && !p.privateZone {
+			continue
+		}
+		vpcZones, err := zones.pzClient.ListPrivateZones(ctx, zones.vpcID)
+		if err != nil {
+			return nil, err
+		}
+		for _, zone := range vpcZones {
+			zoneName := volcengine.StringValue(zone.ZoneName)
+			if zones.domainFilter.IsConfigured() && !zones.domainFilter.Match(zoneName) {
+				continue
+			}
+			records, err := zones.pzClient.GetPrivateZoneRecords(ctx, int64(volcengine.Int32Value(zone.ZID)))
+			if err != nil {
+				return nil, err
+			}
+			for _, record := range removeTombstones(records) {
+				key := endpointKey(getDNSName(strings.ToLower(volcengine.StringValue(record.Host)), zoneName),
+					volcengine.StringValue(record.Type), remarkSetIdentifier(volcengine.StringValue(record.Remark)))
+				managed, seen := keys[key]
+				keys[key] = guard.manages(record) && (managed || !seen)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// endpointKey identifies the endpoint of a name, type and set identifier.
+func endpointKey(dnsName, recordType, setIdentifier string) string {
+	return dnsName + "/" + recordType + "/" + setIdentifier
+}
+
+// sortEndpoints sorts endpoints by name, type and set identifier, plans list them in random order.
+func sortEndpoints(endpoints []*endpoint.Endpoint) {
+	sort.Slice(endpoints, func(i, j int) bool { return endpointLess(endpoints[i], endpoints[j]) })
+}
+
+func endpointLess(a, b *endpoint.Endpoint) bool {
+	if a.DNSName != b.DNSName {
+		return a.DNSName < b.DNSName
+	}
+	if a.RecordType != b.RecordType {
+		return a.RecordType < b.RecordType
+	}
+	return a.SetIdentifier < b.SetIdentifier
+}
+
+// updatePairs sorts the old and new endpoints of updates together by the new endpoint.
+type updatePairs struct{ old, new []*endpoint.Endpoint }
+
+func (u updatePairs) Len() int           { return len(u.new) }
+func (u updatePairs) Less(i, j int) bool { return endpointLess(u.new[i], u.new[j]) }
+func (u updatePairs) Swap(i, j int) {
+	u.old[i], u.old[j] = u.old[j], u.old[i]
+	u.new[i], u.new[j] = u.new[j], u.new[i]
+}
+
+// FormatChanges formats the changes as +/~/- lines of endpoints.
+func FormatChanges(changes *plan.Changes) string {
+	var b strings.Builder
+	for _, ep := range changes.Create {
+		fmt.Fprintf(&b, "+ %s\n", ep)
+	}
+	for i, ep := range changes.UpdateNew {
+		fmt.Fprintf(&b, "~ %s -> %s\n", changes.UpdateOld[i], ep)
+	}
+	for _, ep := range changes.Delete {
+		fmt.Fprintf(&b, "- %s\n", ep)
+	}
+	return b.String()
+}
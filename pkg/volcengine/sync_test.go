@@ -0,0 +1,110 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestReadEndpoints(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		file string
+	}{
+		{name: "yaml list", file: "- dnsName: www.example.com\n  recordType: a\n  targets: [1.2.3.4]\n  recordTTL: 300\n"},
+		{name: "json list", file: `[{"dnsName":"www.example.com","recordType":"A","targets":["1.2.3.4"],"recordTTL":300}]`},
+		{name: "endpoints object", file: "endpoints:\n- dnsName: www.example.com\n  recordType: A\n  targets: [1.2.3.4]\n  recordTTL: 300\n"},
+		{name: "DNSEndpoint", file: "apiVersion: externaldns.k8s.io/v1alpha1\nkind: DNSEndpoint\nmetadata:\n  name: www\nspec:\n  endpoints:\n  - dnsName: www.example.com\n    recordType: A\n    targets: [1.2.3.4]\n    recordTTL: 300\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoints, err := ReadEndpoints(strings.NewReader(tc.file))
+			require.NoError(t, err)
+			require.Len(t, endpoints, 1)
+			assert.Equal(t, "www.example.com", endpoints[0].DNSName)
+			assert.Equal(t, "A", endpoints[0].RecordType)
+			assert.Equal(t, endpoint.Targets{"1.2.3.4"}, endpoints[0].Targets)
+			assert.Equal(t, endpoint.TTL(300), endpoints[0].RecordTTL)
+		})
+	}
+
+	endpoints, err := ReadEndpoints(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+
+	for _, file := range []string{
+		"- dnsName: www.example.com\n  recordType: A\n",
+		"- dnsName: www.example.com\n  type: A\n  targets: [1.2.3.4]\n",
+		"www.example.com",
+	} {
+		_, err := ReadEndpoints(strings.NewReader(file))
+		assert.Error(t, err, file)
+	}
+}
+
+func TestPlanSync(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.1.1.1"), TTL: volcengine.Int32(300)},
+		{Host: volcengine.String("old"), Type: volcengine.String("A"), Value: volcengine.String("3.3.3.3"), TTL: volcengine.Int32(300),
+			Remark: volcengine.String(defaultRecordRemark)},
+		// made by hand, never deleted
+		{Host: volcengine.String("manual"), Type: volcengine.String("A"), Value: volcengine.String("5.5.5.5"), TTL: volcengine.Int32(300)},
+		{Host: volcengine.String("other"), Type: volcengine.String("A"), Value: volcengine.String("6.6.6.6"), TTL: volcengine.Int32(300),
+			Remark: volcengine.String(defaultRecordRemark + remarkSeparator + "owner=other")},
+		{Host: volcengine.String("spf"), Type: volcengine.String("TXT"), Value: volcengine.String("v=spf1 -all"), TTL: volcengine.Int32(300)},
+	}, nil)
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "2.2.2.2"),
+		endpoint.NewEndpointWithTTL("new.example.com", "A", 300, "4.4.4.4"),
+	}
+
+	changes, err := p.PlanSync(context.Background(), desired, "sync", DefaultSyncRecordTypes)
+	require.NoError(t, err)
+	assert.Equal(t, "+ new.example.com 300 IN A  4.4.4.4 []\n"+
+		"~ www.example.com 300 IN A  1.1.1.1 [] -> www.example.com 300 IN A  2.2.2.2 []\n"+
+		"- old.example.com 300 IN A  3.3.3.3 []\n"+
+		"- other.example.com 300 IN A  6.6.6.6 []\n", FormatChanges(changes))
+
+	// with an owner only its own records are deleted
+	p.managedGuard.Owner = "other"
+	changes, err = p.PlanSync(context.Background(), desired, "sync", DefaultSyncRecordTypes)
+	require.NoError(t, err)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "other.example.com", changes.Delete[0].DNSName)
+	p.managedGuard.Owner = ""
+
+	changes, err = p.PlanSync(context.Background(), desired, "upsert-only", DefaultSyncRecordTypes)
+	require.NoError(t, err)
+	assert.Len(t, changes.Create, 1)
+	assert.Len(t, changes.UpdateNew, 1)
+	assert.Empty(t, changes.Delete)
+
+	_, err = p.PlanSync(context.Background(), desired, "delete-all", DefaultSyncRecordTypes)
+	assert.Error(t, err)
+}
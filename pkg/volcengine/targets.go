@@ -0,0 +1,110 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// TargetDotPolicy controls the trailing dot of the names in CNAME, MX, SRV and PTR targets,
+// applied to the values written to privatezone and to the targets returned to external-dns.
+// The zero policy is TargetDotCNAME.
+type TargetDotPolicy string
+
+const (
+	// TargetDotCNAME writes CNAME names fully qualified and other names as they are, and returns names as they are.
+	// It is the default, the records written before the policy existed end with a dot.
+	TargetDotCNAME TargetDotPolicy = "cname"
+	// TargetDotPreserve writes and returns names as they are.
+	TargetDotPreserve TargetDotPolicy = "preserve"
+	// TargetDotAppend fully qualifies names with a trailing dot.
+	TargetDotAppend TargetDotPolicy = "append"
+	// TargetDotStrip removes the trailing dot of names.
+	TargetDotStrip TargetDotPolicy = "strip"
+)
+
+// nameValuedRecordTypes are the record types whose value ends with a domain name.
+var nameValuedRecordTypes = map[string]bool{
+	endpoint.RecordTypeCNAME: true,
+	endpoint.RecordTypeMX:    true,
+	endpoint.RecordTypeSRV:   true,
+	endpoint.RecordTypePTR:   true,
+}
+
+// ParseTargetDotPolicy parses cname, preserve, append or strip, empty is cname.
+func ParseTargetDotPolicy(value string) (TargetDotPolicy, error) {
+	switch policy := TargetDotPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return TargetDotCNAME, nil
+	case TargetDotCNAME, TargetDotPreserve, TargetDotAppend, TargetDotStrip:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid target dot policy %q, expected cname, preserve, append or strip", value)
+	}
+}
+
+// apply applies the policy to the name at the end of a value of a name-valued record type,
+// e.g. the exchange of "10 mail.example.com", values of other types are returned unchanged.
+func (p TargetDotPolicy) apply(recordType, value string) string {
+	// the root name "." of a null MX or SRV target keeps its dot
+	if !nameValuedRecordTypes[recordType] || value == "" || value == "." || strings.HasSuffix(value, " .") {
+		return value
+	}
+	switch p {
+	case TargetDotAppend:
+		if !strings.HasSuffix(value, ".") {
+			return value + "."
+		}
+	case TargetDotStrip:
+		return strings.TrimRight(value, ".")
+	}
+	return value
+}
+
+// write applies the policy to a value written to the zone, TargetDotCNAME fully qualifies the names of CNAME values.
+func (p TargetDotPolicy) write(recordType, value string) string {
+	if (p == "" || p == TargetDotCNAME) && recordType == endpoint.RecordTypeCNAME {
+		return TargetDotAppend.apply(recordType, value)
+	}
+	return p.apply(recordType, value)
+}
+
+// sameTarget reports whether two values of the record type are equal, ignoring the trailing dot of names.
+// MX and SRV values are compared field by field, so the spacing of the fields does not matter either,
+// and AAAA values as addresses, so "2001:db8::1" equals "2001:0db8:0:0:0:0:0:1".
+func sameTarget(recordType, a, b string) bool {
+	if recordType == endpoint.RecordTypeAAAA {
+		return canonicalIPv6(a) == canonicalIPv6(b)
+	}
+	if recordType == endpoint.RecordTypeMX {
+		mxA, errA := parseMX(a)
+		mxB, errB := parseMX(b)
+		if errA == nil && errB == nil {
+			return TargetDotStrip.apply(recordType, mxA.String()) == TargetDotStrip.apply(recordType, mxB.String())
+		}
+	}
+	if recordType == endpoint.RecordTypeSRV {
+		srvA, errA := parseSRV(a)
+		srvB, errB := parseSRV(b)
+		if errA == nil && errB == nil {
+			return srvA.String() == srvB.String()
+		}
+	}
+	return TargetDotStrip.apply(recordType, a) == TargetDotStrip.apply(recordType, b)
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseTargetDotPolicy(t *testing.T) {
+	for value, want := range map[string]TargetDotPolicy{
+		"":         TargetDotCNAME,
+		"cname":    TargetDotCNAME,
+		"preserve": TargetDotPreserve,
+		" Append ": TargetDotAppend,
+		"STRIP":    TargetDotStrip,
+	} {
+		policy, err := ParseTargetDotPolicy(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, policy, value)
+	}
+	_, err := ParseTargetDotPolicy("dot")
+	assert.Error(t, err)
+}
+
+func TestTargetDotPolicyApply(t *testing.T) {
+	tests := []struct {
+		policy     TargetDotPolicy
+		recordType string
+		value      string
+		want       string
+	}{
+		{TargetDotPreserve, "CNAME", "target.example.com", "target.example.com"},
+		{TargetDotPreserve, "CNAME", "target.example.com.", "target.example.com."},
+		{TargetDotAppend, "CNAME", "target.example.com", "target.example.com."},
+		{TargetDotAppend, "CNAME", "target.example.com.", "target.example.com."},
+		{TargetDotAppend, "MX", "10 mail.example.com", "10 mail.example.com."},
+		{TargetDotAppend, "SRV", "10 5 443 svc.example.com", "10 5 443 svc.example.com."},
+		{TargetDotAppend, "A", "1.2.3.4", "1.2.3.4"},
+		{TargetDotAppend, "TXT", "heritage=external-dns", "heritage=external-dns"},
+		{TargetDotAppend, "CNAME", "", ""},
+		{TargetDotStrip, "CNAME", "target.example.com.", "target.example.com"},
+		{TargetDotStrip, "PTR", "host.example.com.", "host.example.com"},
+		{TargetDotStrip, "MX", "10 mail.example.com", "10 mail.example.com"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.policy.apply(tt.recordType, tt.value), "%s %s %q", tt.policy, tt.recordType, tt.value)
+	}
+	assert.Equal(t, "target.example.com.", TargetDotCNAME.write("CNAME", "target.example.com"))
+	assert.Equal(t, "target.example.com.", TargetDotPolicy("").write("CNAME", "target.example.com"))
+	assert.Equal(t, "10 mail.example.com", TargetDotCNAME.write("MX", "10 mail.example.com"))
+	assert.Equal(t, "target.example.com", TargetDotPreserve.write("CNAME", "target.example.com"))
+	assert.Equal(t, "target.example.com", TargetDotStrip.write("CNAME", "target.example.com."))
+	assert.True(t, sameTarget("CNAME", "target.example.com", "target.example.com."))
+	assert.False(t, sameTarget("TXT", "value", "value."))
+}
+
+func TestSameTargetSRV(t *testing.T) {
+	assert.True(t, sameTarget("SRV", "10 5 5060 sip.example.com.", "10  5 5060 sip.example.com"))
+	assert.False(t, sameTarget("SRV", "10 5 5060 sip.example.com", "10 5 5061 sip.example.com"))
+	assert.Equal(t, "0 0 0 .", TargetDotStrip.apply("SRV", "0 0 0 ."))
+	assert.Equal(t, "10 5 5060 sip.example.com.", TargetDotAppend.apply("SRV", "10 5 5060 sip.example.com"))
+}
+
+func TestSameTargetAAAA(t *testing.T) {
+	assert.True(t, sameTarget("AAAA", "2001:db8::1", "2001:0db8:0000:0000:0000:0000:0000:0001"))
+	assert.False(t, sameTarget("AAAA", "2001:db8::1", "2001:db8::2"))
+	// AAAA values are addresses, not names, the dot policy leaves them alone
+	assert.Equal(t, "2001:db8::1", TargetDotAppend.apply("AAAA", "2001:db8::1"))
+}
+
+func TestSameTargetMX(t *testing.T) {
+	assert.True(t, sameTarget("MX", "10 mail.example.com.", "10  Mail.example.com"))
+	assert.False(t, sameTarget("MX", "10 mail.example.com", "20 mail.example.com"))
+	assert.False(t, sameTarget("MX", "10 mail.example.com", "10 mx.example.com"))
+}
+
+func TestUpdateMXPreference(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI}
+	records := []*privatezone.RecordForListRecordsOutput{{
+		RecordID: volcengine.String("r1"),
+		Host:     volcengine.String("@"),
+		Type:     volcengine.String("MX"),
+		Value:    volcengine.String("10 mail.example.com"),
+		TTL:      volcengine.Int32(600),
+	}}
+	// the preference changed, the record is rewritten in place
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "r1", "@", "MX", "20 mail.example.com", int32(600), int32(0), "", mock.Anything).Return(nil)
+
+	p.updateRecordSet(ctx, 123, "@", endpoint.NewEndpoint("example.com", "MX", "20  mail.example.com"), records)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCreateDualStackRecords(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI, targetDot: TargetDotAppend}
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 2 &&
+			volcengine.StringValue(records[0].Type) == "A" && volcengine.StringValue(records[0].Value) == "192.0.2.1" &&
+			volcengine.StringValue(records[1].Type) == "AAAA" && volcengine.StringValue(records[1].Value) == "2001:db8::1"
+	})).Return(nil)
+
+	err := p.createPrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("lb.example.com", "A", "192.0.2.1"),
+		endpoint.NewEndpoint("lb.example.com", "AAAA", "2001:0db8:0:0:0:0:0:1"),
+		endpoint.NewEndpoint("bad.example.com", "AAAA", "192.0.2.2"),
+	})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestTargetDotPolicyCreate(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI, targetDot: TargetDotAppend}
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 2 &&
+			volcengine.StringValue(records[0].Value) == "target.example.com." &&
+			volcengine.StringValue(records[1].Value) == "1.2.3.4"
+	})).Return(nil)
+
+	err := p.createPrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "CNAME", "target.example.com"),
+		endpoint.NewEndpoint("app.example.com", "A", "1.2.3.4"),
+	})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestTargetDotPolicyUpdateMatchesIgnoringDot(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI, targetDot: TargetDotStrip}
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "CNAME").Return([]*privatezone.RecordForListRecordsOutput{{
+		ZID:      volcengine.Int32(123),
+		RecordID: volcengine.String("record-1"),
+		Host:     volcengine.String("www"),
+		Type:     volcengine.String("CNAME"),
+		Value:    volcengine.String("target.example.com."),
+		TTL:      volcengine.Int32(300),
+		Remark:   volcengine.String(defaultRecordRemark),
+	}}, nil)
+
+	// the existing record matches the target, nothing is deleted or created
+	err := p.updatePrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "CNAME", "target.example.com"),
+	})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestZoneRecordsToEndpointsTargetDot(t *testing.T) {
+	zone := &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")}
+	records := []*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("CNAME"), Value: volcengine.String("target.example.com."), TTL: volcengine.Int32(300)},
+		{Host: volcengine.String("www"), Type: volcengine.String("CNAME"), Value: volcengine.String("target.example.com"), TTL: volcengine.Int32(300)},
+	}
+
+	endpoints := zoneRecordsToEndpoints(zone, records, false, TargetDotStrip, txtEscaping{})
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"target.example.com"}, endpoints[0].Targets)
+
+	endpoints = zoneRecordsToEndpoints(zone, records, false, TargetDotAppend, txtEscaping{})
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.Targets{"target.example.com."}, endpoints[0].Targets)
+}
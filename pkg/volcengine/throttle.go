@@ -0,0 +1,233 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+
+	"github.com/volcengine/volcengine-go-sdk/service/dns"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"golang.org/x/time/rate"
+)
+
+// RateLimit is the qps and burst of a class of API calls, a zero QPS disables throttling.
+type RateLimit struct {
+	QPS   float64
+	Burst int
+}
+
+func (r RateLimit) limiter() *rate.Limiter {
+	if r.QPS <= 0 {
+		return nil
+	}
+	l := rate.NewLimiter(rate.Inf, 1)
+	r.apply(l)
+	return l
+}
+
+// apply sets the qps and burst of the limiter, a zero QPS lets every call pass.
+func (r RateLimit) apply(l *rate.Limiter) {
+	burst := r.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	l.SetBurst(burst)
+	if r.QPS <= 0 {
+		l.SetLimit(rate.Inf)
+		return
+	}
+	l.SetLimit(rate.Limit(r.QPS))
+}
+
+// throttledClient waits on the read limiter before list calls and on the write limiter before mutating calls.
+type throttledClient struct {
+	client privateZoneClient
+	read   *rate.Limiter
+	write  *rate.Limiter
+}
+
+var _ privateZoneClient = &throttledClient{}
+
+// newThrottledClient throttles the calls of client, limits with a zero QPS let every call pass until changed.
+func newThrottledClient(client privateZoneClient, read, write RateLimit) *throttledClient {
+	c := &throttledClient{client: client, read: rate.NewLimiter(rate.Inf, 1), write: rate.NewLimiter(rate.Inf, 1)}
+	c.setRateLimits(read, write)
+	return c
+}
+
+// setRateLimits changes the limits of the read and write calls, calls waiting already keep their reservation.
+func (c *throttledClient) setRateLimits(read, write RateLimit) {
+	read.apply(c.read)
+	write.apply(c.write)
+}
+
+// wait blocks until the limiter allows a call, calls that had to wait are counted as throttled in class.
+func wait(ctx context.Context, limiter *rate.Limiter, class string) error {
+	if limiter == nil || limiter.Allow() {
+		return nil
+	}
+	throttledCalls.WithLabelValues(class).Inc()
+	return limiter.Wait(ctx)
+}
+
+func (c *throttledClient) ListPrivateZonesWithContext(ctx context.Context, input *privatezone.ListPrivateZonesInput, options ...request.Option) (*privatezone.ListPrivateZonesOutput, error) {
+	if err := wait(ctx, c.read, "read"); err != nil {
+		return nil, err
+	}
+	return c.client.ListPrivateZonesWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) ListRecordsWithContext(ctx context.Context, input *privatezone.ListRecordsInput, options ...request.Option) (*privatezone.ListRecordsOutput, error) {
+	if err := wait(ctx, c.read, "read"); err != nil {
+		return nil, err
+	}
+	return c.client.ListRecordsWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) CreateRecordWithContext(ctx context.Context, input *privatezone.CreateRecordInput, options ...request.Option) (*privatezone.CreateRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.CreateRecordWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) UpdateRecordWithContext(ctx context.Context, input *privatezone.UpdateRecordInput, options ...request.Option) (*privatezone.UpdateRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.UpdateRecordWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) BatchCreateRecordWithContext(ctx context.Context, input *privatezone.BatchCreateRecordInput, options ...request.Option) (*privatezone.BatchCreateRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.BatchCreateRecordWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) BatchDeleteRecordWithContext(ctx context.Context, input *privatezone.BatchDeleteRecordInput, options ...request.Option) (*privatezone.BatchDeleteRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.BatchDeleteRecordWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) DeleteRecordWithContext(ctx context.Context, input *privatezone.DeleteRecordInput, options ...request.Option) (*privatezone.DeleteRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.DeleteRecordWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) QueryPrivateZoneWithContext(ctx context.Context, input *privatezone.QueryPrivateZoneInput, options ...request.Option) (*privatezone.QueryPrivateZoneOutput, error) {
+	if err := wait(ctx, c.read, "read"); err != nil {
+		return nil, err
+	}
+	return c.client.QueryPrivateZoneWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) UpdatePrivateZoneWithContext(ctx context.Context, input *privatezone.UpdatePrivateZoneInput, options ...request.Option) (*privatezone.UpdatePrivateZoneOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.UpdatePrivateZoneWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) CreatePrivateZoneWithContext(ctx context.Context, input *privatezone.CreatePrivateZoneInput, options ...request.Option) (*privatezone.CreatePrivateZoneOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.CreatePrivateZoneWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) DeletePrivateZoneWithContext(ctx context.Context, input *privatezone.DeletePrivateZoneInput, options ...request.Option) (*privatezone.DeletePrivateZoneOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.DeletePrivateZoneWithContext(ctx, input, options...)
+}
+
+func (c *throttledClient) IncBindVPCWithContext(ctx context.Context, input *privatezone.IncBindVPCInput, options ...request.Option) (*privatezone.IncBindVPCOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.IncBindVPCWithContext(ctx, input, options...)
+}
+
+// throttledCloudDNSClient throttles the CloudDNS calls like throttledClient the PrivateZone calls.
+type throttledCloudDNSClient struct {
+	client cloudDNSClient
+	read   *rate.Limiter
+	write  *rate.Limiter
+}
+
+var _ cloudDNSClient = &throttledCloudDNSClient{}
+
+// newThrottledCloudDNSClient throttles the calls of client, limits with a zero QPS let every call pass until changed.
+func newThrottledCloudDNSClient(client cloudDNSClient, read, write RateLimit) *throttledCloudDNSClient {
+	c := &throttledCloudDNSClient{client: client, read: rate.NewLimiter(rate.Inf, 1), write: rate.NewLimiter(rate.Inf, 1)}
+	c.setRateLimits(read, write)
+	return c
+}
+
+// setRateLimits changes the limits of the read and write calls, calls waiting already keep their reservation.
+func (c *throttledCloudDNSClient) setRateLimits(read, write RateLimit) {
+	read.apply(c.read)
+	write.apply(c.write)
+}
+
+func (c *throttledCloudDNSClient) ListZonesWithContext(ctx context.Context, input *dns.ListZonesInput, options ...request.Option) (*dns.ListZonesOutput, error) {
+	if err := wait(ctx, c.read, "read"); err != nil {
+		return nil, err
+	}
+	return c.client.ListZonesWithContext(ctx, input, options...)
+}
+
+func (c *throttledCloudDNSClient) ListRecordsWithContext(ctx context.Context, input *dns.ListRecordsInput, options ...request.Option) (*dns.ListRecordsOutput, error) {
+	if err := wait(ctx, c.read, "read"); err != nil {
+		return nil, err
+	}
+	return c.client.ListRecordsWithContext(ctx, input, options...)
+}
+
+func (c *throttledCloudDNSClient) CreateRecordWithContext(ctx context.Context, input *dns.CreateRecordInput, options ...request.Option) (*dns.CreateRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.CreateRecordWithContext(ctx, input, options...)
+}
+
+func (c *throttledCloudDNSClient) UpdateRecordWithContext(ctx context.Context, input *dns.UpdateRecordInput, options ...request.Option) (*dns.UpdateRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.UpdateRecordWithContext(ctx, input, options...)
+}
+
+func (c *throttledCloudDNSClient) UpdateRecordStatusWithContext(ctx context.Context, input *dns.UpdateRecordStatusInput, options ...request.Option) (*dns.UpdateRecordStatusOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.UpdateRecordStatusWithContext(ctx, input, options...)
+}
+
+func (c *throttledCloudDNSClient) DeleteRecordWithContext(ctx context.Context, input *dns.DeleteRecordInput, options ...request.Option) (*dns.DeleteRecordOutput, error) {
+	if err := wait(ctx, c.write, "write"); err != nil {
+		return nil, err
+	}
+	return c.client.DeleteRecordWithContext(ctx, input, options...)
+}
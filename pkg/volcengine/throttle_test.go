@@ -0,0 +1,67 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/response"
+)
+
+func TestRateLimitLimiter(t *testing.T) {
+	assert.Nil(t, RateLimit{}.limiter())
+	l := RateLimit{QPS: 5}.limiter()
+	assert.Equal(t, 1, l.Burst())
+	l = RateLimit{QPS: 5, Burst: 10}.limiter()
+	assert.Equal(t, 10, l.Burst())
+}
+
+func TestThrottledClient(t *testing.T) {
+	mockClient := &MockClient{
+		ListRecordsFunc: func(ctx context.Context, input *privatezone.ListRecordsInput) (*privatezone.ListRecordsOutput, error) {
+			return &privatezone.ListRecordsOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+		DeleteRecordFunc: func(ctx context.Context, input *privatezone.DeleteRecordInput) (*privatezone.DeleteRecordOutput, error) {
+			return &privatezone.DeleteRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+	}
+	c := &throttledClient{
+		client: mockClient,
+		read:   RateLimit{QPS: 1000, Burst: 10}.limiter(),
+		write:  RateLimit{QPS: 1, Burst: 1}.limiter(),
+	}
+	ctx := context.Background()
+
+	// Reads are not held back by the write limiter
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		_, err := c.ListRecordsWithContext(ctx, &privatezone.ListRecordsInput{})
+		assert.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// The second write waits for a token, a context ending first aborts it
+	_, err := c.DeleteRecordWithContext(ctx, &privatezone.DeleteRecordInput{})
+	assert.NoError(t, err)
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = c.DeleteRecordWithContext(timeout, &privatezone.DeleteRecordInput{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// tombstoneKey tags the remark of a soft-deleted record with its deletion time.
+	tombstoneKey = "deleted-at"
+
+	// DefaultTombstoneRetention is how long soft-deleted records are kept before they are purged.
+	DefaultTombstoneRetention = 7 * 24 * time.Hour
+)
+
+// tombstoneRemark appends the deletion time to remark, truncating remark to fit maxRemarkLength.
+func tombstoneRemark(remark string, deletedAt time.Time) string {
+	if remark == "" {
+		remark = defaultRecordRemark
+	}
+	part := remarkSeparator + tombstoneKey + "=" + deletedAt.UTC().Format(time.RFC3339)
+	if len(remark)+len(part) > maxRemarkLength {
+		remark = remark[:maxRemarkLength-len(part)]
+	}
+	return remark + part
+}
+
+// tombstoneTime returns the deletion time written by tombstoneRemark.
+func tombstoneTime(remark string) (time.Time, bool) {
+	parts := strings.Split(remark, remarkSeparator)
+	for i := len(parts) - 1; i >= 0; i-- {
+		key, value, ok := strings.Cut(parts[i], "=")
+		if !ok || key != tombstoneKey {
+			continue
+		}
+		deletedAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return deletedAt, true
+	}
+	return time.Time{}, false
+}
+
+// isTombstone reports whether record was soft-deleted.
+func isTombstone(record *privatezone.RecordForListRecordsOutput) bool {
+	_, ok := tombstoneTime(volcengine.StringValue(record.Remark))
+	return ok
+}
+
+// removeTombstones returns the records that were not soft-deleted.
+func removeTombstones(records []*privatezone.RecordForListRecordsOutput) []*privatezone.RecordForListRecordsOutput {
+	res := make([]*privatezone.RecordForListRecordsOutput, 0, len(records))
+	for _, record := range records {
+		if !isTombstone(record) {
+			res = append(res, record)
+		}
+	}
+	return res
+}
+
+// deleteZoneRecords deletes the records of the endpoints from the zone: the records of the zone are listed once and
+// matched by host, type, set identifier and targets locally, then deleted with batch calls, or disabled one by one
+// in soft-delete mode.
+func (p *Provider) deleteZoneRecords(ctx context.Context, zoneID int64, zoneName string, endpoints []*endpoint.Endpoint) (err error) {
+	ctx, span := startSpan(ctx, "Provider.DeleteZoneRecords", append(zoneAttributes(zoneID, zoneName),
+		attribute.Int("volcengine.endpoints", len(endpoints)))...)
+	defer func() { endSpan(span, err) }()
+	records, err := p.pzClient.GetPrivateZoneRecords(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	// tombstones and the records of other set identifiers are never matched
+	live := removeTombstones(records)
+	var toDelete []*privatezone.RecordForListRecordsOutput
+	seen := make(map[string]bool)
+	for _, ep := range endpoints {
+		host, _ := splitDNSName(ep.DNSName, zoneName)
+		matched := false
+		for _, record := range filterSetIdentifier(live, ep.SetIdentifier) {
+			if !matchRecord(record, host, ep.RecordType, ep.Targets, p.txt) {
+				continue
+			}
+			matched = true
+			if !p.managedGuard.manages(record) {
+				p.skipUnmanaged("deletion", zoneID, record)
+				continue
+			}
+			if recordID := volcengine.StringValue(record.RecordID); !seen[recordID] {
+				seen[recordID] = true
+				toDelete = append(toDelete, record)
+			}
+		}
+		if !matched {
+			p.requestLogger(ctx).Errorf("Not found record to delete. zid: %d, host: %s, recordType %s, targets: %v", zoneID, host, ep.RecordType, ep.Targets)
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil
+	}
+	if p.softDelete {
+		for _, record := range toDelete {
+			if err := p.deleteRecord(ctx, zoneID, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	recordIDs := make([]string, 0, len(toDelete))
+	for _, record := range toDelete {
+		recordIDs = append(recordIDs, volcengine.StringValue(record.RecordID))
+	}
+	return p.pzClient.BatchDeletePrivateZoneRecords(ctx, zoneID, recordIDs)
+}
+
+// deleteRecord deletes record, or disables it and tags it as a tombstone in soft-delete mode.
+func (p *Provider) deleteRecord(ctx context.Context, zoneID int64, record *privatezone.RecordForListRecordsOutput) error {
+	recordID := volcengine.StringValue(record.RecordID)
+	if !p.softDelete {
+		return p.pzClient.DeletePrivateZoneRecordById(ctx, zoneID, recordID)
+	}
+	p.requestLogger(ctx).Infof("Soft deleting record %s: host: %s, type: %s, value: %s, zid: %d", recordID,
+		volcengine.StringValue(record.Host), volcengine.StringValue(record.Type), volcengine.StringValue(record.Value), zoneID)
+	return p.pzClient.DisablePrivateZoneRecord(ctx, zoneID, recordID, tombstoneRemark(volcengine.StringValue(record.Remark), time.Now()))
+}
+
+// PurgeTombstones deletes the soft-deleted records older than the retention window of the zones bound to the VPC,
+// it returns the number of purged records. Only records still disabled whose remark marks them as written by
+// external-dns, of the guard owner if set, are purged, a record enabled again or disabled by hand is left alone.
+func (p *Provider) PurgeTombstones(ctx context.Context) (int, error) {
+	zones, err := p.pzClient.ListPrivateZones(ctx, p.vpcID)
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, zone := range zones {
+		zid := int64(volcengine.Int32Value(zone.ZID))
+		records, err := p.pzClient.GetPrivateZoneRecords(ctx, zid)
+		if err != nil {
+			return purged, err
+		}
+		for _, record := range records {
+			deletedAt, ok := tombstoneTime(volcengine.StringValue(record.Remark))
+			if !ok || time.Since(deletedAt) < p.tombstoneRetention {
+				continue
+			}
+			if volcengine.BoolValue(record.Enable) {
+				continue
+			}
+			if !(ManagedRecordGuard{Enabled: true, Owner: p.managedGuard.Owner}).manages(record) {
+				p.skipUnmanaged("purge", zid, record)
+				continue
+			}
+			if err := p.pzClient.DeletePrivateZoneRecordById(ctx, zid, volcengine.StringValue(record.RecordID)); err != nil {
+				return purged, err
+			}
+			p.requestLogger(ctx).Infof("Purged tombstone record %s: host: %s, type: %s, deleted at: %s, zid: %d", volcengine.StringValue(record.RecordID),
+				volcengine.StringValue(record.Host), volcengine.StringValue(record.Type), deletedAt, zid)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// RunTombstoneGC purges expired tombstones every interval until ctx is done.
+func (p *Provider) RunTombstoneGC(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		p.requestLogger(ctx).Warnf("Tombstone GC is disabled, interval: %s", interval)
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := p.PurgeTombstones(ctx)
+			if err != nil {
+				p.requestLogger(ctx).Errorf("Failed to purge tombstone records: %v", err)
+				continue
+			}
+			p.requestLogger(ctx).Debugf("Purged %d tombstone records", purged)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/response"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestTombstoneRemark(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	remark := tombstoneRemark("managed by external-dns; owner=default", deletedAt)
+	assert.Equal(t, "managed by external-dns; owner=default; deleted-at=2025-01-02T03:04:05Z", remark)
+	got, ok := tombstoneTime(remark)
+	assert.True(t, ok)
+	assert.Equal(t, deletedAt, got)
+	assert.Equal(t, endpoint.Labels{endpoint.OwnerLabelKey: "default"}, decodeRemark(remark))
+
+	remark = tombstoneRemark("", deletedAt)
+	assert.Equal(t, "managed by external-dns; deleted-at=2025-01-02T03:04:05Z", remark)
+
+	remark = tombstoneRemark(strings.Repeat("x", maxRemarkLength), deletedAt)
+	assert.Len(t, remark, maxRemarkLength)
+	_, ok = tombstoneTime(remark)
+	assert.True(t, ok)
+
+	_, ok = tombstoneTime("managed by external-dns")
+	assert.False(t, ok)
+	_, ok = tombstoneTime("managed by external-dns; deleted-at=yesterday")
+	assert.False(t, ok)
+}
+
+func TestSoftDeleteApplyChanges(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockZones := []*privatezone.ZoneForListPrivateZonesOutput{
+		{
+			ZID:      volcengine.Int32(123),
+			ZoneName: volcengine.String("example.com"),
+		},
+	}
+	records := []*privatezone.RecordForListRecordsOutput{
+		{
+			RecordID: volcengine.String("record-1"),
+			Host:     volcengine.String("old"),
+			Type:     volcengine.String("A"),
+			Value:    volcengine.String("5.6.7.8"),
+			Remark:   volcengine.String(defaultRecordRemark),
+		},
+		{
+			RecordID: volcengine.String("record-2"),
+			Host:     volcengine.String("old"),
+			Type:     volcengine.String("A"),
+			Value:    volcengine.String("5.6.7.8"),
+			Remark:   volcengine.String(tombstoneRemark(defaultRecordRemark, time.Now())),
+		},
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(mockZones, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(records, nil)
+	mockAPI.On("DisablePrivateZoneRecord", mock.Anything, int64(123), "record-1",
+		mock.MatchedBy(func(remark string) bool {
+			_, ok := tombstoneTime(remark)
+			return ok && strings.HasPrefix(remark, defaultRecordRemark)
+		})).Return(nil).Once()
+
+	provider := &Provider{
+		vpcID:              "vpc-123",
+		privateZone:        true,
+		pzClient:           mockAPI,
+		softDelete:         true,
+		tombstoneRetention: time.Hour,
+	}
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", "A", "5.6.7.8")},
+	})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "BatchDeletePrivateZoneRecords", mock.Anything, mock.Anything, mock.Anything)
+
+	// Tombstones are hidden from Records
+	endpoints, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "old.example.com", endpoints[0].DNSName)
+}
+
+func TestPurgeTombstones(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockZones := []*privatezone.ZoneForListPrivateZonesOutput{
+		{
+			ZID:      volcengine.Int32(123),
+			ZoneName: volcengine.String("example.com"),
+		},
+	}
+	records := []*privatezone.RecordForListRecordsOutput{
+		{
+			RecordID: volcengine.String("live"),
+			Remark:   volcengine.String(defaultRecordRemark),
+		},
+		{
+			RecordID: volcengine.String("recent"),
+			Remark:   volcengine.String(tombstoneRemark("", time.Now().Add(-time.Hour))),
+		},
+		{
+			RecordID: volcengine.String("expired"),
+			Enable:   volcengine.Bool(false),
+			Remark:   volcengine.String(tombstoneRemark("", time.Now().Add(-48*time.Hour))),
+		},
+		// enabled again by hand
+		{
+			RecordID: volcengine.String("restored"),
+			Enable:   volcengine.Bool(true),
+			Remark:   volcengine.String(tombstoneRemark("", time.Now().Add(-48*time.Hour))),
+		},
+		// disabled by hand with a remark of its own
+		{
+			RecordID: volcengine.String("manual"),
+			Enable:   volcengine.Bool(false),
+			Remark:   volcengine.String(tombstoneRemark("maintenance", time.Now().Add(-48*time.Hour))),
+		},
+	}
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return(mockZones, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return(records, nil)
+	mockAPI.On("DeletePrivateZoneRecordById", mock.Anything, int64(123), "expired").Return(nil).Once()
+
+	provider := &Provider{
+		vpcID:              "vpc-123",
+		privateZone:        true,
+		pzClient:           mockAPI,
+		softDelete:         true,
+		tombstoneRetention: 24 * time.Hour,
+	}
+	purged, err := provider.PurgeTombstones(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestDisablePrivateZoneRecord(t *testing.T) {
+	var got *privatezone.UpdateRecordInput
+	wrapper := &PrivateZoneWrapper{
+		client: &MockClient{
+			UpdateRecordFunc: func(ctx context.Context, input *privatezone.UpdateRecordInput) (*privatezone.UpdateRecordOutput, error) {
+				got = input
+				return &privatezone.UpdateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+			},
+		},
+	}
+	err := wrapper.DisablePrivateZoneRecord(context.Background(), 123, "record-1", "remark")
+	assert.NoError(t, err)
+	assert.Equal(t, "record-1", volcengine.StringValue(got.RecordID))
+	assert.Equal(t, int64(123), volcengine.Int64Value(got.ZID))
+	assert.False(t, volcengine.BoolValue(got.Enable))
+	assert.NotNil(t, got.Enable)
+	assert.Equal(t, "remark", volcengine.StringValue(got.Remark))
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+
+	"volcengine-provider/pkg/utils"
+
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName names the tracer of the provider and API call spans.
+const tracerName = "volcengine-provider/pkg/volcengine"
+
+// tracingHandlerName names the request handlers starting and ending the API call spans.
+const tracingHandlerName = "volcengine-provider.Tracing"
+
+// apiSpanKey keys the span of an API call in its request context, so that only spans started by the handlers end.
+type apiSpanKey struct{}
+
+// startSpan starts a span named name, carrying the correlation ID of the webhook request.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if id := utils.RequestID(ctx); id != "" {
+		attrs = append(attrs, attribute.String(utils.RequestIDField, id))
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// zoneAttributes are the span attributes of the work on one zone.
+func zoneAttributes(zoneID int64, zoneName string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("volcengine.zone_id", zoneID),
+		attribute.String("volcengine.zone_name", zoneName),
+	}
+}
+
+// endSpan records err on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// installAPITracing traces every API call of the handlers in a client span named service.Operation, from its
+// validation until it completes after its retries, as a child of the span of the request context.
+func installAPITracing(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: tracingHandlerName,
+		Fn: func(req *request.Request) {
+			service, operation := req.ClientInfo.ServiceName, ""
+			if req.Operation != nil {
+				operation = req.Operation.Name
+			}
+			ctx, span := startSpan(req.Context(), service+"."+operation,
+				attribute.String("rpc.system", "volcengine"),
+				attribute.String("rpc.service", service),
+				attribute.String("rpc.method", operation),
+			)
+			req.SetContext(context.WithValue(ctx, apiSpanKey{}, span))
+		},
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: tracingHandlerName,
+		Fn: func(req *request.Request) {
+			span, ok := req.Context().Value(apiSpanKey{}).(trace.Span)
+			if !ok {
+				return
+			}
+			span.SetAttributes(attribute.Int("volcengine.retries", req.RetryCount))
+			if req.RequestID != "" {
+				span.SetAttributes(attribute.String("volcengine.request_id", req.RequestID))
+			}
+			if req.HTTPResponse != nil {
+				span.SetAttributes(attribute.Int("http.response.status_code", req.HTTPResponse.StatusCode))
+			}
+			var apiErr volcengineerr.Error
+			if errors.As(req.Error, &apiErr) {
+				span.SetAttributes(attribute.String("volcengine.error_code", apiErr.Code()))
+			}
+			endSpan(span, req.Error)
+		},
+	})
+}
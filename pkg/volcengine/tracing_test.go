@@ -0,0 +1,148 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"volcengine-provider/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/client/metadata"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// recordSpans installs a global tracer provider recording the ended spans for the duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestAPITracing(t *testing.T) {
+	recorder := recordSpans(t)
+	handlers := request.Handlers{}
+	installAPITracing(&handlers)
+	call := func(ctx context.Context, err error) {
+		req := &request.Request{
+			ClientInfo:  metadata.ClientInfo{ServiceName: "privatezone"},
+			Operation:   &request.Operation{Name: "ListRecords"},
+			Time:        time.Now(),
+			RetryCount:  2,
+			HTTPRequest: httptest.NewRequest(http.MethodPost, "/", nil),
+		}
+		req.SetContext(ctx)
+		handlers.Validate.Run(req)
+		req.Error = err
+		handlers.Complete.Run(req)
+	}
+
+	ctx, parent := startSpan(utils.WithRequestID(context.Background(), "sync-42"), "Records")
+	call(ctx, nil)
+	call(ctx, volcengineerr.New("Throttling", "slow down", nil))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for _, span := range spans[:2] {
+		assert.Equal(t, "privatezone.ListRecords", span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, "sync-42", spanAttribute(span, utils.RequestIDField).AsString())
+		assert.Equal(t, int64(2), spanAttribute(span, "volcengine.retries").AsInt64())
+	}
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "Throttling", spanAttribute(spans[1], "volcengine.error_code").AsString())
+	assert.Equal(t, "Records", spans[2].Name())
+}
+
+func TestAPITracingEndsOnlyItsSpan(t *testing.T) {
+	recorder := recordSpans(t)
+	handlers := request.Handlers{}
+	installAPITracing(&handlers)
+
+	// a request failing before validation never started a span, the span of its context is not its own
+	ctx, parent := startSpan(context.Background(), "ApplyChanges")
+	req := &request.Request{
+		Operation:   &request.Operation{Name: "CreateRecord"},
+		HTTPRequest: httptest.NewRequest(http.MethodPost, "/", nil),
+	}
+	req.SetContext(ctx)
+	handlers.Complete.Run(req)
+	assert.Empty(t, recorder.Ended())
+	parent.End()
+	assert.Len(t, recorder.Ended(), 1)
+}
+
+func TestProviderSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("GetPrivateZoneRecords", mock.Anything, int64(123)).Return([]*privatezone.RecordForListRecordsOutput{
+		listedRecord("1", "www", "A", "10.0.0.1", 300),
+	}, nil)
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.Anything).Return(nil)
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+
+	_, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", "A", "10.0.0.2")},
+	}))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	assert.Equal(t, "Provider.ListZoneRecords", spans[0].Name())
+	assert.Equal(t, int64(123), spanAttribute(spans[0], "volcengine.zone_id").AsInt64())
+	assert.Equal(t, int64(1), spanAttribute(spans[0], "volcengine.records").AsInt64())
+	assert.Equal(t, "Provider.Records", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, int64(1), spanAttribute(spans[1], "volcengine.endpoints").AsInt64())
+	assert.Equal(t, "Provider.CreateZoneRecords", spans[2].Name())
+	assert.Equal(t, "example.com", spanAttribute(spans[2], "volcengine.zone_name").AsString())
+	assert.Equal(t, "Provider.ApplyChanges", spans[3].Name())
+	assert.Equal(t, spans[3].SpanContext().SpanID(), spans[2].Parent().SpanID())
+	assert.Equal(t, int64(1), spanAttribute(spans[3], "volcengine.creates").AsInt64())
+}
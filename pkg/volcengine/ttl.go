@@ -0,0 +1,206 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// minRecordTTL and maxRecordTTL are the TTL range in seconds accepted by privatezone.
+const (
+	minRecordTTL endpoint.TTL = 5
+	maxRecordTTL endpoint.TTL = 86400
+)
+
+// clampTTL moves a configured TTL into the range accepted by privatezone, an unset TTL is returned unchanged.
+func clampTTL(ttl endpoint.TTL) endpoint.TTL {
+	if !ttl.IsConfigured() {
+		return ttl
+	}
+	return min(max(ttl, minRecordTTL), maxRecordTTL)
+}
+
+// DefaultTTLs are the TTLs in seconds of the records of endpoints without a TTL, by record type.
+// Types without a default are created with the privatezone default TTL.
+type DefaultTTLs map[string]int64
+
+// ParseDefaultTTLs parses comma separated TYPE=seconds pairs, e.g. "A=60,AAAA=60,TXT=3600".
+func ParseDefaultTTLs(value string) (DefaultTTLs, error) {
+	ttls := make(DefaultTTLs)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		recordType, seconds, ok := strings.Cut(pair, "=")
+		recordType = strings.ToUpper(strings.TrimSpace(recordType))
+		if !ok || !supportedRecordTypes[recordType] {
+			return nil, fmt.Errorf("invalid default TTL %q, expected TYPE=seconds of a supported record type", pair)
+		}
+		ttl, err := strconv.ParseInt(strings.TrimSpace(seconds), 10, 64)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid default TTL %q, expected a positive number of seconds", pair)
+		}
+		ttls[recordType] = ttl
+	}
+	return ttls, nil
+}
+
+// recordTTL returns the TTL the records of the endpoint are written with, the endpoint TTL if it has one,
+// otherwise the default TTL of its record type, 0 leaves the TTL to privatezone.
+func (t DefaultTTLs) recordTTL(ep *endpoint.Endpoint) endpoint.TTL {
+	if ep.RecordTTL.IsConfigured() {
+		return ep.RecordTTL
+	}
+	return endpoint.TTL(t[ep.RecordType])
+}
+
+// privateZoneDefaultTTL is the TTL in seconds privatezone gives records created without one.
+const privateZoneDefaultTTL endpoint.TTL = 600
+
+// TTLPolicy is the TTL in seconds of the records of endpoints without a TTL and the range every TTL written is
+// clamped to. A zero Default leaves the TTL to default_ttls or privatezone, a zero Min or Max leaves the range open.
+type TTLPolicy struct {
+	Default int64
+	Min     int64
+	Max     int64
+}
+
+// Validate reports a negative setting or a Min above Max.
+func (t TTLPolicy) Validate() error {
+	if t.Default < 0 || t.Min < 0 || t.Max < 0 {
+		return fmt.Errorf("invalid TTL policy %+v, TTLs must not be negative", t)
+	}
+	if t.Min > 0 && t.Max > 0 && t.Min > t.Max {
+		return fmt.Errorf("invalid TTL policy, min TTL %d is above max TTL %d", t.Min, t.Max)
+	}
+	return nil
+}
+
+// override returns the policy with the non-zero settings of o.
+func (t TTLPolicy) override(o TTLPolicy) TTLPolicy {
+	if o.Default > 0 {
+		t.Default = o.Default
+	}
+	if o.Min > 0 {
+		t.Min = o.Min
+	}
+	if o.Max > 0 {
+		t.Max = o.Max
+	}
+	return t
+}
+
+// clamp moves a TTL into the range of the policy, an unset TTL is returned unchanged.
+func (t TTLPolicy) clamp(ttl endpoint.TTL) endpoint.TTL {
+	if !ttl.IsConfigured() {
+		return ttl
+	}
+	if t.Min > 0 {
+		ttl = max(ttl, endpoint.TTL(t.Min))
+	}
+	if t.Max > 0 {
+		ttl = min(ttl, endpoint.TTL(t.Max))
+	}
+	return ttl
+}
+
+// ZoneTTLPolicies override the TTL policy for the records of zones, by lower case zone name without trailing dot.
+type ZoneTTLPolicies map[string]TTLPolicy
+
+// ParseZoneTTLPolicies parses comma separated ZONE:SETTING=seconds pairs, the settings being default, min and max,
+// e.g. "example.com:default=60,example.com:max=300,internal.corp:min=3600".
+func ParseZoneTTLPolicies(value string) (ZoneTTLPolicies, error) {
+	zones := make(ZoneTTLPolicies)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, seconds, ok := strings.Cut(pair, "=")
+		zone, setting, found := strings.Cut(strings.TrimSpace(key), ":")
+		zone = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(zone)), ".")
+		if !ok || !found || zone == "" {
+			return nil, fmt.Errorf("invalid zone TTL %q, expected ZONE:SETTING=seconds", pair)
+		}
+		ttl, err := strconv.ParseInt(strings.TrimSpace(seconds), 10, 64)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid zone TTL %q, expected a positive number of seconds", pair)
+		}
+		policy := zones[zone]
+		switch strings.ToLower(strings.TrimSpace(setting)) {
+		case "default":
+			policy.Default = ttl
+		case "min":
+			policy.Min = ttl
+		case "max":
+			policy.Max = ttl
+		default:
+			return nil, fmt.Errorf("invalid zone TTL %q, expected the setting default, min or max", pair)
+		}
+		zones[zone] = policy
+	}
+	for zone, policy := range zones {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("zone %s: %v", zone, err)
+		}
+	}
+	return zones, nil
+}
+
+// lookup returns the overrides of the zone with the longest name the DNS name is in.
+func (z ZoneTTLPolicies) lookup(dnsName string) (TTLPolicy, bool) {
+	name := strings.TrimSuffix(strings.ToLower(dnsName), ".")
+	var found TTLPolicy
+	longest := -1
+	for zone, policy := range z {
+		if (name == zone || strings.HasSuffix(name, "."+zone)) && len(zone) > longest {
+			found, longest = policy, len(zone)
+		}
+	}
+	return found, longest >= 0
+}
+
+// ttlPolicy returns the TTL policy of the zone of the endpoint.
+func (p *Provider) ttlPolicy(ep *endpoint.Endpoint) TTLPolicy {
+	if zone, ok := p.zoneTTLs.lookup(ep.DNSName); ok {
+		return p.ttls.override(zone)
+	}
+	return p.ttls
+}
+
+// recordTTL returns the TTL the records of the endpoint are written with: the endpoint TTL, or else the default of
+// its zone, of its record type or the default TTL, clamped to the range of its zone. 0 leaves the TTL to privatezone.
+func (p *Provider) recordTTL(ep *endpoint.Endpoint) endpoint.TTL {
+	policy := p.ttlPolicy(ep)
+	ttl := ep.RecordTTL
+	if !ttl.IsConfigured() {
+		if zone, ok := p.zoneTTLs.lookup(ep.DNSName); ok && zone.Default > 0 {
+			ttl = endpoint.TTL(zone.Default)
+		} else if ttl = p.defaultTTLs.recordTTL(ep); !ttl.IsConfigured() {
+			ttl = endpoint.TTL(p.ttls.Default)
+		}
+	}
+	if !ttl.IsConfigured() && (policy.Min > 0 || policy.Max > 0) {
+		// the privatezone default may be out of the range
+		ttl = privateZoneDefaultTTL
+	}
+	return clampTTL(policy.clamp(ttl))
+}
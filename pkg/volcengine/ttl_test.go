@@ -0,0 +1,189 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseDefaultTTLs(t *testing.T) {
+	ttls, err := ParseDefaultTTLs(" a=60, AAAA=60 ,TXT=3600,")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTTLs{"A": 60, "AAAA": 60, "TXT": 3600}, ttls)
+
+	ttls, err = ParseDefaultTTLs("")
+	require.NoError(t, err)
+	assert.Empty(t, ttls)
+
+	for _, value := range []string{"A", "A=", "A=0", "A=-5", "A=1m", "NS=300", "=300"} {
+		_, err := ParseDefaultTTLs(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestDefaultTTLsRecordTTL(t *testing.T) {
+	ttls := DefaultTTLs{"A": 60, "TXT": 3600}
+	assert.Equal(t, endpoint.TTL(60), ttls.recordTTL(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")))
+	assert.Equal(t, endpoint.TTL(300), ttls.recordTTL(endpoint.NewEndpointWithTTL("www.example.com", "A", 300, "1.2.3.4")))
+	assert.Equal(t, endpoint.TTL(0), ttls.recordTTL(endpoint.NewEndpoint("www.example.com", "CNAME", "target.example.com")))
+	assert.Equal(t, endpoint.TTL(0), DefaultTTLs(nil).recordTTL(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")))
+}
+
+func TestDefaultTTLsCreate(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI, defaultTTLs: DefaultTTLs{"A": 60, "TXT": 3600}}
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		ttls := make(map[string]*int32)
+		for _, r := range records {
+			ttls[volcengine.StringValue(r.Host)+"/"+volcengine.StringValue(r.Type)] = r.TTL
+		}
+		return len(records) == 4 &&
+			volcengine.Int32Value(ttls["www/A"]) == 60 &&
+			volcengine.Int32Value(ttls["api/A"]) == 300 &&
+			volcengine.Int32Value(ttls["www/TXT"]) == 3600 &&
+			ttls["app/CNAME"] == nil
+	})).Return(nil)
+
+	err := p.createPrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("api.example.com", "A", 300, "1.2.3.5"),
+		endpoint.NewEndpoint("www.example.com", "TXT", "heritage=external-dns"),
+		endpoint.NewEndpoint("app.example.com", "CNAME", "www.example.com"),
+	})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestDefaultTTLsUpdate(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI, defaultTTLs: DefaultTTLs{"A": 60}}
+	mockAPI.On("GetPrivateZoneRecordsByHostType", mock.Anything, int64(123), "www", "A").Return([]*privatezone.RecordForListRecordsOutput{{
+		ZID:      volcengine.Int32(123),
+		RecordID: volcengine.String("record-1"),
+		Host:     volcengine.String("www"),
+		Type:     volcengine.String("A"),
+		Value:    volcengine.String("1.2.3.4"),
+		TTL:      volcengine.Int32(600),
+		Remark:   volcengine.String(defaultRecordRemark),
+	}}, nil)
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "record-1", "www", "A", "1.2.3.4", int32(60), int32(0), "", defaultRecordRemark).Return(nil)
+	mockAPI.On("CreatePrivateZoneRecord", mock.Anything, int64(123), "www", "A", "1.2.3.5", int32(60), int32(0), "", defaultRecordRemark).Return(nil)
+
+	err := p.updatePrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4", "1.2.3.5"),
+	})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestParseZoneTTLPolicies(t *testing.T) {
+	zones, err := ParseZoneTTLPolicies(" Example.com.:default=60, example.com:max=300,internal.corp:MIN=3600,")
+	require.NoError(t, err)
+	assert.Equal(t, ZoneTTLPolicies{
+		"example.com":   {Default: 60, Max: 300},
+		"internal.corp": {Min: 3600},
+	}, zones)
+
+	for _, value := range []string{"example.com", "example.com=60", "example.com:default", "example.com:ttl=60",
+		"example.com:min=0", ":max=60", "example.com:min=600,example.com:max=60"} {
+		_, err := ParseZoneTTLPolicies(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestTTLPolicyValidate(t *testing.T) {
+	assert.NoError(t, TTLPolicy{}.Validate())
+	assert.NoError(t, TTLPolicy{Default: 300, Min: 60, Max: 3600}.Validate())
+	assert.NoError(t, TTLPolicy{Min: 60}.Validate())
+	assert.Error(t, TTLPolicy{Min: 3600, Max: 60}.Validate())
+	assert.Error(t, TTLPolicy{Default: -1}.Validate())
+}
+
+func TestProviderRecordTTL(t *testing.T) {
+	p := &Provider{
+		defaultTTLs: DefaultTTLs{"TXT": 3600},
+		ttls:        TTLPolicy{Default: 120, Min: 30, Max: 7200},
+		zoneTTLs: ZoneTTLPolicies{
+			"example.com":     {Default: 60},
+			"dev.example.com": {Max: 300},
+		},
+	}
+	for _, tc := range []struct {
+		ep   *endpoint.Endpoint
+		want endpoint.TTL
+	}{
+		{endpoint.NewEndpointWithTTL("www.other.com", "A", 600, "1.2.3.4"), 600},
+		{endpoint.NewEndpointWithTTL("www.other.com", "A", 10, "1.2.3.4"), 30},
+		{endpoint.NewEndpointWithTTL("www.other.com", "A", 86400, "1.2.3.4"), 7200},
+		{endpoint.NewEndpoint("www.other.com", "A", "1.2.3.4"), 120},
+		{endpoint.NewEndpoint("www.other.com", "TXT", "v=spf1 -all"), 3600},
+		{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"), 60},
+		{endpoint.NewEndpoint("www.example.com", "TXT", "v=spf1 -all"), 60},
+		{endpoint.NewEndpoint("WWW.Example.com.", "A", "1.2.3.4"), 60},
+		{endpoint.NewEndpointWithTTL("api.dev.example.com", "A", 3600, "1.2.3.4"), 300},
+		{endpoint.NewEndpoint("api.dev.example.com", "A", "1.2.3.4"), 120},
+		{endpoint.NewEndpoint("api.notexample.com", "A", "1.2.3.4"), 120},
+	} {
+		assert.Equal(t, tc.want, p.recordTTL(tc.ep), tc.ep.String())
+	}
+
+	// an unset TTL written with the privatezone default when that is out of range
+	p = &Provider{ttls: TTLPolicy{Max: 300}}
+	assert.Equal(t, endpoint.TTL(300), p.recordTTL(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")))
+	p = &Provider{}
+	assert.Equal(t, endpoint.TTL(0), p.recordTTL(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")))
+}
+
+func TestTTLPolicyAdjustEndpoints(t *testing.T) {
+	p := &Provider{ttls: TTLPolicy{Min: 60}, zoneTTLs: ZoneTTLPolicies{"example.com": {Max: 300}}}
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", "A", 10, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("api.example.com", "A", 3600, "1.2.3.4"),
+		endpoint.NewEndpoint("app.example.com", "A", "1.2.3.4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.TTL(60), endpoints[0].RecordTTL)
+	assert.Equal(t, endpoint.TTL(300), endpoints[1].RecordTTL)
+	assert.False(t, endpoints[2].RecordTTL.IsConfigured())
+}
+
+func TestTTLPolicyCreate(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI, ttls: TTLPolicy{Default: 300, Max: 3600}}
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 2 &&
+			volcengine.Int32Value(records[0].TTL) == 300 &&
+			volcengine.Int32Value(records[1].TTL) == 3600
+	})).Return(nil)
+
+	err := p.createPrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("api.example.com", "A", 86400, "1.2.3.5"),
+	})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// TXTEscapeMode controls how TXT values are translated between external-dns and privatezone,
+// which stores values without the surrounding quotes external-dns writes to TXT registry records.
+type TXTEscapeMode string
+
+// DefaultTXTRegistryPrefix starts the values of the external-dns TXT registry records.
+const DefaultTXTRegistryPrefix = "heritage="
+
+const (
+	// TXTEscapeAuto strips and adds the quotes of TXT registry values, recognized by their prefix, only.
+	TXTEscapeAuto TXTEscapeMode = "auto"
+	// TXTEscapeNever stores and compares TXT values verbatim.
+	TXTEscapeNever TXTEscapeMode = "never"
+	// TXTEscapeAlways strips the quotes of every TXT value and quotes every stored value.
+	TXTEscapeAlways TXTEscapeMode = "always"
+)
+
+// ParseTXTEscapeMode parses auto, never or always, empty is auto.
+func ParseTXTEscapeMode(value string) (TXTEscapeMode, error) {
+	switch mode := TXTEscapeMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return TXTEscapeAuto, nil
+	case TXTEscapeAuto, TXTEscapeNever, TXTEscapeAlways:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid TXT escape mode %q, expected auto, never or always", value)
+	}
+}
+
+// txtEscaping translates TXT values with the mode, the zero value is TXTEscapeAuto
+// recognizing registry values by DefaultTXTRegistryPrefix.
+type txtEscaping struct {
+	mode TXTEscapeMode
+	// prefixes of the TXT registry values translated in TXTEscapeAuto mode
+	prefixes []string
+}
+
+// isRegistryValue reports whether the unquoted value starts with one of the registry prefixes.
+func (e txtEscaping) isRegistryValue(value string) bool {
+	prefixes := e.prefixes
+	if len(prefixes) == 0 {
+		prefixes = []string{DefaultTXTRegistryPrefix}
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// escape returns the value of an external-dns TXT target as it is stored in privatezone. Quoted targets, one or
+// more RFC 1035 character-strings, are stored as their unquoted content, values longer than one character-string are
+// stored split into quoted character-strings.
+func (e txtEscaping) escape(value string) string {
+	switch e.mode {
+	case TXTEscapeNever:
+		return value
+	case TXTEscapeAlways:
+		if strs, ok := parseTXTStrings(value); ok {
+			value = strings.Join(strs, "")
+		}
+	default:
+		if strs, ok := parseTXTStrings(value); ok && e.isRegistryValue(strings.Join(strs, "")) {
+			value = strings.Join(strs, "")
+		}
+	}
+	return encodeTXTValue(value)
+}
+
+// unescape returns the stored privatezone TXT value as external-dns expects it, registry values in TXTEscapeAuto mode
+// and all values in TXTEscapeAlways mode as one quoted character-string.
+func (e txtEscaping) unescape(value string) string {
+	if e.mode == TXTEscapeNever {
+		return value
+	}
+	value = decodeTXTValue(value)
+	if e.mode == TXTEscapeAlways || e.isRegistryValue(value) {
+		return quoteCharacterString(value)
+	}
+	return value
+}
+
+// decode returns the content of a stored TXT value, joining the character-strings of long values.
+func (e txtEscaping) decode(value string) string {
+	if e.mode == TXTEscapeNever {
+		return value
+	}
+	return decodeTXTValue(value)
+}
+
+// encodeTXTValue returns the stored form of a TXT value: the value itself if it fits into one character-string,
+// otherwise its quoted character-strings of at most maxTXTValueLength bytes.
+func encodeTXTValue(value string) string {
+	if len(value) <= maxTXTValueLength {
+		return value
+	}
+	return quoteTXTStrings(value)
+}
+
+// decodeTXTValue returns the content of a value stored by encodeTXTValue. Values that do not consist of several
+// quoted character-strings, all but the last filled up to maxTXTValueLength, are returned as they are.
+func decodeTXTValue(value string) string {
+	if strs, ok := splitTXTValue(value); ok {
+		return strings.Join(strs, "")
+	}
+	return value
+}
+
+// splitTXTValue returns the character-strings of a value split by encodeTXTValue.
+func splitTXTValue(value string) ([]string, bool) {
+	if !strings.HasPrefix(value, "\"") {
+		return nil, false
+	}
+	strs, ok := parseTXTStrings(value)
+	if !ok || len(strs) < 2 {
+		return nil, false
+	}
+	for _, str := range strs[:len(strs)-1] {
+		// chunks end early only to keep multi-byte characters whole
+		if len(str) > maxTXTValueLength || len(str) <= maxTXTValueLength-utf8.UTFMax {
+			return nil, false
+		}
+	}
+	return strs, true
+}
+
+// quoteTXTStrings splits value into character-strings of at most maxTXTValueLength bytes, without splitting UTF-8
+// characters, and quotes them separated by spaces, e.g. "v=DKIM1; k=rsa; p=MIIB..." "...IDAQAB".
+func quoteTXTStrings(value string) string {
+	var strs []string
+	for {
+		n := len(value)
+		if n > maxTXTValueLength {
+			n = maxTXTValueLength
+			for n > maxTXTValueLength-utf8.UTFMax && !utf8.RuneStart(value[n]) {
+				n--
+			}
+		}
+		strs = append(strs, quoteCharacterString(value[:n]))
+		value = value[n:]
+		if value == "" {
+			return strings.Join(strs, " ")
+		}
+	}
+}
+
+// quoteCharacterString quotes an RFC 1035 character-string, escaping quotes and backslashes.
+func quoteCharacterString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// parseTXTStrings parses a value consisting of quoted RFC 1035 character-strings separated by blanks, with \X and \DDD
+// escapes. It reports false if the value is not quoted entirely, e.g. "a" b or "a.
+func parseTXTStrings(value string) ([]string, bool) {
+	var strs []string
+	for i := 0; i < len(value); {
+		switch value[i] {
+		case ' ', '\t':
+			i++
+			continue
+		case '"':
+		default:
+			return nil, false
+		}
+		var b strings.Builder
+		closed := false
+		for i++; i < len(value) && !closed; i++ {
+			switch c := value[i]; {
+			case c == '"':
+				closed = true
+			case c == '\\' && i+3 < len(value) && isDigits(value[i+1:i+4]):
+				n := int(value[i+1]-'0')*100 + int(value[i+2]-'0')*10 + int(value[i+3]-'0')
+				if n > 255 {
+					return nil, false
+				}
+				b.WriteByte(byte(n))
+				i += 3
+			case c == '\\' && i+1 < len(value):
+				i++
+				b.WriteByte(value[i])
+			case c == '\\':
+				return nil, false
+			default:
+				b.WriteByte(c)
+			}
+		}
+		if !closed {
+			return nil, false
+		}
+		strs = append(strs, b.String())
+	}
+	return strs, len(strs) > 0
+}
+
+// isDigits reports whether s consists of decimal digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseTXTEscapeMode(t *testing.T) {
+	for value, expected := range map[string]TXTEscapeMode{"": TXTEscapeAuto, "auto": TXTEscapeAuto, " Never ": TXTEscapeNever, "always": TXTEscapeAlways} {
+		mode, err := ParseTXTEscapeMode(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, mode, value)
+	}
+	_, err := ParseTXTEscapeMode("sometimes")
+	assert.Error(t, err)
+}
+
+func TestTXTEscaping(t *testing.T) {
+	heritage := `"heritage=external-dns,external-dns/owner=default"`
+	tests := []struct {
+		mode      TXTEscapeMode
+		value     string
+		escaped   string
+		stored    string
+		unescaped string
+	}{
+		{mode: "", value: heritage, escaped: "heritage=external-dns,external-dns/owner=default", stored: "heritage=x", unescaped: `"heritage=x"`},
+		{mode: TXTEscapeAuto, value: `"v=spf1 -all"`, escaped: `"v=spf1 -all"`, stored: "v=spf1 -all", unescaped: "v=spf1 -all"},
+		{mode: TXTEscapeNever, value: heritage, escaped: heritage, stored: "heritage=x", unescaped: "heritage=x"},
+		{mode: TXTEscapeAlways, value: `"v=spf1 -all"`, escaped: "v=spf1 -all", stored: "v=spf1 -all", unescaped: `"v=spf1 -all"`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode)+tt.value, func(t *testing.T) {
+			e := txtEscaping{mode: tt.mode}
+			assert.Equal(t, tt.escaped, e.escape(tt.value))
+			assert.Equal(t, tt.unescaped, e.unescape(tt.stored))
+		})
+	}
+}
+
+func TestCreateTXTEscapeNever(t *testing.T) {
+	value := `"heritage=external-dns,external-dns/owner=default"`
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(1), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 1 && volcengine.StringValue(records[0].Value) == value
+	})).Return(nil)
+
+	p := &Provider{pzClient: mockAPI, txt: txtEscaping{mode: TXTEscapeNever}}
+	err := p.createPrivateZoneRecords(context.Background(), map[string]string{"1": "example.com"},
+		[]*endpoint.Endpoint{endpoint.NewEndpoint("a-www.example.com", endpoint.RecordTypeTXT, value)})
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestMatchRecordTXTEscaping(t *testing.T) {
+	record := &privatezone.RecordForListRecordsOutput{Host: volcengine.String("a-www"), Type: volcengine.String("TXT"), Value: volcengine.String("heritage=x")}
+	assert.True(t, matchRecord(record, "a-www", "TXT", []string{`"heritage=x"`}, txtEscaping{}))
+	assert.False(t, matchRecord(record, "a-www", "TXT", []string{`"heritage=x"`}, txtEscaping{mode: TXTEscapeNever}))
+	assert.True(t, matchRecord(record, "a-www", "TXT", []string{"heritage=x"}, txtEscaping{mode: TXTEscapeNever}))
+}
+
+func TestTXTEscapingRegistryPrefixes(t *testing.T) {
+	e := txtEscaping{prefixes: []string{"heritage=", "owner="}}
+	assert.Equal(t, "owner=team-a", e.escape(`"owner=team-a"`))
+	assert.Equal(t, `"owner=team-a"`, e.unescape("owner=team-a"))
+	assert.Equal(t, "heritage=x", e.escape(`"heritage=x"`))
+	assert.Equal(t, `"v=spf1 -all"`, e.escape(`"v=spf1 -all"`))
+	assert.Equal(t, "v=spf1 -all", e.unescape("v=spf1 -all"))
+
+	custom := txtEscaping{prefixes: []string{"owner="}}
+	assert.Equal(t, `"heritage=x"`, custom.escape(`"heritage=x"`), "replaced prefixes do not include the default")
+	assert.Equal(t, "heritage=x", custom.unescape("heritage=x"))
+}
+
+func TestWithTXTRegistryPrefixes(t *testing.T) {
+	c := defaultConfig()
+	WithTXTRegistryPrefixes("heritage=", " owner= ", "")(c)
+	assert.Equal(t, []string{"heritage=", "owner="}, c.TXTRegistryPrefixes)
+}
+
+func TestParseTXTStrings(t *testing.T) {
+	tests := map[string][]string{
+		`"a"`:                  {"a"},
+		`"a b" "c"`:            {"a b", "c"},
+		`"say \"hi\"" "\\"`:    {`say "hi"`, `\`},
+		`"\065\066"`:           {"AB"},
+		`  "a"	"b"  `:          {"a", "b"},
+		`""`:                   {""},
+		`"heritage=x,y=\"z\""`: {`heritage=x,y="z"`},
+	}
+	for value, expected := range tests {
+		strs, ok := parseTXTStrings(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, expected, strs, value)
+	}
+	for _, value := range []string{"", "a", `"a" b`, `"a`, `"a\"`, `"\256"`, `a "b"`} {
+		_, ok := parseTXTStrings(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestEncodeTXTValue(t *testing.T) {
+	short := `v=spf1 include:"example.com" -all`
+	assert.Equal(t, short, encodeTXTValue(short))
+	assert.Equal(t, short, decodeTXTValue(short))
+
+	long := strings.Repeat("k", 300) + ` "quoted" \`
+	stored := encodeTXTValue(long)
+	assert.Equal(t, `"`+strings.Repeat("k", 255)+`" "`+strings.Repeat("k", 45)+` \"quoted\" \\"`, stored)
+	assert.NoError(t, validateTXTValue(stored))
+	assert.Equal(t, long, decodeTXTValue(stored))
+
+	// multi-byte characters are not split between strings
+	wide := strings.Repeat("a", 254) + strings.Repeat("中", 10)
+	strs, ok := splitTXTValue(encodeTXTValue(wide))
+	assert.True(t, ok)
+	assert.Equal(t, []string{strings.Repeat("a", 254), strings.Repeat("中", 10)}, strs)
+	assert.Equal(t, wide, decodeTXTValue(encodeTXTValue(wide)))
+
+	// quoted values written verbatim are not mistaken for split values
+	for _, value := range []string{`"v=spf1 -all"`, `"a" "b"`, `"` + strings.Repeat("a", 100) + `" "b"`} {
+		assert.Equal(t, value, decodeTXTValue(value))
+	}
+}
+
+func TestTXTEscapingLongValues(t *testing.T) {
+	registry := "heritage=external-dns,external-dns/owner=default,external-dns/resource=ingress/default/" + strings.Repeat("n", 250)
+	e := txtEscaping{}
+	stored := e.escape(`"` + registry + `"`)
+	assert.Equal(t, encodeTXTValue(registry), stored)
+	assert.Equal(t, `"`+registry+`"`, e.unescape(stored))
+	// external-dns may pass long registry values split into strings as well
+	assert.Equal(t, stored, e.escape(quoteTXTStrings(registry)))
+
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+	stored = e.escape(dkim)
+	assert.Equal(t, encodeTXTValue(dkim), stored)
+	assert.Equal(t, dkim, e.unescape(stored))
+	assert.Equal(t, dkim, e.decode(stored))
+
+	always := txtEscaping{mode: TXTEscapeAlways}
+	assert.Equal(t, `say "hi"`, always.escape(`"say \"hi\""`))
+	assert.Equal(t, `"say \"hi\""`, always.unescape(`say "hi"`))
+
+	never := txtEscaping{mode: TXTEscapeNever}
+	assert.Equal(t, dkim, never.escape(dkim))
+	assert.Equal(t, encodeTXTValue(dkim), never.decode(encodeTXTValue(dkim)))
+}
+
+func TestZoneRecordsToEndpointsJoinsTXTStrings(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+	zone := &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(1), ZoneName: volcengine.String("example.com")}
+	records := []*privatezone.RecordForListRecordsOutput{
+		{RecordID: volcengine.String("1"), Host: volcengine.String("mail._domainkey"), Type: volcengine.String("TXT"), Value: volcengine.String(encodeTXTValue(dkim)),
+			TTL: volcengine.Int32(600), Enable: volcengine.Bool(true)},
+	}
+	endpoints := zoneRecordsToEndpoints(zone, records, false, TargetDotPreserve, txtEscaping{})
+	if assert.Len(t, endpoints, 1) {
+		assert.Equal(t, endpoint.Targets{dkim}, endpoints[0].Targets)
+	}
+}
@@ -16,7 +16,6 @@
 package volcengine
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -32,71 +31,12 @@ func MaskSecret(secret string) string {
 	return secret[:4] + "********" + secret[len(secret)-4:]
 }
 
-// BatchForEach splits the items into batches and calls the function for each batch.
-func BatchForEach[T any, R any](items []T, batchSize int, f func([]T) ([]R, error)) ([]R, error) {
-	if batchSize <= 0 {
-		return nil, fmt.Errorf("batch size must be greater than 0")
-	}
-	n := len(items)
-	if n == 0 {
-		return []R{}, nil
-	}
-	var all []R
-	for i := 0; i < n; i += batchSize {
-		end := i + batchSize
-		if end > n {
-			end = n
-		}
-		part, err := f(items[i:end])
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, part...)
-	}
-
-	return all, nil
-}
-
-// QueryAll is a generic pagination function: query is responsible for cloning, setting page number, and returning (data, total, err)
-func QueryAll[T any](
-	pageSize int,
-	query func(int, int) ([]T, int, error),
-) ([]T, error) {
-
-	if pageSize <= 0 {
-		return nil, fmt.Errorf("pageSize must be greater than 0")
-	}
-	var all []T
-	pageNum := 1
-	for {
-		data, total, err := query(pageNum, pageSize)
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, data...)
-		if pageNum*pageSize >= total {
-			break
-		}
-		pageNum++
-	}
-
-	return all, nil
-}
-
 func escapeTXTRecordValue(value string) string {
-	if strings.HasPrefix(value, "\"heritage=") {
-		// remove \" in txt record value for volcengine privatezone
-		return strings.ReplaceAll(value, "\"", "")
-	}
-	return value
+	return txtEscaping{}.escape(value)
 }
 
 func unescapeTXTRecordValue(value string) string {
-	if strings.HasPrefix(value, "heritage=") {
-		// add \" in txt record value for volcengine privatezone
-		return fmt.Sprintf("\"%s\"", value)
-	}
-	return value
+	return txtEscaping{}.unescape(value)
 }
 
 func getDNSName(host, domain string) string {
@@ -126,11 +66,22 @@ func normalizeDomain(value string) string {
 	return strings.TrimSuffix(value, ".")
 }
 
-func completeCNAMEValue(value string) string {
-	if !strings.HasSuffix(value, ".") {
-		return value + "."
+// matchRecord reports whether record has the host and type and one of the targets,
+// TXT values are unescaped with txt and the trailing dot of names is ignored.
+func matchRecord(record *privatezone.RecordForListRecordsOutput, host, recordType string, targets []string, txt txtEscaping) bool {
+	if host != volcengine.StringValue(record.Host) || recordType != volcengine.StringValue(record.Type) {
+		return false
+	}
+	value := volcengine.StringValue(record.Value)
+	if recordType == "TXT" {
+		value = txt.unescape(value)
 	}
-	return value
+	for _, target := range targets {
+		if sameTarget(recordType, target, value) {
+			return true
+		}
+	}
+	return false
 }
 
 type LoggerAdapter struct {
@@ -149,15 +100,35 @@ func groupPrivateZoneRecords(zone []*privatezone.RecordForListRecordsOutput) (en
 	endpointMap = make(map[string][]Record)
 
 	for _, record := range zone {
-		key := volcengine.StringValue(record.Type) + ":" + volcengine.StringValue(record.Host)
+		setIdentifier := remarkSetIdentifier(volcengine.StringValue(record.Remark))
+		key := recordSetKey(record, setIdentifier)
 		recordList := endpointMap[key]
 		endpointMap[key] = append(recordList, Record{
 			Host:   volcengine.StringValue(record.Host),
 			Type:   volcengine.StringValue(record.Type),
 			TTL:    int(volcengine.Int32Value(record.TTL)),
 			Target: volcengine.StringValue(record.Value),
+			Remark: volcengine.StringValue(record.Remark),
+			// records without the Enable flag are enabled
+			Disabled:      record.Enable != nil && !*record.Enable,
+			SetIdentifier: setIdentifier,
+			Weight:        int(volcengine.Int32Value(record.Weight)),
+			Line:          recordLine(volcengine.StringValue(record.Line)),
 		})
 	}
 
 	return endpointMap
 }
+
+// recordSetKey identifies the record set of the record, hosts are case-insensitive so
+// records differing in case belong to the same name, records of different lines do not.
+func recordSetKey(record *privatezone.RecordForListRecordsOutput, setIdentifier string) string {
+	key := volcengine.StringValue(record.Type) + ":" + strings.ToLower(volcengine.StringValue(record.Host))
+	if setIdentifier != "" {
+		key += ":" + setIdentifier
+	}
+	if line := recordLine(volcengine.StringValue(record.Line)); line != "" {
+		key += "@" + line
+	}
+	return key
+}
@@ -16,7 +16,6 @@
 package volcengine
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -83,87 +82,6 @@ func TestMaskSecret(t *testing.T) {
 	}
 }
 
-func TestBatchForEach(t *testing.T) {
-	cases := []struct {
-		name      string
-		items     []int
-		batchSize int
-		expected  []int
-		wantErr   bool
-	}{{
-		name:      "normal case with batch size 2",
-		items:     []int{1, 2, 3, 4, 5},
-		batchSize: 2,
-		expected:  []int{2, 4, 6, 8, 10},
-		wantErr:   false,
-	}, {
-		name:      "empty items",
-		items:     []int{},
-		batchSize: 2,
-		expected:  []int{},
-		wantErr:   false,
-	}, {
-		name:      "batch size 0",
-		items:     []int{1, 2, 3},
-		batchSize: 0,
-		expected:  nil,
-		wantErr:   true,
-	}}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			// Define a function that multiplies each number by 2
-			doubleFunc := func(batch []int) ([]int, error) {
-				result := make([]int, 0, len(batch))
-				for _, item := range batch {
-					result = append(result, item*2)
-				}
-				return result, nil
-			}
-
-			result, err := BatchForEach(tc.items, tc.batchSize, doubleFunc)
-
-			if tc.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tc.expected, result)
-			}
-		})
-	}
-}
-
-func TestQueryAll(t *testing.T) {
-	// Mock a query function that returns paginated data
-	mockQuery := func(pageNum, pageSize int) ([]string, int, error) {
-		// Mock a total of 100 data items
-		total := 100
-		start := (pageNum - 1) * pageSize
-		end := start + pageSize
-		if end > total {
-			end = total
-		}
-
-		// Generate mock data
-		data := make([]string, 0, end-start)
-		for i := start; i < end; i++ {
-			data = append(data, fmt.Sprintf("item-%d", i))
-		}
-
-		return data, total, nil
-	}
-
-	// Test normal case
-	result, err := QueryAll(20, mockQuery)
-	assert.NoError(t, err)
-	assert.Len(t, result, 100)
-
-	// Test case when pageSize is 0
-	result, err = QueryAll(0, mockQuery)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-}
-
 func TestEscapeTXTRecordValue(t *testing.T) {
 	cases := []struct {
 		name     string
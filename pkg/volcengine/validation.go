@@ -0,0 +1,364 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	maxDNSNameLength  = 253
+	maxDNSLabelLength = 63
+	// maxTXTValueLength is the length of a DNS character-string, privatezone stores shorter TXT values as one
+	// unquoted string and longer ones split into quoted strings.
+	maxTXTValueLength = 255
+	// maxTXTRecordLength bounds the stored value of a TXT value split into several strings.
+	maxTXTRecordLength = 4096
+)
+
+// supportedRecordTypes are the record types privatezone accepts.
+var supportedRecordTypes = map[string]bool{
+	endpoint.RecordTypeA:     true,
+	endpoint.RecordTypeAAAA:  true,
+	endpoint.RecordTypeCNAME: true,
+	endpoint.RecordTypeTXT:   true,
+	endpoint.RecordTypeMX:    true,
+	endpoint.RecordTypeSRV:   true,
+	endpoint.RecordTypePTR:   true,
+}
+
+// publicOnlyRecordTypes are record types external-dns manages that privatezone does not accept,
+// they need the public CloudDNS service.
+var publicOnlyRecordTypes = map[string]bool{
+	"CAA":                    true,
+	endpoint.RecordTypeNAPTR: true,
+}
+
+// ValidateDNSName checks that name is a syntactically valid DNS name.
+// A single trailing dot is accepted, and the first label may be a wildcard "*".
+func ValidateDNSName(name string) error {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return fmt.Errorf("dns name is empty")
+	}
+	if len(name) > maxDNSNameLength {
+		return fmt.Errorf("dns name %q exceeds %d characters", name, maxDNSNameLength)
+	}
+	for i, label := range strings.Split(name, ".") {
+		if i == 0 && label == "*" {
+			continue
+		}
+		if err := validateDNSLabel(label); err != nil {
+			return fmt.Errorf("invalid dns name %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func validateDNSLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("empty label")
+	}
+	if len(label) > maxDNSLabelLength {
+		return fmt.Errorf("label %q exceeds %d characters", label, maxDNSLabelLength)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("label %q must not start or end with a hyphen", label)
+	}
+	for _, c := range label {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return fmt.Errorf("label %q contains invalid character %q", label, c)
+		}
+	}
+	return nil
+}
+
+// NormalizeTXT returns the TXT value as it is stored in privatezone.
+func NormalizeTXT(value string) string {
+	return escapeTXTRecordValue(value)
+}
+
+// NormalizeCNAME returns the CNAME target as it is stored in privatezone, always fully qualified.
+func NormalizeCNAME(value string) string {
+	return strings.TrimRight(value, ".") + "."
+}
+
+// validateEndpoint checks the endpoint name, record type, set identifier, weight, line and, for name-valued record types, its targets.
+// public accepts the publicOnlyRecordTypes of public CloudDNS zones.
+func validateEndpoint(ep *endpoint.Endpoint, public bool) error {
+	if err := ValidateDNSName(ep.DNSName); err != nil {
+		return err
+	}
+	if publicOnlyRecordTypes[ep.RecordType] && !public {
+		return fmt.Errorf("record type %s of %s is not supported by privatezone, only by public CloudDNS zones", ep.RecordType, ep.DNSName)
+	}
+	if !supportedRecordTypes[ep.RecordType] && !publicOnlyRecordTypes[ep.RecordType] {
+		return fmt.Errorf("unsupported record type %q of %s", ep.RecordType, ep.DNSName)
+	}
+	if len(ep.SetIdentifier) > maxSetIdentifierLength || strings.ContainsAny(ep.SetIdentifier, ";=") {
+		return fmt.Errorf("invalid set identifier %q of %s: at most %d characters without ';' or '='", ep.SetIdentifier, ep.DNSName, maxSetIdentifierLength)
+	}
+	if _, err := endpointWeight(ep); err != nil {
+		return err
+	}
+	if _, err := endpointLine(ep); err != nil {
+		return err
+	}
+	if ep.RecordType == endpoint.RecordTypeCNAME {
+		for _, target := range ep.Targets {
+			if err := ValidateDNSName(target); err != nil {
+				return fmt.Errorf("invalid CNAME target for %s: %v", ep.DNSName, err)
+			}
+		}
+	}
+	if ep.RecordType == endpoint.RecordTypeAAAA {
+		for _, target := range ep.Targets {
+			if _, err := parseIPv6(target); err != nil {
+				return fmt.Errorf("invalid AAAA target for %s: %v", ep.DNSName, err)
+			}
+		}
+	}
+	if ep.RecordType == endpoint.RecordTypeMX {
+		for _, target := range ep.Targets {
+			if _, err := parseMX(target); err != nil {
+				return fmt.Errorf("invalid MX target for %s: %v", ep.DNSName, err)
+			}
+		}
+	}
+	if ep.RecordType == endpoint.RecordTypeSRV {
+		for _, target := range ep.Targets {
+			if _, err := parseSRV(target); err != nil {
+				return fmt.Errorf("invalid SRV target for %s: %v", ep.DNSName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseIPv6 parses the IPv6 literal of an AAAA value, IPv4 addresses and scoped addresses are rejected.
+func parseIPv6(value string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("%q is not an IPv6 address", value)
+	}
+	if !addr.Is6() || addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("%q is not an unscoped IPv6 address", value)
+	}
+	return addr, nil
+}
+
+// canonicalIPv6 returns the RFC 5952 form of an AAAA value, e.g. "2001:db8::1" for "2001:0DB8:0:0:0:0:0:1",
+// values that do not parse are returned unchanged.
+func canonicalIPv6(value string) string {
+	if addr, err := parseIPv6(value); err == nil {
+		return addr.String()
+	}
+	return value
+}
+
+// mxValue is an MX record value "preference host".
+type mxValue struct {
+	preference uint16
+	host       string
+}
+
+// parseMX parses an MX value, e.g. "10 mail.example.com", the host "." is a null MX.
+func parseMX(value string) (mxValue, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return mxValue{}, fmt.Errorf("%q is not \"preference host\"", value)
+	}
+	preference, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return mxValue{}, fmt.Errorf("%q: preference %s is not a number from 0 to 65535", value, fields[0])
+	}
+	mx := mxValue{preference: uint16(preference), host: fields[1]}
+	if mx.host != "." {
+		if err := ValidateDNSName(mx.host); err != nil {
+			return mxValue{}, fmt.Errorf("%q: %v", value, err)
+		}
+	}
+	return mx, nil
+}
+
+// String returns the value with a single space and the host lower-cased, its trailing dot as written.
+func (v mxValue) String() string {
+	return fmt.Sprintf("%d %s", v.preference, strings.ToLower(v.host))
+}
+
+// normalizeTarget returns the value of the record type in the form privatezone lists it back:
+// IPv6 addresses in their canonical form and MX values with a single space, names lower-cased.
+// Values that do not parse are returned unchanged.
+func normalizeTarget(recordType, value string) string {
+	switch recordType {
+	case endpoint.RecordTypeAAAA:
+		return canonicalIPv6(value)
+	case endpoint.RecordTypeMX:
+		if mx, err := parseMX(value); err == nil {
+			return mx.String()
+		}
+	}
+	return value
+}
+
+// srvValue is an SRV record value "priority weight port target".
+type srvValue struct {
+	priority, weight, port uint16
+	target                 string
+}
+
+// parseSRV parses an SRV value, e.g. "10 5 5060 sip.example.com.", the target "." means no service.
+func parseSRV(value string) (srvValue, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return srvValue{}, fmt.Errorf("%q is not \"priority weight port target\"", value)
+	}
+	var numbers [3]uint16
+	for i, field := range fields[:3] {
+		n, err := strconv.ParseUint(field, 10, 16)
+		if err != nil {
+			return srvValue{}, fmt.Errorf("%q: %s is not a number from 0 to 65535", value, field)
+		}
+		numbers[i] = uint16(n)
+	}
+	srv := srvValue{priority: numbers[0], weight: numbers[1], port: numbers[2], target: fields[3]}
+	if srv.target != "." {
+		if err := ValidateDNSName(srv.target); err != nil {
+			return srvValue{}, fmt.Errorf("%q: %v", value, err)
+		}
+	}
+	return srv, nil
+}
+
+// String returns the value with single spaces and the target without trailing dot.
+func (v srvValue) String() string {
+	target := v.target
+	if target != "." {
+		target = strings.TrimRight(target, ".")
+	}
+	return fmt.Sprintf("%d %d %d %s", v.priority, v.weight, v.port, target)
+}
+
+// validateTXTValue checks a TXT value as it is stored in privatezone: its length, control characters and
+// that its double quotes are balanced, quotes escaped with a backslash do not count. The content of values split into
+// several strings is checked for control characters only.
+func validateTXTValue(value string) error {
+	if strs, ok := splitTXTValue(value); ok {
+		if len(value) > maxTXTRecordLength {
+			return fmt.Errorf("TXT value is %d bytes long, at most %d are allowed", len(value), maxTXTRecordLength)
+		}
+		return validateTXTContent(strings.Join(strs, ""))
+	}
+	if len(value) > maxTXTValueLength {
+		return fmt.Errorf("TXT value is %d bytes long, at most %d are allowed", len(value), maxTXTValueLength)
+	}
+	if err := validateTXTContent(value); err != nil {
+		return err
+	}
+	quotes := 0
+	for i, c := range value {
+		if c == '"' && (i == 0 || value[i-1] != '\\') {
+			quotes++
+		}
+	}
+	if quotes%2 != 0 {
+		return fmt.Errorf("TXT value has unbalanced double quotes")
+	}
+	return nil
+}
+
+// validateTXTContent rejects control characters in a TXT value.
+func validateTXTContent(value string) error {
+	for i, c := range value {
+		if c < 0x20 || c == 0x7f {
+			return fmt.Errorf("TXT value contains control character %U at offset %d", c, i)
+		}
+	}
+	return nil
+}
+
+// endpointSource names the endpoint and the Kubernetes resource it was created for, when known.
+func endpointSource(ep *endpoint.Endpoint) string {
+	if resource := ep.Labels[endpoint.ResourceLabelKey]; resource != "" {
+		return fmt.Sprintf("%s %s (%s)", ep.DNSName, ep.RecordType, resource)
+	}
+	return fmt.Sprintf("%s %s", ep.DNSName, ep.RecordType)
+}
+
+// skipInvalidTXT drops the TXT endpoints with values privatezone would reject and returns them separately,
+// with one error per endpoint naming its source.
+func (p *Provider) skipInvalidTXT(action string, endpoints []*endpoint.Endpoint) (valid, invalid []*endpoint.Endpoint, errs []error) {
+	valid = make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			valid = append(valid, ep)
+			continue
+		}
+		var err error
+		for _, target := range ep.Targets {
+			if err = validateTXTValue(p.txt.escape(target)); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %v", endpointSource(ep), err)
+			p.logger().Errorf("Refusing to %s invalid endpoint: %v", action, err)
+			invalid = append(invalid, ep)
+			errs = append(errs, err)
+			continue
+		}
+		valid = append(valid, ep)
+	}
+	return valid, invalid, errs
+}
+
+// skipUnsupportedRecordTypes returns the changes without the endpoints of record types the managed zones do not
+// accept, and those endpoints. AdjustEndpoints drops them already, they only reach ApplyChanges from clients that
+// skip it.
+func (p *Provider) skipUnsupportedRecordTypes(ctx context.Context, changes *plan.Changes) (*plan.Changes, []*endpoint.Endpoint) {
+	var unsupported []*endpoint.Endpoint
+	filter := func(action string, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+		supported := make([]*endpoint.Endpoint, 0, len(endpoints))
+		for _, ep := range endpoints {
+			if p.acceptsRecordType(ep.RecordType) {
+				supported = append(supported, ep)
+				continue
+			}
+			if action != "" {
+				p.requestLogger(ctx).Warnf("Refusing to %s endpoint %s %s, the record type is not supported by the managed zones",
+					action, ep.DNSName, ep.RecordType)
+				unsupported = append(unsupported, ep)
+			}
+		}
+		return supported
+	}
+	filtered := *changes
+	filtered.Create = filter("create", changes.Create)
+	// the old endpoints are reported with the new ones
+	filtered.UpdateOld = filter("", changes.UpdateOld)
+	filtered.UpdateNew = filter("update", changes.UpdateNew)
+	filtered.Delete = filter("delete", changes.Delete)
+	return &filtered, unsupported
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestValidateDNSName(t *testing.T) {
+	cases := []struct {
+		name    string
+		dnsName string
+		wantErr bool
+	}{{
+		name:    "normal dns name",
+		dnsName: "www.example.com",
+	}, {
+		name:    "trailing dot",
+		dnsName: "www.example.com.",
+	}, {
+		name:    "wildcard",
+		dnsName: "*.example.com",
+	}, {
+		name:    "underscore label",
+		dnsName: "_http._tcp.example.com",
+	}, {
+		name:    "empty",
+		dnsName: "",
+		wantErr: true,
+	}, {
+		name:    "empty label",
+		dnsName: "www..example.com",
+		wantErr: true,
+	}, {
+		name:    "wildcard not in first label",
+		dnsName: "www.*.example.com",
+		wantErr: true,
+	}, {
+		name:    "leading hyphen",
+		dnsName: "-www.example.com",
+		wantErr: true,
+	}, {
+		name:    "invalid character",
+		dnsName: "www example.com",
+		wantErr: true,
+	}, {
+		name:    "label too long",
+		dnsName: strings.Repeat("a", 64) + ".example.com",
+		wantErr: true,
+	}, {
+		name:    "name too long",
+		dnsName: strings.Repeat("a.", 127) + "com",
+		wantErr: true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDNSName(tc.dnsName)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	assert.NoError(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "CNAME", "target.example.com."), false))
+	assert.Error(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "CNAME", "bad target"), false))
+	assert.Error(t, validateEndpoint(endpoint.NewEndpoint("bad name.example.com", "A", "1.2.3.4"), false))
+	assert.NoError(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithSetIdentifier("blue"), false))
+	assert.Error(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithSetIdentifier("a=b"), false))
+	assert.Error(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "FOO", "1.2.3.4"), false))
+	assert.ErrorContains(t, validateEndpoint(endpoint.NewEndpoint("example.com", "CAA", `0 issue "letsencrypt.org"`), false), "public CloudDNS")
+	assert.NoError(t, validateEndpoint(endpoint.NewEndpoint("example.com", "CAA", `0 issue "letsencrypt.org"`), true))
+	assert.ErrorContains(t, validateEndpoint(endpoint.NewEndpoint("example.com", "NAPTR", `100 10 "u" "E2U+sip" "!^.*$!sip:info@example.com!" .`), false), "public CloudDNS")
+	assert.NoError(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "TXT", "hello"), false))
+}
+
+func TestParseSRV(t *testing.T) {
+	srv, err := parseSRV("10  5 5060 sip.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, srvValue{priority: 10, weight: 5, port: 5060, target: "sip.example.com."}, srv)
+	assert.Equal(t, "10 5 5060 sip.example.com", srv.String())
+
+	srv, err = parseSRV("0 0 0 .")
+	require.NoError(t, err)
+	assert.Equal(t, "0 0 0 .", srv.String())
+
+	for _, value := range []string{"", "10 5 sip.example.com", "10 5 70000 sip.example.com", "-1 5 80 sip.example.com", "10 5 80 bad_name!"} {
+		_, err := parseSRV(value)
+		assert.Error(t, err, value)
+	}
+	assert.NoError(t, validateEndpoint(endpoint.NewEndpoint("_sip._udp.example.com", "SRV", "10 5 5060 sip.example.com"), false))
+	assert.Error(t, validateEndpoint(endpoint.NewEndpoint("_sip._udp.example.com", "SRV", "sip.example.com"), false))
+}
+
+func TestParseIPv6(t *testing.T) {
+	for _, value := range []string{"2001:db8::1", "::1", "2001:0DB8:0:0:0:0:0:1", "::ffff:192.0.2.1"} {
+		_, err := parseIPv6(value)
+		assert.NoError(t, err, value)
+	}
+	for _, value := range []string{"", "192.0.2.1", "fe80::1%eth0", "2001:db8::g", "2001:db8::1."} {
+		_, err := parseIPv6(value)
+		assert.Error(t, err, value)
+	}
+	assert.Equal(t, "2001:db8::1", canonicalIPv6("2001:0DB8:0:0:0:0:0:1"))
+	assert.Equal(t, "not-an-address", canonicalIPv6("not-an-address"))
+	assert.NoError(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "AAAA", "2001:db8::1", "2001:db8::2"), false))
+	assert.ErrorContains(t, validateEndpoint(endpoint.NewEndpoint("www.example.com", "AAAA", "2001:db8::1", "192.0.2.1"), false), "invalid AAAA target")
+}
+
+func TestParseMX(t *testing.T) {
+	mx, err := parseMX("10  Mail.Example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, mxValue{preference: 10, host: "Mail.Example.com."}, mx)
+	assert.Equal(t, "10 mail.example.com.", mx.String())
+
+	mx, err = parseMX("0 .")
+	require.NoError(t, err)
+	assert.Equal(t, "0 .", mx.String())
+
+	for _, value := range []string{"", "mail.example.com", "70000 mail.example.com", "-1 mail.example.com", "10 bad_name!", "10 mail.example.com extra"} {
+		_, err := parseMX(value)
+		assert.Error(t, err, value)
+	}
+	assert.NoError(t, validateEndpoint(endpoint.NewEndpoint("example.com", "MX", "10 mail.example.com"), false))
+	assert.ErrorContains(t, validateEndpoint(endpoint.NewEndpoint("example.com", "MX", "mail.example.com"), false), "invalid MX target")
+	assert.Equal(t, "20 mx.example.com", normalizeTarget("MX", "20   MX.example.com"))
+	assert.Equal(t, "mail.example.com", normalizeTarget("MX", "mail.example.com"))
+}
+
+func TestNormalizeCNAME(t *testing.T) {
+	assert.Equal(t, "example.com.", NormalizeCNAME("example.com"))
+	assert.Equal(t, "example.com.", NormalizeCNAME("example.com."))
+}
+
+func FuzzSplitDNSName(f *testing.F) {
+	f.Add("www.example.com", "example.com")
+	f.Add("example.com.", "example.com")
+	f.Add("www.different.com", "example.com")
+	f.Add("", "")
+	f.Fuzz(func(t *testing.T, dnsName, zoneName string) {
+		host, domain := splitDNSName(dnsName, zoneName)
+		if host == "" {
+			t.Fatalf("splitDNSName(%q, %q) returned empty host", dnsName, zoneName)
+		}
+		if domain == "" {
+			return
+		}
+		if got := getDNSName(host, domain); host != nullHostPrivateZone && got != strings.TrimSuffix(dnsName, ".") {
+			t.Fatalf("getDNSName(%q, %q) = %q, want %q", host, domain, got, strings.TrimSuffix(dnsName, "."))
+		}
+	})
+}
+
+func FuzzTXTRecordValue(f *testing.F) {
+	f.Add("heritage=external-dns,external-dns/owner=example")
+	f.Add("normal txt record")
+	f.Add(`"quoted"`)
+	f.Add(`"heritage=external-dns,external-dns/owner=\"x\"" "` + strings.Repeat("y", 300) + `"`)
+	f.Fuzz(func(t *testing.T, value string) {
+		// stored values split into several strings decode to the value
+		if got := decodeTXTValue(encodeTXTValue(value)); got != value {
+			t.Fatalf("decodeTXTValue(encodeTXTValue(%q)) = %q", value, got)
+		}
+		// a heritage value must round trip through the quoted value external-dns reads
+		if strings.HasPrefix(value, "heritage=") {
+			stored := encodeTXTValue(value)
+			if got := escapeTXTRecordValue(unescapeTXTRecordValue(stored)); got != stored {
+				t.Fatalf("round trip of %q = %q", stored, got)
+			}
+		}
+	})
+}
+
+func FuzzNormalizeCNAME(f *testing.F) {
+	f.Add("example.com")
+	f.Add("example.com.")
+	f.Add(".")
+	f.Fuzz(func(t *testing.T, value string) {
+		normalized := NormalizeCNAME(value)
+		if !strings.HasSuffix(normalized, ".") {
+			t.Fatalf("NormalizeCNAME(%q) = %q is not fully qualified", value, normalized)
+		}
+		if NormalizeCNAME(normalized) != normalized {
+			t.Fatalf("NormalizeCNAME(%q) is not idempotent", value)
+		}
+	})
+}
+
+func FuzzValidateDNSName(f *testing.F) {
+	f.Add("www.example.com")
+	f.Add("*.example.com.")
+	f.Add("a..b")
+	f.Fuzz(func(t *testing.T, name string) {
+		if err := ValidateDNSName(name); err != nil {
+			return
+		}
+		// valid names always split cleanly against their own parent zone
+		trimmed := strings.TrimSuffix(name, ".")
+		if i := strings.Index(trimmed, "."); i > 0 {
+			host, domain := splitDNSName(name, trimmed[i+1:])
+			if host != trimmed[:i] || domain != trimmed[i+1:] {
+				t.Fatalf("splitDNSName(%q) = %q, %q", name, host, domain)
+			}
+		}
+	})
+}
+
+func TestValidateTXTValue(t *testing.T) {
+	assert.NoError(t, validateTXTValue("heritage=external-dns,external-dns/owner=default"))
+	assert.NoError(t, validateTXTValue(`"v=spf1 -all"`))
+	assert.NoError(t, validateTXTValue(`say \"hi`))
+	assert.ErrorContains(t, validateTXTValue(strings.Repeat("a", maxTXTValueLength+1)), "at most 255")
+	// the limit counts bytes, multi-byte characters count several times
+	assert.NoError(t, validateTXTValue(strings.Repeat("é", maxTXTValueLength/2)))
+	assert.ErrorContains(t, validateTXTValue(strings.Repeat("é", maxTXTValueLength/2+1)), "is 256 bytes long")
+	assert.ErrorContains(t, validateTXTValue("bell\a"), "U+0007")
+	assert.ErrorContains(t, validateTXTValue("line\nbreak"), "control character")
+	assert.ErrorContains(t, validateTXTValue(`"unbalanced`), "unbalanced")
+}
+
+func TestProviderInvalidTXT(t *testing.T) {
+	mockAPI := new(MockPrivateZoneAPI)
+	mockAPI.On("ListPrivateZones", mock.Anything, "vpc-123").Return([]*privatezone.ZoneForListPrivateZonesOutput{
+		{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")},
+	}, nil)
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 1 && volcengine.StringValue(records[0].Host) == "good"
+	})).Return(nil).Once()
+
+	bad := endpoint.NewEndpoint("bad.example.com", "TXT", "\"broken")
+	bad.Labels[endpoint.ResourceLabelKey] = "ingress/default/web"
+	p := &Provider{pzClient: mockAPI, privateZone: true, vpcID: "vpc-123"}
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("good.example.com", "TXT", "hello"), bad},
+	})
+
+	var changeErr *ChangeError
+	require.True(t, errors.As(err, &changeErr))
+	assert.Equal(t, ErrCodeInvalidTXT, changeErr.Code)
+	assert.Equal(t, []*endpoint.Endpoint{bad}, changeErr.Endpoints)
+	assert.ErrorContains(t, err, "bad.example.com TXT (ingress/default/web): TXT value has unbalanced double quotes")
+	mockAPI.AssertExpectations(t)
+}
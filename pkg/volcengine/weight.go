@@ -0,0 +1,89 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// ProviderSpecificWeight sets the weight of the records of an endpoint, privatezone load balances the
+	// records of a name by weight, e.g. between the endpoints of several set identifiers.
+	ProviderSpecificWeight = "volcengine-privatezone/weight"
+	// providerSpecificWebhookWeight is the property of the external-dns.alpha.kubernetes.io/webhook-weight annotation.
+	providerSpecificWebhookWeight = "webhook/weight"
+
+	// defaultRecordWeight is the weight of records created without one.
+	defaultRecordWeight = 1
+	maxRecordWeight     = 100
+)
+
+// endpointWeight returns the weight of the endpoint records, defaultRecordWeight when none is set.
+func endpointWeight(ep *endpoint.Endpoint) (int32, error) {
+	value, ok := weightProperty.get(ep)
+	if !ok {
+		return defaultRecordWeight, nil
+	}
+	weight, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || weight < defaultRecordWeight || weight > maxRecordWeight {
+		return 0, fmt.Errorf("invalid weight %q of %s, expected a number from %d to %d", value, ep.DNSName, defaultRecordWeight, maxRecordWeight)
+	}
+	return int32(weight), nil
+}
+
+// recordWeight returns the weight of a listed record, records listed without one have defaultRecordWeight.
+func recordWeight(weight int32) int32 {
+	if weight <= 0 {
+		return defaultRecordWeight
+	}
+	return weight
+}
+
+// createWeight returns the weight to create a record with, 0 leaves the default to the API.
+func createWeight(weight int32) int32 {
+	if weight == defaultRecordWeight {
+		return 0
+	}
+	return weight
+}
+
+// updateWeight returns the weight to update the record with, 0 keeps the weight of the record.
+func updateWeight(record *privatezone.RecordForListRecordsOutput, weight int32) int32 {
+	if recordWeight(volcengine.Int32Value(record.Weight)) == weight {
+		return 0
+	}
+	return weight
+}
+
+// adjustWeight sets the weight of the endpoint the way Records returns it: under ProviderSpecificWeight,
+// and not at all for the default weight.
+func adjustWeight(ep *endpoint.Endpoint) {
+	weight, err := endpointWeight(ep)
+	if err != nil {
+		// invalid weights are reported when the endpoint is applied
+		return
+	}
+	value := ""
+	if weight != defaultRecordWeight {
+		value = strconv.Itoa(int(weight))
+	}
+	weightProperty.set(ep, value)
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestEndpointWeight(t *testing.T) {
+	weight, err := endpointWeight(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(defaultRecordWeight), weight)
+
+	weight, err = endpointWeight(endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificWeight, "20"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(20), weight)
+
+	for _, value := range []string{"0", "101", "heavy", ""} {
+		ep := endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificWeight, value)
+		_, err := endpointWeight(ep)
+		assert.Error(t, err, value)
+		assert.ErrorContains(t, validateEndpoint(ep, false), "invalid weight")
+	}
+}
+
+func TestZoneRecordsToEndpointsWeight(t *testing.T) {
+	zone := &privatezone.ZoneForListPrivateZonesOutput{ZID: volcengine.Int32(123), ZoneName: volcengine.String("example.com")}
+	records := []*privatezone.RecordForListRecordsOutput{
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.4"), Weight: volcengine.Int32(80),
+			Remark: volcengine.String((&Provider{}).encodeRemark(nil, "blue"))},
+		{Host: volcengine.String("www"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.5"), Weight: volcengine.Int32(1),
+			Remark: volcengine.String((&Provider{}).encodeRemark(nil, "green"))},
+		{Host: volcengine.String("app"), Type: volcengine.String("A"), Value: volcengine.String("1.2.3.6"), Weight: volcengine.Int32(0)},
+	}
+
+	endpoints := zoneRecordsToEndpoints(zone, records, false, TargetDotPreserve, txtEscaping{})
+	require.Len(t, endpoints, 3)
+	assert.Empty(t, endpoints[0].ProviderSpecific)
+	assert.Equal(t, "blue", endpoints[1].SetIdentifier)
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: ProviderSpecificWeight, Value: "80"}}, endpoints[1].ProviderSpecific)
+	assert.Equal(t, "green", endpoints[2].SetIdentifier)
+	assert.Empty(t, endpoints[2].ProviderSpecific)
+}
+
+func TestCreateWeightedRecords(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI}
+	mockAPI.On("BatchCreatePrivateZoneRecord", mock.Anything, int64(123), mock.MatchedBy(func(records []*privatezone.RecordForBatchCreateRecordInput) bool {
+		return len(records) == 2 &&
+			volcengine.Int32Value(records[0].Weight) == 80 &&
+			records[1].Weight == nil
+	})).Return(nil)
+
+	err := p.createPrivateZoneRecords(ctx, map[string]string{"123": "example.com"}, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithSetIdentifier("blue").WithProviderSpecific(ProviderSpecificWeight, "80"),
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.5").WithSetIdentifier("green"),
+		endpoint.NewEndpoint("www.example.com", "A", "1.2.3.6").WithSetIdentifier("red").WithProviderSpecific(ProviderSpecificWeight, "0"),
+	})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestUpdateRecordWeight(t *testing.T) {
+	ctx := context.Background()
+	mockAPI := new(MockPrivateZoneAPI)
+	p := &Provider{pzClient: mockAPI}
+	records := []*privatezone.RecordForListRecordsOutput{{
+		RecordID: volcengine.String("r1"),
+		Host:     volcengine.String("www"),
+		Type:     volcengine.String("A"),
+		Value:    volcengine.String("1.2.3.4"),
+		TTL:      volcengine.Int32(300),
+		Weight:   volcengine.Int32(80),
+	}}
+	// only the weight changed, the record keeps its TTL
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "r1", "www", "A", "1.2.3.4", int32(300), int32(20), "", mock.Anything).Return(nil).Once()
+	p.updateRecordSet(ctx, 123, "www", endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificWeight, "20"), records)
+
+	// the weight property was removed, the record is reset to the default weight
+	mockAPI.On("UpdatePrivateZoneRecord", mock.Anything, int64(123), "r1", "www", "A", "1.2.3.4", int32(300), int32(defaultRecordWeight), "", mock.Anything).Return(nil).Once()
+	p.updateRecordSet(ctx, 123, "www", endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4"), records)
+
+	// the weight is unchanged, nothing is written
+	p.updateRecordSet(ctx, 123, "www", endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4").WithProviderSpecific(ProviderSpecificWeight, "80"), records)
+	mockAPI.AssertExpectations(t)
+}
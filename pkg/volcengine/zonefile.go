@@ -0,0 +1,300 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// writeZoneFile writes the zones as BIND zone files, one $ORIGIN section per zone. The weight, line and remark of
+// a record, which zone files cannot express, follow the record in a comment, disabled records are commented out.
+func writeZoneFile(w io.Writer, zones []ExportedZone) error {
+	bw := bufio.NewWriter(w)
+	for i, zone := range zones {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintf(bw, "$ORIGIN %s.\n", zone.Zone)
+		if zone.ZID != 0 {
+			fmt.Fprintf(bw, "; zid %d\n", zone.ZID)
+		}
+		for _, r := range zone.Records {
+			line := zoneFileRecord(r)
+			if r.Disabled {
+				line = "; disabled: " + line
+			}
+			fmt.Fprintln(bw, line)
+		}
+	}
+	return bw.Flush()
+}
+
+// zoneFileRecord formats the record as a zone file line relative to the $ORIGIN of its zone.
+func zoneFileRecord(r ExportedRecord) string {
+	fields := []string{r.Host}
+	if r.TTL != 0 {
+		fields = append(fields, strconv.Itoa(int(r.TTL)))
+	}
+	value := TargetDotAppend.apply(r.Type, r.Value)
+	if r.Type == endpoint.RecordTypeTXT {
+		value = quoteTXTStrings(decodeTXTValue(r.Value))
+	}
+	fields = append(fields, "IN", r.Type, value)
+	var attrs []string
+	if r.Weight != 0 {
+		attrs = append(attrs, fmt.Sprintf("weight=%d", r.Weight))
+	}
+	if r.Line != "" {
+		attrs = append(attrs, "line="+r.Line)
+	}
+	if r.Remark != "" {
+		attrs = append(attrs, "remark="+quoteCharacterString(r.Remark))
+	}
+	if len(attrs) > 0 {
+		fields = append(fields, "; "+strings.Join(attrs, " "))
+	}
+	return strings.Join(fields, " ")
+}
+
+// zoneFileToken is a field of a zone file line, quoted tokens are character-strings.
+type zoneFileToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeZoneFileLine splits a line into its fields and the comment following them.
+func tokenizeZoneFileLine(line string) (tokens []zoneFileToken, comment string, err error) {
+	for i := 0; i < len(line); {
+		switch c := line[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == ';':
+			return tokens, strings.TrimSpace(line[i+1:]), nil
+		case c == '(' || c == ')':
+			return nil, "", fmt.Errorf("multi-line records are not supported")
+		case c == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+				}
+				b.WriteByte(line[i])
+			}
+			if i == len(line) {
+				return nil, "", fmt.Errorf("unterminated quoted string")
+			}
+			i++
+			tokens = append(tokens, zoneFileToken{text: b.String(), quoted: true})
+		default:
+			start := i
+			for i < len(line) && !strings.ContainsRune(" \t;\"()", rune(line[i])) {
+				i++
+			}
+			tokens = append(tokens, zoneFileToken{text: line[start:i]})
+		}
+	}
+	return tokens, "", nil
+}
+
+// parseZoneFile reads zones written by writeZoneFile and BIND zone files of other providers, every $ORIGIN starts
+// a zone. Owners and names in values may be relative to the origin, $TTL sets the TTL of records without one.
+func parseZoneFile(data string) ([]ExportedZone, error) {
+	var (
+		zones      []ExportedZone
+		zone       *ExportedZone
+		defaultTTL int32
+		owner      string
+	)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		tokens, comment, err := tokenizeZoneFileLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		if len(tokens) == 0 {
+			if zone != nil && zone.ZID == 0 && strings.HasPrefix(comment, "zid ") {
+				zone.ZID, _ = strconv.ParseInt(strings.TrimSpace(strings.TrimPrefix(comment, "zid ")), 10, 64)
+			}
+			continue
+		}
+		switch strings.ToUpper(tokens[0].text) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN expects a domain name", n)
+			}
+			zones = append(zones, ExportedZone{Zone: strings.TrimSuffix(tokens[1].text, ".")})
+			zone, owner = &zones[len(zones)-1], ""
+			continue
+		case "$TTL":
+			ttl, err := parseZoneFileTTL(tokens)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			defaultTTL = ttl
+			continue
+		}
+		if zone == nil {
+			return nil, fmt.Errorf("line %d: record before $ORIGIN", n)
+		}
+		// a line starting with a blank continues the owner of the previous record
+		if line[0] != ' ' && line[0] != '\t' {
+			owner = zoneFileHost(tokens[0].text, zone.Zone)
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: record without owner", n)
+		}
+		record, err := parseZoneFileRecord(tokens, zone.Zone, defaultTTL)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		record.Host = owner
+		if err := parseZoneFileAttributes(&record, comment); err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		zone.Records = append(zone.Records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+func parseZoneFileTTL(tokens []zoneFileToken) (int32, error) {
+	if len(tokens) != 2 {
+		return 0, fmt.Errorf("$TTL expects a number of seconds")
+	}
+	ttl, err := strconv.ParseInt(tokens[1].text, 10, 32)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid TTL %q", tokens[1].text)
+	}
+	return int32(ttl), nil
+}
+
+// zoneFileHost returns the privatezone host of a zone file name: "@" for the origin, names are made relative.
+func zoneFileHost(name, zoneName string) string {
+	if name == "@" || strings.EqualFold(strings.TrimSuffix(name, "."), zoneName) {
+		return nullHostPrivateZone
+	}
+	if !strings.HasSuffix(name, ".") {
+		return name
+	}
+	name = strings.TrimSuffix(name, ".")
+	if suffix := "." + zoneName; len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)]
+	}
+	// a name outside the zone is kept absolute and rejected by privatezone
+	return name + "."
+}
+
+// zoneFileName returns the absolute name, without trailing dot, of a name in a value.
+func zoneFileName(name, zoneName string) string {
+	switch {
+	case name == "@":
+		return zoneName
+	case name == "." || strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + zoneName
+}
+
+// parseZoneFileRecord parses the optional TTL and class, in either order, the type and the value of a record.
+func parseZoneFileRecord(tokens []zoneFileToken, zoneName string, defaultTTL int32) (ExportedRecord, error) {
+	record := ExportedRecord{TTL: defaultTTL}
+	for len(tokens) > 0 && !tokens[0].quoted {
+		if strings.EqualFold(tokens[0].text, "IN") {
+			tokens = tokens[1:]
+			continue
+		}
+		ttl, err := strconv.ParseInt(tokens[0].text, 10, 32)
+		if err != nil {
+			break
+		}
+		record.TTL = int32(ttl)
+		tokens = tokens[1:]
+	}
+	if len(tokens) < 2 {
+		return record, fmt.Errorf("record without type and value")
+	}
+	record.Type = strings.ToUpper(tokens[0].text)
+	tokens = tokens[1:]
+	if record.Type == endpoint.RecordTypeTXT {
+		var b strings.Builder
+		for _, t := range tokens {
+			b.WriteString(t.text)
+		}
+		record.Value = encodeTXTValue(b.String())
+		return record, nil
+	}
+	fields := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		fields = append(fields, t.text)
+	}
+	if nameValuedRecordTypes[record.Type] {
+		last := len(fields) - 1
+		fields[last] = zoneFileName(fields[last], zoneName)
+	}
+	record.Value = TargetDotStrip.apply(record.Type, strings.Join(fields, " "))
+	return record, nil
+}
+
+// parseZoneFileAttributes sets the weight, line and remark written in the comment following a record.
+// Comments of other zone files are ignored unless they consist of key=value pairs only.
+func parseZoneFileAttributes(record *ExportedRecord, comment string) error {
+	if comment == "" {
+		return nil
+	}
+	tokens, _, err := tokenizeZoneFileLine(comment)
+	if err != nil {
+		return nil
+	}
+	attrs := make(map[string]string, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		key, value, ok := strings.Cut(tokens[i].text, "=")
+		if !ok || tokens[i].quoted {
+			return nil
+		}
+		// a quoted value, e.g. of remark="a b", is the next token
+		if value == "" && i+1 < len(tokens) && tokens[i+1].quoted {
+			i++
+			value = tokens[i].text
+		}
+		attrs[key] = value
+	}
+	for key, value := range attrs {
+		switch key {
+		case "weight":
+			weight, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid weight %q", value)
+			}
+			record.Weight = int32(weight)
+		case "line":
+			record.Line = value
+		case "remark":
+			record.Remark = value
+		}
+	}
+	return nil
+}
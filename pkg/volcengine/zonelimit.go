@@ -0,0 +1,149 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"sync"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+)
+
+// zoneLimitedClient bounds the mutating calls in flight per zone, PrivateZone locks a zone while it is written
+// so concurrent batch writes to one zone conflict. Calls to different zones are not limited by each other,
+// list calls pass through.
+type zoneLimitedClient struct {
+	client privateZoneClient
+	limit  int
+
+	mu    sync.Mutex
+	zones map[int64]chan struct{}
+}
+
+var _ privateZoneClient = &zoneLimitedClient{}
+
+func newZoneLimitedClient(client privateZoneClient, limit int) *zoneLimitedClient {
+	return &zoneLimitedClient{client: client, limit: limit, zones: map[int64]chan struct{}{}}
+}
+
+// acquire waits for a write slot of the zone, the returned func releases it.
+func (c *zoneLimitedClient) acquire(ctx context.Context, zid *int64) (func(), error) {
+	id := volcengine.Int64Value(zid)
+	c.mu.Lock()
+	slots, ok := c.zones[id]
+	if !ok {
+		slots = make(chan struct{}, c.limit)
+		c.zones[id] = slots
+	}
+	c.mu.Unlock()
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *zoneLimitedClient) ListPrivateZonesWithContext(ctx context.Context, input *privatezone.ListPrivateZonesInput, options ...request.Option) (*privatezone.ListPrivateZonesOutput, error) {
+	return c.client.ListPrivateZonesWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) ListRecordsWithContext(ctx context.Context, input *privatezone.ListRecordsInput, options ...request.Option) (*privatezone.ListRecordsOutput, error) {
+	return c.client.ListRecordsWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) QueryPrivateZoneWithContext(ctx context.Context, input *privatezone.QueryPrivateZoneInput, options ...request.Option) (*privatezone.QueryPrivateZoneOutput, error) {
+	return c.client.QueryPrivateZoneWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) CreateRecordWithContext(ctx context.Context, input *privatezone.CreateRecordInput, options ...request.Option) (*privatezone.CreateRecordOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.CreateRecordWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) UpdateRecordWithContext(ctx context.Context, input *privatezone.UpdateRecordInput, options ...request.Option) (*privatezone.UpdateRecordOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.UpdateRecordWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) BatchCreateRecordWithContext(ctx context.Context, input *privatezone.BatchCreateRecordInput, options ...request.Option) (*privatezone.BatchCreateRecordOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.BatchCreateRecordWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) BatchDeleteRecordWithContext(ctx context.Context, input *privatezone.BatchDeleteRecordInput, options ...request.Option) (*privatezone.BatchDeleteRecordOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.BatchDeleteRecordWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) DeleteRecordWithContext(ctx context.Context, input *privatezone.DeleteRecordInput, options ...request.Option) (*privatezone.DeleteRecordOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.DeleteRecordWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) UpdatePrivateZoneWithContext(ctx context.Context, input *privatezone.UpdatePrivateZoneInput, options ...request.Option) (*privatezone.UpdatePrivateZoneOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.UpdatePrivateZoneWithContext(ctx, input, options...)
+}
+
+// CreatePrivateZoneWithContext is not limited, the zone does not exist yet.
+func (c *zoneLimitedClient) CreatePrivateZoneWithContext(ctx context.Context, input *privatezone.CreatePrivateZoneInput, options ...request.Option) (*privatezone.CreatePrivateZoneOutput, error) {
+	return c.client.CreatePrivateZoneWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) DeletePrivateZoneWithContext(ctx context.Context, input *privatezone.DeletePrivateZoneInput, options ...request.Option) (*privatezone.DeletePrivateZoneOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.DeletePrivateZoneWithContext(ctx, input, options...)
+}
+
+func (c *zoneLimitedClient) IncBindVPCWithContext(ctx context.Context, input *privatezone.IncBindVPCInput, options ...request.Option) (*privatezone.IncBindVPCOutput, error) {
+	release, err := c.acquire(ctx, input.ZID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.IncBindVPCWithContext(ctx, input, options...)
+}
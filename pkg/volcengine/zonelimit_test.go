@@ -0,0 +1,76 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/response"
+)
+
+func TestZoneLimitedClient(t *testing.T) {
+	started := make(chan int64, 4)
+	unblock := make(chan struct{})
+	mockClient := &MockClient{
+		BatchCreateRecordFunc: func(ctx context.Context, input *privatezone.BatchCreateRecordInput) (*privatezone.BatchCreateRecordOutput, error) {
+			started <- volcengine.Int64Value(input.ZID)
+			if volcengine.Int64Value(input.ZID) == 1 {
+				<-unblock
+			}
+			return &privatezone.BatchCreateRecordOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+		ListRecordsFunc: func(ctx context.Context, input *privatezone.ListRecordsInput) (*privatezone.ListRecordsOutput, error) {
+			return &privatezone.ListRecordsOutput{Metadata: &response.ResponseMetadata{}}, nil
+		},
+	}
+	c := newZoneLimitedClient(mockClient, 1)
+	ctx := context.Background()
+
+	// The first write to zone 1 holds its only slot
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.BatchCreateRecordWithContext(ctx, &privatezone.BatchCreateRecordInput{ZID: volcengine.Int64(1)})
+		done <- err
+	}()
+	require.Equal(t, int64(1), <-started)
+
+	// Other zones and reads are not held back
+	_, err := c.BatchCreateRecordWithContext(ctx, &privatezone.BatchCreateRecordInput{ZID: volcengine.Int64(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), <-started)
+	_, err = c.ListRecordsWithContext(ctx, &privatezone.ListRecordsInput{ZID: volcengine.Int64(1)})
+	assert.NoError(t, err)
+
+	// A second write to zone 1 waits, a context ending first aborts it without calling the API
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = c.BatchCreateRecordWithContext(timeout, &privatezone.BatchCreateRecordInput{ZID: volcengine.Int64(1)})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, started)
+
+	// Once the first write returned the slot is free again
+	close(unblock)
+	require.NoError(t, <-done)
+	_, err = c.BatchCreateRecordWithContext(ctx, &privatezone.BatchCreateRecordInput{ZID: volcengine.Int64(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), <-started)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+// ZoneStats summarizes the records of a zone.
+type ZoneStats struct {
+	Records  int
+	Disabled int
+	// Managed records carry the remark written by the webhook, Unmanaged were created by someone else
+	Managed   int
+	Unmanaged int
+	// Types counts the records of each record type
+	Types map[string]int
+	// TTLs counts the records of each TTL
+	TTLs map[int]int
+	// LastUpdated is the latest update time of a record, zero if no record reports one
+	LastUpdated     time.Time
+	LastUpdatedHost string
+}
+
+// IsManagedRemark reports whether a record remark was written by the webhook.
+func IsManagedRemark(remark string) bool {
+	head, _, _ := strings.Cut(remark, remarkSeparator)
+	return isManagedRemarkHead(head)
+}
+
+// ComputeZoneStats summarizes the records of a zone, update times that do not parse as RFC 3339 are ignored.
+func ComputeZoneStats(records []*privatezone.RecordForListRecordsOutput) *ZoneStats {
+	stats := &ZoneStats{
+		Types: make(map[string]int),
+		TTLs:  make(map[int]int),
+	}
+	for _, record := range records {
+		stats.Records++
+		if record.Enable != nil && !*record.Enable {
+			stats.Disabled++
+		}
+		if IsManagedRemark(volcengine.StringValue(record.Remark)) {
+			stats.Managed++
+		} else {
+			stats.Unmanaged++
+		}
+		stats.Types[volcengine.StringValue(record.Type)]++
+		stats.TTLs[int(volcengine.Int32Value(record.TTL))]++
+		updated, err := time.Parse(time.RFC3339, volcengine.StringValue(record.UpdatedAt))
+		if err == nil && updated.After(stats.LastUpdated) {
+			stats.LastUpdated = updated
+			stats.LastUpdatedHost = volcengine.StringValue(record.Host)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package volcengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+)
+
+func TestIsManagedRemark(t *testing.T) {
+	assert.True(t, IsManagedRemark(defaultRecordRemark))
+	assert.True(t, IsManagedRemark((&Provider{}).encodeRemark(nil, "blue")))
+	assert.False(t, IsManagedRemark(""))
+	assert.False(t, IsManagedRemark("managed by external-dns-other"))
+	assert.False(t, IsManagedRemark("created by hand"))
+}
+
+func TestComputeZoneStats(t *testing.T) {
+	record := func(host, recordType string, ttl int32, remark, updated string, enable bool) *privatezone.RecordForListRecordsOutput {
+		return &privatezone.RecordForListRecordsOutput{
+			Host:      volcengine.String(host),
+			Type:      volcengine.String(recordType),
+			TTL:       volcengine.Int32(ttl),
+			Remark:    volcengine.String(remark),
+			UpdatedAt: volcengine.String(updated),
+			Enable:    volcengine.Bool(enable),
+		}
+	}
+	stats := ComputeZoneStats([]*privatezone.RecordForListRecordsOutput{
+		record("www", "A", 300, defaultRecordRemark, "2025-03-01T10:00:00Z", true),
+		record("www", "A", 300, defaultRecordRemark, "2025-03-02T10:00:00Z", true),
+		record("txt-www", "TXT", 600, (&Provider{}).encodeRemark(nil, "blue"), "2025-02-01T10:00:00Z", true),
+		record("legacy", "CNAME", 600, "", "not a time", false),
+	})
+
+	assert.Equal(t, 4, stats.Records)
+	assert.Equal(t, 1, stats.Disabled)
+	assert.Equal(t, 3, stats.Managed)
+	assert.Equal(t, 1, stats.Unmanaged)
+	assert.Equal(t, map[string]int{"A": 2, "TXT": 1, "CNAME": 1}, stats.Types)
+	assert.Equal(t, map[int]int{300: 2, 600: 2}, stats.TTLs)
+	assert.Equal(t, time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC), stats.LastUpdated)
+	assert.Equal(t, "www", stats.LastUpdatedHost)
+
+	empty := ComputeZoneStats(nil)
+	assert.Zero(t, empty.Records)
+	assert.True(t, empty.LastUpdated.IsZero())
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+func TestWithAuthToken(t *testing.T) {
+	h := NewHandler(&fakeProvider{}, WithAuthToken("", "s3cret"))
+
+	cases := []struct {
+		name   string
+		path   string
+		header func(req *http.Request)
+		status int
+	}{
+		{name: "no token", path: api.UrlRecords, status: http.StatusUnauthorized},
+		{name: "wrong bearer", path: api.UrlRecords, header: func(req *http.Request) { req.Header.Set("Authorization", "Bearer nope") }, status: http.StatusUnauthorized},
+		{name: "bearer", path: api.UrlRecords, header: func(req *http.Request) { req.Header.Set("Authorization", "bearer s3cret") }, status: http.StatusOK},
+		{name: "basic auth password", path: "/", header: func(req *http.Request) { req.SetBasicAuth("external-dns", "s3cret") }, status: http.StatusOK},
+		{name: "wrong basic auth password", path: "/", header: func(req *http.Request) { req.SetBasicAuth("s3cret", "nope") }, status: http.StatusUnauthorized},
+		{name: "probe", path: UrlHealthz, status: http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, c.path, nil)
+			req.Header.Set(acceptHeader, api.MediaTypeFormatAndVersion)
+			if c.header != nil {
+				c.header(req)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, c.status, rec.Code)
+			if c.status == http.StatusUnauthorized {
+				var resp ErrorResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, ErrCodeUnauthorized, resp.Code)
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestWithAuthTokenCustomHeader(t *testing.T) {
+	h := NewHandler(&fakeProvider{}, WithAuthToken("X-Webhook-Token", "s3cret"))
+
+	rec := doRequest(h, http.MethodGet, api.UrlRecords, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, rec.Header().Get("WWW-Authenticate"))
+
+	req := httptest.NewRequest(http.MethodGet, api.UrlRecords, nil)
+	req.Header.Set("X-Webhook-Token", "s3cret")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// the bearer token is only accepted in the Authorization header
+	req = httptest.NewRequest(http.MethodGet, api.UrlRecords, nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
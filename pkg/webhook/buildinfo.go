@@ -0,0 +1,62 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Version and Commit are set at build time with
+// -ldflags "-X volcengine-provider/pkg/webhook.Version=v1.2.3 -X volcengine-provider/pkg/webhook.Commit=abc123",
+// Commit falls back to the VCS revision stamped by the go toolchain.
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+var buildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "volcengine_webhook_build_info",
+	Help: "Build and configuration of the running webhook, always 1.",
+}, []string{"version", "commit", "goversion", "config_hash"})
+
+func init() {
+	prometheus.MustRegister(buildInfo)
+}
+
+// BuildCommit returns Commit, or the VCS revision stamped into the binary, or "unknown".
+func BuildCommit() string {
+	if Commit != "" {
+		return Commit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
+
+// SetBuildInfo exports the volcengine_webhook_build_info metric with the hash of the running configuration,
+// so dashboards can tell which build and configuration each webhook runs.
+func SetBuildInfo(configHash string) {
+	buildInfo.Reset()
+	buildInfo.WithLabelValues(Version, BuildCommit(), runtime.Version(), configHash).Set(1)
+}
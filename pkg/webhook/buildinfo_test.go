@@ -0,0 +1,40 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBuildInfo(t *testing.T) {
+	defer func(version, commit string) { Version, Commit = version, commit }(Version, Commit)
+	Version, Commit = "v1.2.3", "abc123"
+
+	SetBuildInfo("0123456789ab")
+	SetBuildInfo("ba9876543210")
+
+	expected := `
+# HELP volcengine_webhook_build_info Build and configuration of the running webhook, always 1.
+# TYPE volcengine_webhook_build_info gauge
+volcengine_webhook_build_info{commit="abc123",config_hash="ba9876543210",goversion="` + runtime.Version() + `",version="v1.2.3"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(buildInfo, strings.NewReader(expected)))
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// UrlDebugState is the internal endpoint dumping the provider state.
+const UrlDebugState = "/debug/state"
+
+// NewDebugHandler returns the handler of the internal listener, serving the JSON of state() on /debug/state.
+// It exposes zone names and planned changes, so it must not be served on the webhook port.
+func NewDebugHandler(state func() any) http.Handler {
+	m := http.NewServeMux()
+	m.HandleFunc(UrlDebugState, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(state()); err != nil {
+			log.Errorf("Failed to encode debug state: %v", err)
+		}
+	})
+	return m
+}
+
+// StartDebugListener serves handler on addr until the listener fails, the error is logged.
+func StartDebugListener(addr string, handler http.Handler) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.Serve(l, handler); err != nil {
+			log.Errorf("Debug listener on %s stopped: %v", addr, err)
+		}
+	}()
+	return nil
+}
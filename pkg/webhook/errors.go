@@ -0,0 +1,74 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"volcengine-provider/pkg/volcengine"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// Error codes of ErrorResponse that are not produced by the provider.
+const (
+	ErrCodeInternal             = "InternalError"
+	ErrCodeInvalidRequest       = "InvalidRequest"
+	ErrCodeNotAcceptable        = "NotAcceptable"
+	ErrCodeUnsupportedMediaType = "UnsupportedMediaType"
+	ErrCodeMethodNotAllowed     = "MethodNotAllowed"
+	ErrCodeNotLeader            = "NotLeader"
+	ErrCodeUnauthorized         = "Unauthorized"
+)
+
+// ErrorResponse is the JSON body returned when a webhook request fails.
+type ErrorResponse struct {
+	Code      string               `json:"code"`
+	Message   string               `json:"message"`
+	Endpoints []*endpoint.Endpoint `json:"endpoints,omitempty"`
+}
+
+// writeError writes an ErrorResponse with the given status.
+func writeError(w http.ResponseWriter, status int, code, message string, endpoints ...*endpoint.Endpoint) {
+	w.Header().Set(api.ContentTypeHeader, api.MediaTypeFormatAndVersion)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(ErrorResponse{
+		Code:      code,
+		Message:   message,
+		Endpoints: endpoints,
+	}); err != nil {
+		log.Errorf("Failed to encode error response: %v", err)
+	}
+}
+
+// writeProviderError writes err as an ErrorResponse, reporting the failed endpoints of a provider ChangeError.
+// Endpoints of record types the zones do not accept are a client error, other failures a server error.
+func writeProviderError(w http.ResponseWriter, err error) {
+	var changeErr *volcengine.ChangeError
+	if errors.As(err, &changeErr) {
+		status := http.StatusInternalServerError
+		if changeErr.Code == volcengine.ErrCodeUnsupportedRecordType {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, changeErr.Code, changeErr.Err.Error(), changeErr.Endpoints...)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+}
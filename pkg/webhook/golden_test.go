@@ -0,0 +1,121 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"volcengine-provider/pkg/fakepz"
+	"volcengine-provider/pkg/volcengine"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/privatezone"
+	sdk "github.com/volcengine/volcengine-go-sdk/volcengine"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenProvider runs the provider against an in-memory PrivateZone API holding a representative record set.
+func goldenProvider(t *testing.T) *volcengine.Provider {
+	store := fakepz.NewStore()
+	zid := store.AddZone("example.com", "vpc-golden")
+	for _, r := range []struct {
+		host, recordType, value string
+		ttl                     int32
+	}{
+		{"www", "A", "10.0.0.1", 300},
+		{"www", "A", "10.0.0.2", 300},
+		{"api", "CNAME", "lb.example.com", 600},
+		{"a-api", "TXT", "\"heritage=external-dns,external-dns/owner=default,external-dns/resource=service/default/api\"", 600},
+		{"v6", "AAAA", "fd00::1", 60},
+		{"@", "MX", "10 mail.example.com", 3600},
+	} {
+		_, err := store.CreateRecord(zid, &privatezone.RecordForBatchCreateRecordInput{
+			Host:  sdk.String(r.host),
+			Type:  sdk.String(r.recordType),
+			Value: sdk.String(r.value),
+			TTL:   sdk.Int32(r.ttl),
+		})
+		require.NoError(t, err)
+	}
+	api := httptest.NewServer(fakepz.NewServer(store, "cn-beijing"))
+	t.Cleanup(api.Close)
+
+	p, err := volcengine.NewVolcengineProvider([]volcengine.Option{
+		volcengine.WithPrivateZone("cn-beijing", "vpc-golden"),
+		volcengine.WithPrivateZoneEndpoint(api.URL),
+		volcengine.WithStaticCredentials("ak", "sk"),
+	})
+	require.NoError(t, err)
+	return p
+}
+
+// assertGolden compares the indented JSON body with testdata/name, rewriting it with -update.
+func assertGolden(t *testing.T, name string, body []byte) {
+	var got bytes.Buffer
+	require.NoError(t, json.Indent(&got, body, "", "  "))
+	got.WriteByte('\n')
+
+	file := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(file, got.Bytes(), 0o644))
+	}
+	want, err := os.ReadFile(file)
+	require.NoError(t, err, "run go test ./pkg/webhook -update to create the golden file")
+	assert.Equal(t, string(want), got.String(), "wire format of %s changed, run go test ./pkg/webhook -update if intended", name)
+}
+
+func TestGoldenRecords(t *testing.T) {
+	h := NewHandler(goldenProvider(t))
+
+	rec := doRequest(h, http.MethodGet, api.UrlRecords, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, api.MediaTypeFormatAndVersion, rec.Header().Get(api.ContentTypeHeader))
+	assertGolden(t, "records.json", rec.Body.Bytes())
+}
+
+func TestGoldenAdjustEndpoints(t *testing.T) {
+	h := NewHandler(goldenProvider(t))
+
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "10.0.0.1", "10.0.0.2"),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.com"),
+		endpoint.NewEndpoint("a-api.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=default\""),
+		endpoint.NewEndpoint("blue.example.com", endpoint.RecordTypeA, "10.0.1.1").
+			WithSetIdentifier("blue").
+			WithProviderSpecific("weight", "10"),
+		endpoint.NewEndpointWithTTL("short.example.com", endpoint.RecordTypeA, 1, "10.0.2.1", "10.0.2.1"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.example.com"),
+	}
+	body, err := json.Marshal(endpoints)
+	require.NoError(t, err)
+
+	rec := doRequest(h, http.MethodPost, api.UrlAdjustEndpoints, string(body))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, api.MediaTypeFormatAndVersion, rec.Header().Get(api.ContentTypeHeader))
+	assertGolden(t, "adjustendpoints.json", rec.Body.Bytes())
+}
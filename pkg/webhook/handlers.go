@@ -0,0 +1,115 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// handlers serves the webhook API, failures are reported as ErrorResponse bodies.
+type handlers struct {
+	provider provider.Provider
+	health   *Health
+	leader   Leader
+	startup  *Startup
+	// webhook API requests must present authToken in authHeader when set
+	authHeader string
+	authToken  string
+}
+
+func (h *handlers) negotiate(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set(api.ContentTypeHeader, api.MediaTypeFormatAndVersion)
+	if err := json.NewEncoder(w).Encode(h.provider.GetDomainFilter()); err != nil {
+		log.Errorf("Failed to encode domain filter: %v", err)
+	}
+}
+
+func (h *handlers) records(w http.ResponseWriter, req *http.Request) {
+	logger := requestLog(req)
+	switch req.Method {
+	case http.MethodGet:
+		records, err := h.provider.Records(req.Context())
+		h.health.Observe(err)
+		h.startup.observe(err)
+		if err != nil {
+			logger.Errorf("Failed to get Records: %v", err)
+			writeProviderError(w, err)
+			return
+		}
+		w.Header().Set(api.ContentTypeHeader, api.MediaTypeFormatAndVersion)
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(records); err != nil {
+			logger.Errorf("Failed to encode records: %v", err)
+		}
+	case http.MethodPost:
+		if h.leader != nil && !h.leader.IsLeader() {
+			logger.Warnf("Rejecting changes, the leader is %q", h.leader.Leader())
+			writeError(w, http.StatusServiceUnavailable, ErrCodeNotLeader,
+				fmt.Sprintf("this replica is not the leader, changes are applied by %q", h.leader.Leader()))
+			return
+		}
+		var changes plan.Changes
+		if err := json.NewDecoder(req.Body).Decode(&changes); err != nil {
+			logger.Errorf("Failed to decode changes: %v", err)
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("failed to decode changes: %v", err))
+			return
+		}
+		err := h.provider.ApplyChanges(req.Context(), &changes)
+		h.health.Observe(err)
+		if err != nil {
+			logger.Errorf("Failed to apply changes: %v", err)
+			writeProviderError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		logger.Errorf("Unsupported method %s", req.Method)
+		writeError(w, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, fmt.Sprintf("method %s is not supported", req.Method))
+	}
+}
+
+func (h *handlers) adjustEndpoints(w http.ResponseWriter, req *http.Request) {
+	logger := requestLog(req)
+	if req.Method != http.MethodPost {
+		logger.Errorf("Unsupported method %s", req.Method)
+		writeError(w, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, fmt.Sprintf("method %s is not supported", req.Method))
+		return
+	}
+	var endpoints []*endpoint.Endpoint
+	if err := json.NewDecoder(req.Body).Decode(&endpoints); err != nil {
+		logger.Errorf("Failed to decode endpoints: %v", err)
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("failed to decode endpoints: %v", err))
+		return
+	}
+	endpoints, err := h.provider.AdjustEndpoints(endpoints)
+	if err != nil {
+		logger.Errorf("Failed to adjust endpoints: %v", err)
+		writeProviderError(w, err)
+		return
+	}
+	w.Header().Set(api.ContentTypeHeader, api.MediaTypeFormatAndVersion)
+	if err := json.NewEncoder(w).Encode(&endpoints); err != nil {
+		logger.Errorf("Failed to encode adjusted endpoints: %v", err)
+	}
+}
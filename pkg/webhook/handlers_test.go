@@ -0,0 +1,202 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"volcengine-provider/pkg/volcengine"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// fakeProvider returns the configured records and error.
+type fakeProvider struct {
+	provider.BaseProvider
+	records []*endpoint.Endpoint
+	err     error
+}
+
+func (p *fakeProvider) Records(context.Context) ([]*endpoint.Endpoint, error) {
+	return p.records, p.err
+}
+
+func (p *fakeProvider) ApplyChanges(context.Context, *plan.Changes) error {
+	return p.err
+}
+
+func doRequest(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(acceptHeader, api.MediaTypeFormatAndVersion)
+	if method == http.MethodPost {
+		req.Header.Set(api.ContentTypeHeader, api.MediaTypeFormatAndVersion)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestApplyChangesErrorResponse(t *testing.T) {
+	failed := endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")
+	p := &fakeProvider{err: fmt.Errorf("apply: %w", &volcengine.ChangeError{
+		Code:      volcengine.ErrCodeCreateFailed,
+		Endpoints: []*endpoint.Endpoint{failed},
+		Err:       errors.New("quota exceeded"),
+	})}
+
+	rec := doRequest(NewHandler(p), http.MethodPost, api.UrlRecords, "{}")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, api.MediaTypeFormatAndVersion, rec.Header().Get(api.ContentTypeHeader))
+
+	var resp ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, volcengine.ErrCodeCreateFailed, resp.Code)
+	assert.Equal(t, "quota exceeded", resp.Message)
+	assert.Len(t, resp.Endpoints, 1)
+	assert.Equal(t, "www.example.com", resp.Endpoints[0].DNSName)
+}
+
+func TestErrorResponses(t *testing.T) {
+	cases := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		err     error
+		status  int
+		code    string
+		message string
+	}{{
+		name:    "records error",
+		method:  http.MethodGet,
+		path:    api.UrlRecords,
+		err:     errors.New("list failed"),
+		status:  http.StatusInternalServerError,
+		code:    ErrCodeInternal,
+		message: "list failed",
+	}, {
+		name:    "invalid changes",
+		method:  http.MethodPost,
+		path:    api.UrlRecords,
+		body:    "not json",
+		status:  http.StatusBadRequest,
+		code:    ErrCodeInvalidRequest,
+		message: "failed to decode changes",
+	}, {
+		name:    "invalid endpoints",
+		method:  http.MethodPost,
+		path:    api.UrlAdjustEndpoints,
+		body:    "{",
+		status:  http.StatusBadRequest,
+		code:    ErrCodeInvalidRequest,
+		message: "failed to decode endpoints",
+	}, {
+		name:   "unsupported record type",
+		method: http.MethodPost,
+		path:   api.UrlRecords,
+		body:   "{}",
+		err: &volcengine.ChangeError{Code: volcengine.ErrCodeUnsupportedRecordType,
+			Err: errors.New("the record types are not supported by the managed zones")},
+		status:  http.StatusBadRequest,
+		code:    volcengine.ErrCodeUnsupportedRecordType,
+		message: "not supported",
+	}, {
+		name:    "unsupported method",
+		method:  http.MethodDelete,
+		path:    api.UrlRecords,
+		status:  http.StatusMethodNotAllowed,
+		code:    ErrCodeMethodNotAllowed,
+		message: "DELETE",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := doRequest(NewHandler(&fakeProvider{err: tc.err}), tc.method, tc.path, tc.body)
+			assert.Equal(t, tc.status, rec.Code)
+			var resp ErrorResponse
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, tc.code, resp.Code)
+			assert.Contains(t, resp.Message, tc.message)
+		})
+	}
+}
+
+func TestHandlersSuccess(t *testing.T) {
+	p := &fakeProvider{records: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")}}
+	h := NewHandler(p)
+
+	rec := doRequest(h, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doRequest(h, http.MethodGet, api.UrlRecords, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "www.example.com")
+
+	rec = doRequest(h, http.MethodPost, api.UrlRecords, "{}")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = doRequest(h, http.MethodPost, api.UrlAdjustEndpoints, "[]")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doRequest(h, http.MethodGet, UrlMetrics, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "volcengine_privatezone_")
+}
+
+// fakeLeader is a fixed leadership state.
+type fakeLeader struct {
+	leading bool
+}
+
+func (l *fakeLeader) IsLeader() bool {
+	return l.leading
+}
+
+func (l *fakeLeader) Leader() string {
+	return "pod-a"
+}
+
+func TestApplyChangesNotLeader(t *testing.T) {
+	l := &fakeLeader{}
+	p := &fakeProvider{records: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")}}
+	h := NewHandler(p, WithLeaderElection(l))
+
+	rec := doRequest(h, http.MethodPost, api.UrlRecords, "{}")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, ErrCodeNotLeader, resp.Code)
+	assert.Contains(t, resp.Message, "pod-a")
+
+	// Followers serve reads
+	rec = doRequest(h, http.MethodGet, api.UrlRecords, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	l.leading = true
+	rec = doRequest(h, http.MethodPost, api.UrlRecords, "{}")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// UrlHealthz is the liveness endpoint.
+	UrlHealthz = "/healthz"
+
+	ErrCodeUnhealthy = "Unhealthy"
+)
+
+// Health tracks consecutive backend failures and reports unhealthy once they reach the threshold,
+// so Kubernetes restarts a pod with a wedged SDK session or expired credentials. Checks added with
+// AddCheck report unhealthy while they fail.
+type Health struct {
+	threshold int
+	checks    []healthCheck
+
+	mu        sync.Mutex
+	failures  int
+	lastError error
+}
+
+type healthCheck struct {
+	name  string
+	check func() error
+}
+
+// NewHealth returns a Health that turns unhealthy after threshold consecutive failures, 0 never turns unhealthy.
+func NewHealth(threshold int) *Health {
+	return &Health{threshold: threshold}
+}
+
+// AddCheck adds a check run on every probe, e.g. that a mounted credentials file is still valid.
+// It must be called before the health is served.
+func (h *Health) AddCheck(name string, check func() error) {
+	h.checks = append(h.checks, healthCheck{name: name, check: check})
+}
+
+// Observe records the result of a backend call, a success resets the failure count.
+func (h *Health) Observe(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err == nil {
+		h.failures = 0
+		h.lastError = nil
+		return
+	}
+	h.failures++
+	h.lastError = err
+	if h.threshold > 0 && h.failures == h.threshold {
+		log.Errorf("Marking webhook unhealthy after %d consecutive backend failures, last error: %v", h.failures, err)
+	}
+}
+
+// Healthy reports whether the consecutive failures are below the threshold and every check passes.
+func (h *Health) Healthy() bool {
+	return h.unhealthyReason() == ""
+}
+
+// unhealthyReason returns why the webhook is unhealthy, "" if it is healthy.
+func (h *Health) unhealthyReason() string {
+	for _, c := range h.checks {
+		if err := c.check(); err != nil {
+			return fmt.Sprintf("%s check failed: %v", c.name, err)
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.threshold > 0 && h.failures >= h.threshold {
+		return fmt.Sprintf("%d consecutive backend failures, last error: %v", h.failures, h.lastError)
+	}
+	return ""
+}
+
+// ServeHTTP serves the liveness probe.
+func (h *Health) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if reason := h.unhealthyReason(); reason != "" {
+		writeError(w, http.StatusServiceUnavailable, ErrCodeUnhealthy, reason)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+func TestHealth(t *testing.T) {
+	h := NewHealth(2)
+	assert.True(t, h.Healthy())
+
+	h.Observe(errors.New("timeout"))
+	assert.True(t, h.Healthy())
+	h.Observe(nil)
+	h.Observe(errors.New("timeout"))
+	assert.True(t, h.Healthy())
+	h.Observe(errors.New("credentials expired"))
+	assert.False(t, h.Healthy())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, UrlHealthz, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, ErrCodeUnhealthy, resp.Code)
+	assert.Equal(t, "2 consecutive backend failures, last error: credentials expired", resp.Message)
+
+	h.Observe(nil)
+	assert.True(t, h.Healthy())
+}
+
+func TestHealthDisabled(t *testing.T) {
+	h := NewHealth(0)
+	for i := 0; i < 10; i++ {
+		h.Observe(errors.New("timeout"))
+	}
+	assert.True(t, h.Healthy())
+}
+
+func TestHealthCheck(t *testing.T) {
+	h := NewHealth(0)
+	var checkErr error
+	h.AddCheck("oidc token", func() error { return checkErr })
+	assert.True(t, h.Healthy())
+
+	checkErr = errors.New("token expired")
+	assert.False(t, h.Healthy())
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, UrlHealthz, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "oidc token check failed: token expired", resp.Message)
+
+	checkErr = nil
+	assert.True(t, h.Healthy())
+}
+
+func TestHealthzHandler(t *testing.T) {
+	h := NewHealth(1)
+	handler := NewHandler(&fakeProvider{err: errors.New("timeout")}, WithHealth(h))
+
+	req := httptest.NewRequest(http.MethodGet, UrlHealthz, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// A failed Records call is observed by the health
+	rec = doRequest(handler, http.MethodGet, api.UrlRecords, "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
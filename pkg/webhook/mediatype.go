@@ -0,0 +1,77 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+const (
+	acceptHeader     = "Accept"
+	webhookMediaType = "application/external.dns.webhook+json"
+	webhookVersion   = "1"
+)
+
+// withMediaType rejects requests whose Accept or Content-Type header names another
+// webhook media type or version, so incompatible external-dns versions fail loudly.
+// Requests without the headers are accepted.
+func withMediaType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if accept := req.Header.Get(acceptHeader); accept != "" && !acceptsWebhookMediaType(accept) {
+			requestLog(req).Warnf("Rejecting %s %s with unsupported Accept header %q", req.Method, req.URL.Path, accept)
+			writeError(w, http.StatusNotAcceptable, ErrCodeNotAcceptable,
+				fmt.Sprintf("unsupported Accept header %q, expected %s", accept, api.MediaTypeFormatAndVersion))
+			return
+		}
+		if req.Method == http.MethodPost {
+			if contentType := req.Header.Get(api.ContentTypeHeader); !isWebhookMediaType(contentType) {
+				requestLog(req).Warnf("Rejecting %s %s with unsupported Content-Type header %q", req.Method, req.URL.Path, contentType)
+				writeError(w, http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType,
+					fmt.Sprintf("unsupported Content-Type header %q, expected %s", contentType, api.MediaTypeFormatAndVersion))
+				return
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// acceptsWebhookMediaType reports whether any media range of the Accept header matches the webhook media type.
+func acceptsWebhookMediaType(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		if mediaType == "*/*" || mediaType == "application/*" || isWebhookMediaType(mediaRange) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWebhookMediaType reports whether value is the webhook media type with a supported version.
+func isWebhookMediaType(value string) bool {
+	mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	return mediaType == webhookMediaType && params["version"] == webhookVersion
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+func TestWithMediaType(t *testing.T) {
+	cases := []struct {
+		name        string
+		method      string
+		accept      string
+		contentType string
+		expected    int
+	}{{
+		name:     "negotiate with webhook media type",
+		method:   http.MethodGet,
+		accept:   api.MediaTypeFormatAndVersion,
+		expected: http.StatusOK,
+	}, {
+		name:     "no accept header",
+		method:   http.MethodGet,
+		expected: http.StatusOK,
+	}, {
+		name:     "wildcard accept header",
+		method:   http.MethodGet,
+		accept:   "text/html, */*;q=0.8",
+		expected: http.StatusOK,
+	}, {
+		name:     "future version",
+		method:   http.MethodGet,
+		accept:   "application/external.dns.webhook+json;version=2",
+		expected: http.StatusNotAcceptable,
+	}, {
+		name:        "apply changes with webhook media type",
+		method:      http.MethodPost,
+		accept:      api.MediaTypeFormatAndVersion,
+		contentType: api.MediaTypeFormatAndVersion,
+		expected:    http.StatusOK,
+	}, {
+		name:        "apply changes with plain json",
+		method:      http.MethodPost,
+		contentType: "application/json",
+		expected:    http.StatusUnsupportedMediaType,
+	}, {
+		name:     "apply changes without content type",
+		method:   http.MethodPost,
+		expected: http.StatusUnsupportedMediaType,
+	}}
+
+	handler := withMediaType(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, api.UrlRecords, strings.NewReader("{}"))
+			if tc.accept != "" {
+				req.Header.Set(acceptHeader, tc.accept)
+			}
+			if tc.contentType != "" {
+				req.Header.Set(api.ContentTypeHeader, tc.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tc.expected, rec.Code)
+			if tc.expected != http.StatusOK {
+				assert.Contains(t, rec.Body.String(), api.MediaTypeFormatAndVersion)
+			}
+		})
+	}
+}
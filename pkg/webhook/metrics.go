@@ -0,0 +1,58 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+// requestDuration is the latency of the webhook API requests of external-dns.
+var requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "volcengine_webhook_request_duration_seconds",
+	Help:    "Latency of the webhook API requests by path, method and status code.",
+	Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+}, []string{"path", "method", "code"})
+
+func init() {
+	prometheus.MustRegister(requestDuration)
+}
+
+// instrument observes the latency of the requests served by h under path.
+func instrument(path string, h http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerDuration(requestDuration.MustCurryWith(prometheus.Labels{"path": path}), h)
+}
+
+// StartMetricsListener serves /metrics on addr, apart from the webhook port, e.g. for a Prometheus scrape port
+// that is not exposed to external-dns. /metrics stays available on the webhook port as well.
+func StartMetricsListener(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	m := http.NewServeMux()
+	m.Handle(UrlMetrics, promhttp.Handler())
+	go func() {
+		if err := http.Serve(l, m); err != nil {
+			log.Errorf("Metrics listener on %s stopped: %v", addr, err)
+		}
+	}()
+	return nil
+}
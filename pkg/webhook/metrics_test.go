@@ -0,0 +1,61 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+func TestRequestDurationMetric(t *testing.T) {
+	h := NewHandler(&fakeProvider{records: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")}})
+	before := requestCount(t, api.UrlRecords, "get", "200")
+	rec := doRequest(h, http.MethodGet, api.UrlRecords, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, before+1, requestCount(t, api.UrlRecords, "get", "200"))
+}
+
+// requestCount returns the number of requests observed for the path, method and code.
+func requestCount(t *testing.T, path, method, code string) uint64 {
+	m := &dto.Metric{}
+	require.NoError(t, requestDuration.WithLabelValues(path, method, code).(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestStartMetricsListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	require.NoError(t, StartMetricsListener(addr))
+	resp, err := http.Get("http://" + addr + UrlMetrics)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "volcengine_webhook_request_duration_seconds")
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// UrlReadyz is the readiness endpoint, served on the probe listener only.
+	UrlReadyz = "/readyz"
+
+	ErrCodeNotReady = "NotReady"
+
+	// readyCheckTimeout bounds the check of one readiness probe.
+	readyCheckTimeout = 5 * time.Second
+)
+
+// Readiness runs its check on every probe, e.g. listing the zones to verify the credentials, so Kubernetes does not
+// route traffic to a webhook that cannot reach the Volcengine API.
+type Readiness struct {
+	check func(ctx context.Context) error
+}
+
+// NewReadiness returns a Readiness reporting ready while check succeeds.
+func NewReadiness(check func(ctx context.Context) error) *Readiness {
+	return &Readiness{check: check}
+}
+
+// ServeHTTP serves the readiness probe.
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), readyCheckTimeout)
+	defer cancel()
+	if err := r.check(ctx); err != nil {
+		writeError(w, http.StatusServiceUnavailable, ErrCodeNotReady, fmt.Sprintf("readiness check failed: %v", err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// NewProbeHandler serves /healthz, /startupz and /readyz, the probes of Kubernetes apart from the webhook port that
+// the external-dns webhook spec reserves for the provider API, and that may require TLS client certificates.
+func NewProbeHandler(health *Health, startup *Startup, ready *Readiness) http.Handler {
+	m := http.NewServeMux()
+	m.Handle(UrlHealthz, health)
+	m.Handle(UrlStartupz, startup)
+	m.Handle(UrlReadyz, ready)
+	return m
+}
+
+// StartProbeListener serves the probe handler on addr until the listener fails, the error is logged.
+func StartProbeListener(addr string, handler http.Handler) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.Serve(l, handler); err != nil {
+			log.Errorf("Probe listener on %s stopped: %v", addr, err)
+		}
+	}()
+	return nil
+}
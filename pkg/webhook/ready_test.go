@@ -0,0 +1,62 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeHandler(t *testing.T) {
+	var checkErr error
+	checks := 0
+	ready := NewReadiness(func(ctx context.Context) error {
+		checks++
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return checkErr
+	})
+	handler := NewProbeHandler(NewHealth(0), NewStartup(&fakeProvider{}, NewHealth(0)), ready)
+	probe := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, probe(UrlHealthz).Code)
+	assert.Equal(t, http.StatusOK, probe(UrlReadyz).Code)
+
+	// Every probe runs the check, broken credentials turn the webhook not ready
+	checkErr = errors.New("failed to list private zones: InvalidAccessKey")
+	rec := probe(UrlReadyz)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, ErrCodeNotReady, resp.Code)
+	assert.Equal(t, "readiness check failed: failed to list private zones: InvalidAccessKey", resp.Message)
+	assert.Equal(t, 2, checks)
+
+	// Liveness does not depend on the readiness check
+	assert.Equal(t, http.StatusOK, probe(UrlHealthz).Code)
+	// The webhook API is not served on the probe listener
+	assert.Equal(t, http.StatusNotFound, probe("/records").Code)
+}
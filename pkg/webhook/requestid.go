@@ -0,0 +1,45 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"net/http"
+
+	"volcengine-provider/pkg/utils"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withRequestID attaches a correlation ID to every request, the X-Request-Id header of the caller or a generated one,
+// and returns it in the X-Request-Id response header. Provider and API client logs and the request span carry it.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(utils.RequestIDHeader)
+		if !utils.ValidRequestID(id) {
+			id = utils.NewRequestID()
+		}
+		w.Header().Set(utils.RequestIDHeader, id)
+		trace.SpanFromContext(req.Context()).SetAttributes(attribute.String(utils.RequestIDField, id))
+		next.ServeHTTP(w, req.WithContext(utils.WithRequestID(req.Context(), id)))
+	})
+}
+
+// requestLog returns the logger of the request, with its correlation ID.
+func requestLog(req *http.Request) *log.Entry {
+	return log.WithField(utils.RequestIDField, utils.RequestID(req.Context()))
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"volcengine-provider/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRequestID(t *testing.T) {
+	var seen string
+	h := withRequestID(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		seen = utils.RequestID(req.Context())
+	}))
+
+	cases := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "caller id", header: "sync-42", keep: true},
+		{name: "no header"},
+		{name: "blank", header: "a b"},
+		{name: "too long", header: strings.Repeat("x", 129)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/records", nil)
+			if c.header != "" {
+				req.Header.Set(utils.RequestIDHeader, c.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.NotEmpty(t, seen)
+			assert.Equal(t, seen, rec.Header().Get(utils.RequestIDHeader))
+			assert.Equal(t, c.keep, seen == c.header)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// UrlMetrics is the Prometheus metrics endpoint.
+const UrlMetrics = "/metrics"
+
+// Option configures the webhook handler.
+type Option func(*handlers)
+
+// WithHealth observes the result of every provider call and serves h on /healthz.
+func WithHealth(h *Health) Option {
+	return func(hs *handlers) {
+		hs.health = h
+	}
+}
+
+// WithStartup observes the Records calls of external-dns in s, so /startupz can be served by the probe handler too.
+func WithStartup(s *Startup) Option {
+	return func(hs *handlers) {
+		hs.startup = s
+	}
+}
+
+// Leader reports whether this replica holds the leadership, *leader.Elector satisfies it.
+type Leader interface {
+	IsLeader() bool
+	Leader() string
+}
+
+// WithLeaderElection rejects ApplyChanges on replicas that are not the leader, reads are served by every replica.
+func WithLeaderElection(l Leader) Option {
+	return func(hs *handlers) {
+		hs.leader = l
+	}
+}
+
+// NewHandler returns the webhook API handler for the provider:
+//   - / (GET): initialization, negotiates headers and returns the domain filter
+//   - /records (GET): returns the current records
+//   - /records (POST): applies the changes, only on the leader when WithLeaderElection is set
+//   - /adjustendpoints (POST): executes the AdjustEndpoints method
+//   - /healthz (GET): liveness, unhealthy after consecutive provider failures when WithHealth is set
+//   - /startupz (GET): startup, succeeds once one Records listing succeeded, listing the records itself until then
+//   - /metrics (GET): Prometheus metrics
+//
+// Webhook API requests carry a correlation ID, see withRequestID, and are traced in a server span continuing the
+// trace context of the caller, see spanName. They must present the token of WithAuthToken when set, the probes and
+// /metrics need none.
+func NewHandler(p provider.Provider, options ...Option) http.Handler {
+	h := &handlers{
+		provider: p,
+		health:   NewHealth(0),
+	}
+	for _, option := range options {
+		option(h)
+	}
+	if h.startup == nil {
+		h.startup = NewStartup(p, h.health)
+	}
+
+	webhookMux := http.NewServeMux()
+	webhookMux.Handle("/", instrument("/", http.HandlerFunc(h.negotiate)))
+	webhookMux.Handle(api.UrlRecords, instrument(api.UrlRecords, http.HandlerFunc(h.records)))
+	webhookMux.Handle(api.UrlAdjustEndpoints, instrument(api.UrlAdjustEndpoints, http.HandlerFunc(h.adjustEndpoints)))
+
+	webhookAPI := withMediaType(webhookMux)
+	if h.authToken != "" {
+		webhookAPI = withAuth(h.authHeader, h.authToken, webhookAPI)
+	}
+
+	m := http.NewServeMux()
+	m.Handle(UrlHealthz, h.health)
+	m.Handle(UrlStartupz, h.startup)
+	m.Handle(UrlMetrics, promhttp.Handler())
+	m.Handle("/", otelhttp.NewHandler(withRequestID(webhookAPI), "webhook",
+		otelhttp.WithSpanNameFormatter(spanName)))
+
+	return m
+}
+
+// StartHTTPApi starts the webhook HTTP server for the provider on addr, serving HTTPS when tlsConfig is set.
+// startedChan is signaled once the listener is ready. When ctx is done the server stops accepting connections
+// and StartHTTPApi returns once in-flight requests completed, or after shutdownGracePeriod.
+func StartHTTPApi(ctx context.Context, p provider.Provider, startedChan chan struct{}, readTimeout, writeTimeout, shutdownGracePeriod time.Duration, addr string, tlsConfig *tls.Config, options ...Option) {
+	s := &http.Server{
+		Addr:         addr,
+		Handler:      NewHandler(p, options...),
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+	}
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if tlsConfig != nil {
+		l = tls.NewListener(l, tlsConfig)
+	}
+
+	if startedChan != nil {
+		startedChan <- struct{}{}
+	}
+
+	if err := serve(ctx, s, l, shutdownGracePeriod); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// serve serves on the listener until ctx is done, then shuts the server down, waiting up to gracePeriod for
+// in-flight requests, e.g. ApplyChanges writing records, and closing the connections left after it.
+func serve(ctx context.Context, s *http.Server, l net.Listener, gracePeriod time.Duration) error {
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(l)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Infof("Draining in-flight requests for up to %s", gracePeriod)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		log.Warnf("In-flight requests did not complete within %s, closing their connections: %v", gracePeriod, err)
+		return s.Close()
+	}
+	return nil
+}
+
+// spanName names the span of a webhook API request by its method and route, unknown paths are served by the
+// negotiation route.
+func spanName(_ string, req *http.Request) string {
+	route := "/"
+	switch req.URL.Path {
+	case api.UrlRecords, api.UrlAdjustEndpoints:
+		route = req.URL.Path
+	}
+	return req.Method + " " + route
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"volcengine-provider/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// startServe serves handler on a local listener until ctx is done, the returned channel yields the serve result.
+func startServe(t *testing.T, ctx context.Context, handler http.Handler, gracePeriod time.Duration) (string, chan error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, &http.Server{Handler: handler}, l, gracePeriod)
+	}()
+	return "http://" + l.Addr().String(), done
+}
+
+func TestServeDrainsInFlightRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	entered, release := make(chan struct{}), make(chan struct{})
+	url, done := startServe(t, ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}), time.Minute)
+
+	responded := make(chan int, 1)
+	go func() {
+		resp, err := http.Post(url+"/records", "application/json", nil)
+		if err != nil {
+			responded <- 0
+			return
+		}
+		resp.Body.Close()
+		responded <- resp.StatusCode
+	}()
+	<-entered
+
+	// Termination waits for the request in flight
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("serve returned before the request completed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	assert.Equal(t, http.StatusNoContent, <-responded)
+	require.NoError(t, <-done)
+
+	// No new connections are accepted once shut down
+	_, err := http.Get(url + "/healthz")
+	assert.Error(t, err)
+}
+
+func TestServeGracePeriodExceeded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	entered, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	url, done := startServe(t, ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+	}), 50*time.Millisecond)
+
+	failed := make(chan error, 1)
+	go func() {
+		resp, err := http.Post(url+"/records", "application/json", nil)
+		if err == nil {
+			resp.Body.Close()
+		}
+		failed <- err
+	}()
+	<-entered
+
+	// The connection is closed once the grace period passed
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after the grace period")
+	}
+	assert.Error(t, <-failed)
+}
+
+func TestHandlerSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	h := NewHandler(&fakeProvider{})
+
+	req := httptest.NewRequest(http.MethodGet, api.UrlRecords, nil)
+	req.Header.Set(acceptHeader, api.MediaTypeFormatAndVersion)
+	req.Header.Set(utils.RequestIDHeader, "sync-42")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	doRequest(h, http.MethodGet, "/unknown", "")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GET /records", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Contains(t, spans[0].Attributes(), attribute.String(utils.RequestIDField, "sync-42"))
+	assert.Equal(t, "GET /", spans[1].Name())
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// UrlStartupz is the startup endpoint.
+	UrlStartupz = "/startupz"
+
+	ErrCodeNotStarted = "NotStarted"
+)
+
+// Startup reports started once the provider completed one full Records listing, either for external-dns
+// or for a startup probe, which lists the records itself until one listing succeeded.
+type Startup struct {
+	provider provider.Provider
+	health   *Health
+
+	mu        sync.Mutex
+	started   bool
+	lastError error
+	// fetching serializes the listings of concurrent probes
+	fetching sync.Mutex
+}
+
+// NewStartup returns a Startup listing the records of p on a probe until one listing succeeded, the listings
+// of the probes are observed by health.
+func NewStartup(p provider.Provider, health *Health) *Startup {
+	return &Startup{provider: p, health: health}
+}
+
+// observe records the result of a Records call.
+func (s *Startup) observe(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.lastError = err
+		return
+	}
+	if !s.started {
+		log.Infof("Webhook started, the first records listing succeeded")
+	}
+	s.started = true
+	s.lastError = nil
+}
+
+func (s *Startup) isStarted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// ServeHTTP serves the startup probe.
+func (s *Startup) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !s.isStarted() && s.fetching.TryLock() {
+		if !s.isStarted() {
+			_, err := s.provider.Records(req.Context())
+			s.health.Observe(err)
+			s.observe(err)
+		}
+		s.fetching.Unlock()
+	}
+	if s.isStarted() {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+		return
+	}
+	s.mu.Lock()
+	message := "the first records listing is in progress"
+	if s.lastError != nil {
+		message = fmt.Sprintf("the records could not be listed yet, last error: %v", s.lastError)
+	}
+	s.mu.Unlock()
+	writeError(w, http.StatusServiceUnavailable, ErrCodeNotStarted, message)
+}
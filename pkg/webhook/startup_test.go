@@ -0,0 +1,85 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+func TestStartupzHandler(t *testing.T) {
+	p := &fakeProvider{err: errors.New("timeout")}
+	handler := NewHandler(p)
+	probe := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, UrlStartupz, nil))
+		return rec
+	}
+
+	// The probe lists the records itself until a listing succeeds
+	rec := probe()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), ErrCodeNotStarted)
+	assert.Contains(t, rec.Body.String(), "timeout")
+
+	p.err = nil
+	assert.Equal(t, http.StatusOK, probe().Code)
+
+	// Once started, later failures do not fail the probe
+	p.err = errors.New("timeout")
+	assert.Equal(t, http.StatusOK, probe().Code)
+}
+
+func TestStartupzAfterRecords(t *testing.T) {
+	p := &fakeProvider{records: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")}}
+	handler := NewHandler(p)
+
+	// A Records call of external-dns marks the webhook started
+	assert.Equal(t, http.StatusOK, doRequest(handler, http.MethodGet, api.UrlRecords, "").Code)
+	p.err = errors.New("timeout")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, UrlStartupz, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestStartupzOnProbeHandler(t *testing.T) {
+	p := &fakeProvider{err: errors.New("timeout")}
+	health := NewHealth(0)
+	startup := NewStartup(p, health)
+	handler := NewHandler(p, WithHealth(health), WithStartup(startup))
+	probes := NewProbeHandler(health, startup, NewReadiness(func(context.Context) error { return nil }))
+	probe := func() int {
+		rec := httptest.NewRecorder()
+		probes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, UrlStartupz, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, probe())
+
+	// A Records call of external-dns on the webhook port marks the probe listener started
+	p.err = nil
+	assert.Equal(t, http.StatusOK, doRequest(handler, http.MethodGet, api.UrlRecords, "").Code)
+	p.err = errors.New("timeout")
+	assert.Equal(t, http.StatusOK, probe())
+}
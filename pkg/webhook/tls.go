@@ -0,0 +1,145 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// tlsReloadInterval is how often the certificate files are checked for changes, at most once per handshake.
+const tlsReloadInterval = 10 * time.Second
+
+// certReloader serves the certificate and client CAs of the files, reloaded once the files changed,
+// e.g. after cert-manager renewed the certificate secret.
+type certReloader struct {
+	certFile, keyFile, clientCAFile string
+	now                             func() time.Time
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  []time.Time
+	checkedAt time.Time
+}
+
+// NewTLSConfig returns the TLS config of the webhook server serving certFile and keyFile. With clientCAFile,
+// clients must present a certificate signed by one of its CAs. The files are reloaded when they change.
+func NewTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile, now: time.Now}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.configForClient,
+	}, nil
+}
+
+// files returns the watched files.
+func (r *certReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+	return files
+}
+
+// load reads the certificate, key and client CAs.
+func (r *certReloader) load() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate %s: %w", r.certFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read TLS client CA %s: %w", r.clientCAFile, err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in TLS client CA %s", r.clientCAFile)
+		}
+	}
+	r.cert, r.clientCAs, r.modTimes, r.checkedAt = &cert, clientCAs, modTimes, r.now()
+	return nil
+}
+
+// stat returns the modification times of the files.
+func (r *certReloader) stat() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+// reloadIfChanged reloads the files when one changed since the last load, keeping the loaded ones on errors.
+func (r *certReloader) reloadIfChanged() {
+	now := r.now()
+	if now.Sub(r.checkedAt) < tlsReloadInterval {
+		return
+	}
+	r.checkedAt = now
+	modTimes, err := r.stat()
+	if err != nil {
+		log.Errorf("Failed to check TLS files, keeping the loaded certificate: %v", err)
+		return
+	}
+	changed := false
+	for i, modTime := range modTimes {
+		changed = changed || !modTime.Equal(r.modTimes[i])
+	}
+	if !changed {
+		return
+	}
+	if err := r.load(); err != nil {
+		log.Errorf("Failed to reload TLS files, keeping the loaded certificate: %v", err)
+		return
+	}
+	log.Infof("Reloaded TLS certificate %s", r.certFile)
+}
+
+// configForClient returns the config of a handshake with the current certificate and client CAs.
+func (r *certReloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reloadIfChanged()
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+	}
+	if r.clientCAs != nil {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = r.clientCAs
+	}
+	return config, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Beijing Volcano Engine Technology Co., Ltd. Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package webhook
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCert is a certificate with its key, self-signed or signed by a CA.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+	tls  tls.Certificate
+}
+
+func newTestCert(t *testing.T, name string, ca *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	parent, signer := template, key
+	if ca == nil {
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+		template.BasicConstraintsValid = true
+	} else {
+		parent, signer = ca.cert, ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, pem: certPEM, tls: pair}
+}
+
+// write writes the certificate and key files to dir.
+func (c *testCert) write(t *testing.T, dir string) (certFile, keyFile string) {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, c.pem, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+// serveTLS serves 200 OK with the config and returns the URL.
+func serveTLS(t *testing.T, config *tls.Config) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+	go func() { _ = server.Serve(tls.NewListener(l, config)) }()
+	t.Cleanup(func() { _ = server.Close() })
+	return "https://" + l.Addr().String()
+}
+
+func tlsClient(ca *testCert, cert *testCert) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	config := &tls.Config{RootCAs: roots}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{cert.tls}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+}
+
+func TestTLSConfigMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	certFile, keyFile := newTestCert(t, "webhook", ca).write(t, dir)
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+
+	config, err := NewTLSConfig(certFile, keyFile, "")
+	require.NoError(t, err)
+	resp, err := tlsClient(ca, nil).Get(serveTLS(t, config))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	config, err = NewTLSConfig(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	url := serveTLS(t, config)
+	_, err = tlsClient(ca, nil).Get(url)
+	assert.Error(t, err, "clients without a certificate are rejected")
+	_, err = tlsClient(ca, newTestCert(t, "other", nil)).Get(url)
+	assert.Error(t, err, "clients with a certificate of another CA are rejected")
+	resp, err = tlsClient(ca, newTestCert(t, "external-dns", ca)).Get(url)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	_, err = NewTLSConfig(certFile, keyFile, certFile+".missing")
+	assert.Error(t, err)
+}
+
+func TestTLSConfigReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	first := newTestCert(t, "first", ca)
+	certFile, keyFile := first.write(t, dir)
+
+	now := time.Now()
+	r := &certReloader{certFile: certFile, keyFile: keyFile, now: func() time.Time { return now }}
+	require.NoError(t, r.load())
+
+	second := newTestCert(t, "second", ca)
+	second.write(t, dir)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+
+	config, err := r.configForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.tls.Certificate[0], config.Certificates[0].Certificate[0], "files are checked every tlsReloadInterval")
+
+	now = now.Add(tlsReloadInterval)
+	config, err = r.configForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.tls.Certificate[0], config.Certificates[0].Certificate[0])
+
+	// a broken file keeps the loaded certificate
+	require.NoError(t, os.WriteFile(certFile, []byte("broken"), 0o600))
+	require.NoError(t, os.Chtimes(certFile, later.Add(time.Minute), later.Add(time.Minute)))
+	now = now.Add(tlsReloadInterval)
+	config, err = r.configForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.tls.Certificate[0], config.Certificates[0].Certificate[0])
+}
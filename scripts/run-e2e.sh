@@ -22,6 +22,12 @@
 # export VOLCENGINE_CLUSTER_ID="your-cluster-id"
 # export TEST_DOMAIN_NAME="test.example.com"
 # export PRIVATE_ZONE_ID="123456"
+# Optional, for the upgrade spec (skipped unless both images are set)
+# export PREVIOUS_WEBHOOK_IMAGE="registry/external-dns-volcengine-webhook:v0.1.0"
+# export CURRENT_WEBHOOK_IMAGE="registry/external-dns-volcengine-webhook:dev"
+# export WEBHOOK_DEPLOYMENT="external-dns/external-dns"
+# export WEBHOOK_CONTAINER="webhook"
+# export EXTERNAL_DNS_INTERVAL="1m"
 
 cd $(dirname $0)/..
 go test -v ./e2e/... -ginkgo.v -ginkgo.trace -ginkgo.show-node-events -test.v --timeout=30m